@@ -1,17 +1,26 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/shellhub-io/shellhub/pkg/agent"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/selfupdater"
-	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+	"github.com/shellhub-io/shellhub/pkg/portforward"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +34,9 @@ import (
 var AgentVersion string
 
 func main() {
+	// configFile is the path to the agent's configuration file. When empty, only the environment variables are used.
+	var configFile string
+
 	// Default command.
 	rootCmd := &cobra.Command{ // nolint: exhaustruct
 		Use: "agent",
@@ -50,12 +62,19 @@ func main() {
 			//
 			//  This behavior is driven by the [envconfig] package. Check it out for more information.
 			//
+			// When a configuration file is set, its values are used for the variables that are not set on the
+			// environment. Check [agent.LoadConfig] for more information.
+			//
 			// [envconfig]: https://github.com/sethvargo/go-envconfig
-			cfg, err := envs.ParseWithPrefix[agent.Config]("SHELLHUB_")
+			cfg, err := agent.LoadConfig("SHELLHUB_", configFile)
 			if err != nil {
 				log.Fatal(err)
 			}
 
+			if err := loglevel.UpdateLogLevel(cfg.LogLevel); err != nil {
+				log.WithError(err).WithField("log_level", cfg.LogLevel).Warn("Failed to set the log level")
+			}
+
 			if os.Geteuid() == 0 && cfg.SingleUserPassword != "" {
 				log.Error("ShellHub agent cannot run as root when single-user mode is enabled.")
 				log.Error("To disable single-user mode unset SHELLHUB_SINGLE_USER_PASSWORD env.")
@@ -119,6 +138,13 @@ func main() {
 
 			ctx := cmd.Context()
 
+			if _, err := tracing.Start(ctx, "agent", &cfg.Tracing); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"version":  AgentVersion,
+					"exporter": cfg.Tracing.Exporter,
+				}).Warn("Failed to start the tracing; the spans are not exported")
+			}
+
 			go func() {
 				// NOTICE: We only start to ping the server when the agent is ready to accept connections.
 				// It will make the agent ping to server after the ticker time set on ping function, what is 10 minutes
@@ -143,6 +169,42 @@ func main() {
 				}).Info("Stopped pinging server")
 			}()
 
+			reports := []struct {
+				name     string
+				interval int
+				run      func(context.Context, time.Duration) error
+			}{
+				{name: "inventory", interval: cfg.InventoryInterval, run: ag.ReportInventory},
+				{name: "metrics", interval: cfg.MetricsInterval, run: ag.ReportMetrics},
+			}
+
+			for _, report := range reports {
+				if report.interval <= 0 {
+					continue
+				}
+
+				go func(name string, interval time.Duration, run func(context.Context, time.Duration) error) {
+					if err := run(ctx, interval); err != nil {
+						log.WithError(err).WithFields(log.Fields{
+							"version":        AgentVersion,
+							"tenant_id":      cfg.TenantID,
+							"server_address": cfg.ServerAddress,
+						}).Errorf("Failed to report the %s", name)
+					}
+				}(report.name, time.Duration(report.interval)*time.Second, report.run)
+			}
+
+			if cfg.MetricsListenAddress != "" {
+				go func() {
+					if err := http.ListenAndServe(cfg.MetricsListenAddress, metrics.Handler()); err != nil { //nolint:gosec
+						log.WithError(err).WithFields(log.Fields{
+							"version": AgentVersion,
+							"address": cfg.MetricsListenAddress,
+						}).Error("Failed to expose the metrics")
+					}
+				}()
+			}
+
 			log.WithFields(log.Fields{
 				"version":            AgentVersion,
 				"mode":               mode,
@@ -203,6 +265,27 @@ func main() {
 				}()
 			}
 
+			go func() {
+				// NOTICE: SIGHUP reloads only the settings that can be changed without dropping the connection to
+				// the server. Check [agent.Agent.Reload] for more information.
+				signals := make(chan os.Signal, 1)
+				signal.Notify(signals, syscall.SIGHUP)
+
+				for range signals {
+					cfg, err := agent.LoadConfig("SHELLHUB_", configFile)
+					if err != nil {
+						log.WithError(err).WithFields(log.Fields{
+							"version": AgentVersion,
+							"config":  configFile,
+						}).Error("Failed to reload configuration")
+
+						continue
+					}
+
+					ag.Reload(cfg)
+				}
+			}()
+
 			if err := ag.Listen(ctx); err != nil {
 				log.WithError(err).WithFields(log.Fields{
 					"version":            AgentVersion,
@@ -229,7 +312,7 @@ func main() {
 		Run: func(cmd *cobra.Command, args []string) {
 			loglevel.SetLogLevel()
 
-			cfg, err := envs.ParseWithPrefix[agent.Config]("SHELLHUB_")
+			cfg, err := agent.LoadConfig("SHELLHUB_", configFile)
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -281,6 +364,91 @@ It is initialized by the agent when a new SFTP session is created.`,
 		},
 	})
 
+	// portForward is the configuration of the port-forward command, whose credentials are read again for each new
+	// connection, so they can be renewed while the command runs.
+	var portForward struct {
+		token       string
+		tokenFile   string
+		certificate string
+		key         string
+		insecure    bool
+	}
+
+	portForwardCmd := &cobra.Command{ // nolint: exhaustruct
+		Use:   "port-forward <url> <local address>",
+		Short: "Forwards a local address to a device's published port",
+		Long: `Forwards the connections to the local address, like localhost:5432, to the device's TCP port published at the
+URL through the ShellHub's gateway.
+
+Through an http or https URL, like https://<address>.tcp.shellhub.io, the token issued to connect to the port is sent.
+It is set by the --token flag, the SHELLHUB_PUBLISHED_PORT_TOKEN environment variable or the --token-file flag.
+
+Through a tls URL, like tls://<address>.tcp.shellhub.io:4433 or tls://shellhub.io:20000 for a port allocated on the
+gateway, the client certificate issued to connect to the port is presented. It is set by the --certificate and --key
+flags.
+
+As the tokens and the certificates are short-lived, the token file and the certificate files are read again for each
+new connection, so they can be renewed while the command runs.`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			loglevel.SetLogLevel()
+
+			config := func() (*portforward.Config, error) {
+				cfg := &portforward.Config{Token: portForward.token, InsecureSkipVerify: portForward.insecure}
+
+				if portForward.tokenFile != "" {
+					data, err := os.ReadFile(portForward.tokenFile)
+					if err != nil {
+						return nil, err
+					}
+
+					cfg.Token = strings.TrimSpace(string(data))
+				}
+
+				if portForward.certificate != "" || portForward.key != "" {
+					certificate, err := tls.LoadX509KeyPair(portForward.certificate, portForward.key)
+					if err != nil {
+						return nil, err
+					}
+
+					cfg.Certificate = &certificate
+				}
+
+				return cfg, nil
+			}
+
+			if cfg, err := config(); err != nil {
+				log.WithError(err).Fatal("Failed to read the credentials to connect to the published port")
+			} else if cfg.Token == "" && cfg.Certificate == nil {
+				log.Fatal("A token or a certificate is required to connect to the published port")
+			}
+
+			listener, err := net.Listen("tcp", args[1])
+			if err != nil {
+				log.WithError(err).WithField("address", args[1]).Fatal("Failed to listen on the local address")
+			}
+
+			log.WithFields(log.Fields{
+				"url":     args[0],
+				"address": listener.Addr().String(),
+			}).Info("Forwarding the local address to the published port")
+
+			if err := portforward.Serve(cmd.Context(), listener, args[0], config); err != nil {
+				log.WithError(err).Fatal("Failed to forward the connections to the published port")
+			}
+		},
+	}
+
+	portForwardCmd.Flags().StringVar(&portForward.token, "token", os.Getenv("SHELLHUB_PUBLISHED_PORT_TOKEN"), "token issued to connect to the published port")
+	portForwardCmd.Flags().StringVar(&portForward.tokenFile, "token-file", "", "path of the file with the token issued to connect to the published port")
+	portForwardCmd.Flags().StringVar(&portForward.certificate, "certificate", "", "path of the client certificate issued to connect to the published port")
+	portForwardCmd.Flags().StringVar(&portForward.key, "key", "", "path of the client certificate's private key")
+	portForwardCmd.Flags().BoolVar(&portForward.insecure, "insecure", false, "skip the verification of the gateway's certificate")
+
+	rootCmd.AddCommand(portForwardCmd)
+
+	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", os.Getenv("SHELLHUB_CONFIG_FILE"), "path to the YAML or TOML configuration file")
+
 	rootCmd.Version = AgentVersion
 
 	rootCmd.SetVersionTemplate(fmt.Sprintf("{{ .Name }} version: {{ .Version }}\ngo: %s\n",
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/labstack/echo/v4"
@@ -72,6 +74,11 @@ func NewErrors(reporter *sentry.Client) func(error, echo.Context) {
 			status = converter.FromErrRouteToHTTPStatus(e.Code)
 		case services.ErrLayer:
 			status = converter.FromErrServiceToHTTPStatus(e.Code)
+
+			// When the client must wait before a new request, it is informed by the Retry-After header, in seconds.
+			if data, ok := e.Data.(services.ErrDataRetryAfter); ok {
+				ctx.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(data.RetryAfter.Seconds()))))
+			}
 		case store.ErrLayer:
 			// What happens when an error is returned directly from the store's layer, which means it doesn't have a
 			// service error affecting it, which requires fixing.
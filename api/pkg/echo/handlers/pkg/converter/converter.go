@@ -26,6 +26,8 @@ func FromErrServiceToHTTPStatus(code int) int {
 		return http.StatusForbidden
 	case services.ErrCodeNoContentChange:
 		return http.StatusNoContent
+	case services.ErrCodeTooManyRequests:
+		return http.StatusTooManyRequests
 	default:
 		return http.StatusInternalServerError
 	}
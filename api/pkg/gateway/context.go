@@ -99,3 +99,13 @@ func (c *Context) GetRole() (string, bool) {
 
 	return "", false
 }
+
+// GetSessionID returns the ID of the user's session that issued the JWT got through gateway.
+func (c *Context) GetSessionID() (string, bool) {
+	session := c.Request().Header.Get("X-Session-ID")
+	if session != "" {
+		return session, true
+	}
+
+	return "", false
+}
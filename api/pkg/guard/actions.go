@@ -13,7 +13,7 @@ type AllActions struct {
 }
 
 type DeviceActions struct {
-	Accept, Reject, Update, Remove, Connect, Rename, CreateTag, UpdateTag, RemoveTag, RenameTag, DeleteTag int
+	Accept, Reject, Update, Remove, Connect, Rename, ForwardAgent, CreateTag, UpdateTag, RemoveTag, RenameTag, DeleteTag, CreateGroup, EditGroup, RemoveGroup, UpdateLabel, RemoveLabel int
 }
 
 type SessionActions struct {
@@ -29,7 +29,7 @@ type PublicKeyActions struct {
 }
 
 type NamespaceActions struct {
-	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete int
+	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, EnableAgentForwarding, EditMetricsThresholds, Delete int
 }
 
 type BillingActions struct {
@@ -40,17 +40,23 @@ type BillingActions struct {
 // You should use it to get the code's action.
 var Actions = AllActions{
 	Device: DeviceActions{
-		Accept:    DeviceAccept,
-		Reject:    DeviceReject,
-		Update:    DeviceUpdate,
-		Remove:    DeviceRemove,
-		Connect:   DeviceConnect,
-		Rename:    DeviceRename,
-		CreateTag: DeviceCreateTag,
-		UpdateTag: DeviceUpdateTag,
-		RemoveTag: DeviceRemoveTag,
-		RenameTag: DeviceRenameTag,
-		DeleteTag: DeviceDeleteTag,
+		Accept:       DeviceAccept,
+		Reject:       DeviceReject,
+		Update:       DeviceUpdate,
+		Remove:       DeviceRemove,
+		Connect:      DeviceConnect,
+		Rename:       DeviceRename,
+		ForwardAgent: DeviceForwardAgent,
+		CreateTag:    DeviceCreateTag,
+		UpdateTag:    DeviceUpdateTag,
+		RemoveTag:    DeviceRemoveTag,
+		RenameTag:    DeviceRenameTag,
+		DeleteTag:    DeviceDeleteTag,
+		CreateGroup:  DeviceCreateGroup,
+		EditGroup:    DeviceEditGroup,
+		RemoveGroup:  DeviceRemoveGroup,
+		UpdateLabel:  DeviceUpdateLabel,
+		RemoveLabel:  DeviceRemoveLabel,
 	},
 	Session: SessionActions{
 		Play:    SessionPlay,
@@ -72,12 +78,14 @@ var Actions = AllActions{
 		UpdateTag: PublicKeyUpdateTag,
 	},
 	Namespace: NamespaceActions{
-		Rename:              NamespaceRename,
-		AddMember:           NamespaceAddMember,
-		RemoveMember:        NamespaceRemoveMember,
-		EditMember:          NamespaceEditMember,
-		EnableSessionRecord: NamespaceEnableSessionRecord,
-		Delete:              NamespaceDelete,
+		Rename:                NamespaceRename,
+		AddMember:             NamespaceAddMember,
+		RemoveMember:          NamespaceRemoveMember,
+		EditMember:            NamespaceEditMember,
+		EnableSessionRecord:   NamespaceEnableSessionRecord,
+		EnableAgentForwarding: NamespaceEnableAgentForwarding,
+		EditMetricsThresholds: NamespaceEditMetricsThresholds,
+		Delete:                NamespaceDelete,
 	},
 	Billing: BillingActions{
 		CreateCustomer:      BillingCreateCustomer,
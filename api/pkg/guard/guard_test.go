@@ -339,6 +339,12 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.RenameTag,
 				Actions.Device.DeleteTag,
 
+				Actions.Device.CreateGroup,
+				Actions.Device.EditGroup,
+				Actions.Device.RemoveGroup,
+				Actions.Device.UpdateLabel,
+				Actions.Device.RemoveLabel,
+
 				Actions.Session.Details,
 			},
 			requiredMocks: func() {
@@ -362,6 +368,12 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.RenameTag,
 				Actions.Device.DeleteTag,
 
+				Actions.Device.CreateGroup,
+				Actions.Device.EditGroup,
+				Actions.Device.RemoveGroup,
+				Actions.Device.UpdateLabel,
+				Actions.Device.RemoveLabel,
+
 				Actions.Session.Play,
 				Actions.Session.Close,
 				Actions.Session.Remove,
@@ -402,6 +414,12 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.RenameTag,
 				Actions.Device.DeleteTag,
 
+				Actions.Device.CreateGroup,
+				Actions.Device.EditGroup,
+				Actions.Device.RemoveGroup,
+				Actions.Device.UpdateLabel,
+				Actions.Device.RemoveLabel,
+
 				Actions.Session.Play,
 				Actions.Session.Close,
 				Actions.Session.Remove,
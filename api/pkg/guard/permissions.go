@@ -10,6 +10,7 @@ const (
 	DeviceConnect
 	DeviceRename
 	DeviceDetails
+	DeviceForwardAgent
 
 	DeviceCreateTag
 	DeviceUpdateTag
@@ -17,6 +18,13 @@ const (
 	DeviceRenameTag
 	DeviceDeleteTag
 
+	DeviceCreateGroup
+	DeviceEditGroup
+	DeviceRemoveGroup
+
+	DeviceUpdateLabel
+	DeviceRemoveLabel
+
 	SessionPlay
 	SessionClose
 	SessionRemove
@@ -43,6 +51,8 @@ const (
 	NamespaceRemoveMember
 	NamespaceEditMember
 	NamespaceEnableSessionRecord
+	NamespaceEnableAgentForwarding
+	NamespaceEditMetricsThresholds
 	NamespaceDelete
 
 	BillingCreateCustomer
@@ -69,6 +79,7 @@ var operatorPermissions = Permissions{
 	DeviceRename,
 	DeviceDetails,
 	DeviceUpdate,
+	DeviceForwardAgent,
 
 	DeviceCreateTag,
 	DeviceUpdateTag,
@@ -76,6 +87,13 @@ var operatorPermissions = Permissions{
 	DeviceRenameTag,
 	DeviceDeleteTag,
 
+	DeviceCreateGroup,
+	DeviceEditGroup,
+	DeviceRemoveGroup,
+
+	DeviceUpdateLabel,
+	DeviceRemoveLabel,
+
 	SessionDetails,
 }
 
@@ -87,6 +105,7 @@ var adminPermissions = Permissions{
 	DeviceRename,
 	DeviceDetails,
 	DeviceUpdate,
+	DeviceForwardAgent,
 
 	DeviceCreateTag,
 	DeviceUpdateTag,
@@ -94,6 +113,13 @@ var adminPermissions = Permissions{
 	DeviceRenameTag,
 	DeviceDeleteTag,
 
+	DeviceCreateGroup,
+	DeviceEditGroup,
+	DeviceRemoveGroup,
+
+	DeviceUpdateLabel,
+	DeviceRemoveLabel,
+
 	DeviceUpdate,
 
 	SessionPlay,
@@ -120,6 +146,8 @@ var adminPermissions = Permissions{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceEnableAgentForwarding,
+	NamespaceEditMetricsThresholds,
 }
 
 var ownerPermissions = Permissions{
@@ -130,6 +158,7 @@ var ownerPermissions = Permissions{
 	DeviceRename,
 	DeviceDetails,
 	DeviceUpdate,
+	DeviceForwardAgent,
 
 	DeviceCreateTag,
 	DeviceUpdateTag,
@@ -137,6 +166,13 @@ var ownerPermissions = Permissions{
 	DeviceRenameTag,
 	DeviceDeleteTag,
 
+	DeviceCreateGroup,
+	DeviceEditGroup,
+	DeviceRemoveGroup,
+
+	DeviceUpdateLabel,
+	DeviceRemoveLabel,
+
 	DeviceUpdate,
 
 	SessionPlay,
@@ -163,6 +199,8 @@ var ownerPermissions = Permissions{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceEnableAgentForwarding,
+	NamespaceEditMetricsThresholds,
 	NamespaceDelete,
 
 	BillingCreateCustomer,
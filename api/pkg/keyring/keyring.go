@@ -0,0 +1,302 @@
+// Package keyring keeps the RSA keys used to sign and verify the API's tokens.
+//
+// The key loaded from the API's key file signs the tokens until the first rotation. Each rotation generates a new key,
+// stored on the database to be shared by every API's instance, and retires the previous one, which keeps verifying the
+// tokens it signed during an overlap window. The keys able to verify tokens are published as a JWKS, so other services
+// can verify the ShellHub's tokens without the private key.
+package keyring
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrKeyNotFound is returned when there is no key to verify a token.
+var ErrKeyNotFound = errors.New("signing key not found")
+
+const (
+	// reloadInterval is how often the keys are reloaded from the store, picking the keys rotated by other instances.
+	reloadInterval = time.Minute
+	// forcedReloadInterval is the minimum time between reloads forced by an unknown key's ID.
+	forcedReloadInterval = 10 * time.Second
+)
+
+// Key is a key of the ring.
+type Key struct {
+	// ID is the key's identifier, set as the "kid" header of the tokens it signs.
+	ID string
+	// PrivateKey signs the tokens. It is nil for keys that only verify tokens.
+	PrivateKey *rsa.PrivateKey
+	PublicKey  *rsa.PublicKey
+}
+
+// Keyring keeps the current key to sign tokens and every key still able to verify them.
+type Keyring struct {
+	store store.SigningKeyStore
+	// file is the key loaded from the API's key file.
+	file *Key
+
+	mu       sync.RWMutex
+	current  *Key
+	keys     map[string]*Key
+	loadedAt time.Time
+}
+
+// New creates a Keyring from the API's key file's private key and the keys rotated on store. When store is nil, the
+// ring has only the file's key and cannot be rotated.
+func New(store store.SigningKeyStore, privateKey *rsa.PrivateKey) *Keyring {
+	file := &Key{ID: Thumbprint(&privateKey.PublicKey), PrivateKey: privateKey, PublicKey: &privateKey.PublicKey}
+
+	return &Keyring{
+		store:   store,
+		file:    file,
+		current: file,
+		keys:    map[string]*Key{file.ID: file},
+	}
+}
+
+// Current returns the key that signs new tokens.
+func (k *Keyring) Current(ctx context.Context) *Key {
+	k.reload(ctx, false)
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.current
+}
+
+// PublicKey returns the public key identified by id to verify a token. Tokens signed before the rotation have no key's
+// ID and are verified by the file's key, while it is not expired.
+func (k *Keyring) PublicKey(ctx context.Context, id string) (*rsa.PublicKey, error) {
+	if id == "" {
+		id = k.file.ID
+	}
+
+	k.reload(ctx, false)
+
+	if key := k.get(id); key != nil {
+		return key.PublicKey, nil
+	}
+
+	// NOTICE: the key could be rotated by another instance after the last reload.
+	k.reload(ctx, true)
+
+	if key := k.get(id); key != nil {
+		return key.PublicKey, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// JWKS returns the keys able to verify tokens as a JSON Web Key Set.
+func (k *Keyring) JWKS(ctx context.Context) *JWKS {
+	k.reload(ctx, false)
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	jwks := &JWKS{Keys: make([]JWK, 0, len(k.keys))}
+	for _, key := range k.keys {
+		jwks.Keys = append(jwks.Keys, newJWK(key.ID, key.PublicKey))
+	}
+
+	return jwks
+}
+
+// Rotate generates a new key to sign the tokens and retires the current one, which keeps verifying tokens for the
+// overlap window.
+func (k *Keyring) Rotate(ctx context.Context, overlap time.Duration) (*Key, error) {
+	if k.store == nil {
+		return nil, errors.New("keyring without store cannot be rotated")
+	}
+
+	keys, err := k.store.SigningKeyList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	expiresAt := now.Add(overlap)
+
+	// NOTICE: on the first rotation, the file's key is recorded as retired, so it stops verifying tokens after the
+	// overlap window too.
+	if len(keys) == 0 {
+		if err := k.store.SigningKeyCreate(ctx, &models.SigningKey{
+			ID:        k.file.ID,
+			CreatedAt: now,
+			RetiredAt: &now,
+			ExpiresAt: &expiresAt,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	key := &Key{ID: Thumbprint(&privateKey.PublicKey), PrivateKey: privateKey, PublicKey: &privateKey.PublicKey}
+	if err := k.store.SigningKeyCreate(ctx, &models.SigningKey{
+		ID:        key.ID,
+		Data:      pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)}),
+		CreatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := k.store.SigningKeyRetire(ctx, key.ID, now, expiresAt); err != nil {
+		return nil, err
+	}
+
+	if err := k.load(ctx); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (k *Keyring) get(id string) *Key {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.keys[id]
+}
+
+// reload loads the keys from the store when they are stale or, when forced, at most each forcedReloadInterval. When
+// it fails, the loaded keys are kept.
+func (k *Keyring) reload(ctx context.Context, force bool) {
+	if k.store == nil {
+		return
+	}
+
+	k.mu.RLock()
+	elapsed := clock.Now().Sub(k.loadedAt)
+	k.mu.RUnlock()
+
+	if elapsed < reloadInterval && (!force || elapsed < forcedReloadInterval) {
+		return
+	}
+
+	if err := k.load(ctx); err != nil {
+		log.WithError(err).Warn("failed to load the signing keys")
+	}
+}
+
+// load replaces the ring's keys by the ones on the store.
+func (k *Keyring) load(ctx context.Context) error {
+	records, err := k.store.SigningKeyList(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := k.file
+	keys := make(map[string]*Key, len(records)+1)
+
+	// NOTICE: until the first rotation, there are no keys on the store and the file's key is the current one.
+	if len(records) == 0 {
+		keys[k.file.ID] = k.file
+	}
+
+	for _, record := range records {
+		if record.ID == k.file.ID {
+			keys[k.file.ID] = k.file
+
+			continue
+		}
+
+		key, err := parse(record)
+		if err != nil {
+			log.WithError(err).WithField("kid", record.ID).Warn("failed to parse the signing key")
+
+			continue
+		}
+
+		keys[key.ID] = key
+
+		// NOTICE: the records are sorted by the creation date, newest first.
+		if record.RetiredAt == nil && current == k.file {
+			current = key
+		}
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.current = current
+	k.keys = keys
+	k.loadedAt = clock.Now()
+
+	return nil
+}
+
+func parse(record models.SigningKey) (*Key, error) {
+	block, _ := pem.Decode(record.Data)
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+
+	privateKey, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Key{ID: record.ID, PrivateKey: privateKey, PublicKey: &privateKey.PublicKey}, nil
+}
+
+// JWK is a RSA public key in the JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func newJWK(id string, key *rsa.PublicKey) JWK {
+	return JWK{
+		Kty: "RSA",
+		Use: "sig",
+		Alg: "RS256",
+		Kid: id,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of the key, used as its ID.
+func Thumbprint(key *rsa.PublicKey) string {
+	jwk := newJWK("", key)
+
+	// NOTICE: the thumbprint is computed over the required members in lexicographic order, without whitespaces.
+	data, _ := json.Marshal(struct {
+		E   string `json:"e"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+	}{jwk.E, jwk.Kty, jwk.N})
+
+	sum := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
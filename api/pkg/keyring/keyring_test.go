@@ -0,0 +1,157 @@
+package keyring
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore is a minimal in-memory store to keep the signing keys during the tests.
+type memoryStore struct {
+	keys []models.SigningKey
+}
+
+func (m *memoryStore) SigningKeyCreate(_ context.Context, key *models.SigningKey) error {
+	m.keys = append(m.keys, *key)
+
+	return nil
+}
+
+func (m *memoryStore) SigningKeyList(_ context.Context) ([]models.SigningKey, error) {
+	keys := make([]models.SigningKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		if key.ExpiresAt == nil || key.ExpiresAt.After(clock.Now()) {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+
+	return keys, nil
+}
+
+func (m *memoryStore) SigningKeyRetire(_ context.Context, id string, retiredAt, expiresAt time.Time) error {
+	for i := range m.keys {
+		if m.keys[i].ID != id && m.keys[i].RetiredAt == nil {
+			m.keys[i].RetiredAt = &retiredAt
+			m.keys[i].ExpiresAt = &expiresAt
+		}
+	}
+
+	return nil
+}
+
+// fakeClock is a clock whose time is moved by the tests.
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func TestKeyringStatic(t *testing.T) {
+	ctx := context.Background()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys := New(nil, privateKey)
+
+	current := keys.Current(ctx)
+	assert.Equal(t, privateKey, current.PrivateKey)
+	assert.Equal(t, Thumbprint(&privateKey.PublicKey), current.ID)
+
+	key, err := keys.PublicKey(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, &privateKey.PublicKey, key)
+
+	key, err = keys.PublicKey(ctx, current.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, &privateKey.PublicKey, key)
+
+	_, err = keys.PublicKey(ctx, "unknown")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	jwks := keys.JWKS(ctx)
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, current.ID, jwks.Keys[0].Kid)
+	assert.Equal(t, "RS256", jwks.Keys[0].Alg)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+
+	_, err = keys.Rotate(ctx, time.Hour)
+	assert.Error(t, err)
+}
+
+func TestKeyringRotate(t *testing.T) {
+	ctx := context.Background()
+
+	fake := &fakeClock{now: time.Now()}
+	backend := clock.DefaultBackend
+	clock.DefaultBackend = fake
+	t.Cleanup(func() { clock.DefaultBackend = backend })
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	store := &memoryStore{}
+	keys := New(store, privateKey)
+	file := keys.Current(ctx)
+
+	first, err := keys.Rotate(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.NotEqual(t, file.ID, first.ID)
+	assert.Equal(t, first.ID, keys.Current(ctx).ID)
+
+	// The tokens signed by the file's key, with or without its ID, are still verified during the overlap window.
+	key, err := keys.PublicKey(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, file.PublicKey, key)
+
+	key, err = keys.PublicKey(ctx, file.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, file.PublicKey, key)
+
+	assert.Len(t, keys.JWKS(ctx).Keys, 2)
+
+	// Another instance picks the rotated keys from the store.
+	other := New(store, privateKey)
+	assert.Equal(t, first.ID, other.Current(ctx).ID)
+
+	fake.now = fake.now.Add(30 * time.Minute)
+	assert.Equal(t, first.ID, other.Current(ctx).ID)
+
+	second, err := keys.Rotate(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, keys.Current(ctx).ID)
+	assert.Len(t, keys.JWKS(ctx).Keys, 3)
+
+	// The other instance verifies a token signed by the new key before its periodic reload, as the unknown ID forces it.
+	fake.now = fake.now.Add(forcedReloadInterval)
+	assert.Equal(t, first.ID, other.Current(ctx).ID)
+
+	key, err = other.PublicKey(ctx, second.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, second.PublicKey, key)
+	assert.Equal(t, second.ID, other.Current(ctx).ID)
+
+	// After the overlap window, the file's key stops verifying tokens.
+	fake.now = fake.now.Add(45 * time.Minute)
+
+	_, err = keys.PublicKey(ctx, "")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	key, err = keys.PublicKey(ctx, first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, first.PublicKey, key)
+
+	assert.Equal(t, second.ID, keys.Current(ctx).ID)
+}
@@ -0,0 +1,172 @@
+// Package lockout protects the authentication against brute-force attacks.
+//
+// It counts, on the cache, the failed attempts for each key, like a username, an email or a source IP. After some
+// failures, each new attempt must wait a delay that doubles on every failure, and after more of them, the key is locked
+// out for a while.
+package lockout
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+)
+
+// Policy defines how the failed attempts of a key are limited.
+type Policy struct {
+	// Window is the period the failed attempts are counted for after the last one.
+	Window time.Duration
+	// DelayAfter is the number of failures after which each new attempt must wait a delay.
+	DelayAfter int
+	// Delay is the first delay, doubled on every failure.
+	Delay time.Duration
+	// MaxDelay is the max delay between attempts.
+	MaxDelay time.Duration
+	// Threshold is the number of failures that locks the key out.
+	Threshold int
+	// Duration is the period the key stays locked out after its last failure. It must not be longer than the window.
+	Duration time.Duration
+}
+
+var (
+	// UserPolicy is the policy applied to the user's identifiers, like its username, email and ID.
+	UserPolicy = Policy{
+		Window:     15 * time.Minute,
+		DelayAfter: 3,
+		Delay:      time.Second,
+		MaxDelay:   time.Minute,
+		Threshold:  10,
+		Duration:   15 * time.Minute,
+	}
+	// IPPolicy is the policy applied to the source IP. It is more permissive than the user's one as many users could
+	// share the same IP.
+	IPPolicy = Policy{
+		Window:     15 * time.Minute,
+		DelayAfter: 20,
+		Delay:      time.Second,
+		MaxDelay:   time.Minute,
+		Threshold:  100,
+		Duration:   15 * time.Minute,
+	}
+)
+
+// Key identifies what the failed attempts are counted for.
+type Key struct {
+	// Name is the cache key.
+	Name string
+	// Policy is the policy applied to the key.
+	Policy Policy
+}
+
+// IdentifierKey returns the key for a user's identifier, like its username or email.
+func IdentifierKey(identifier string) Key {
+	return Key{Name: "lockout_identifier_" + strings.ToLower(identifier), Policy: UserPolicy}
+}
+
+// UserKey returns the key for a user's ID.
+func UserKey(id string) Key {
+	return Key{Name: "lockout_user_" + id, Policy: UserPolicy}
+}
+
+// MFAKey returns the key for the attempts to validate the second factor of a user's ID.
+func MFAKey(id string) Key {
+	return Key{Name: "lockout_mfa_" + id, Policy: UserPolicy}
+}
+
+// IPKey returns the key for a source IP.
+func IPKey(ip string) Key {
+	return Key{Name: "lockout_ip_" + ip, Policy: IPPolicy}
+}
+
+// Lockout counts the failed attempts of keys on the cache.
+//
+// The failures are counted atomically, so concurrent attempts cannot fail without being counted, and each failure sets
+// until when the next attempt must wait.
+type Lockout struct {
+	cache cache.Cache
+}
+
+// New creates a new Lockout using cache to store the failed attempts.
+func New(cache cache.Cache) *Lockout {
+	return &Lockout{cache: cache}
+}
+
+// until returns the cache key of the time a new attempt of key must wait for.
+func until(key Key) string {
+	return key.Name + "_until"
+}
+
+// Check checks if any of the keys must wait before a new attempt. It returns the longest time to wait, what is zero
+// when every key is allowed.
+func (l *Lockout) Check(ctx context.Context, keys ...Key) (time.Duration, error) {
+	var wait time.Duration
+
+	for _, key := range keys {
+		var t time.Time
+		if err := l.cache.Get(ctx, until(key), &t); err != nil {
+			return 0, err
+		}
+
+		if t.IsZero() {
+			continue
+		}
+
+		if w := t.Sub(clock.Now()); w > wait {
+			wait = w
+		}
+	}
+
+	return wait, nil
+}
+
+// Fail records a failed attempt for each key.
+func (l *Lockout) Fail(ctx context.Context, keys ...Key) error {
+	for _, key := range keys {
+		failures, err := l.cache.Increment(ctx, key.Name, key.Policy.Window)
+		if err != nil {
+			return err
+		}
+
+		if delay := key.Policy.delay(failures); delay > 0 {
+			if err := l.cache.Set(ctx, until(key), clock.Now().Add(delay), delay); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reset removes the failed attempts of each key, unlocking them.
+func (l *Lockout) Reset(ctx context.Context, keys ...Key) error {
+	for _, key := range keys {
+		if err := l.cache.Delete(ctx, key.Name); err != nil {
+			return err
+		}
+
+		if err := l.cache.Delete(ctx, until(key)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// delay returns how long a new attempt must wait after the failures.
+func (p Policy) delay(failures int64) time.Duration {
+	switch {
+	case failures == 0 || failures < int64(p.DelayAfter):
+		return 0
+	case failures >= int64(p.Threshold):
+		return p.Duration
+	}
+
+	delay := p.Delay << (failures - int64(p.DelayAfter))
+	if delay > p.MaxDelay || delay <= 0 {
+		delay = p.MaxDelay
+	}
+
+	return delay
+}
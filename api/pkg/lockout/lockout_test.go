@@ -0,0 +1,140 @@
+package lockout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCache is a minimal in-memory cache to keep the failed attempts during the tests. The values expire by the fake
+// clock.
+type memoryCache struct {
+	clock    *fakeClock
+	counters map[string]int64
+	times    map[string]time.Time
+	expires  map[string]time.Time
+}
+
+func newMemoryCache(clock *fakeClock) *memoryCache {
+	return &memoryCache{
+		clock:    clock,
+		counters: make(map[string]int64),
+		times:    make(map[string]time.Time),
+		expires:  make(map[string]time.Time),
+	}
+}
+
+func (m *memoryCache) expire(key string) {
+	if expires, ok := m.expires[key]; ok && !m.clock.now.Before(expires) {
+		delete(m.counters, key)
+		delete(m.times, key)
+		delete(m.expires, key)
+	}
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	m.expire(key)
+	*value.(*time.Time) = m.times[key]
+
+	return nil
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, ttl time.Duration) error {
+	m.times[key] = value.(time.Time)
+	m.expires[key] = m.clock.now.Add(ttl)
+
+	return nil
+}
+
+func (m *memoryCache) Delete(_ context.Context, key string) error {
+	delete(m.counters, key)
+	delete(m.times, key)
+	delete(m.expires, key)
+
+	return nil
+}
+
+func (m *memoryCache) Increment(_ context.Context, key string, ttl time.Duration) (int64, error) {
+	m.expire(key)
+	m.counters[key]++
+	m.expires[key] = m.clock.now.Add(ttl)
+
+	return m.counters[key], nil
+}
+
+// fakeClock is a clock whose time is moved by the tests.
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func TestLockout(t *testing.T) {
+	ctx := context.Background()
+
+	fake := &fakeClock{now: time.Now()}
+	backend := clock.DefaultBackend
+	clock.DefaultBackend = fake
+	t.Cleanup(func() { clock.DefaultBackend = backend })
+
+	policy := Policy{
+		Window:     time.Hour,
+		DelayAfter: 2,
+		Delay:      time.Second,
+		MaxDelay:   4 * time.Second,
+		Threshold:  5,
+		Duration:   10 * time.Minute,
+	}
+
+	key := Key{Name: "key", Policy: policy}
+	lockout := New(newMemoryCache(fake))
+
+	fail := func(n int) {
+		for i := 0; i < n; i++ {
+			assert.NoError(t, lockout.Fail(ctx, key))
+		}
+	}
+
+	check := func() time.Duration {
+		wait, err := lockout.Check(ctx, key)
+		assert.NoError(t, err)
+
+		return wait
+	}
+
+	// allows the attempts before the delay starts.
+	fail(1)
+	assert.Equal(t, time.Duration(0), check())
+
+	// delays the attempts, doubling the delay on every failure.
+	fail(1)
+	assert.Equal(t, time.Second, check())
+	fail(1)
+	assert.Equal(t, 2*time.Second, check())
+	fail(1)
+	assert.Equal(t, 4*time.Second, check())
+
+	fake.now = fake.now.Add(4 * time.Second)
+	assert.Equal(t, time.Duration(0), check())
+
+	// locks out after the threshold.
+	fail(1)
+	assert.Equal(t, 10*time.Minute, check())
+
+	fake.now = fake.now.Add(5 * time.Minute)
+	assert.Equal(t, 5*time.Minute, check())
+
+	// unlocks after reset.
+	assert.NoError(t, lockout.Reset(ctx, key))
+	assert.Equal(t, time.Duration(0), check())
+
+	// forgets the failures out of the window.
+	fail(5)
+	fake.now = fake.now.Add(policy.Window + time.Second)
+	assert.Equal(t, time.Duration(0), check())
+}
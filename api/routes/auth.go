@@ -5,7 +5,7 @@ import (
 	"net/http"
 	"strconv"
 
-	jwt "github.com/golang-jwt/jwt"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/mitchellh/mapstructure"
@@ -27,6 +27,11 @@ const (
 	AuthUserTokenInternalURL = "/auth/token/:id"     //nolint:gosec
 	AuthUserTokenPublicURL   = "/auth/token/:tenant" //nolint:gosec
 
+	AuthRefreshTokenURL = "/auth/refresh"
+
+	// JWKSURL publishes the public keys able to verify the API's tokens.
+	JWKSURL = "/.well-known/jwks.json"
+
 	AuthPublicKeyURL = "/auth/ssh"
 	AuthMFAURL       = "/auth/mfa"
 )
@@ -87,14 +92,23 @@ func (h *Handler) AuthRequest(c gateway.Context) error {
 			return err
 		}
 
+		// NOTICE: "mfa" is the "skip" of the routes that validate the user's second factor, whose attempts are limited.
 		args := c.QueryParam("args")
-		if args != "skip" && claims.Tenant != "" {
+		skip := args == "skip" || args == "mfa"
+		if !skip && claims.Tenant != "" {
 			// This forces any no cached token to be invalid, even if it not not expired.
 			if ok, err := h.service.AuthIsCacheToken(c.Ctx(), claims.Tenant, claims.ID); err != nil || !ok {
 				return svc.NewErrAuthUnathorized(err)
 			}
 		}
 
+		// NOTICE: tokens issued before the sessions have no session's ID and are valid until they expire.
+		if claims.SessionID != "" {
+			if revoked, err := h.service.IsUserSessionRevoked(c.Ctx(), claims.SessionID); err != nil || revoked {
+				return svc.NewErrAuthUnathorized(err)
+			}
+		}
+
 		MFA, err := h.service.AuthMFA(c.Ctx(), claims.ID)
 		if err != nil {
 			return err
@@ -109,9 +123,15 @@ func (h *Handler) AuthRequest(c gateway.Context) error {
 			// on user is enable. It is used by the route that validate the OTP from the user's OTP APP, avoiding extra
 			// logic in a middleware apart. When that is true, only the user's ID and username are send to the next
 			// route; other values are set for its default value.
-			if args != "skip" && !claims.MFA.Validate {
+			if !skip && !claims.MFA.Validate {
 				return svc.NewErrAuthUnathorized(errors.New("this token isn't validated"))
 			}
+
+			if args == "mfa" && !claims.MFA.Validate {
+				if err := h.service.AuthMFAAttempt(c.Ctx(), claims.ID); err != nil {
+					return err
+				}
+			}
 		}
 
 		// Extract datas of user from JWT
@@ -119,6 +139,7 @@ func (h *Handler) AuthRequest(c gateway.Context) error {
 		c.Response().Header().Set("X-Username", claims.Username)
 		c.Response().Header().Set("X-ID", claims.ID)
 		c.Response().Header().Set("X-Role", claims.Role)
+		c.Response().Header().Set("X-Session-ID", claims.SessionID)
 		c.Response().Header().Set("X-MFA", strconv.FormatBool(claims.MFA.Enable))
 		c.Response().Header().Set("X-Validate-MFA", strconv.FormatBool(claims.MFA.Validate))
 
@@ -178,7 +199,8 @@ func (h *Handler) AuthUser(c gateway.Context) error {
 	res, err := h.service.AuthUser(c.Ctx(), &models.UserAuthRequest{
 		Identifier: models.UserAuthIdentifier(req.Username),
 		Password:   req.Password,
-	})
+		UserAgent:  c.Request().UserAgent(),
+	}, c.Request().Header.Get("X-Real-IP"))
 	if err != nil {
 		if errors.Is(err, svc.ErrUserNotFound) {
 			return errs.NewErrUnauthorized(err)
@@ -236,7 +258,27 @@ func (h *Handler) AuthSwapToken(c gateway.Context) error {
 		id = v.ID
 	}
 
-	res, err := h.service.AuthSwapToken(c.Ctx(), id, req.Tenant)
+	session, _ := c.GetSessionID()
+
+	res, err := h.service.AuthSwapToken(c.Ctx(), id, req.Tenant, session)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) AuthRefreshToken(c gateway.Context) error {
+	var req requests.AuthTokenRefresh
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	res, err := h.service.AuthRefreshToken(c.Ctx(), req.RefreshToken)
 	if err != nil {
 		return err
 	}
@@ -262,6 +304,10 @@ func (h *Handler) AuthPublicKey(c gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+func (h *Handler) GetJWKS(c gateway.Context) error {
+	return c.JSON(http.StatusOK, h.service.JWKS(c.Ctx()))
+}
+
 func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, ok := c.Get("ctx").(*gateway.Context)
@@ -269,9 +315,9 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return svc.ErrTypeAssertion
 		}
 		jwt := middleware.JWTWithConfig(middleware.JWTConfig{ //nolint:staticcheck
-			Claims:        &jwt.MapClaims{},
-			SigningKey:    ctx.Service().(svc.Service).PublicKey(),
-			SigningMethod: "RS256",
+			ParseTokenFunc: func(auth string, _ echo.Context) (interface{}, error) {
+				return jwt.ParseWithClaims(auth, &jwt.MapClaims{}, svc.VerificationKeyFunc(ctx.Ctx(), ctx.Service().(svc.Service)))
+			},
 		})
 
 		return jwt(next)(c)
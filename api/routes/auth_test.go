@@ -175,6 +175,7 @@ func TestAuthUser(t *testing.T) {
 	type Expected struct {
 		expectedResponse *models.UserAuthResponse
 		expectedStatus   int
+		retryAfter       string
 	}
 
 	cases := []struct {
@@ -195,7 +196,7 @@ func TestAuthUser(t *testing.T) {
 					Password:   "testpassword",
 				}
 
-				mock.On("AuthUser", gomock.Anything, req).Return(&models.UserAuthResponse{}, nil).Once()
+				mock.On("AuthUser", gomock.Anything, req, "").Return(&models.UserAuthResponse{}, nil).Once()
 			},
 			expected: Expected{
 				expectedResponse: &models.UserAuthResponse{},
@@ -233,13 +234,28 @@ func TestAuthUser(t *testing.T) {
 				Password:   "password",
 			},
 			requiredMocks: func() {
-				mock.On("AuthUser", gomock.Anything, gomock.Anything).Return(nil, svc.ErrAuthUnathorized).Once()
+				mock.On("AuthUser", gomock.Anything, gomock.Anything, gomock.Anything).Return(nil, svc.ErrAuthUnathorized).Once()
 			},
 			expected: Expected{
 				expectedResponse: nil,
 				expectedStatus:   http.StatusUnauthorized,
 			},
 		},
+		{
+			title: "fail when the user is locked out",
+			requestBody: &models.UserAuthRequest{
+				Identifier: "username",
+				Password:   "password",
+			},
+			requiredMocks: func() {
+				mock.On("AuthUser", gomock.Anything, gomock.Anything, gomock.Anything).Return(nil, svc.NewErrAuthLocked(90*time.Second, nil)).Once()
+			},
+			expected: Expected{
+				expectedResponse: nil,
+				expectedStatus:   http.StatusTooManyRequests,
+				retryAfter:       "90",
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -259,6 +275,7 @@ func TestAuthUser(t *testing.T) {
 			e.ServeHTTP(rec, req)
 
 			assert.Equal(t, tc.expected.expectedStatus, rec.Result().StatusCode)
+			assert.Equal(t, tc.expected.retryAfter, rec.Result().Header.Get("Retry-After"))
 
 			if tc.expected.expectedResponse != nil {
 				var response models.UserAuthResponse
@@ -364,7 +381,7 @@ func TestAuthSwapToken(t *testing.T) {
 			title:       "success when try to swap token",
 			requestBody: "tenant",
 			requiredMocks: func() {
-				mock.On("AuthSwapToken", gomock.Anything, "id", "tenant").Return(&models.UserAuthResponse{}, nil).Once()
+				mock.On("AuthSwapToken", gomock.Anything, "id", "tenant", "").Return(&models.UserAuthResponse{}, nil).Once()
 			},
 			expected: Expected{
 				expectedResponse: &models.UserAuthResponse{},
@@ -410,6 +427,83 @@ func TestAuthSwapToken(t *testing.T) {
 	}
 }
 
+func TestAuthRefreshToken(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		expectedResponse *models.UserAuthResponse
+		expectedStatus   int
+	}
+
+	cases := []struct {
+		title         string
+		requestBody   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			title:         "fails when the refresh token is missing",
+			requestBody:   `{}`,
+			requiredMocks: func() {},
+			expected: Expected{
+				expectedResponse: nil,
+				expectedStatus:   http.StatusBadRequest,
+			},
+		},
+		{
+			title:       "fails when the refresh token is invalid",
+			requestBody: `{"refresh_token": "session.used"}`,
+			requiredMocks: func() {
+				mock.On("AuthRefreshToken", gomock.Anything, "session.used").Return(nil, svc.NewErrAuthUnathorized(nil)).Once()
+			},
+			expected: Expected{
+				expectedResponse: nil,
+				expectedStatus:   http.StatusUnauthorized,
+			},
+		},
+		{
+			title:       "success when try to refresh the token",
+			requestBody: `{"refresh_token": "session.secret"}`,
+			requiredMocks: func() {
+				mock.On("AuthRefreshToken", gomock.Anything, "session.secret").Return(&models.UserAuthResponse{
+					Token:        "token",
+					RefreshToken: "session.new",
+				}, nil).Once()
+			},
+			expected: Expected{
+				expectedResponse: &models.UserAuthResponse{
+					Token:        "token",
+					RefreshToken: "session.new",
+				},
+				expectedStatus: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", strings.NewReader(tc.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expected.expectedResponse != nil {
+				var response models.UserAuthResponse
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&response))
+				assert.Equal(t, tc.expected.expectedResponse, &response)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestAuthPublicKey(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -489,32 +583,20 @@ func TestAuthRequest(t *testing.T) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	assert.NoError(t, err)
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserAuthClaims{
-		Username: "username",
-		Admin:    true,
-		Tenant:   "tenant",
-		Role:     "role",
-		ID:       "id",
-		AuthClaims: models.AuthClaims{
-			Claims: "user",
-		},
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Hour * 72)),
-		},
-	})
-
 	type Expected struct {
 		expectedStatus int
 	}
 	cases := []struct {
 		title         string
+		args          string
+		mfa           models.MFA
 		requiredMocks func()
 		expected      Expected
 	}{
 		{
 			title: "success when trying to verify token authorization",
 			requiredMocks: func() {
-				mock.On("PublicKey").Return(&privateKey.PublicKey).Once()
+				mock.On("VerificationKey", gomock.Anything, "").Return(&privateKey.PublicKey, nil).Once()
 				mock.On("AuthIsCacheToken", gomock.Anything, "tenant", "id").Return(true, nil).Once()
 				mock.On("AuthMFA", gomock.Anything, "id").Return(false, nil).Once()
 			},
@@ -525,19 +607,72 @@ func TestAuthRequest(t *testing.T) {
 		{
 			title: "fails when token dont have cache",
 			requiredMocks: func() {
-				mock.On("PublicKey").Return(&privateKey.PublicKey).Once()
+				mock.On("VerificationKey", gomock.Anything, "").Return(&privateKey.PublicKey, nil).Once()
 				mock.On("AuthIsCacheToken", gomock.Anything, "tenant", "id").Return(false, nil).Once()
 			},
 			expected: Expected{
 				expectedStatus: http.StatusUnauthorized,
 			},
 		},
+		{
+			title: "success when trying to validate the MFA with an unvalidated token",
+			args:  "mfa",
+			mfa:   models.MFA{Enable: true, Validate: false},
+			requiredMocks: func() {
+				mock.On("VerificationKey", gomock.Anything, "").Return(&privateKey.PublicKey, nil).Once()
+				mock.On("AuthMFA", gomock.Anything, "id").Return(true, nil).Once()
+				mock.On("AuthMFAAttempt", gomock.Anything, "id").Return(nil).Once()
+			},
+			expected: Expected{
+				expectedStatus: http.StatusOK,
+			},
+		},
+		{
+			title: "fails when the MFA attempts of the user are locked",
+			args:  "mfa",
+			mfa:   models.MFA{Enable: true, Validate: false},
+			requiredMocks: func() {
+				mock.On("VerificationKey", gomock.Anything, "").Return(&privateKey.PublicKey, nil).Once()
+				mock.On("AuthMFA", gomock.Anything, "id").Return(true, nil).Once()
+				mock.On("AuthMFAAttempt", gomock.Anything, "id").Return(svc.NewErrAuthLocked(time.Minute, nil)).Once()
+			},
+			expected: Expected{
+				expectedStatus: http.StatusTooManyRequests,
+			},
+		},
+		{
+			title: "fails when trying to access a route with an unvalidated token",
+			mfa:   models.MFA{Enable: true, Validate: false},
+			requiredMocks: func() {
+				mock.On("VerificationKey", gomock.Anything, "").Return(&privateKey.PublicKey, nil).Once()
+				mock.On("AuthIsCacheToken", gomock.Anything, "tenant", "id").Return(true, nil).Once()
+				mock.On("AuthMFA", gomock.Anything, "id").Return(true, nil).Once()
+			},
+			expected: Expected{
+				expectedStatus: http.StatusUnauthorized,
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.title, func(t *testing.T) {
 			tc.requiredMocks()
 
-			req := httptest.NewRequest(http.MethodGet, "/internal/auth", nil)
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserAuthClaims{
+				Username: "username",
+				Admin:    true,
+				Tenant:   "tenant",
+				Role:     "role",
+				ID:       "id",
+				MFA:      tc.mfa,
+				AuthClaims: models.AuthClaims{
+					Claims: "user",
+				},
+				RegisteredClaims: jwt.RegisteredClaims{
+					ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Hour * 72)),
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/internal/auth?args="+tc.args, nil)
 			req.Header.Set("Content-Type", "application/json")
 
 			tokenStr, err := token.SignedString(privateKey)
@@ -555,4 +690,6 @@ func TestAuthRequest(t *testing.T) {
 			assert.Equal(t, tc.expected.expectedStatus, rec.Result().StatusCode)
 		})
 	}
+
+	mock.AssertExpectations(t)
 }
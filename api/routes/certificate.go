@@ -0,0 +1,97 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	// GetCertificateAuthorityURL gets the SSH user certificate authority of the namespace from the user's or device's
+	// token.
+	GetCertificateAuthorityURL = "/sshkeys/certificate-authority"
+	// GetNamespaceCertificateAuthorityURL gets the SSH user certificate authority of a namespace by its tenant.
+	GetNamespaceCertificateAuthorityURL = "/sshkeys/certificate-authority/:tenant"
+	// CreateUserCertificateURL signs a short-lived SSH user certificate for the authenticated member.
+	CreateUserCertificateURL = "/sshkeys/certificates"
+)
+
+func (h *Handler) GetCertificateAuthority(c gateway.Context) error {
+	var tenant string
+	switch {
+	case c.Tenant() != nil:
+		tenant = c.Tenant().ID
+	case c.Request().Header.Get(client.DeviceUIDHeader) != "":
+		device, err := h.service.GetDevice(c.Ctx(), models.UID(c.Request().Header.Get(client.DeviceUIDHeader)))
+		if err != nil {
+			return err
+		}
+
+		tenant = device.TenantID
+	default:
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	ca, err := h.service.GetCertificateAuthority(c.Ctx(), tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ca)
+}
+
+func (h *Handler) GetNamespaceCertificateAuthority(c gateway.Context) error {
+	var req requests.TenantParam
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	ca, err := h.service.GetCertificateAuthority(c.Ctx(), req.Tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ca)
+}
+
+func (h *Handler) CreateUserCertificate(c gateway.Context) error {
+	var req requests.UserCertificateCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant, id string
+	if v := c.Tenant(); v != nil {
+		tenant = v.ID
+	}
+
+	if v := c.ID(); v != nil {
+		id = v.ID
+	}
+
+	var cert *models.UserCertificate
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		var err error
+		cert, err = h.service.CreateUserCertificate(c.Ctx(), tenant, id, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, cert)
+}
@@ -3,11 +3,13 @@ package routes
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/filter"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -17,6 +19,7 @@ const (
 	GetDeviceListURL            = "/devices"
 	GetDeviceURL                = "/devices/:uid"
 	GetDeviceByPublicURLAddress = "/devices/public/:address"
+	AuthorizeDevicePublicURL    = "/devices/public/:address/authorize"
 	DeleteDeviceURL             = "/devices/:uid"
 	RenameDeviceURL             = "/devices/:uid"
 	OfflineDeviceURL            = "/devices/:uid/offline"
@@ -27,6 +30,16 @@ const (
 	UpdateTagURL                = "/devices/:uid/tags"      // Update device's tags with a new set.
 	RemoveTagURL                = "/devices/:uid/tags/:tag" // Delete a tag from a device.
 	UpdateDevice                = "/devices/:uid"
+	UpdateLabelsURL             = "/devices/:uid/labels"      // Set labels to a device, keeping the others.
+	RemoveLabelURL              = "/devices/:uid/labels/:key" // Delete a label from a device.
+	CreatePublicURLServiceURL   = "/devices/:uid/public-urls"
+	UpdatePublicURLServiceURL   = "/devices/:uid/public-urls/:address"
+	DeletePublicURLServiceURL   = "/devices/:uid/public-urls/:address"
+	CreatePublishedPortURL      = "/devices/:uid/ports"
+	DeletePublishedPortURL      = "/devices/:uid/ports/:address"
+	CreatePublishedPortTokenURL = "/devices/:uid/ports/:address/token"
+	CreatePublishedPortCertURL  = "/devices/:uid/ports/:address/certificate"
+	AuthorizePublishedPortURL   = "/devices/ports/authorize"
 )
 
 const (
@@ -37,12 +50,33 @@ const (
 
 type filterQuery struct {
 	Filter  string              `query:"filter"`
+	Search  string              `query:"search"`
 	Status  models.DeviceStatus `query:"status"`
 	SortBy  string              `query:"sort_by"`
 	OrderBy string              `query:"order_by"`
 	paginator.Query
 }
 
+// filters gets the query's filters, compiling them from the search syntax when a search is set, or decoding them from
+// the base64 encoded JSON otherwise.
+func (q *filterQuery) filters() ([]models.Filter, error) {
+	if q.Search != "" {
+		return filter.Parse(q.Search)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(q.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var filters []models.Filter
+	if err := json.Unmarshal(raw, &filters); len(raw) > 0 && err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
 func (h *Handler) GetDeviceList(c gateway.Context) error {
 	query := filterQuery{}
 	if err := c.Bind(&query); err != nil {
@@ -51,13 +85,12 @@ func (h *Handler) GetDeviceList(c gateway.Context) error {
 
 	query.Normalize()
 
-	raw, err := base64.StdEncoding.DecodeString(query.Filter)
-	if err != nil {
-		return err
+	filters, err := query.filters()
+	if syntax := new(filter.SyntaxError); errors.As(err, &syntax) {
+		return c.JSON(http.StatusBadRequest, syntax)
 	}
 
-	var filter []models.Filter
-	if err := json.Unmarshal(raw, &filter); len(raw) > 0 && err != nil {
+	if err != nil {
 		return err
 	}
 
@@ -66,7 +99,7 @@ func (h *Handler) GetDeviceList(c gateway.Context) error {
 		tenant = c.Tenant().ID
 	}
 
-	devices, count, err := h.service.ListDevices(c.Ctx(), tenant, query.Query, filter, query.Status, query.SortBy, query.OrderBy)
+	devices, count, err := h.service.ListDevices(c.Ctx(), tenant, query.Query, filters, query.Status, query.SortBy, query.OrderBy)
 	if err != nil {
 		return err
 	}
@@ -112,6 +145,23 @@ func (h *Handler) GetDeviceByPublicURLAddress(c gateway.Context) error {
 	return c.JSON(http.StatusOK, url)
 }
 
+func (h *Handler) AuthorizeDevicePublicURL(c gateway.Context) error {
+	var req requests.DevicePublicURLAuthorize
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.AuthorizeDevicePublicURL(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) DeleteDevice(c gateway.Context) error {
 	var req requests.DeviceDelete
 	if err := c.Bind(&req); err != nil {
@@ -307,6 +357,56 @@ func (h *Handler) UpdateDeviceTag(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) UpdateDeviceLabels(c gateway.Context) error {
+	var req requests.DeviceUpdateLabels
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.UpdateLabel, func() error {
+		return h.service.UpdateDeviceLabels(c.Ctx(), tenant, models.UID(req.UID), req.Labels)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) RemoveDeviceLabel(c gateway.Context) error {
+	var req requests.DeviceRemoveLabel
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.RemoveLabel, func() error {
+		return h.service.RemoveDeviceLabel(c.Ctx(), tenant, models.UID(req.UID), req.Key)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) UpdateDevice(c gateway.Context) error {
 	var req requests.DeviceUpdate
 	if err := c.Bind(&req); err != nil {
@@ -330,3 +430,213 @@ func (h *Handler) UpdateDevice(c gateway.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+func (h *Handler) CreateDevicePublicURLService(c gateway.Context) error {
+	var req requests.DevicePublicURLServiceCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var service *models.DevicePublicURLService
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		var err error
+		service, err = h.service.CreateDevicePublicURLService(c.Ctx(), tenant, models.UID(req.UID), req.DevicePublicURLServiceFields)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, service)
+}
+
+func (h *Handler) UpdateDevicePublicURLService(c gateway.Context) error {
+	var req requests.DevicePublicURLServiceUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var service *models.DevicePublicURLService
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		var err error
+		service, err = h.service.UpdateDevicePublicURLService(c.Ctx(), tenant, models.UID(req.UID), req.Address, req.DevicePublicURLServiceFields)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, service)
+}
+
+func (h *Handler) DeleteDevicePublicURLService(c gateway.Context) error {
+	var req requests.DevicePublicURLServiceDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		return h.service.DeleteDevicePublicURLService(c.Ctx(), tenant, models.UID(req.UID), req.Address)
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) CreateDevicePublishedPort(c gateway.Context) error {
+	var req requests.DevicePublishedPortCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var port *models.DevicePublishedPort
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		var err error
+		port, err = h.service.CreateDevicePublishedPort(c.Ctx(), tenant, models.UID(req.UID), req)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, port)
+}
+
+func (h *Handler) DeleteDevicePublishedPort(c gateway.Context) error {
+	var req requests.DevicePublishedPortDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		return h.service.DeleteDevicePublishedPort(c.Ctx(), tenant, models.UID(req.UID), req.Address)
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) CreateDevicePublishedPortToken(c gateway.Context) error {
+	var req requests.DevicePublishedPortToken
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	id, _ := c.GetID()
+
+	var token *models.DevicePublishedPortToken
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		var err error
+		token, err = h.service.CreateDevicePublishedPortToken(c.Ctx(), tenant, id, models.UID(req.UID), req.Address)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, token)
+}
+
+func (h *Handler) CreateDevicePublishedPortCertificate(c gateway.Context) error {
+	var req requests.DevicePublishedPortCertificate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	id, _ := c.GetID()
+
+	var certificate *models.DevicePublishedPortCertificate
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		var err error
+		certificate, err = h.service.CreateDevicePublishedPortCertificate(c.Ctx(), tenant, id, req)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, certificate)
+}
+
+func (h *Handler) AuthorizeDevicePublishedPort(c gateway.Context) error {
+	var req requests.DevicePublishedPortAuthorize
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	device, err := h.service.AuthorizeDevicePublishedPort(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, device)
+}
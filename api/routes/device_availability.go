@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	// GetDeviceAvailabilityURL reports the availability, outages and MTTR of the device.
+	GetDeviceAvailabilityURL = "/devices/:uid/availability"
+	// GetTagAvailabilityURL reports the availability, outages and MTTR of the devices with the tag. It is not nested in
+	// the tag's path, so a tag with a slash keeps being rejected as invalid instead of as not found.
+	GetTagAvailabilityURL = "/availability/tags/:tag"
+)
+
+func (h *Handler) GetDeviceAvailability(c gateway.Context) error {
+	var req requests.DeviceAvailabilityGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	availability, err := h.service.GetDeviceAvailability(c.Ctx(), tenant, models.UID(req.UID), req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, availability)
+}
+
+func (h *Handler) GetTagAvailability(c gateway.Context) error {
+	var req requests.TagAvailabilityGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	availability, err := h.service.GetTagAvailability(c.Ctx(), tenant, req.Tag, req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, availability)
+}
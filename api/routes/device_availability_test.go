@@ -0,0 +1,145 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestGetDeviceAvailability(t *testing.T) {
+	mock := new(mocks.Service)
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	availability := &models.DeviceAvailability{
+		UID:          "uid",
+		Name:         "name",
+		From:         from,
+		To:           to,
+		Availability: 99.5,
+		Outages:      []models.DeviceOutage{{Start: from, End: from.Add(time.Hour), Duration: 3600}},
+		MTTR:         3600,
+	}
+
+	cases := []struct {
+		description    string
+		query          string
+		requiredMocks  func()
+		expectedStatus int
+		expected       *models.DeviceAvailability
+	}{
+		{
+			description:    "fails when the period is not a date",
+			query:          "from=yesterday",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			description: "fails when the device is not found",
+			query:       "",
+			requiredMocks: func() {
+				mock.On("GetDeviceAvailability", gomock.Anything, "tenant-id", models.UID("uid"), time.Time{}, time.Time{}).
+					Return(nil, svc.NewErrDeviceNotFound("uid", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "success when the availability is reported",
+			query:       "from=2023-01-01T00:00:00Z&to=2023-02-01T00:00:00Z",
+			requiredMocks: func() {
+				mock.On("GetDeviceAvailability", gomock.Anything, "tenant-id", models.UID("uid"), from, to).
+					Return(availability, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expected:       availability,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/uid/availability?"+tc.query, nil)
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expected != nil {
+				var reported models.DeviceAvailability
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&reported))
+				assert.Equal(t, *tc.expected, reported)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetTagAvailability(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		description    string
+		tag            string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			description:    "fails when the tag is invalid",
+			tag:            "ab",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the tag has too many devices",
+			tag:         "production",
+			requiredMocks: func() {
+				mock.On("GetTagAvailability", gomock.Anything, "tenant-id", "production", time.Time{}, time.Time{}).
+					Return(nil, svc.NewErrDeviceAvailabilityLimit(svc.DeviceAvailabilityMaxDevices, nil)).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "success when the availability is reported",
+			tag:         "production",
+			requiredMocks: func() {
+				mock.On("GetTagAvailability", gomock.Anything, "tenant-id", "production", time.Time{}, time.Time{}).
+					Return(&models.TagAvailability{Tag: "production", Devices: []models.DeviceAvailability{}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/availability/tags/"+tc.tag, nil)
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
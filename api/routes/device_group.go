@@ -0,0 +1,185 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListDeviceGroupsURL       = "/groups"
+	GetDeviceGroupURL         = "/groups/:id"
+	CreateDeviceGroupURL      = "/groups"
+	UpdateDeviceGroupURL      = "/groups/:id"
+	DeleteDeviceGroupURL      = "/groups/:id"
+	ListDeviceGroupDevicesURL = "/groups/:id/devices" // List the devices matching a group.
+)
+
+const (
+	ParamDeviceGroupID = "id"
+)
+
+func (h *Handler) ListDeviceGroups(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	groups, count, err := h.service.ListDeviceGroups(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, groups)
+}
+
+func (h *Handler) GetDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	group, err := h.service.GetDeviceGroup(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) CreateDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant, id string
+	if v := c.Tenant(); v != nil {
+		tenant = v.ID
+	}
+
+	if v := c.ID(); v != nil {
+		id = v.ID
+	}
+
+	var group *models.DeviceGroup
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.CreateGroup, func() error {
+		var err error
+		group, err = h.service.CreateDeviceGroup(c.Ctx(), tenant, id, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) UpdateDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	var group *models.DeviceGroup
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.EditGroup, func() error {
+		var err error
+		group, err = h.service.UpdateDeviceGroup(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, group)
+}
+
+func (h *Handler) DeleteDeviceGroup(c gateway.Context) error {
+	var req requests.DeviceGroupDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.RemoveGroup, func() error {
+		return h.service.DeleteDeviceGroup(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListDeviceGroupDevices(c gateway.Context) error {
+	var req requests.DeviceGroupDevices
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	devices, count, err := h.service.ListDeviceGroupDevices(c.Ctx(), tenant, req.ID, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, devices)
+}
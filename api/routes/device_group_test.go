@@ -0,0 +1,215 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/filter"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceGroup(t *testing.T) {
+	mock := new(mocks.Service)
+
+	group := requests.DeviceGroupCreate{
+		DeviceGroupFields: requests.DeviceGroupFields{
+			Name: "arm64",
+			Filter: filter.FilterList{
+				{Type: "property", Params: &filter.FilterTypeProperty{Name: "info.arch", Operator: "eq", Value: "arm64"}},
+				{Type: "operator", Params: &filter.FilterTypeOperator{Name: "and"}},
+			},
+			OrderBy: "asc",
+		},
+	}
+
+	body := `{"name":"arm64","filter":[{"type":"property","params":{"name":"info.arch","operator":"eq","value":"arm64"}},{"type":"operator","params":{"name":"and"}}],"order_by":"asc"}`
+
+	cases := []struct {
+		description    string
+		role           string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			description:    "fails when the name is missing",
+			role:           guard.RoleOperator,
+			body:           `{"filter":[{"type":"operator","params":{"name":"and"}}]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "fails when the order is invalid",
+			role:           guard.RoleOperator,
+			body:           `{"name":"arm64","filter":[{"type":"operator","params":{"name":"and"}}],"order_by":"up"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "fails when the role cannot create groups",
+			role:           guard.RoleObserver,
+			body:           body,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "fails when a group with the same name exists",
+			role:        guard.RoleOperator,
+			body:        body,
+			requiredMocks: func() {
+				mock.On("CreateDeviceGroup", gomock.Anything, "tenant-id", "user-id", group).
+					Return(nil, svc.NewErrDeviceGroupDuplicated([]string{"arm64"}, nil)).Once()
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			description: "success when the group is created",
+			role:        guard.RoleOperator,
+			body:        body,
+			requiredMocks: func() {
+				mock.On("CreateDeviceGroup", gomock.Anything, "tenant-id", "user-id", group).
+					Return(&models.DeviceGroup{ID: "65a5f3ec2a5b4c0e9b3a0d71"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/groups", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-ID", "user-id")
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteDeviceGroup(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		description    string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			description:    "fails when the role cannot remove groups",
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "fails when the group is not found",
+			role:        guard.RoleOperator,
+			requiredMocks: func() {
+				mock.On("DeleteDeviceGroup", gomock.Anything, "tenant-id", "65a5f3ec2a5b4c0e9b3a0d71").
+					Return(svc.NewErrDeviceGroupNotFound("65a5f3ec2a5b4c0e9b3a0d71", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "success when the group is removed",
+			role:        guard.RoleOperator,
+			requiredMocks: func() {
+				mock.On("DeleteDeviceGroup", gomock.Anything, "tenant-id", "65a5f3ec2a5b4c0e9b3a0d71").Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/groups/65a5f3ec2a5b4c0e9b3a0d71", nil)
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceGroupDevices(t *testing.T) {
+	mock := new(mocks.Service)
+
+	devices := []models.Device{{UID: "uid", Name: "device"}}
+
+	cases := []struct {
+		description    string
+		requiredMocks  func()
+		expectedStatus int
+		expected       []models.Device
+	}{
+		{
+			description: "fails when the group is not found",
+			requiredMocks: func() {
+				mock.On("ListDeviceGroupDevices", gomock.Anything, "tenant-id", "65a5f3ec2a5b4c0e9b3a0d71", paginator.Query{Page: 2, PerPage: 5}).
+					Return(nil, 0, svc.NewErrDeviceGroupNotFound("65a5f3ec2a5b4c0e9b3a0d71", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "success when the group's devices are listed",
+			requiredMocks: func() {
+				mock.On("ListDeviceGroupDevices", gomock.Anything, "tenant-id", "65a5f3ec2a5b4c0e9b3a0d71", paginator.Query{Page: 2, PerPage: 5}).
+					Return(devices, 1, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expected:       devices,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/groups/65a5f3ec2a5b4c0e9b3a0d71/devices?page=2&per_page=5", nil)
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expected != nil {
+				var listed []models.Device
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&listed))
+				assert.Equal(t, tc.expected, listed)
+				assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,74 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	// ReportDeviceInventoryURL receives the inventory reported by the agent of the device's token.
+	ReportDeviceInventoryURL = "/devices/inventory"
+	// ListDeviceInventoryURL lists the device's inventory history.
+	ListDeviceInventoryURL = "/devices/:uid/inventory"
+)
+
+func (h *Handler) ReportDeviceInventory(c gateway.Context) error {
+	uid := c.Request().Header.Get(client.DeviceUIDHeader)
+	if uid == "" {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	var req requests.DeviceInventoryReport
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.ReportDeviceInventory(c.Ctx(), models.UID(uid), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListDeviceInventory(c gateway.Context) error {
+	var req requests.DeviceInventoryList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	inventories, count, err := h.service.ListDeviceInventory(c.Ctx(), tenant, models.UID(req.UID), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, inventories)
+}
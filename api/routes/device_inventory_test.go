@@ -0,0 +1,149 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestReportDeviceInventory(t *testing.T) {
+	mock := new(mocks.Service)
+
+	inventory := requests.DeviceInventoryReport{
+		Kernel:   "6.1.0",
+		CPU:      requests.DeviceInventoryCPU{Model: "ARMv7 Processor rev 4 (v7l)", Count: 4},
+		Uptime:   3600,
+		Packages: 512,
+	}
+
+	body := `{"kernel":"6.1.0","cpu":{"model":"ARMv7 Processor rev 4 (v7l)","count":4},"uptime":3600,"packages":512}`
+
+	cases := []struct {
+		description    string
+		uid            string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			description:    "fails when the request is not from a device",
+			uid:            "",
+			body:           body,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			description:    "fails when the packages count is invalid",
+			uid:            "uid",
+			body:           `{"packages":-1}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the device is not found",
+			uid:         "uid",
+			body:        body,
+			requiredMocks: func() {
+				mock.On("ReportDeviceInventory", gomock.Anything, models.UID("uid"), inventory).
+					Return(svc.NewErrDeviceNotFound("uid", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "success when the inventory is reported",
+			uid:         "uid",
+			body:        body,
+			requiredMocks: func() {
+				mock.On("ReportDeviceInventory", gomock.Anything, models.UID("uid"), inventory).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/inventory", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.uid != "" {
+				req.Header.Set("X-Device-UID", tc.uid)
+			}
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceInventory(t *testing.T) {
+	mock := new(mocks.Service)
+
+	inventories := []models.DeviceInventory{{Kernel: "6.1.0", Uptime: 3600}}
+
+	cases := []struct {
+		description    string
+		requiredMocks  func()
+		expectedStatus int
+		expected       []models.DeviceInventory
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("ListDeviceInventory", gomock.Anything, "tenant-id", models.UID("uid"), paginator.Query{Page: 2, PerPage: 5}).
+					Return(nil, 0, svc.NewErrDeviceNotFound("uid", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "success when the inventory history is listed",
+			requiredMocks: func() {
+				mock.On("ListDeviceInventory", gomock.Anything, "tenant-id", models.UID("uid"), paginator.Query{Page: 2, PerPage: 5}).
+					Return(inventories, 1, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expected:       inventories,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/uid/inventory?page=2&per_page=5", nil)
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expected != nil {
+				var listed []models.DeviceInventory
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&listed))
+				assert.Equal(t, tc.expected, listed)
+				assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	CreateDeviceJobURL = "/devices/bulk"     // Run an operation on several devices.
+	GetDeviceJobURL    = "/devices/bulk/:id" // Poll the status of a bulk operation.
+)
+
+func (h *Handler) CreateDeviceJob(c gateway.Context) error {
+	var req requests.DeviceJobCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant, id string
+	if v := c.Tenant(); v != nil {
+		tenant = v.ID
+	}
+
+	if v := c.ID(); v != nil {
+		id = v.ID
+	}
+
+	// NOTICE: the permission is evaluated for the operation by the service, and again for each device when the job runs.
+	job, err := h.service.CreateDeviceJob(c.Ctx(), tenant, id, c.Role(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusAccepted, job)
+}
+
+func (h *Handler) GetDeviceJob(c gateway.Context) error {
+	var req requests.DeviceJobGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	job, err := h.service.GetDeviceJob(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,140 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		description    string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			description:    "fails when the operation is unknown",
+			body:           `{"operation":"reboot","uids":["uid"]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "fails when neither uids nor filter are set",
+			body:           `{"operation":"accept"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "fails when both uids and filter are set",
+			body:           `{"operation":"accept","uids":["uid"],"filter":[{"type":"operator","params":{"name":"and"}}]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the role cannot run the operation",
+			body:        `{"operation":"remove","uids":["uid"]}`,
+			requiredMocks: func() {
+				mock.On("CreateDeviceJob", gomock.Anything, "tenant-id", "user-id", guard.RoleOperator,
+					requests.DeviceJobCreate{Operation: "remove", UIDs: []string{"uid"}}).
+					Return(nil, guard.ErrForbidden).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "fails when the filter matches too many devices",
+			body:        `{"operation":"accept","filter":[{"type":"operator","params":{"name":"and"}}]}`,
+			requiredMocks: func() {
+				mock.On("CreateDeviceJob", gomock.Anything, "tenant-id", "user-id", guard.RoleOperator, gomock.AnythingOfType("requests.DeviceJobCreate")).
+					Return(nil, svc.NewErrDeviceJobLimit(svc.DeviceJobMaxItems, nil)).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			description: "success when the job is created",
+			body:        `{"operation":"tag","uids":["uid","uid2"],"tag":"production"}`,
+			requiredMocks: func() {
+				mock.On("CreateDeviceJob", gomock.Anything, "tenant-id", "user-id", guard.RoleOperator,
+					requests.DeviceJobCreate{Operation: "tag", UIDs: []string{"uid", "uid2"}, Tag: "production"}).
+					Return(&models.DeviceJob{ID: "00000000-0000-4000-0000-000000000001"}, nil).Once()
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/bulk", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOperator)
+			req.Header.Set("X-ID", "user-id")
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetDeviceJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		description    string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			description: "fails when the job is not found",
+			requiredMocks: func() {
+				mock.On("GetDeviceJob", gomock.Anything, "tenant-id", "00000000-0000-4000-0000-000000000001").
+					Return(nil, svc.NewErrDeviceJobNotFound("00000000-0000-4000-0000-000000000001", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "success when the job is found",
+			requiredMocks: func() {
+				mock.On("GetDeviceJob", gomock.Anything, "tenant-id", "00000000-0000-4000-0000-000000000001").
+					Return(&models.DeviceJob{ID: "00000000-0000-4000-0000-000000000001"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/bulk/00000000-0000-4000-0000-000000000001", nil)
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	// ReportDeviceMetricsURL receives the metrics pushed by the agent of the device's token.
+	ReportDeviceMetricsURL = "/devices/metrics"
+	// ListDeviceMetricsURL lists the time series of the device's metrics.
+	ListDeviceMetricsURL = "/devices/:uid/metrics"
+	// ListDeviceAlertsURL lists the alerts raised by the namespace's devices.
+	ListDeviceAlertsURL = "/alerts"
+)
+
+func (h *Handler) ReportDeviceMetrics(c gateway.Context) error {
+	uid := c.Request().Header.Get(client.DeviceUIDHeader)
+	if uid == "" {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	var req requests.DeviceMetricsReport
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.ReportDeviceMetrics(c.Ctx(), models.UID(uid), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListDeviceMetrics(c gateway.Context) error {
+	var req requests.DeviceMetricsList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	points, err := h.service.ListDeviceMetrics(c.Ctx(), tenant, models.UID(req.UID), models.DeviceMetricsResolution(req.Resolution), req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, points)
+}
+
+func (h *Handler) ListDeviceAlerts(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if t := c.Tenant(); t != nil {
+		tenant = t.ID
+	}
+
+	alerts, count, err := h.service.ListDeviceAlerts(c.Ctx(), tenant, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, alerts)
+}
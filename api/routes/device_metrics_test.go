@@ -0,0 +1,180 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestReportDeviceMetrics(t *testing.T) {
+	mock := new(mocks.Service)
+
+	temperature := 52.5
+
+	cases := []struct {
+		description    string
+		uid            string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			description:    "fails when the request is not from a device",
+			uid:            "",
+			body:           `{"load1":0.5}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			description:    "fails when the memory is not a percentage",
+			uid:            "uid",
+			body:           `{"memory":120}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "success when the metrics are reported",
+			uid:         "uid",
+			body:        `{"load1":0.5,"load5":0.25,"load15":0.1,"memory":40,"disk":75,"temperature":52.5}`,
+			requiredMocks: func() {
+				mock.On("ReportDeviceMetrics", gomock.Anything, models.UID("uid"), requests.DeviceMetricsReport{
+					Load1:       0.5,
+					Load5:       0.25,
+					Load15:      0.1,
+					Memory:      40,
+					Disk:        75,
+					Temperature: &temperature,
+				}).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/metrics", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.uid != "" {
+				req.Header.Set("X-Device-UID", tc.uid)
+			}
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceMetrics(t *testing.T) {
+	mock := new(mocks.Service)
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	points := []models.DeviceMetricsPoint{
+		{Timestamp: from, Samples: 60, Average: models.DeviceMetrics{Load1: 0.5}, Max: models.DeviceMetrics{Load1: 1}},
+	}
+
+	cases := []struct {
+		description    string
+		query          string
+		requiredMocks  func()
+		expectedStatus int
+		expected       []models.DeviceMetricsPoint
+	}{
+		{
+			description:    "fails when the resolution is invalid",
+			query:          "resolution=second",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description: "fails when the device is not found",
+			query:       "resolution=hour",
+			requiredMocks: func() {
+				mock.On("ListDeviceMetrics", gomock.Anything, "tenant-id", models.UID("uid"), models.DeviceMetricsResolutionHour, time.Time{}, time.Time{}).
+					Return(nil, svc.NewErrDeviceNotFound("uid", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			description: "success when the metrics are listed",
+			query:       "resolution=minute&from=2023-01-01T00:00:00Z&to=2023-01-02T00:00:00Z",
+			requiredMocks: func() {
+				mock.On("ListDeviceMetrics", gomock.Anything, "tenant-id", models.UID("uid"), models.DeviceMetricsResolutionMinute, from, to).
+					Return(points, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expected:       points,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/uid/metrics?"+tc.query, nil)
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expected != nil {
+				var listed []models.DeviceMetricsPoint
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&listed))
+				assert.Equal(t, tc.expected, listed)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceAlerts(t *testing.T) {
+	mock := new(mocks.Service)
+
+	alerts := []models.DeviceAlert{
+		{ID: "id", UID: "uid", TenantID: "tenant-id", Metric: models.DeviceMetricDisk, Value: 95, Threshold: 90, RaisedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	mock.On("ListDeviceAlerts", gomock.Anything, "tenant-id", paginator.Query{Page: 1, PerPage: 10}).Return(alerts, 1, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/alerts?page=1&per_page=10", nil)
+	req.Header.Set("X-Role", guard.RoleObserver)
+	req.Header.Set("X-Tenant-ID", "tenant-id")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+
+	var listed []models.DeviceAlert
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&listed))
+	assert.Equal(t, alerts, listed)
+
+	mock.AssertExpectations(t)
+}
@@ -940,6 +940,132 @@ func TestUpdateDeviceTag(t *testing.T) {
 	}
 }
 
+func TestUpdateDeviceLabels(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the labels are missing",
+			role:           guard.RoleOperator,
+			body:           `{"labels":{}}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when a label key is invalid",
+			role:           guard.RoleOperator,
+			body:           `{"labels":{"site.name":"lisbon"}}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role cannot update labels",
+			role:           guard.RoleObserver,
+			body:           `{"labels":{"site":"lisbon"}}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the device has the maximum of labels",
+			role:  guard.RoleOperator,
+			body:  `{"labels":{"site":"lisbon"}}`,
+			requiredMocks: func() {
+				mock.On("UpdateDeviceLabels", gomock.Anything, "tenant-id", models.UID("123"), map[string]string{"site": "lisbon"}).
+					Return(svc.NewErrDeviceLabelLimit(svc.DeviceMaxLabels, nil)).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the labels are updated",
+			role:  guard.RoleOperator,
+			body:  `{"labels":{"site":"lisbon","owner":"team-x"}}`,
+			requiredMocks: func() {
+				mock.On("UpdateDeviceLabels", gomock.Anything, "tenant-id", models.UID("123"), map[string]string{"site": "lisbon", "owner": "team-x"}).
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/devices/123/labels", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRemoveDeviceLabel(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		key            string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the label key is invalid",
+			key:            "1site",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the label is not found",
+			key:   "owner",
+			requiredMocks: func() {
+				mock.On("RemoveDeviceLabel", gomock.Anything, "tenant-id", models.UID("123"), "owner").
+					Return(svc.NewErrDeviceLabelNotFound("owner", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the label is removed",
+			key:   "site",
+			requiredMocks: func() {
+				mock.On("RemoveDeviceLabel", gomock.Anything, "tenant-id", models.UID("123"), "site").Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/devices/123/labels/%s", tc.key), nil)
+			req.Header.Set("X-Role", guard.RoleOperator)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestUpdateDevice(t *testing.T) {
 	mock := new(mocks.Service)
 	name := "new device name"
@@ -1000,3 +1126,497 @@ func TestUpdateDevice(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateDevicePublicURLService(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		body           string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the port is missing",
+			uid:            "1234",
+			body:           `{"scheme":"https"}`,
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the scheme is not supported",
+			uid:            "1234",
+			body:           `{"port":8443,"scheme":"ftp"}`,
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role cannot update the device",
+			uid:            "1234",
+			body:           `{"port":8443,"scheme":"https"}`,
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the device is not found",
+			uid:   "1234",
+			body:  `{"port":3000}`,
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateDevicePublicURLService", gomock.Anything, "tenant-id", models.UID("1234"), requests.DevicePublicURLServiceFields{Port: 3000}).
+					Return(nil, svc.ErrNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the service is published",
+			uid:   "1234",
+			body:  `{"port":8443,"scheme":"https","insecure_skip_verify":true}`,
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateDevicePublicURLService", gomock.Anything, "tenant-id", models.UID("1234"), requests.DevicePublicURLServiceFields{Port: 8443, Scheme: "https", InsecureSkipVerify: true}).
+					Return(&models.DevicePublicURLService{Address: "address", Port: 8443, Scheme: "https", InsecureSkipVerify: true}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/devices/%s/public-urls", tc.uid), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteDevicePublicURLService(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		address        string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:   "fails when the service is not found",
+			uid:     "1234",
+			address: "address",
+			requiredMocks: func() {
+				mock.On("DeleteDevicePublicURLService", gomock.Anything, "tenant-id", models.UID("1234"), "address").
+					Return(svc.ErrNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:   "success when the service is deleted",
+			uid:     "1234",
+			address: "address",
+			requiredMocks: func() {
+				mock.On("DeleteDevicePublicURLService", gomock.Anything, "tenant-id", models.UID("1234"), "address").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/devices/%s/public-urls/%s", tc.uid, tc.address), nil)
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthorizeDevicePublicURL(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		address        string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:   "fails when the credentials are invalid",
+			address: "address",
+			body:    `{"username":"admin","password":"wrong"}`,
+			requiredMocks: func() {
+				mock.On("AuthorizeDevicePublicURL", gomock.Anything, requests.DevicePublicURLAuthorize{
+					DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "address"},
+					Username:               "admin",
+					Password:               "wrong",
+				}).Return(svc.ErrAuthUnathorized).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:   "success when the credentials are valid",
+			address: "address",
+			body:    `{"token":"token"}`,
+			requiredMocks: func() {
+				mock.On("AuthorizeDevicePublicURL", gomock.Anything, requests.DevicePublicURLAuthorize{
+					DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "address"},
+					Token:                  "token",
+				}).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/internal/devices/public/%s/authorize", tc.address), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateDevicePublishedPort(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		body           string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the port is missing",
+			uid:            "1234",
+			body:           `{"host":"localhost"}`,
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the port is out of range",
+			uid:            "1234",
+			body:           `{"port":70000}`,
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role cannot update the device",
+			uid:            "1234",
+			body:           `{"port":5432}`,
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the port is already published",
+			uid:   "1234",
+			body:  `{"port":5432}`,
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateDevicePublishedPort", gomock.Anything, "tenant-id", models.UID("1234"), requests.DevicePublishedPortCreate{DeviceParam: requests.DeviceParam{UID: "1234"}, Port: 5432}).
+					Return(nil, svc.ErrDevicePublishedPortDuplicate).Once()
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			title: "success when the port is published",
+			uid:   "1234",
+			body:  `{"host":"db","port":5432}`,
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateDevicePublishedPort", gomock.Anything, "tenant-id", models.UID("1234"), requests.DevicePublishedPortCreate{DeviceParam: requests.DeviceParam{UID: "1234"}, Host: "db", Port: 5432}).
+					Return(&models.DevicePublishedPort{Address: "address", Host: "db", Port: 5432}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/devices/%s/ports", tc.uid), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteDevicePublishedPort(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		address        string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:   "fails when the port is not found",
+			uid:     "1234",
+			address: "address",
+			requiredMocks: func() {
+				mock.On("DeleteDevicePublishedPort", gomock.Anything, "tenant-id", models.UID("1234"), "address").
+					Return(svc.ErrDevicePublishedPortNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:   "success when the port is unpublished",
+			uid:     "1234",
+			address: "address",
+			requiredMocks: func() {
+				mock.On("DeleteDevicePublishedPort", gomock.Anything, "tenant-id", models.UID("1234"), "address").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/devices/%s/ports/%s", tc.uid, tc.address), nil)
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateDevicePublishedPortToken(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		address        string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:   "fails when the port is not found",
+			uid:     "1234",
+			address: "address",
+			requiredMocks: func() {
+				mock.On("CreateDevicePublishedPortToken", gomock.Anything, "tenant-id", "user-id", models.UID("1234"), "address").
+					Return(nil, svc.ErrDevicePublishedPortNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:   "success when the token is created",
+			uid:     "1234",
+			address: "address",
+			requiredMocks: func() {
+				mock.On("CreateDevicePublishedPortToken", gomock.Anything, "tenant-id", "user-id", models.UID("1234"), "address").
+					Return(&models.DevicePublishedPortToken{Token: "token"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/devices/%s/ports/%s/token", tc.uid, tc.address), nil)
+			req.Header.Set("X-Role", guard.RoleOperator)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			req.Header.Set("X-ID", "user-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateDevicePublishedPortCertificate(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the public key is missing",
+			body:           `{"validity":60}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the validity is too long",
+			body:           `{"public_key":"key","validity":1441}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when the certificate is created",
+			body:  `{"public_key":"key","validity":60}`,
+			requiredMocks: func() {
+				mock.On("CreateDevicePublishedPortCertificate", gomock.Anything, "tenant-id", "user-id", requests.DevicePublishedPortCertificate{
+					DevicePublishedPortParam: requests.DevicePublishedPortParam{
+						DeviceParam: requests.DeviceParam{UID: "1234"},
+						Address:     "address",
+					},
+					PublicKey: "key",
+					Validity:  60,
+				}).Return(&models.DevicePublishedPortCertificate{Certificate: "certificate"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/1234/ports/address/certificate", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOperator)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			req.Header.Set("X-ID", "user-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthorizeDevicePublishedPort(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the token and the certificate are missing",
+			body:           `{"address":"address"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the address and the gateway's port are missing",
+			body:           `{"token":"token"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the token is invalid",
+			body:  `{"address":"address","token":"token"}`,
+			requiredMocks: func() {
+				mock.On("AuthorizeDevicePublishedPort", gomock.Anything, requests.DevicePublishedPortAuthorize{Address: "address", Token: "token"}).
+					Return(nil, svc.ErrAuthUnathorized).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title: "success when the token is valid",
+			body:  `{"address":"address","token":"token"}`,
+			requiredMocks: func() {
+				mock.On("AuthorizeDevicePublishedPort", gomock.Anything, requests.DevicePublishedPortAuthorize{Address: "address", Token: "token"}).
+					Return(&models.Device{UID: "1234"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title: "success when the certificate is valid",
+			body:  `{"gateway_port":20000,"certificate":"certificate"}`,
+			requiredMocks: func() {
+				mock.On("AuthorizeDevicePublishedPort", gomock.Anything, requests.DevicePublishedPortAuthorize{GatewayPort: 20000, Certificate: "certificate"}).
+					Return(&models.Device{UID: "1234"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/internal/devices/ports/authorize", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
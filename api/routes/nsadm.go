@@ -16,6 +16,7 @@ const (
 	ListNamespaceURL           = "/namespaces"
 	CreateNamespaceURL         = "/namespaces"
 	GetNamespaceURL            = "/namespaces/:tenant"
+	LookupNamespaceURL         = "/namespaces/lookup"
 	DeleteNamespaceURL         = "/namespaces/:tenant"
 	EditNamespaceURL           = "/namespaces/:tenant"
 	AddNamespaceUserURL        = "/namespaces/:tenant/members"
@@ -23,6 +24,9 @@ const (
 	EditNamespaceUserURL       = "/namespaces/:tenant/members/:uid"
 	GetSessionRecordURL        = "/users/security"
 	EditSessionRecordStatusURL = "/users/security/:tenant"
+	EditAgentForwardingURL     = "/users/security/:tenant/agent-forwarding"
+	EvaluateAgentForwardingURL = "/namespaces/:tenant/agent-forwarding"
+	EditMetricsThresholdsURL   = "/namespaces/:tenant/metrics-thresholds"
 )
 
 const (
@@ -104,6 +108,44 @@ func (h *Handler) GetNamespace(c gateway.Context) error {
 	return c.JSON(http.StatusOK, ns)
 }
 
+func (h *Handler) LookupNamespace(c gateway.Context) error {
+	var req requests.NamespaceLookup
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	namespace, err := h.service.LookupNamespace(c.Ctx(), req.Name)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, namespace)
+}
+
+// EvaluateAgentForwarding replies if a client, authenticated as the member of the username query param or with a
+// public key registered on the namespace when it is empty, can forward its SSH agent to the namespace's devices.
+func (h *Handler) EvaluateAgentForwarding(c gateway.Context) error {
+	var req requests.NamespaceEvaluateAgentForwarding
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	allowed, err := h.service.EvaluateAgentForwarding(c.Ctx(), req.Tenant, req.Username)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, allowed)
+}
+
 func (h *Handler) DeleteNamespace(c gateway.Context) error {
 	var req requests.NamespaceDelete
 	if err := c.Bind(&req); err != nil {
@@ -302,6 +344,66 @@ func (h *Handler) EditSessionRecordStatus(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) EditAgentForwardingStatus(c gateway.Context) error {
+	var req requests.NamespaceEditAgentForwarding
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EnableAgentForwarding, func() error {
+		return h.service.EditAgentForwardingStatus(c.Ctx(), req.AgentForwarding, ns.TenantID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EditMetricsThresholds(c gateway.Context) error {
+	var req requests.NamespaceEditMetricsThresholds
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EditMetricsThresholds, func() error {
+		return h.service.EditMetricsThresholds(c.Ctx(), req.Thresholds, ns.TenantID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) GetSessionRecord(c gateway.Context) error {
 	var tenant string
 	if v := c.Tenant(); v != nil {
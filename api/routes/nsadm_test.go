@@ -164,6 +164,150 @@ func TestGetNamespace(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestLookupNamespace(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		namespace *models.Namespace
+		status    int
+	}
+	cases := []struct {
+		title         string
+		name          string
+		expected      Expected
+		requiredMocks func()
+	}{
+		{
+			title: "fails when the name is empty",
+			name:  "",
+			expected: Expected{
+				status: http.StatusBadRequest,
+			},
+			requiredMocks: func() {},
+		},
+		{
+			title: "fails when the namespace is not found",
+			name:  "nonexistent",
+			expected: Expected{
+				status: http.StatusNotFound,
+			},
+			requiredMocks: func() {
+				mock.On("LookupNamespace", gomock.Anything, "nonexistent").Return(nil, svc.NewErrNamespaceNotFound("nonexistent", nil)).Once()
+			},
+		},
+		{
+			title: "succeeds when the namespace is found",
+			name:  "namespace",
+			expected: Expected{
+				namespace: &models.Namespace{Name: "namespace", TenantID: "tenant"},
+				status:    http.StatusOK,
+			},
+			requiredMocks: func() {
+				mock.On("LookupNamespace", gomock.Anything, "namespace").Return(&models.Namespace{Name: "namespace", TenantID: "tenant"}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/internal/namespaces/lookup?name=%s", tc.name), nil)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			var namespace *models.Namespace
+			if err := json.NewDecoder(rec.Result().Body).Decode(&namespace); err != nil {
+				assert.ErrorIs(t, io.EOF, err)
+			}
+			assert.Equal(t, tc.expected.namespace, namespace)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateAgentForwarding(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		allowed bool
+		status  int
+	}
+	cases := []struct {
+		title         string
+		tenant        string
+		username      string
+		expected      Expected
+		requiredMocks func()
+	}{
+		{
+			title:    "fails when the namespace is not found",
+			tenant:   "00000000-0000-4000-0000-000000000000",
+			username: "john_doe",
+			expected: Expected{
+				status: http.StatusNotFound,
+			},
+			requiredMocks: func() {
+				mock.On("EvaluateAgentForwarding", gomock.Anything, "00000000-0000-4000-0000-000000000000", "john_doe").
+					Return(false, svc.NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", nil)).Once()
+			},
+		},
+		{
+			title:    "succeeds when the member is not allowed to forward the agent",
+			tenant:   "00000000-0000-4000-0000-000000000000",
+			username: "john_doe",
+			expected: Expected{
+				allowed: false,
+				status:  http.StatusOK,
+			},
+			requiredMocks: func() {
+				mock.On("EvaluateAgentForwarding", gomock.Anything, "00000000-0000-4000-0000-000000000000", "john_doe").
+					Return(false, nil).Once()
+			},
+		},
+		{
+			title:    "succeeds when the public key can forward the agent",
+			tenant:   "00000000-0000-4000-0000-000000000000",
+			username: "",
+			expected: Expected{
+				allowed: true,
+				status:  http.StatusOK,
+			},
+			requiredMocks: func() {
+				mock.On("EvaluateAgentForwarding", gomock.Anything, "00000000-0000-4000-0000-000000000000", "").
+					Return(true, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/internal/namespaces/%s/agent-forwarding?username=%s", tc.tenant, tc.username), nil)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			if tc.expected.status == http.StatusOK {
+				var allowed bool
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&allowed))
+				assert.Equal(t, tc.expected.allowed, allowed)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestDeleteNamespace(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -430,3 +574,150 @@ func TestEditNamespace(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestEditAgentForwardingStatus(t *testing.T) {
+	mock := new(mocks.Service)
+
+	namespace := func(role string) *models.Namespace {
+		return &models.Namespace{
+			Name:     "namespace-name",
+			Owner:    "owner-name",
+			TenantID: "tenant-id",
+			Members: []models.Member{
+				{ID: "123", Username: "userexemple", Role: role},
+			},
+			Settings: &models.NamespaceSettings{},
+		}
+	}
+
+	cases := []struct {
+		title          string
+		uid            string
+		req            string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when namespace is not found",
+			uid:   "123",
+			req:   `{"agent_forwarding": true, "tenant": "tenant-id"}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "tenant-id").Return(nil, svc.ErrNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "fails when member's role cannot enable the agent forwarding",
+			uid:   "123",
+			req:   `{"agent_forwarding": true, "tenant": "tenant-id"}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "tenant-id").Return(namespace(guard.RoleOperator), nil).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when try to enable the agent forwarding",
+			uid:   "123",
+			req:   `{"agent_forwarding": true, "tenant": "tenant-id"}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "tenant-id").Return(namespace(guard.RoleOwner), nil).Once()
+				mock.On("EditAgentForwardingStatus", gomock.Anything, true, "tenant-id").Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/users/security/tenant-id/agent-forwarding", strings.NewReader(tc.req))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-ID", tc.uid)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEditMetricsThresholds(t *testing.T) {
+	mock := new(mocks.Service)
+
+	namespace := func(role string) *models.Namespace {
+		return &models.Namespace{
+			Name:     "namespace-name",
+			Owner:    "owner-name",
+			TenantID: "tenant-id",
+			Members: []models.Member{
+				{ID: "123", Username: "userexemple", Role: role},
+			},
+			Settings: &models.NamespaceSettings{},
+		}
+	}
+
+	cases := []struct {
+		title          string
+		req            string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the metric is unknown",
+			req:            `{"thresholds":[{"metric":"swap","value":90}]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when a metric has more than one threshold",
+			req:            `{"thresholds":[{"metric":"disk","value":90},{"metric":"disk","value":80}]}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when member's role cannot edit the thresholds",
+			req:   `{"thresholds":[{"metric":"disk","value":90}]}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "tenant-id").Return(namespace(guard.RoleOperator), nil).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the thresholds are edited",
+			req:   `{"thresholds":[{"metric":"disk","value":90},{"metric":"temperature","value":80}]}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "tenant-id").Return(namespace(guard.RoleAdministrator), nil).Once()
+				mock.On("EditMetricsThresholds", gomock.Anything, []requests.MetricsThreshold{
+					{Metric: "disk", Value: 90},
+					{Metric: "temperature", Value: 80},
+				}, "tenant-id").Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/namespaces/tenant-id/metrics-thresholds", strings.NewReader(tc.req))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-ID", "123")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -31,9 +31,13 @@ func NewRouter(service services.Service) *echo.Echo {
 	internalAPI.GET(AuthUserTokenInternalURL, gateway.Handler(handler.AuthGetToken))
 
 	internalAPI.GET(GetDeviceByPublicURLAddress, gateway.Handler(handler.GetDeviceByPublicURLAddress))
+	internalAPI.POST(AuthorizeDevicePublicURL, gateway.Handler(handler.AuthorizeDevicePublicURL))
+	internalAPI.POST(AuthorizePublishedPortURL, gateway.Handler(handler.AuthorizeDevicePublishedPort))
 	internalAPI.POST(OfflineDeviceURL, gateway.Handler(handler.OfflineDevice))
 	internalAPI.POST(HeartbeatDeviceURL, gateway.Handler(handler.HeartbeatDevice))
 	internalAPI.GET(LookupDeviceURL, gateway.Handler(handler.LookupDevice))
+	internalAPI.GET(LookupNamespaceURL, gateway.Handler(handler.LookupNamespace))
+	internalAPI.GET(EvaluateAgentForwardingURL, gateway.Handler(handler.EvaluateAgentForwarding))
 
 	internalAPI.PATCH(SetSessionAuthenticatedURL, gateway.Handler(handler.SetSessionAuthenticated))
 	internalAPI.POST(CreateSessionURL, gateway.Handler(handler.CreateSession))
@@ -42,8 +46,10 @@ func NewRouter(service services.Service) *echo.Echo {
 	internalAPI.POST(RecordSessionURL, gateway.Handler(handler.RecordSession))
 
 	internalAPI.GET(GetPublicKeyURL, gateway.Handler(handler.GetPublicKey))
+	internalAPI.GET(ListPublicKeysByFPURL, gateway.Handler(handler.ListPublicKeysByFingerprint))
 	internalAPI.POST(CreatePrivateKeyURL, gateway.Handler(handler.CreatePrivateKey))
 	internalAPI.POST(EvaluateKeyURL, gateway.Handler(handler.EvaluateKey))
+	internalAPI.GET(GetNamespaceCertificateAuthorityURL, gateway.Handler(handler.GetNamespaceCertificateAuthority))
 
 	// Public routes for external access through API gateway
 	publicAPI := e.Group("/api")
@@ -55,16 +61,39 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.GET(AuthUserURLV2, gateway.Handler(handler.AuthUserInfo))
 	publicAPI.POST(AuthPublicKeyURL, gateway.Handler(handler.AuthPublicKey))
 	publicAPI.GET(AuthUserTokenPublicURL, gateway.Handler(handler.AuthSwapToken))
+	publicAPI.POST(AuthRefreshTokenURL, gateway.Handler(handler.AuthRefreshToken))
+	publicAPI.GET(JWKSURL, gateway.Handler(handler.GetJWKS))
+	publicAPI.POST(BeginWebAuthnLoginURL, gateway.Handler(handler.BeginWebAuthnLogin))
+	publicAPI.POST(FinishWebAuthnLoginURL, gateway.Handler(handler.FinishWebAuthnLogin))
+	publicAPI.POST(BeginWebAuthnPasswordlessLoginURL, gateway.Handler(handler.BeginWebAuthnPasswordlessLogin))
+	publicAPI.POST(FinishWebAuthnPasswordlessLoginURL, gateway.Handler(handler.FinishWebAuthnPasswordlessLogin))
 
 	publicAPI.PATCH(UpdateUserDataURL, gateway.Handler(handler.UpdateUserData))
 	publicAPI.PATCH(UpdateUserPasswordURL, gateway.Handler(handler.UpdateUserPassword))
+	publicAPI.GET(ListUserSessionsURL, gateway.Handler(handler.ListUserSessions))
+	publicAPI.DELETE(RevokeUserSessionURL, gateway.Handler(handler.RevokeUserSession))
+	publicAPI.DELETE(RevokeUserSessionsURL, gateway.Handler(handler.RevokeUserSessions))
+	publicAPI.POST(BeginWebAuthnRegistrationURL, gateway.Handler(handler.BeginWebAuthnRegistration))
+	publicAPI.POST(FinishWebAuthnRegistrationURL, gateway.Handler(handler.FinishWebAuthnRegistration))
+	publicAPI.GET(ListWebAuthnCredentialsURL, gateway.Handler(handler.ListWebAuthnCredentials))
+	publicAPI.PATCH(RenameWebAuthnCredentialURL, gateway.Handler(handler.RenameWebAuthnCredential))
+	publicAPI.DELETE(DeleteWebAuthnCredentialURL, gateway.Handler(handler.DeleteWebAuthnCredential))
 	publicAPI.PUT(EditSessionRecordStatusURL, gateway.Handler(handler.EditSessionRecordStatus))
 	publicAPI.GET(GetSessionRecordURL, gateway.Handler(handler.GetSessionRecord))
+	publicAPI.PUT(EditAgentForwardingURL, gateway.Handler(handler.EditAgentForwardingStatus))
+	publicAPI.PUT(EditMetricsThresholdsURL, gateway.Handler(handler.EditMetricsThresholds))
 
 	publicAPI.GET(GetDeviceListURL, apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceList)))
 	publicAPI.GET(GetDeviceURL, apiMiddleware.Authorize(gateway.Handler(handler.GetDevice)))
 	publicAPI.DELETE(DeleteDeviceURL, gateway.Handler(handler.DeleteDevice))
 	publicAPI.PUT(UpdateDevice, gateway.Handler(handler.UpdateDevice))
+	publicAPI.POST(CreatePublicURLServiceURL, gateway.Handler(handler.CreateDevicePublicURLService))
+	publicAPI.PUT(UpdatePublicURLServiceURL, gateway.Handler(handler.UpdateDevicePublicURLService))
+	publicAPI.DELETE(DeletePublicURLServiceURL, gateway.Handler(handler.DeleteDevicePublicURLService))
+	publicAPI.POST(CreatePublishedPortURL, gateway.Handler(handler.CreateDevicePublishedPort))
+	publicAPI.DELETE(DeletePublishedPortURL, gateway.Handler(handler.DeleteDevicePublishedPort))
+	publicAPI.POST(CreatePublishedPortTokenURL, gateway.Handler(handler.CreateDevicePublishedPortToken))
+	publicAPI.POST(CreatePublishedPortCertURL, gateway.Handler(handler.CreateDevicePublishedPortCertificate))
 	publicAPI.PATCH(RenameDeviceURL, gateway.Handler(handler.RenameDevice))
 	publicAPI.PATCH(UpdateDeviceStatusURL, gateway.Handler(handler.UpdateDeviceStatus))
 
@@ -72,10 +101,33 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.DELETE(RemoveTagURL, gateway.Handler(handler.RemoveDeviceTag))
 	publicAPI.PUT(UpdateTagURL, gateway.Handler(handler.UpdateDeviceTag))
 
+	publicAPI.PATCH(UpdateLabelsURL, gateway.Handler(handler.UpdateDeviceLabels))
+	publicAPI.DELETE(RemoveLabelURL, gateway.Handler(handler.RemoveDeviceLabel))
+
+	publicAPI.POST(ReportDeviceInventoryURL, gateway.Handler(handler.ReportDeviceInventory))
+	publicAPI.GET(ListDeviceInventoryURL, apiMiddleware.Authorize(gateway.Handler(handler.ListDeviceInventory)))
+
+	publicAPI.POST(ReportDeviceMetricsURL, gateway.Handler(handler.ReportDeviceMetrics))
+	publicAPI.GET(ListDeviceMetricsURL, apiMiddleware.Authorize(gateway.Handler(handler.ListDeviceMetrics)))
+	publicAPI.GET(ListDeviceAlertsURL, apiMiddleware.Authorize(gateway.Handler(handler.ListDeviceAlerts)))
+
+	publicAPI.GET(GetDeviceAvailabilityURL, apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceAvailability)))
+	publicAPI.GET(GetTagAvailabilityURL, apiMiddleware.Authorize(gateway.Handler(handler.GetTagAvailability)))
+
 	publicAPI.GET(GetTagsURL, gateway.Handler(handler.GetTags))
 	publicAPI.PUT(RenameTagURL, gateway.Handler(handler.RenameTag))
 	publicAPI.DELETE(DeleteTagsURL, gateway.Handler(handler.DeleteTag))
 
+	publicAPI.GET(ListDeviceGroupsURL, apiMiddleware.Authorize(gateway.Handler(handler.ListDeviceGroups)))
+	publicAPI.GET(GetDeviceGroupURL, apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceGroup)))
+	publicAPI.POST(CreateDeviceGroupURL, gateway.Handler(handler.CreateDeviceGroup))
+	publicAPI.PUT(UpdateDeviceGroupURL, gateway.Handler(handler.UpdateDeviceGroup))
+	publicAPI.DELETE(DeleteDeviceGroupURL, gateway.Handler(handler.DeleteDeviceGroup))
+	publicAPI.GET(ListDeviceGroupDevicesURL, apiMiddleware.Authorize(gateway.Handler(handler.ListDeviceGroupDevices)))
+
+	publicAPI.POST(CreateDeviceJobURL, apiMiddleware.Authorize(gateway.Handler(handler.CreateDeviceJob)))
+	publicAPI.GET(GetDeviceJobURL, apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceJob)))
+
 	publicAPI.GET(GetSessionsURL, apiMiddleware.Authorize(gateway.Handler(handler.GetSessionList)))
 	publicAPI.GET(GetSessionURL, apiMiddleware.Authorize(gateway.Handler(handler.GetSession)))
 	publicAPI.GET(PlaySessionURL, gateway.Handler(handler.PlaySession))
@@ -94,6 +146,9 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.DELETE(RemovePublicKeyTagURL, gateway.Handler(handler.RemovePublicKeyTag))
 	publicAPI.PUT(UpdatePublicKeyTagsURL, gateway.Handler(handler.UpdatePublicKeyTags))
 
+	publicAPI.GET(GetCertificateAuthorityURL, gateway.Handler(handler.GetCertificateAuthority))
+	publicAPI.POST(CreateUserCertificateURL, gateway.Handler(handler.CreateUserCertificate))
+
 	publicAPI.GET(ListNamespaceURL, gateway.Handler(handler.GetNamespaceList))
 	publicAPI.GET(GetNamespaceURL, gateway.Handler(handler.GetNamespace))
 	publicAPI.POST(CreateNamespaceURL, gateway.Handler(handler.CreateNamespace))
@@ -1,10 +1,12 @@
 package routes
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/filter"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -26,15 +28,24 @@ const (
 )
 
 func (h *Handler) GetSessionList(c gateway.Context) error {
-	query := paginator.NewQuery()
-	if err := c.Bind(query); err != nil {
+	query := filterQuery{Query: *paginator.NewQuery()}
+	if err := c.Bind(&query); err != nil {
 		return err
 	}
 
 	// TODO: normalize is not required when request is privileged
 	query.Normalize()
 
-	sessions, count, err := h.service.ListSessions(c.Ctx(), *query)
+	filters, err := query.filters()
+	if syntax := new(filter.SyntaxError); errors.As(err, &syntax) {
+		return c.JSON(http.StatusBadRequest, syntax)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sessions, count, err := h.service.ListSessions(c.Ctx(), query.Query, filters, query.SortBy, query.OrderBy)
 	if err != nil {
 		return err
 	}
@@ -1,11 +1,13 @@
 package routes
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
@@ -15,6 +17,7 @@ import (
 
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/filter"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -32,6 +35,7 @@ func TestGetSessionList(t *testing.T) {
 	cases := []struct {
 		title         string
 		payload       paginator.Query
+		query         string
 		requiredMocks func(payload *paginator.Query)
 		expected      Expected
 	}{
@@ -42,7 +46,7 @@ func TestGetSessionList(t *testing.T) {
 				PerPage: 10,
 			},
 			requiredMocks: func(payload *paginator.Query) {
-				mock.On("ListSessions", gomock.Anything, *payload).Return(nil, 0, svc.ErrNotFound).Once()
+				mock.On("ListSessions", gomock.Anything, *payload, []models.Filter(nil), "", "").Return(nil, 0, svc.ErrNotFound).Once()
 			},
 			expected: Expected{
 				expectedSession: nil,
@@ -57,7 +61,88 @@ func TestGetSessionList(t *testing.T) {
 			},
 			requiredMocks: func(payload *paginator.Query) {
 				ss := []models.Session{}
-				mock.On("ListSessions", gomock.Anything, *payload).Return(ss, 1, nil).Once()
+				mock.On("ListSessions", gomock.Anything, *payload, []models.Filter(nil), "", "").Return(ss, 1, nil).Once()
+			},
+			expected: Expected{
+				expectedSession: []models.Session{},
+				expectedStatus:  http.StatusOK,
+			},
+		},
+		{
+			title: "success when try to filtering and sorting the session list",
+			payload: paginator.Query{
+				Page:    1,
+				PerPage: 10,
+			},
+			query: "?" + url.Values{
+				"filter":   {base64.StdEncoding.EncodeToString([]byte(`[{"type":"property","params":{"name":"username","operator":"eq","value":"root"}}]`))},
+				"sort_by":  {"started_at"},
+				"order_by": {"desc"},
+			}.Encode(),
+			requiredMocks: func(payload *paginator.Query) {
+				filter := []models.Filter{
+					{
+						Type:   "property",
+						Params: &models.PropertyParams{Name: "username", Operator: "eq", Value: "root"},
+					},
+				}
+
+				mock.On("ListSessions", gomock.Anything, *payload, filter, "started_at", "desc").Return([]models.Session{}, 0, nil).Once()
+			},
+			expected: Expected{
+				expectedSession: []models.Session{},
+				expectedStatus:  http.StatusOK,
+			},
+		},
+		{
+			title: "fails when the source network of the filter is invalid",
+			payload: paginator.Query{
+				Page:    1,
+				PerPage: 10,
+			},
+			query: "?" + url.Values{
+				"filter": {base64.StdEncoding.EncodeToString([]byte(`[{"type":"property","params":{"name":"ip_address","operator":"eq","value":"10.0.0.0/64"}}]`))},
+			}.Encode(),
+			requiredMocks: func(payload *paginator.Query) {
+				filter := []models.Filter{
+					{
+						Type:   "property",
+						Params: &models.PropertyParams{Name: "ip_address", Operator: "eq", Value: "10.0.0.0/64"},
+					},
+				}
+
+				mock.On("ListSessions", gomock.Anything, *payload, filter, "", "").
+					Return(nil, 0, svc.NewErrSessionFilterInvalid(map[string]interface{}{"ip_address": "10.0.0.0/64"}, nil)).Once()
+			},
+			expected: Expected{
+				expectedSession: nil,
+				expectedStatus:  http.StatusBadRequest,
+			},
+		},
+		{
+			title: "success when try to searching the session list",
+			payload: paginator.Query{
+				Page:    1,
+				PerPage: 10,
+			},
+			query: "?" + url.Values{"search": {"username:root recorded:true"}}.Encode(),
+			requiredMocks: func(payload *paginator.Query) {
+				filter := []models.Filter{
+					{
+						Type:   "property",
+						Params: &models.PropertyParams{Name: "username", Operator: "eq", Value: "root"},
+					},
+					{
+						Type:   "property",
+						Params: &models.PropertyParams{Name: "recorded", Operator: "bool", Value: "true"},
+					},
+					{
+						Type:   "operator",
+						Params: &models.OperatorParams{Name: "and"},
+					},
+				}
+
+				mock.On("ListSessions", gomock.Anything, *payload, filter, "", "").Return([]models.Session{}, 0, nil).Once()
 			},
 			expected: Expected{
 				expectedSession: []models.Session{},
@@ -75,7 +160,7 @@ func TestGetSessionList(t *testing.T) {
 				assert.NoError(t, err)
 			}
 
-			req := httptest.NewRequest(http.MethodGet, "/api/sessions", strings.NewReader(string(jsonData)))
+			req := httptest.NewRequest(http.MethodGet, "/api/sessions"+tc.query, strings.NewReader(string(jsonData)))
 			req.Header.Set("Content-Type", "application/json")
 			req.Header.Set("X-Role", guard.RoleOwner)
 			rec := httptest.NewRecorder()
@@ -96,6 +181,25 @@ func TestGetSessionList(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestGetSessionListSearchSyntaxError(t *testing.T) {
+	mock := new(mocks.Service)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions?"+url.Values{"search": {"username"}}.Encode(), nil)
+	req.Header.Set("X-Role", guard.RoleOwner)
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
+
+	var syntax filter.SyntaxError
+	assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&syntax))
+	assert.Equal(t, filter.SyntaxError{Message: `expected an operator (:, ~, < or >) after "username"`, Position: 8}, syntax)
+
+	mock.AssertExpectations(t)
+}
+
 func TestGetSession(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -16,6 +16,7 @@ import (
 const (
 	GetPublicKeysURL       = "/sshkeys/public-keys"
 	GetPublicKeyURL        = "/sshkeys/public-keys/:fingerprint/:tenant"
+	ListPublicKeysByFPURL  = "/sshkeys/public-keys/:fingerprint"
 	CreatePublicKeyURL     = "/sshkeys/public-keys"
 	UpdatePublicKeyURL     = "/sshkeys/public-keys/:fingerprint"
 	DeletePublicKeyURL     = "/sshkeys/public-keys/:fingerprint"
@@ -73,6 +74,24 @@ func (h *Handler) GetPublicKey(c gateway.Context) error {
 	return c.JSON(http.StatusOK, pubKey)
 }
 
+func (h *Handler) ListPublicKeysByFingerprint(c gateway.Context) error {
+	var req requests.PublicKeyListByFingerprint
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	keys, err := h.service.ListPublicKeysByFingerprint(c.Ctx(), req.Fingerprint)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, keys)
+}
+
 func (h *Handler) CreatePublicKey(c gateway.Context) error {
 	var req requests.PublicKeyCreate
 	if err := c.Bind(&req); err != nil {
@@ -173,6 +173,27 @@ func TestGetPublicKey(t *testing.T) {
 	}
 }
 
+func TestListPublicKeysByFingerprint(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListPublicKeysByFingerprint", gomock.Anything, "fingerprint").
+		Return([]models.PublicKey{{Fingerprint: "fingerprint", TenantID: "tenant"}}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/internal/sshkeys/public-keys/fingerprint", nil)
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	var keys []models.PublicKey
+	assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&keys))
+	assert.Equal(t, []models.PublicKey{{Fingerprint: "fingerprint", TenantID: "tenant"}}, keys)
+
+	mock.AssertExpectations(t)
+}
+
 func TestDeletePublicKey(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -66,7 +66,8 @@ func (h *Handler) UpdateUserPassword(c gateway.Context) error {
 		return err
 	}
 
-	if err := h.service.UpdatePasswordUser(c.Ctx(), req.ID, req.CurrentPassword, req.NewPassword); err != nil {
+	session, _ := c.GetSessionID()
+	if err := h.service.UpdatePasswordUser(c.Ctx(), req.ID, session, req.CurrentPassword, req.NewPassword); err != nil {
 		return err
 	}
 
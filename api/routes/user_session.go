@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	// ListUserSessionsURL lists the active logins of the authenticated user.
+	ListUserSessionsURL = "/users/sessions"
+	// RevokeUserSessionURL revokes one login of the authenticated user.
+	RevokeUserSessionURL = "/users/sessions/:session"
+	// RevokeUserSessionsURL revokes every login of the authenticated user, except the current one.
+	RevokeUserSessionsURL = "/users/sessions"
+)
+
+func (h *Handler) ListUserSessions(c gateway.Context) error {
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	current, _ := c.GetSessionID()
+
+	sessions, err := h.service.ListUserSessions(c.Ctx(), user.ID, current)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, sessions)
+}
+
+func (h *Handler) RevokeUserSession(c gateway.Context) error {
+	var req requests.UserSessionParam
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	if err := h.service.RevokeUserSession(c.Ctx(), user.ID, req.ID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) RevokeUserSessions(c gateway.Context) error {
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	current, _ := c.GetSessionID()
+
+	if err := h.service.RevokeUserSessions(c.Ctx(), user.ID, current); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
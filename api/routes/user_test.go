@@ -197,7 +197,7 @@ func TestUpdateUserPassword(t *testing.T) {
 				NewPassword:     "new_password",
 			},
 			requiredMocks: func(updatePayloadMock requests.UserPasswordUpdate) {
-				mock.On("UpdatePasswordUser", gomock.Anything, "123", updatePayloadMock.CurrentPassword, updatePayloadMock.NewPassword).Return(svc.ErrUserNotFound).Once()
+				mock.On("UpdatePasswordUser", gomock.Anything, "123", "", updatePayloadMock.CurrentPassword, updatePayloadMock.NewPassword).Return(svc.ErrUserNotFound).Once()
 			},
 			expectedStatus: http.StatusNotFound,
 		},
@@ -212,7 +212,7 @@ func TestUpdateUserPassword(t *testing.T) {
 				NewPassword:     "new_password",
 			},
 			requiredMocks: func(updatePayloadMock requests.UserPasswordUpdate) {
-				mock.On("UpdatePasswordUser", gomock.Anything, "123", updatePayloadMock.CurrentPassword, updatePayloadMock.NewPassword).Return(nil).Once()
+				mock.On("UpdatePasswordUser", gomock.Anything, "123", "", updatePayloadMock.CurrentPassword, updatePayloadMock.NewPassword).Return(nil).Once()
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -0,0 +1,194 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+)
+
+const (
+	BeginWebAuthnRegistrationURL  = "/users/webauthn/registration/begin"
+	FinishWebAuthnRegistrationURL = "/users/webauthn/registration/finish"
+	ListWebAuthnCredentialsURL    = "/users/webauthn/credentials"
+	RenameWebAuthnCredentialURL   = "/users/webauthn/credentials/:credential"
+	DeleteWebAuthnCredentialURL   = "/users/webauthn/credentials/:credential"
+
+	// BeginWebAuthnLoginURL starts the WebAuthn as second factor. It is accessed by a token whose MFA wasn't validated.
+	BeginWebAuthnLoginURL  = "/auth/webauthn/begin"
+	FinishWebAuthnLoginURL = "/auth/webauthn/finish"
+
+	// BeginWebAuthnPasswordlessLoginURL starts the login without password. It is accessed without a token.
+	BeginWebAuthnPasswordlessLoginURL  = "/auth/webauthn/passwordless/begin"
+	FinishWebAuthnPasswordlessLoginURL = "/auth/webauthn/passwordless/finish"
+)
+
+func (h *Handler) BeginWebAuthnRegistration(c gateway.Context) error {
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	ceremony, creation, err := h.service.BeginWebAuthnRegistration(c.Ctx(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, struct {
+		Ceremony string `json:"ceremony"`
+		*protocol.CredentialCreation
+	}{ceremony, creation})
+}
+
+func (h *Handler) FinishWebAuthnRegistration(c gateway.Context) error {
+	var req requests.WebAuthnRegistrationFinish
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	res, err := h.service.FinishWebAuthnRegistration(c.Ctx(), user.ID, req.Ceremony, req.Name, req.Credential)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) ListWebAuthnCredentials(c gateway.Context) error {
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	res, err := h.service.ListWebAuthnCredentials(c.Ctx(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) RenameWebAuthnCredential(c gateway.Context) error {
+	var req requests.WebAuthnCredentialRename
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	if err := h.service.RenameWebAuthnCredential(c.Ctx(), user.ID, req.ID, req.Name); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) DeleteWebAuthnCredential(c gateway.Context) error {
+	var req requests.WebAuthnCredentialParam
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	if err := h.service.DeleteWebAuthnCredential(c.Ctx(), user.ID, req.ID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) BeginWebAuthnLogin(c gateway.Context) error {
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	ceremony, assertion, err := h.service.BeginWebAuthnLogin(c.Ctx(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, struct {
+		Ceremony string `json:"ceremony"`
+		*protocol.CredentialAssertion
+	}{ceremony, assertion})
+}
+
+func (h *Handler) FinishWebAuthnLogin(c gateway.Context) error {
+	var req requests.WebAuthnLoginFinish
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	user := c.ID()
+	if user == nil {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	res, err := h.service.FinishWebAuthnLogin(c.Ctx(), user.ID, req.Ceremony, req.Credential)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) BeginWebAuthnPasswordlessLogin(c gateway.Context) error {
+	ceremony, assertion, err := h.service.BeginWebAuthnPasswordlessLogin(c.Ctx())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, struct {
+		Ceremony string `json:"ceremony"`
+		*protocol.CredentialAssertion
+	}{ceremony, assertion})
+}
+
+func (h *Handler) FinishWebAuthnPasswordlessLogin(c gateway.Context) error {
+	var req requests.WebAuthnLoginFinish
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	res, err := h.service.FinishWebAuthnPasswordlessLogin(c.Ctx(), req.Ceremony, req.Credential, c.Request().Header.Get("X-Real-IP"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
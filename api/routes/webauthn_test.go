@@ -0,0 +1,190 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestFinishWebAuthnRegistration(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the ceremony is missing",
+			body:           `{"name": "key", "credential": {"id": "credential"}}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the name is missing",
+			body:           `{"ceremony": "ceremony", "credential": {"id": "credential"}}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the credential is missing",
+			body:           `{"ceremony": "ceremony", "name": "key"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when the credential is registered",
+			body:  `{"ceremony": "ceremony", "name": "key", "credential": {"id": "credential"}}`,
+			requiredMocks: func() {
+				mock.On("FinishWebAuthnRegistration", gomock.Anything, "id", "ceremony", "key", []byte(`{"id": "credential"}`)).
+					Return(&models.WebAuthnCredential{ID: "credential", Name: "key"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/users/webauthn/registration/finish", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-ID", "id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteWebAuthnCredential(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		credential     string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:      "fails when the credential is not found",
+			credential: "credential",
+			requiredMocks: func() {
+				mock.On("DeleteWebAuthnCredential", gomock.Anything, "id", "credential").
+					Return(svc.NewErrNotFound(svc.ErrWebAuthnCredentialNotFound, "credential", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:      "success when the credential is deleted",
+			credential: "credential",
+			requiredMocks: func() {
+				mock.On("DeleteWebAuthnCredential", gomock.Anything, "id", "credential").Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/users/webauthn/credentials/"+tc.credential, nil)
+			req.Header.Set("X-ID", "id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestFinishWebAuthnPasswordlessLogin(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+		expected       *models.UserAuthResponse
+	}{
+		{
+			title:          "fails when the ceremony is missing",
+			body:           `{"credential": {"id": "credential"}}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the assertion is invalid",
+			body:  `{"ceremony": "ceremony", "credential": {"id": "credential"}}`,
+			requiredMocks: func() {
+				mock.On("FinishWebAuthnPasswordlessLogin", gomock.Anything, "ceremony", []byte(`{"id": "credential"}`), "127.0.0.1").
+					Return(nil, svc.NewErrAuthUnathorized(nil)).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title: "fails when the attempts are locked",
+			body:  `{"ceremony": "ceremony", "credential": {"id": "credential"}}`,
+			requiredMocks: func() {
+				mock.On("FinishWebAuthnPasswordlessLogin", gomock.Anything, "ceremony", []byte(`{"id": "credential"}`), "127.0.0.1").
+					Return(nil, svc.NewErrAuthLocked(time.Minute, nil)).Once()
+			},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			title: "success when the assertion is valid",
+			body:  `{"ceremony": "ceremony", "credential": {"id": "credential"}}`,
+			requiredMocks: func() {
+				mock.On("FinishWebAuthnPasswordlessLogin", gomock.Anything, "ceremony", []byte(`{"id": "credential"}`), "127.0.0.1").
+					Return(&models.UserAuthResponse{Token: "token", MFA: models.MFA{Enable: true, Validate: true}}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expected:       &models.UserAuthResponse{Token: "token", MFA: models.MFA{Enable: true, Validate: true}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/webauthn/passwordless/finish", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Real-IP", "127.0.0.1")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+
+			if tc.expected != nil {
+				var res models.UserAuthResponse
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&res))
+				assert.Equal(t, tc.expected, &res)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
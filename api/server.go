@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"os"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/shellhub-io/shellhub/api/pkg/echo/handlers"
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/keyring"
 	"github.com/shellhub-io/shellhub/api/routes"
 	"github.com/shellhub-io/shellhub/api/services"
 	"github.com/shellhub-io/shellhub/api/store"
@@ -17,7 +21,9 @@ import (
 	requests "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
 	"github.com/shellhub-io/shellhub/pkg/middleware"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -50,13 +56,14 @@ var serverCmd = &cobra.Command{
 
 		log.Info("Connected to MongoDB")
 
-		worker, err := workers.New(store)
+		privateKey, _, err := services.LoadKeys()
 		if err != nil {
-			log.WithError(err).Warn("Failed to create workers.")
+			log.WithError(err).Fatal("failed to load the API's keys")
 		}
-		worker.Start()
 
-		return startServer(cfg, store, cache)
+		keys := keyring.New(store, privateKey)
+
+		return startServer(cfg, store, cache, keys)
 	},
 }
 
@@ -77,6 +84,16 @@ type config struct {
 	SessionRecordCleanupSchedule string `env:"SESSION_RECORD_CLEANUP_SCHEDULE,default=@daily"`
 	// Sentry DSN.
 	SentryDSN string `env:"SENTRY_DSN,default="`
+	// Domain is the ShellHub's domain.
+	Domain string `env:"SHELLHUB_DOMAIN,default=localhost"`
+	// WebAuthnRPID is the domain the users' WebAuthn credentials are bound to. When empty, the ShellHub's domain is
+	// used. Changing it invalidates every credential already registered.
+	WebAuthnRPID string `env:"WEBAUTHN_RP_ID,default="`
+	// WebAuthnRPOrigins are the origins, comma separated, allowed to use the WebAuthn credentials. When empty, the
+	// relying party's domain over HTTPS is allowed.
+	WebAuthnRPOrigins []string `env:"WEBAUTHN_RP_ORIGINS"`
+	// Tracing is the configuration of the export of the API's spans, disabled by default.
+	Tracing tracing.Config
 }
 
 func init() {
@@ -114,7 +131,33 @@ func startSentry(dsn string) (*sentry.Client, error) {
 	return nil, errors.New("sentry DSN not provided")
 }
 
-func startServer(cfg *config, store store.Store, cache storecache.Cache) error {
+// newWebAuthn creates the relying party of the users' WebAuthn credentials.
+func newWebAuthn(cfg *config) (*webauthn.WebAuthn, error) {
+	id := cfg.WebAuthnRPID
+	if id == "" {
+		id = cfg.Domain
+	}
+
+	origins := cfg.WebAuthnRPOrigins
+	if len(origins) == 0 {
+		origins = []string{"https://" + id}
+		// NOTICE: browsers consider localhost a secure context, so it is allowed without HTTPS on development.
+		if id == "localhost" {
+			origins = append(origins, "http://"+id)
+		}
+	}
+
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: services.WebAuthnCeremonyTimeout}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          id,
+		RPDisplayName: "ShellHub",
+		RPOrigins:     origins,
+		Timeouts:      webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+}
+
+func startServer(cfg *config, store store.Store, cache storecache.Cache, keys *keyring.Keyring) error {
 	log.Info("Starting Sentry client")
 
 	reporter, err := startSentry(cfg.SentryDSN)
@@ -124,6 +167,10 @@ func startServer(cfg *config, store store.Store, cache storecache.Cache) error {
 		log.Info("Sentry client started")
 	}
 
+	if _, err := tracing.Start(context.Background(), "api", &cfg.Tracing); err != nil {
+		log.WithError(err).Warn("Failed to start the tracing; the spans are not exported.")
+	}
+
 	log.Info("Starting API server")
 
 	requestClient := requests.NewClient()
@@ -140,13 +187,39 @@ func startServer(cfg *config, store store.Store, cache storecache.Cache) error {
 		locator = geoip.NewNullGeoLite()
 	}
 
-	service := services.NewService(store, nil, nil, cache, requestClient, locator)
+	relyingParty, err := newWebAuthn(cfg)
+	if err != nil {
+		log.WithError(err).Warn("Failed to configure the WebAuthn; it is disabled.")
+	}
+
+	options := []services.Option{
+		services.WithKeyring(keys),
+		services.WithWebAuthn(relyingParty),
+	}
+
+	if addr, err := asynq.ParseRedisURI(cfg.RedisURI); err != nil {
+		log.WithError(err).Warn("Failed to parse the Redis URI; the device jobs are disabled.")
+	} else {
+		options = append(options, services.WithTaskEnqueuer(asynq.NewClient(addr)))
+	}
+
+	service := services.NewService(store, nil, nil, cache, requestClient, locator, options...)
+
+	worker, err := workers.New(store, keys, workers.WithDeviceJobRunner(service))
+	if err != nil {
+		log.WithError(err).Warn("Failed to create workers.")
+	}
+	worker.Start()
 
 	e := routes.NewRouter(service)
+	e.Use(tracing.Middleware)
+	e.Use(metrics.Middleware)
 	e.Use(middleware.Log)
 	e.Use(echoMiddleware.RequestID())
 	e.HTTPErrorHandler = handlers.NewErrors(reporter)
 
+	e.GET(metrics.Path, echo.WrapHandler(metrics.Handler()))
+
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			apicontext := gateway.NewContext(service, c)
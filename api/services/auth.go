@@ -10,15 +10,19 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/cnf/structhash"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/keyring"
+	"github.com/shellhub-io/shellhub/api/pkg/lockout"
 	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
 )
 
 type AuthService interface {
@@ -26,13 +30,34 @@ type AuthService interface {
 	AuthIsCacheToken(ctx context.Context, tenant, id string) (bool, error)
 	AuthUncacheToken(ctx context.Context, tenant, id string) error
 	AuthDevice(ctx context.Context, req requests.DeviceAuth, remoteAddr string) (*models.DeviceAuthResponse, error)
-	AuthUser(ctx context.Context, model *models.UserAuthRequest) (*models.UserAuthResponse, error)
+	// AuthUser authenticates a user by its identifier, username or email, and password.
+	//
+	// The failed attempts are limited per identifier, user and sourceIP. After some failures, each new attempt must
+	// wait a progressive delay and, after more of them, the user is temporarily locked out. In both cases, it returns
+	// [ErrAuthLocked] with the time to wait as [ErrDataRetryAfter].
+	AuthUser(ctx context.Context, model *models.UserAuthRequest, sourceIP string) (*models.UserAuthResponse, error)
+	// AuthGetToken issues a token to the user on a new session. When mfa is true, the token has the MFA validated and
+	// the user's sessions without it, like the one started by the password, are revoked.
 	AuthGetToken(ctx context.Context, id string, mfa bool) (*models.UserAuthResponse, error)
 	AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth) (*models.PublicKeyAuthResponse, error)
-	AuthSwapToken(ctx context.Context, ID, tenant string) (*models.UserAuthResponse, error)
+	// AuthSwapToken issues an access token to the user on the namespace identified by tenant. When session is not
+	// empty, the session starts to issue its access tokens to the namespace.
+	AuthSwapToken(ctx context.Context, ID, tenant, session string) (*models.UserAuthResponse, error)
+	// AuthRefreshToken issues a new access token and a new refresh token from a refresh token, invalidating it.
+	//
+	// When an already used refresh token is received, the whole session is revoked, as it could have been stolen.
+	AuthRefreshToken(ctx context.Context, refreshToken string) (*models.UserAuthResponse, error)
 	AuthUserInfo(ctx context.Context, username, tenant, token string) (*models.UserAuthResponse, error)
+	// AuthMFA checks if the user has a second factor, a TOTP secret or a WebAuthn credential.
 	AuthMFA(ctx context.Context, id string) (bool, error)
-	PublicKey() *rsa.PublicKey
+	// AuthMFAAttempt records an attempt to validate the user's second factor. Every attempt counts as a failure until
+	// [AuthService.AuthGetToken] issues a validated token, so after some of them it returns [ErrAuthLocked] with the
+	// time to wait as [ErrDataRetryAfter].
+	AuthMFAAttempt(ctx context.Context, id string) error
+	// VerificationKey returns the public key identified by kid to verify a token signed by the API.
+	VerificationKey(ctx context.Context, kid string) (*rsa.PublicKey, error)
+	// JWKS returns the public keys able to verify the tokens signed by the API as a JSON Web Key Set.
+	JWKS(ctx context.Context) *keyring.JWKS
 }
 
 func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remoteAddr string) (*models.DeviceAuthResponse, error) {
@@ -53,6 +78,8 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 
 	key := hex.EncodeToString(uid[:])
 
+	signer := s.keys.Current(ctx)
+
 	token, err := jwttoken.New().
 		WithMethod(jwt.SigningMethodRS256).
 		WithClaims(&models.DeviceAuthClaims{
@@ -61,7 +88,8 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 				Claims: "device",
 			},
 		}).
-		WithPrivateKey(s.privKey).
+		WithPrivateKey(signer.PrivateKey).
+		WithKeyID(signer.ID).
 		Sign()
 	if err != nil {
 		return nil, NewErrTokenSigned(err)
@@ -114,6 +142,14 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 		return nil, NewErrDeviceCreate(device, err)
 	}
 
+	// NOTICE: the labels reported by the agent are kept apart from the ones set by the users, as only those can be
+	// trusted by the filters, and a failure to set them does not prevent the device from authenticating.
+	if len(req.Labels) > 0 {
+		if err := s.store.DeviceSetAgentLabels(ctx, models.UID(device.UID), req.Labels); err != nil {
+			logrus.WithError(err).WithField("uid", device.UID).Warn("failed to set the labels reported by the device")
+		}
+	}
+
 	for _, uid := range req.Sessions {
 		if err := s.store.SessionSetLastSeen(ctx, models.UID(uid)); err != nil {
 			continue
@@ -136,10 +172,21 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	}, nil
 }
 
-func (s *service) AuthUser(ctx context.Context, model *models.UserAuthRequest) (*models.UserAuthResponse, error) {
+func (s *service) AuthUser(ctx context.Context, model *models.UserAuthRequest, sourceIP string) (*models.UserAuthResponse, error) {
 	var err error
 	var user *models.User
 
+	keys := []lockout.Key{lockout.IdentifierKey(string(model.Identifier))}
+	if sourceIP != "" {
+		keys = append(keys, lockout.IPKey(sourceIP))
+	}
+
+	if wait, err := s.lockout.Check(ctx, keys...); err != nil {
+		logrus.WithError(err).Warn("failed to check the authentication attempts")
+	} else if wait > 0 {
+		return nil, NewErrAuthLocked(wait, nil)
+	}
+
 	if model.Identifier.IsEmail() {
 		user, err = s.store.UserGetByEmail(ctx, strings.ToLower(string(model.Identifier)))
 	} else {
@@ -147,9 +194,19 @@ func (s *service) AuthUser(ctx context.Context, model *models.UserAuthRequest) (
 	}
 
 	if err != nil {
+		s.lockout.Fail(ctx, keys...) // nolint: errcheck
+
 		return nil, NewErrAuthUnathorized(nil)
 	}
 
+	// NOTICE: the user's key shares the failed attempts between its username and email.
+	keys = append(keys, lockout.UserKey(user.ID))
+	if wait, err := s.lockout.Check(ctx, lockout.UserKey(user.ID)); err != nil {
+		logrus.WithError(err).Warn("failed to check the authentication attempts")
+	} else if wait > 0 {
+		return nil, NewErrAuthLocked(wait, nil)
+	}
+
 	if !user.Confirmed {
 		return nil, NewErrUserNotConfirmed(nil)
 	}
@@ -167,35 +224,27 @@ func (s *service) AuthUser(ctx context.Context, model *models.UserAuthRequest) (
 	}
 
 	if !user.UserPassword.Compare(models.NewUserPassword(model.Password)) {
+		s.lockout.Fail(ctx, keys...) // nolint: errcheck
+
 		return nil, NewErrAuthUnathorized(nil)
 	}
 
+	// NOTICE: the source IP's failed attempts are kept, as other users could be attacked from it.
+	s.lockout.Reset(ctx, lockout.IdentifierKey(string(model.Identifier)), lockout.UserKey(user.ID)) // nolint: errcheck
+
 	status, err := s.AuthMFA(ctx, user.ID)
 	if err != nil {
 		return nil, NewErrUserNotFound(user.ID, err)
 	}
 
-	token, err := jwttoken.New().
-		WithMethod(jwt.SigningMethodRS256).
-		WithExpire(clock.Now().Add(time.Hour * 72)).
-		WithClaims(&models.UserAuthClaims{
-			ID:       user.ID,
-			Tenant:   tenant,
-			Role:     role,
-			Admin:    true,
-			Username: user.Username,
-			MFA: models.MFA{
-				Enable:   status,
-				Validate: false,
-			},
-			AuthClaims: models.AuthClaims{
-				Claims: "user",
-			},
-		}).
-		WithPrivateKey(s.privKey).
-		Sign()
+	session, refresh, err := s.newUserSession(ctx, user.ID, tenant, false, sourceIP, model.UserAgent)
 	if err != nil {
-		return nil, NewErrTokenSigned(err)
+		return nil, err
+	}
+
+	token, err := s.signUserToken(ctx, user, session.ID, tenant, role, models.MFA{Enable: status, Validate: false})
+	if err != nil {
+		return nil, err
 	}
 
 	user.LastLogin = clock.Now()
@@ -204,16 +253,17 @@ func (s *service) AuthUser(ctx context.Context, model *models.UserAuthRequest) (
 		return nil, NewErrUserUpdate(user, err)
 	}
 
-	s.AuthCacheToken(ctx, tenant, user.ID, token.String()) // nolint: errcheck
+	s.AuthCacheToken(ctx, tenant, user.ID, token) // nolint: errcheck
 
 	return &models.UserAuthResponse{
-		Token:  token.String(),
-		Name:   user.Name,
-		ID:     user.ID,
-		User:   user.Username,
-		Tenant: tenant,
-		Role:   role,
-		Email:  user.Email,
+		Token:        token,
+		RefreshToken: refresh,
+		Name:         user.Name,
+		ID:           user.ID,
+		User:         user.Username,
+		Tenant:       tenant,
+		Role:         role,
+		Email:        user.Email,
 		MFA: models.MFA{
 			Enable:   status,
 			Validate: false,
@@ -243,39 +293,40 @@ func (s *service) AuthGetToken(ctx context.Context, id string, mfa bool) (*model
 		return nil, NewErrUserNotFound(id, err)
 	}
 
-	token, err := jwttoken.New().
-		WithMethod(jwt.SigningMethodRS256).
-		WithExpire(clock.Now().Add(time.Hour * 72)).
-		WithClaims(&models.UserAuthClaims{
-			ID:       user.ID,
-			Tenant:   tenant,
-			Role:     role,
-			Admin:    true,
-			Username: user.Username,
-			MFA: models.MFA{
-				Enable:   status,
-				Validate: mfa,
-			},
-			AuthClaims: models.AuthClaims{
-				Claims: "user",
-			},
-		}).
-		WithPrivateKey(s.privKey).
-		Sign()
+	if mfa {
+		s.lockout.Reset(ctx, lockout.MFAKey(user.ID)) // nolint: errcheck
+	}
+
+	// NOTICE: this token is requested after the MFA's validation, so it starts a new session that remembers it.
+	session, refresh, err := s.newUserSession(ctx, user.ID, tenant, mfa, "", "")
 	if err != nil {
-		return nil, NewErrTokenSigned(err)
+		return nil, err
+	}
+
+	token, err := s.signUserToken(ctx, user, session.ID, tenant, role, models.MFA{Enable: status, Validate: mfa})
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTICE: the session started by the password before the MFA's validation would keep issuing unvalidated tokens
+	// from its refresh token, so it is revoked.
+	if mfa {
+		if err := s.revokeUnvalidatedUserSessions(ctx, user.ID); err != nil {
+			return nil, err
+		}
 	}
 
-	s.AuthCacheToken(ctx, tenant, user.ID, token.String()) // nolint: errcheck
+	s.AuthCacheToken(ctx, tenant, user.ID, token) // nolint: errcheck
 
 	return &models.UserAuthResponse{
-		Token:  token.String(),
-		Name:   user.Name,
-		ID:     user.ID,
-		User:   user.Username,
-		Tenant: tenant,
-		Role:   role,
-		Email:  user.Email,
+		Token:        token,
+		RefreshToken: refresh,
+		Name:         user.Name,
+		ID:           user.ID,
+		User:         user.Username,
+		Tenant:       tenant,
+		Role:         role,
+		Email:        user.Email,
 		MFA: models.MFA{
 			Enable:   status,
 			Validate: mfa,
@@ -310,7 +361,7 @@ func (s *service) AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth)
 	}, nil
 }
 
-func (s *service) AuthSwapToken(ctx context.Context, id, tenant string) (*models.UserAuthResponse, error) {
+func (s *service) AuthSwapToken(ctx context.Context, id, tenant, session string) (*models.UserAuthResponse, error) {
 	namespace, err := s.store.NamespaceGet(ctx, tenant)
 	if err != nil {
 		return nil, NewErrNamespaceNotFound(tenant, err)
@@ -323,29 +374,21 @@ func (s *service) AuthSwapToken(ctx context.Context, id, tenant string) (*models
 
 	for _, member := range namespace.Members {
 		if user.ID == member.ID {
-			token, err := jwttoken.New().
-				WithMethod(jwt.SigningMethodRS256).
-				WithExpire(clock.Now().Add(time.Hour * 72)).
-				WithClaims(&models.UserAuthClaims{
-					ID:       user.ID,
-					Tenant:   tenant,
-					Role:     member.Role,
-					Admin:    true,
-					Username: user.Username,
-					AuthClaims: models.AuthClaims{
-						Claims: "user",
-					},
-				}).
-				WithPrivateKey(s.privKey).
-				Sign()
+			if session != "" {
+				if err := s.store.UserSessionUpdateTenant(ctx, session, tenant); err != nil {
+					return nil, NewErrAuthUnathorized(err)
+				}
+			}
+
+			token, err := s.signUserToken(ctx, user, session, tenant, member.Role, models.MFA{})
 			if err != nil {
-				return nil, NewErrTokenSigned(err)
+				return nil, err
 			}
 
-			s.AuthCacheToken(ctx, tenant, user.ID, token.String()) // nolint: errcheck
+			s.AuthCacheToken(ctx, tenant, user.ID, token) // nolint: errcheck
 
 			return &models.UserAuthResponse{
-				Token:  token.String(),
+				Token:  token,
 				Name:   user.Name,
 				ID:     user.ID,
 				User:   user.Username,
@@ -359,6 +402,61 @@ func (s *service) AuthSwapToken(ctx context.Context, id, tenant string) (*models
 	return nil, nil
 }
 
+func (s *service) AuthRefreshToken(ctx context.Context, refreshToken string) (*models.UserAuthResponse, error) {
+	session, refresh, err := s.rotateUserSession(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, session.UserID, false)
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	namespace, _ := s.store.NamespaceGet(ctx, session.TenantID)
+	if namespace == nil {
+		// NOTICE: when the session's namespace is gone, the tokens are issued to the user's first namespace, like on
+		// the login.
+		namespace, _ = s.store.NamespaceGetFirst(ctx, user.ID)
+	}
+
+	var role string
+	var tenant string
+	if namespace != nil {
+		if member, ok := namespace.FindMember(user.ID); ok {
+			tenant = namespace.TenantID
+			role = member.Role
+		}
+	}
+
+	status, err := s.AuthMFA(ctx, user.ID)
+	if err != nil {
+		return nil, NewErrUserNotFound(user.ID, err)
+	}
+
+	token, err := s.signUserToken(ctx, user, session.ID, tenant, role, models.MFA{Enable: status, Validate: session.MFA})
+	if err != nil {
+		return nil, err
+	}
+
+	s.AuthCacheToken(ctx, tenant, user.ID, token) // nolint: errcheck
+
+	return &models.UserAuthResponse{
+		Token:        token,
+		RefreshToken: refresh,
+		Name:         user.Name,
+		ID:           user.ID,
+		User:         user.Username,
+		Tenant:       tenant,
+		Role:         role,
+		Email:        user.Email,
+		MFA: models.MFA{
+			Enable:   status,
+			Validate: session.MFA,
+		},
+	}, nil
+}
+
 func (s *service) AuthUserInfo(ctx context.Context, username, tenant, token string) (*models.UserAuthResponse, error) {
 	user, err := s.store.UserGetByUsername(ctx, username)
 	if err != nil || user == nil {
@@ -395,19 +493,67 @@ func (s *service) AuthUserInfo(ctx context.Context, username, tenant, token stri
 	}, nil
 }
 
-func (s *service) PublicKey() *rsa.PublicKey {
-	return s.pubKey
+func (s *service) VerificationKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	return s.keys.PublicKey(ctx, kid)
+}
+
+// VerificationKeyFunc returns the function that resolves the key to verify a token signed by the API. The token must be
+// signed with RS256, and its "kid" header identifies which key of the API's keyring signed it.
+func VerificationKeyFunc(ctx context.Context, service Service) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+		}
+
+		kid, _ := token.Header["kid"].(string)
+
+		return service.VerificationKey(ctx, kid)
+	}
+}
+
+func (s *service) JWKS(ctx context.Context) *keyring.JWKS {
+	return s.keys.JWKS(ctx)
+}
+
+// signUserToken signs a short-lived access token to the user on the namespace identified by tenant, issued by the
+// user's session.
+func (s *service) signUserToken(ctx context.Context, user *models.User, session, tenant, role string, mfa models.MFA) (string, error) {
+	signer := s.keys.Current(ctx)
+
+	token, err := jwttoken.New().
+		WithMethod(jwt.SigningMethodRS256).
+		WithExpire(clock.Now().Add(AccessTokenLifetime)).
+		WithClaims(&models.UserAuthClaims{
+			ID:        user.ID,
+			Tenant:    tenant,
+			Role:      role,
+			Admin:     true,
+			Username:  user.Username,
+			SessionID: session,
+			MFA:       mfa,
+			AuthClaims: models.AuthClaims{
+				Claims: "user",
+			},
+		}).
+		WithPrivateKey(signer.PrivateKey).
+		WithKeyID(signer.ID).
+		Sign()
+	if err != nil {
+		return "", NewErrTokenSigned(err)
+	}
+
+	return token.String(), nil
 }
 
 // AuthCacheToken caches the user's namespace token.
 //
 // It receives a context, used to "control" the request flow, the namespace's tenant, user's ID and the token to cache.
 //
-// Cache times is the sametime of the token expiry time, what is 72 hours.
+// Cache times is the sametime of the refresh token expiry time, as the token is cached again on each refresh.
 //
 // AuthCacheToken returns an erro when it could not cache the token.
 func (s *service) AuthCacheToken(ctx context.Context, tenant, id, token string) error {
-	return s.cache.Set(ctx, "token_"+tenant+id, token, time.Hour*72)
+	return s.cache.Set(ctx, "token_"+tenant+id, token, RefreshTokenLifetime)
 }
 
 // AuthIsCacheToken checks if the user's namespace token is cached.
@@ -440,5 +586,26 @@ func (s *service) AuthMFA(ctx context.Context, id string) (bool, error) {
 		return false, err
 	}
 
-	return status, nil
+	if status {
+		return true, nil
+	}
+
+	credentials, err := s.store.WebAuthnCredentialList(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	return len(credentials) > 0, nil
+}
+
+func (s *service) AuthMFAAttempt(ctx context.Context, id string) error {
+	wait, err := s.lockout.Check(ctx, lockout.MFAKey(id))
+	if err != nil {
+		logrus.WithError(err).Warn("failed to check the MFA attempts")
+	} else if wait > 0 {
+		return NewErrAuthLocked(wait, nil)
+	}
+
+	// NOTICE: the second factor is validated outside the API, so the attempt is counted before knowing its result.
+	return s.lockout.Fail(ctx, lockout.MFAKey(id))
 }
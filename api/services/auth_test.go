@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/cnf/structhash"
+	"github.com/shellhub-io/shellhub/api/pkg/lockout"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -17,6 +18,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
 	"github.com/undefinedlabs/go-mpatch"
 )
 
@@ -31,6 +33,7 @@ func TestAuthDevice(t *testing.T) {
 			MAC: "mac",
 		},
 		Sessions: []string{"session"},
+		Labels:   map[string]string{"site": "lisbon"},
 	}
 
 	auth := models.DeviceAuth{
@@ -57,6 +60,8 @@ func TestAuthDevice(t *testing.T) {
 
 	mock.On("DeviceCreate", ctx, *device, "").
 		Return(nil).Once()
+	mock.On("DeviceSetAgentLabels", ctx, models.UID(device.UID), authReq.Labels).
+		Return(nil).Once()
 	mock.On("SessionSetLastSeen", ctx, models.UID(authReq.Sessions[0])).
 		Return(nil).Once()
 	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
@@ -112,6 +117,7 @@ func TestAuthUser(t *testing.T) {
 			expectedErr: errors.New("error", "", 0),
 			requiredMocks: func() {
 				mock.On("UserGetByUsername", ctx, "user").Return(nil, errors.New("error", "", 0)).Once()
+				clockMock.On("Now").Return(now).Once()
 			},
 			expected: Expected{nil, NewErrAuthUnathorized(nil)},
 		},
@@ -124,6 +130,7 @@ func TestAuthUser(t *testing.T) {
 			expectedErr: errors.New("error", "", 0),
 			requiredMocks: func() {
 				mock.On("UserGetByEmail", ctx, "user@test.com").Return(nil, errors.New("error", "", 0)).Once()
+				clockMock.On("Now").Return(now).Once()
 			},
 			expected: Expected{nil, NewErrAuthUnathorized(nil)},
 		},
@@ -154,6 +161,7 @@ func TestAuthUser(t *testing.T) {
 				}
 
 				mock.On("NamespaceGetFirst", ctx, user.ID).Return(namespace, nil).Once()
+				clockMock.On("Now").Return(now).Once()
 			},
 			expected: Expected{nil, NewErrAuthUnathorized(nil)},
 		},
@@ -170,7 +178,7 @@ func TestAuthUser(t *testing.T) {
 			authRes, err := service.AuthUser(ctx, &models.UserAuthRequest{
 				Identifier: models.UserAuthIdentifier(tc.req.Username),
 				Password:   tc.req.Password,
-			})
+			}, "127.0.0.1")
 			assert.Equal(t, tc.expected, Expected{authRes, err})
 		})
 	}
@@ -232,6 +240,7 @@ func TestAuthUserInfo(t *testing.T) {
 				}, nil).Once()
 				mock.On("NamespaceGet", ctx, "xxxxxx").Return(namespace, nil).Once()
 				mock.On("GetStatusMFA", ctx, "id").Return(false, nil).Once()
+				mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{}, nil).Once()
 			},
 			expected: Expected{
 				userAuthResponse: &models.UserAuthResponse{
@@ -267,6 +276,27 @@ func TestAuthUserInfo(t *testing.T) {
 	}
 }
 
+func TestAuthMFAAttempt(t *testing.T) {
+	ctx := context.TODO()
+
+	s := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, memoryCache{}, clientMock, nil)
+
+	// NOTICE: every attempt counts as a failure, so the user must wait after the policy's free attempts.
+	for i := 0; i < lockout.UserPolicy.DelayAfter; i++ {
+		if i == lockout.UserPolicy.DelayAfter-1 {
+			clockMock.On("Now").Return(now).Once()
+		}
+
+		assert.NoError(t, s.AuthMFAAttempt(ctx, "user"))
+	}
+
+	clockMock.On("Now").Return(now).Once()
+	assert.Equal(t, NewErrAuthLocked(lockout.UserPolicy.Delay, nil), s.AuthMFAAttempt(ctx, "user"))
+
+	// NOTICE: the attempts are counted per user.
+	assert.NoError(t, s.AuthMFAAttempt(ctx, "other"))
+}
+
 func TestAuthGetToken(t *testing.T) {
 	mock := new(mocks.Store)
 
@@ -280,6 +310,7 @@ func TestAuthGetToken(t *testing.T) {
 	tests := []struct {
 		description   string
 		userID        string
+		mfa           bool
 		requiredMocks func()
 		expected      Expected
 	}{
@@ -309,8 +340,39 @@ func TestAuthGetToken(t *testing.T) {
 				}, 1, nil).Once()
 				mock.On("NamespaceGetFirst", ctx, "id").Return(namespace, nil).Once()
 				mock.On("GetStatusMFA", ctx, "id").Return(false, nil).Once()
+				mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{}, nil).Once()
+				mock.On("UserSessionCreate", ctx, gomock.AnythingOfType("*models.UserSession")).Return(nil).Once()
+
+				clockMock.On("Now").Return(now).Times(3)
+			},
+			expected: Expected{
+				userAuthResponse: &models.UserAuthResponse{},
+				err:              nil,
+			},
+		},
+		{
+			description: "success when try to get a token after the MFA's validation revoking the unvalidated sessions",
+			userID:      "user",
+			mfa:         true,
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "user", false).Return(&models.User{
+					UserData: models.UserData{
+						Username: "user",
+						Name:     "user",
+						Email:    "email@email.com",
+					},
+					ID: "id",
+				}, 1, nil).Once()
+				mock.On("NamespaceGetFirst", ctx, "id").Return(nil, store.ErrNoDocuments).Once()
+				mock.On("GetStatusMFA", ctx, "id").Return(true, nil).Once()
+				mock.On("UserSessionCreate", ctx, gomock.AnythingOfType("*models.UserSession")).Return(nil).Once()
+				mock.On("UserSessionList", ctx, "id").Return([]models.UserSession{
+					{ID: "password", UserID: "id", MFA: false},
+					{ID: "validated", UserID: "id", MFA: true},
+				}, nil).Once()
+				mock.On("UserSessionDelete", ctx, "password").Return(nil).Once()
 
-				clockMock.On("Now").Return(now).Twice()
+				clockMock.On("Now").Return(now).Times(3)
 			},
 			expected: Expected{
 				userAuthResponse: &models.UserAuthResponse{},
@@ -329,7 +391,7 @@ func TestAuthGetToken(t *testing.T) {
 
 			service := NewService(mock, privateKey, &privateKey.PublicKey, storecache.NewNullCache(), clientMock, nil)
 
-			authRes, err := service.AuthGetToken(ctx, tc.userID, false)
+			authRes, err := service.AuthGetToken(ctx, tc.userID, tc.mfa)
 			assert.NotNil(t, authRes)
 			assert.Equal(t, tc.expected.err, err)
 
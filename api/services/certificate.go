@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"golang.org/x/crypto/ssh"
+)
+
+type CertificateService interface {
+	// GetCertificateAuthority returns the SSH user certificate authority of a namespace, creating it when the namespace
+	// does not have one yet.
+	GetCertificateAuthority(ctx context.Context, tenant string) (*models.CertificateAuthority, error)
+	// CreateUserCertificate signs a short-lived OpenSSH user certificate for a namespace's member using the namespace's
+	// certificate authority.
+	CreateUserCertificate(ctx context.Context, tenant, id string, req requests.UserCertificateCreate) (*models.UserCertificate, error)
+}
+
+// userCertificateBackdate is subtracted from the certificate's valid after to tolerate small clock drifts between the
+// API and the servers that check the certificate.
+const userCertificateBackdate = time.Minute
+
+func (s *service) GetCertificateAuthority(ctx context.Context, tenant string) (*models.CertificateAuthority, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	ca, err := s.store.CertificateAuthorityGet(ctx, tenant)
+	switch {
+	case err == nil:
+		return ca, nil
+	case err != store.ErrNoDocuments:
+		return nil, err
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := ssh.MarshalPrivateKey(priv, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	ca = &models.CertificateAuthority{
+		TenantID:    tenant,
+		Data:        pem.EncodeToMemory(block),
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+		Fingerprint: ssh.FingerprintSHA256(key),
+		CreatedAt:   clock.Now(),
+	}
+
+	if err := s.store.CertificateAuthorityCreate(ctx, ca); err != nil {
+		// NOTICE: when two requests try to create the namespace's authority at the same time, the unique index on
+		// tenant_id rejects the last one, so we return the authority created by the first.
+		if err == store.ErrDuplicate {
+			return s.store.CertificateAuthorityGet(ctx, tenant)
+		}
+
+		return nil, err
+	}
+
+	return ca, nil
+}
+
+func (s *service) CreateUserCertificate(ctx context.Context, tenant, id string, req requests.UserCertificateCreate) (*models.UserCertificate, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, id, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(id, err)
+	}
+
+	member, ok := namespace.FindMember(user.ID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(user.ID, nil)
+	}
+
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.PublicKey)) //nolint:dogsled
+	if err != nil {
+		return nil, NewErrPublicKeyDataInvalid([]byte(req.PublicKey), nil)
+	}
+
+	// NOTICE: a certificate cannot be used to sign another certificate.
+	if _, ok := pubKey.(*ssh.Certificate); ok {
+		return nil, NewErrPublicKeyDataInvalid([]byte(req.PublicKey), nil)
+	}
+
+	// NOTICE: only the public keys registered on the namespace are signed, and the username of the registered key
+	// restricts the device's usernames the certificate is allowed to log in as. Its filter is checked by the SSH server
+	// on each login.
+	fingerprint := ssh.FingerprintLegacyMD5(pubKey)
+
+	key, err := s.store.PublicKeyGet(ctx, fingerprint, tenant)
+	if err != nil {
+		return nil, NewErrPublicKeyNotFound(fingerprint, err)
+	}
+
+	for _, principal := range req.Principals {
+		if ok, err := s.EvaluateKeyUsername(ctx, key, principal); !ok || err != nil {
+			return nil, NewErrCertificatePrincipal(err)
+		}
+	}
+
+	ca, err := s.GetCertificateAuthority(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.ParsePrivateKey(ca.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var serial [8]byte
+	if _, err := rand.Read(serial[:]); err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	cert := &ssh.Certificate{
+		Key:             pubKey,
+		Serial:          binary.BigEndian.Uint64(serial[:]),
+		CertType:        ssh.UserCert,
+		KeyId:           user.Username,
+		ValidPrincipals: req.Principals,
+		ValidAfter:      uint64(now.Add(-userCertificateBackdate).Unix()),
+		ValidBefore:     uint64(now.Add(time.Duration(req.Validity) * time.Minute).Unix()),
+		Permissions: ssh.Permissions{
+			CriticalOptions: map[string]string{},
+			Extensions: map[string]string{
+				"permit-X11-forwarding":  "",
+				"permit-port-forwarding": "",
+				"permit-pty":             "",
+				"permit-user-rc":         "",
+			},
+		},
+	}
+
+	// NOTICE: the agent forwarding is opt-in, so the certificate only permits it when the namespace has enabled it and
+	// the member's role is allowed to forward its agent to the devices. Both are checked again by the SSH server when
+	// the certificate is used, as they may change while it is valid.
+	if canForwardAgent(namespace, member) {
+		cert.Permissions.Extensions["permit-agent-forwarding"] = ""
+	}
+
+	if len(req.SourceAddresses) > 0 {
+		cert.Permissions.CriticalOptions["source-address"] = strings.Join(req.SourceAddresses, ",")
+	}
+
+	if err := cert.SignCert(rand.Reader, signer); err != nil {
+		return nil, err
+	}
+
+	return &models.UserCertificate{
+		Certificate: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(cert))),
+		Serial:      cert.Serial,
+		KeyID:       cert.KeyId,
+		Principals:  cert.ValidPrincipals,
+		ValidAfter:  time.Unix(int64(cert.ValidAfter), 0),
+		ValidBefore: time.Unix(int64(cert.ValidBefore), 0),
+	}, nil
+}
+
+// certificateAuthoritySigner returns the private key of the namespace's certificate authority, which also signs the
+// X.509 client certificates used to reach the devices' published ports.
+func certificateAuthoritySigner(ca *models.CertificateAuthority) (crypto.Signer, error) {
+	key, err := ssh.ParseRawPrivateKey(ca.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *ed25519.PrivateKey:
+		return *key, nil
+	case crypto.Signer:
+		return key, nil
+	default:
+		return nil, errors.New("unsupported certificate authority's key")
+	}
+}
+
+// certificateAuthorityPublicKey returns the public key of the namespace's certificate authority.
+func certificateAuthorityPublicKey(ca *models.CertificateAuthority) (crypto.PublicKey, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(ca.PublicKey)) //nolint:dogsled
+	if err != nil {
+		return nil, err
+	}
+
+	public, ok := key.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, errors.New("unsupported certificate authority's key")
+	}
+
+	return public.CryptoPublicKey(), nil
+}
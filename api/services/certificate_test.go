@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCreateUserCertificate(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	caPub, caPriv, _ := ed25519.GenerateKey(rand.Reader)
+	caBlock, _ := ssh.MarshalPrivateKey(caPriv, "")
+	caKey, _ := ssh.NewPublicKey(caPub)
+
+	ca := &models.CertificateAuthority{
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		Data:        pem.EncodeToMemory(caBlock),
+		PublicKey:   string(ssh.MarshalAuthorizedKey(caKey)),
+		Fingerprint: ssh.FingerprintSHA256(caKey),
+	}
+
+	userPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	userKey, _ := ssh.NewPublicKey(userPub)
+
+	namespace := &models.Namespace{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Members:  []models.Member{{ID: "member", Role: "operator"}},
+	}
+
+	forwarding := &models.Namespace{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Members:  []models.Member{{ID: "member", Role: "operator"}, {ID: "observer", Role: "observer"}},
+		Settings: &models.NamespaceSettings{AgentForwarding: true},
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		req           requests.UserCertificateCreate
+		requiredMocks func()
+		expected      func(*models.UserCertificate, error)
+	}{
+		{
+			description: "fails when namespace is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "member",
+			req:         requests.UserCertificateCreate{},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: func(cert *models.UserCertificate, err error) {
+				assert.Nil(t, cert)
+				assert.Equal(t, NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", errors.New("error", "", 0)), err)
+			},
+		},
+		{
+			description: "fails when user is not a member of the namespace",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "user",
+			req:         requests.UserCertificateCreate{},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("UserGetByID", ctx, "user", false).Return(&models.User{ID: "user"}, 0, nil).Once()
+			},
+			expected: func(cert *models.UserCertificate, err error) {
+				assert.Nil(t, cert)
+				assert.Equal(t, NewErrNamespaceMemberNotFound("user", nil), err)
+			},
+		},
+		{
+			description: "fails when public key is invalid",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "member",
+			req: requests.UserCertificateCreate{
+				PublicKey:  "invalid",
+				Principals: []string{"root"},
+				Validity:   5,
+			},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("UserGetByID", ctx, "member", false).Return(&models.User{ID: "member"}, 0, nil).Once()
+			},
+			expected: func(cert *models.UserCertificate, err error) {
+				assert.Nil(t, cert)
+				assert.Equal(t, NewErrPublicKeyDataInvalid([]byte("invalid"), nil), err)
+			},
+		},
+		{
+			description: "fails when public key is not registered on the namespace",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "member",
+			req: requests.UserCertificateCreate{
+				PublicKey:  string(ssh.MarshalAuthorizedKey(userKey)),
+				Principals: []string{"admin"},
+				Validity:   5,
+			},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("UserGetByID", ctx, "member", false).Return(&models.User{ID: "member"}, 0, nil).Once()
+				mock.On("PublicKeyGet", ctx, ssh.FingerprintLegacyMD5(userKey), "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: func(cert *models.UserCertificate, err error) {
+				assert.Nil(t, cert)
+				assert.Equal(t, NewErrPublicKeyNotFound(ssh.FingerprintLegacyMD5(userKey), errors.New("error", "", 0)), err)
+			},
+		},
+		{
+			description: "fails when a principal is not allowed by the public key's username",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "member",
+			req: requests.UserCertificateCreate{
+				PublicKey:  string(ssh.MarshalAuthorizedKey(userKey)),
+				Principals: []string{"admin", "root"},
+				Validity:   5,
+			},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("UserGetByID", ctx, "member", false).Return(&models.User{ID: "member"}, 0, nil).Once()
+				mock.On("PublicKeyGet", ctx, ssh.FingerprintLegacyMD5(userKey), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{PublicKeyFields: models.PublicKeyFields{Username: "^admin$"}}, nil).Once()
+			},
+			expected: func(cert *models.UserCertificate, err error) {
+				assert.Nil(t, cert)
+				assert.Equal(t, NewErrCertificatePrincipal(nil), err)
+			},
+		},
+		{
+			description: "succeeds to sign the certificate with the namespace's authority",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "member",
+			req: requests.UserCertificateCreate{
+				PublicKey:       string(ssh.MarshalAuthorizedKey(userKey)),
+				Principals:      []string{"root", "admin"},
+				Validity:        5,
+				SourceAddresses: []string{"192.168.0.0/24"},
+			},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Twice()
+				mock.On("UserGetByID", ctx, "member", false).
+					Return(&models.User{ID: "member", UserData: models.UserData{Username: "john_doe"}}, 0, nil).Once()
+				mock.On("PublicKeyGet", ctx, ssh.FingerprintLegacyMD5(userKey), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{}, nil).Once()
+				mock.On("CertificateAuthorityGet", ctx, "00000000-0000-4000-0000-000000000000").Return(ca, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: func(cert *models.UserCertificate, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "john_doe", cert.KeyID)
+				assert.Equal(t, []string{"root", "admin"}, cert.Principals)
+				assert.Equal(t, now.Add(5*time.Minute).Unix(), cert.ValidBefore.Unix())
+
+				parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cert.Certificate)) //nolint:dogsled
+				assert.NoError(t, err)
+
+				sshcert, ok := parsed.(*ssh.Certificate)
+				assert.True(t, ok)
+				assert.Equal(t, ssh.FingerprintSHA256(caKey), ssh.FingerprintSHA256(sshcert.SignatureKey))
+				assert.Equal(t, "192.168.0.0/24", sshcert.CriticalOptions["source-address"])
+				assert.NotContains(t, sshcert.Extensions, "permit-agent-forwarding")
+			},
+		},
+		{
+			description: "succeeds to permit the agent forwarding when namespace and role allow it",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "member",
+			req: requests.UserCertificateCreate{
+				PublicKey:  string(ssh.MarshalAuthorizedKey(userKey)),
+				Principals: []string{"root"},
+				Validity:   5,
+			},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(forwarding, nil).Twice()
+				mock.On("UserGetByID", ctx, "member", false).
+					Return(&models.User{ID: "member", UserData: models.UserData{Username: "john_doe"}}, 0, nil).Once()
+				mock.On("PublicKeyGet", ctx, ssh.FingerprintLegacyMD5(userKey), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{}, nil).Once()
+				mock.On("CertificateAuthorityGet", ctx, "00000000-0000-4000-0000-000000000000").Return(ca, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: func(cert *models.UserCertificate, err error) {
+				assert.NoError(t, err)
+
+				parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cert.Certificate)) //nolint:dogsled
+				assert.NoError(t, err)
+				assert.Contains(t, parsed.(*ssh.Certificate).Extensions, "permit-agent-forwarding")
+			},
+		},
+		{
+			description: "succeeds without permitting the agent forwarding when role does not allow it",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "observer",
+			req: requests.UserCertificateCreate{
+				PublicKey:  string(ssh.MarshalAuthorizedKey(userKey)),
+				Principals: []string{"root"},
+				Validity:   5,
+			},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(forwarding, nil).Twice()
+				mock.On("UserGetByID", ctx, "observer", false).
+					Return(&models.User{ID: "observer", UserData: models.UserData{Username: "jane_doe"}}, 0, nil).Once()
+				mock.On("PublicKeyGet", ctx, ssh.FingerprintLegacyMD5(userKey), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{}, nil).Once()
+				mock.On("CertificateAuthorityGet", ctx, "00000000-0000-4000-0000-000000000000").Return(ca, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: func(cert *models.UserCertificate, err error) {
+				assert.NoError(t, err)
+
+				parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cert.Certificate)) //nolint:dogsled
+				assert.NoError(t, err)
+				assert.NotContains(t, parsed.(*ssh.Certificate).Extensions, "permit-agent-forwarding")
+			},
+		},
+	}
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			cert, err := s.CreateUserCertificate(ctx, tc.tenant, tc.id, tc.req)
+			tc.expected(cert, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDevicePublishedPortCertificate(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	caPub, caPriv, _ := ed25519.GenerateKey(rand.Reader)
+	caBlock, _ := ssh.MarshalPrivateKey(caPriv, "")
+	caKey, _ := ssh.NewPublicKey(caPub)
+
+	ca := &models.CertificateAuthority{
+		TenantID:    tenant,
+		Data:        pem.EncodeToMemory(caBlock),
+		PublicKey:   string(ssh.MarshalAuthorizedKey(caKey)),
+		Fingerprint: ssh.FingerprintSHA256(caKey),
+	}
+
+	clientPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	clientDER, _ := x509.MarshalPKIXPublicKey(clientPub)
+	clientKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: clientDER}))
+
+	device := &models.Device{
+		UID:            "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+		TenantID:       tenant,
+		PublishedPorts: []models.DevicePublishedPort{{Address: "address", Port: 5432, GatewayPort: 20000}},
+	}
+
+	namespace := &models.Namespace{
+		TenantID: tenant,
+		Members:  []models.Member{{ID: "member", Role: "operator"}},
+	}
+
+	req := requests.DevicePublishedPortCertificate{
+		DevicePublishedPortParam: requests.DevicePublishedPortParam{
+			DeviceParam: requests.DeviceParam{UID: device.UID},
+			Address:     "address",
+		},
+		PublicKey: clientKey,
+		Validity:  60,
+	}
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	t.Run("fails when the port is not found", func(t *testing.T) {
+		mock.On("DeviceGetByUID", ctx, models.UID(device.UID), tenant).Return(device, nil).Once()
+
+		unknown := req
+		unknown.Address = "unknown"
+
+		cert, err := s.CreateDevicePublishedPortCertificate(ctx, tenant, "member", unknown)
+		assert.Nil(t, cert)
+		assert.Equal(t, NewErrDevicePublishedPortNotFound("unknown", nil), err)
+	})
+
+	t.Run("fails when the user is not a member of the namespace", func(t *testing.T) {
+		mock.On("DeviceGetByUID", ctx, models.UID(device.UID), tenant).Return(device, nil).Once()
+		mock.On("NamespaceGet", ctx, tenant).Return(namespace, nil).Once()
+
+		cert, err := s.CreateDevicePublishedPortCertificate(ctx, tenant, "stranger", req)
+		assert.Nil(t, cert)
+		assert.Equal(t, NewErrNamespaceMemberNotFound("stranger", nil), err)
+	})
+
+	t.Run("fails when the public key is invalid", func(t *testing.T) {
+		mock.On("DeviceGetByUID", ctx, models.UID(device.UID), tenant).Return(device, nil).Once()
+		mock.On("NamespaceGet", ctx, tenant).Return(namespace, nil).Once()
+
+		invalid := req
+		invalid.PublicKey = "invalid"
+
+		cert, err := s.CreateDevicePublishedPortCertificate(ctx, tenant, "member", invalid)
+		assert.Nil(t, cert)
+		assert.Equal(t, NewErrPublicKeyDataInvalid([]byte("invalid"), nil), err)
+	})
+
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), tenant).Return(device, nil).Once()
+	mock.On("NamespaceGet", ctx, tenant).Return(namespace, nil).Twice()
+	mock.On("CertificateAuthorityGet", ctx, tenant).Return(ca, nil).Once()
+	clockMock.On("Now").Return(now).Once()
+
+	cert, err := s.CreateDevicePublishedPortCertificate(ctx, tenant, "member", req)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), cert.ExpiresAt)
+
+	block, _ := pem.Decode([]byte(cert.Certificate))
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, "member", parsed.Subject.CommonName)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, parsed.ExtKeyUsage)
+	assert.Equal(t, clientPub, parsed.PublicKey)
+
+	t.Run("fails when the certificate is invalid", func(t *testing.T) {
+		authorized, err := s.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{GatewayPort: 20000, Certificate: "invalid"})
+		assert.Nil(t, authorized)
+		assert.Equal(t, NewErrAuthUnathorized(nil), err)
+	})
+
+	t.Run("fails when the certificate was signed by another authority", func(t *testing.T) {
+		otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+		otherKey, _ := ssh.NewPublicKey(otherPub)
+
+		mock.On("DeviceGetByPublishedPortGatewayPort", ctx, 20000).Return(device, nil).Once()
+		mock.On("CertificateAuthorityGet", ctx, tenant).
+			Return(&models.CertificateAuthority{TenantID: tenant, PublicKey: string(ssh.MarshalAuthorizedKey(otherKey))}, nil).Once()
+
+		authorized, err := s.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{GatewayPort: 20000, Certificate: cert.Certificate})
+		assert.Nil(t, authorized)
+		assert.ErrorIs(t, err, ErrAuthUnathorized)
+	})
+
+	t.Run("fails when the certificate is expired", func(t *testing.T) {
+		mock.On("DeviceGetByPublishedPortGatewayPort", ctx, 20000).Return(device, nil).Once()
+		mock.On("CertificateAuthorityGet", ctx, tenant).Return(ca, nil).Once()
+		clockMock.On("Now").Return(now).Once()
+
+		expired := *parsed
+		expired.NotBefore = now.Add(-2 * time.Hour)
+		expired.NotAfter = now.Add(-time.Hour)
+
+		der, err := x509.CreateCertificate(rand.Reader, &expired, &x509.Certificate{}, clientPub, caPriv)
+		assert.NoError(t, err)
+
+		authorized, err := s.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{
+			GatewayPort: 20000,
+			Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		})
+		assert.Nil(t, authorized)
+		assert.ErrorIs(t, err, ErrAuthUnathorized)
+	})
+
+	t.Run("success when the certificate was issued to the port", func(t *testing.T) {
+		mock.On("DeviceGetByPublishedPortGatewayPort", ctx, 20000).Return(device, nil).Once()
+		mock.On("CertificateAuthorityGet", ctx, tenant).Return(ca, nil).Once()
+		mock.On("NamespaceGet", ctx, tenant).Return(namespace, nil).Once()
+		clockMock.On("Now").Return(now).Once()
+
+		authorized, err := s.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{GatewayPort: 20000, Certificate: cert.Certificate})
+		assert.NoError(t, err)
+		assert.Equal(t, device, authorized)
+	})
+
+	mock.AssertExpectations(t)
+}
@@ -2,23 +2,37 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/shellhub-io/shellhub/api/store"
 	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 	"github.com/sirupsen/logrus"
 )
 
 const StatusAccepted = "accepted"
 
+// DevicePublishedPortTokenLifetime is how long a token to connect to a device's published port is valid.
+const DevicePublishedPortTokenLifetime = 5 * time.Minute
+
 type DeviceService interface {
 	ListDevices(ctx context.Context, tenant string, pagination paginator.Query, filter []models.Filter, status models.DeviceStatus, sort, order string) ([]models.Device, int, error)
 	GetDevice(ctx context.Context, uid models.UID) (*models.Device, error)
@@ -31,6 +45,15 @@ type DeviceService interface {
 	SetDevicePosition(ctx context.Context, uid models.UID, ip string) error
 	DeviceHeartbeat(ctx context.Context, uid models.UID) error
 	UpdateDevice(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error
+	CreateDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, req requests.DevicePublicURLServiceFields) (*models.DevicePublicURLService, error)
+	UpdateDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, address string, req requests.DevicePublicURLServiceFields) (*models.DevicePublicURLService, error)
+	DeleteDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, address string) error
+	AuthorizeDevicePublicURL(ctx context.Context, req requests.DevicePublicURLAuthorize) error
+	CreateDevicePublishedPort(ctx context.Context, tenant string, uid models.UID, req requests.DevicePublishedPortCreate) (*models.DevicePublishedPort, error)
+	DeleteDevicePublishedPort(ctx context.Context, tenant string, uid models.UID, address string) error
+	CreateDevicePublishedPortToken(ctx context.Context, tenant, id string, uid models.UID, address string) (*models.DevicePublishedPortToken, error)
+	CreateDevicePublishedPortCertificate(ctx context.Context, tenant, id string, req requests.DevicePublishedPortCertificate) (*models.DevicePublishedPortCertificate, error)
+	AuthorizeDevicePublishedPort(ctx context.Context, req requests.DevicePublishedPortAuthorize) (*models.Device, error)
 }
 
 func (s *service) ListDevices(ctx context.Context, tenant string, pagination paginator.Query, filter []models.Filter, status models.DeviceStatus, sort, order string) ([]models.Device, int, error) {
@@ -173,7 +196,11 @@ func (s *service) LookupDevice(ctx context.Context, namespace, name string) (*mo
 }
 
 func (s *service) OffineDevice(ctx context.Context, uid models.UID, online bool) error {
-	err := s.store.DeviceSetOnline(ctx, uid, clock.Now(), online)
+	now := clock.Now()
+
+	s.recordDeviceConnectivity(ctx, uid, online, now)
+
+	err := s.store.DeviceSetOnline(ctx, uid, now, online)
 	if err == store.ErrNoDocuments {
 		return NewErrDeviceNotFound(uid, err)
 	}
@@ -330,13 +357,29 @@ func (s *service) SetDevicePosition(ctx context.Context, uid models.UID, ip stri
 }
 
 func (s *service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
-	if err := s.store.DeviceSetOnline(ctx, uid, clock.Now(), true); err != nil {
+	now := clock.Now()
+
+	s.recordDeviceConnectivity(ctx, uid, true, now)
+
+	if err := s.store.DeviceSetOnline(ctx, uid, now, true); err != nil {
 		return NewErrDeviceNotFound(uid, err)
 	}
 
 	return nil
 }
 
+// recordDeviceConnectivity records the device's transition to online or offline, for its availability reports. As the
+// device's last seen is used to know if it went offline since its previous heartbeat, it must be called before the last
+// seen is updated. A failure to record is just logged, so it does not prevent the device's state from being set.
+func (s *service) recordDeviceConnectivity(ctx context.Context, uid models.UID, online bool, timestamp time.Time) {
+	if err := s.store.DeviceConnectivityRecord(ctx, uid, online, timestamp); err != nil && err != store.ErrNoDocuments {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"uid":    uid,
+			"online": online,
+		}).Warn("failed to record the device's connectivity")
+	}
+}
+
 func (s *service) UpdateDevice(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
 	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
 	if err != nil {
@@ -374,3 +417,497 @@ func (s *service) UpdateDevice(ctx context.Context, tenant string, uid models.UI
 
 	return s.store.DeviceUpdate(ctx, tenant, uid, name, publicURL)
 }
+
+// CreateDevicePublicURLService publishes a device's local service through a public URL.
+//
+// The service receives its own random address, so a device can expose more than one service at once. It returns an
+// error when the device is not found or when it already publishes a service on the same target.
+func (s *service) CreateDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, req requests.DevicePublicURLServiceFields) (*models.DevicePublicURLService, error) {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	service := newDevicePublicURLService(req)
+	for _, published := range device.PublicURLServices {
+		if published.Target() == service.Target() {
+			return nil, NewErrDevicePublicURLDuplicated(service.Target(), nil)
+		}
+	}
+
+	service.Address = newPublicAddress()
+
+	if err := s.store.DevicePublicURLServiceCreate(ctx, uid, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// UpdateDevicePublicURLService changes where a device's public URL service is listening, keeping its address.
+func (s *service) UpdateDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, address string, req requests.DevicePublicURLServiceFields) (*models.DevicePublicURLService, error) {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	service := newDevicePublicURLService(req)
+	service.Address = address
+
+	found := false
+	for _, published := range device.PublicURLServices {
+		switch {
+		case published.Address == address:
+			found = true
+		case published.Target() == service.Target():
+			return nil, NewErrDevicePublicURLDuplicated(service.Target(), nil)
+		}
+	}
+
+	if !found {
+		return nil, NewErrDevicePublicURLNotFound(address, nil)
+	}
+
+	if err := s.store.DevicePublicURLServiceUpdate(ctx, uid, address, service); err != nil {
+		if err == store.ErrNoDocuments {
+			return nil, NewErrDevicePublicURLNotFound(address, err)
+		}
+
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// DeleteDevicePublicURLService stops publishing a device's local service through its public URL.
+func (s *service) DeleteDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, address string) error {
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if err := s.store.DevicePublicURLServiceDelete(ctx, uid, address); err != nil {
+		if err == store.ErrNoDocuments {
+			return NewErrDevicePublicURLNotFound(address, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func newDevicePublicURLService(req requests.DevicePublicURLServiceFields) *models.DevicePublicURLService {
+	scheme := req.Scheme
+	if scheme == "" {
+		scheme = models.DevicePublicURLSchemeHTTP
+	}
+
+	access := models.DevicePublicURLAccess{Mode: req.Access}
+	switch req.Access {
+	case models.DevicePublicURLAccessBasic:
+		access.Username = req.Username
+		access.Password = models.NewUserPassword(req.Password).HashedPassword
+	case models.DevicePublicURLAccessAllowlist:
+		access.AllowedCIDRs = req.AllowedCIDRs
+	}
+
+	return &models.DevicePublicURLService{
+		Host:               req.Host,
+		Port:               req.Port,
+		Scheme:             scheme,
+		InsecureSkipVerify: scheme == models.DevicePublicURLSchemeHTTPS && req.InsecureSkipVerify,
+		Access:             access,
+	}
+}
+
+// AuthorizeDevicePublicURL checks the credentials required to reach a device's public URL service.
+//
+// Services on login access require a valid ShellHub's user token from a member of the device's namespace, while
+// services on basic access require the configured username and password. Other access modes don't depend on
+// credentials and are always authorized here; the allowlist is evaluated by the caller, that knows the client's
+// address.
+func (s *service) AuthorizeDevicePublicURL(ctx context.Context, req requests.DevicePublicURLAuthorize) error {
+	device, err := s.store.DeviceGetByPublicURLAddress(ctx, req.PublicURLAddress)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(req.PublicURLAddress), err)
+	}
+
+	published, ok := device.PublicURLService(req.PublicURLAddress)
+	if !ok {
+		return NewErrDevicePublicURLNotFound(req.PublicURLAddress, nil)
+	}
+
+	switch published.Access.Mode {
+	case models.DevicePublicURLAccessBasic:
+		password := models.NewUserPassword(req.Password)
+
+		if subtle.ConstantTimeCompare([]byte(req.Username), []byte(published.Access.Username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(password.HashedPassword), []byte(published.Access.Password)) != 1 {
+			return NewErrAuthUnathorized(nil)
+		}
+	case models.DevicePublicURLAccessLogin:
+		claims, err := s.parseUserToken(ctx, req.Token)
+		if err != nil {
+			return NewErrAuthUnathorized(err)
+		}
+
+		if claims.SessionID != "" {
+			if revoked, err := s.IsUserSessionRevoked(ctx, claims.SessionID); err != nil || revoked {
+				return NewErrAuthUnathorized(err)
+			}
+		}
+
+		if claims.MFA.Enable && !claims.MFA.Validate {
+			return NewErrAuthUnathorized(nil)
+		}
+
+		namespace, err := s.store.NamespaceGet(ctx, device.TenantID)
+		if err != nil {
+			return NewErrNamespaceNotFound(device.TenantID, err)
+		}
+
+		if _, ok := namespace.FindMember(claims.ID); !ok {
+			return NewErrForbidden(ErrDevicePublicURLForbidden, nil)
+		}
+	}
+
+	return nil
+}
+
+// parseUserToken parses and verifies a user's token signed by the API.
+func (s *service) parseUserToken(ctx context.Context, token string) (*models.UserAuthClaims, error) {
+	claims := new(models.UserAuthClaims)
+	if _, err := jwt.ParseWithClaims(token, claims, VerificationKeyFunc(ctx, s)); err != nil {
+		return nil, err
+	}
+
+	if claims.Claims != "user" {
+		return nil, fmt.Errorf("unexpected token claims: %s", claims.Claims)
+	}
+
+	return claims, nil
+}
+
+// CreateDevicePublishedPort publishes a device's local TCP port through the ShellHub's gateway.
+//
+// The port receives its own random address, used as the hostname's prefix to reach it, and a gateway's port when the
+// gateway allocates them. It returns an error when the device is not found, when the target is already published or
+// when every gateway's port is allocated.
+func (s *service) CreateDevicePublishedPort(ctx context.Context, tenant string, uid models.UID, req requests.DevicePublishedPortCreate) (*models.DevicePublishedPort, error) {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	port := &models.DevicePublishedPort{Host: req.Host, Port: req.Port}
+	for _, published := range device.PublishedPorts {
+		if published.Target() == port.Target() {
+			return nil, NewErrDevicePublishedPortDuplicated(port.Target(), nil)
+		}
+	}
+
+	port.Address = newPublicAddress()
+
+	if port.GatewayPort, err = s.allocateGatewayPort(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.DevicePublishedPortCreate(ctx, uid, port); err != nil {
+		return nil, err
+	}
+
+	return port, nil
+}
+
+// allocateGatewayPort returns the first gateway's port of the SHELLHUB_PUBLISHED_PORTS_RANGE not allocated to a
+// published port yet. It is zero when the range is not set, as the published ports are only reached by their hostnames.
+func (s *service) allocateGatewayPort(ctx context.Context) (int, error) {
+	value := envs.DefaultBackend.Get("SHELLHUB_PUBLISHED_PORTS_RANGE")
+	if value == "" {
+		return 0, nil
+	}
+
+	ports, err := models.ParsePortRange(value)
+	if err != nil {
+		return 0, err
+	}
+
+	allocated, err := s.store.DevicePublishedPortListGatewayPorts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	used := make(map[int]bool, len(allocated))
+	for _, port := range allocated {
+		used[port] = true
+	}
+
+	for port := ports.First; port <= ports.Last; port++ {
+		if !used[port] {
+			return port, nil
+		}
+	}
+
+	return 0, NewErrDevicePublishedPortLimit(ports.Last-ports.First+1, nil)
+}
+
+// DeleteDevicePublishedPort stops publishing a device's local TCP port.
+func (s *service) DeleteDevicePublishedPort(ctx context.Context, tenant string, uid models.UID, address string) error {
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if err := s.store.DevicePublishedPortDelete(ctx, uid, address); err != nil {
+		if err == store.ErrNoDocuments {
+			return NewErrDevicePublishedPortNotFound(address, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// CreateDevicePublishedPortToken issues a short-lived token that allows the namespace's member identified by id to
+// connect to a device's published port.
+func (s *service) CreateDevicePublishedPortToken(ctx context.Context, tenant, id string, uid models.UID, address string) (*models.DevicePublishedPortToken, error) {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	if _, ok := device.PublishedPort(address); !ok {
+		return nil, NewErrDevicePublishedPortNotFound(address, nil)
+	}
+
+	signer := s.keys.Current(ctx)
+	expiresAt := clock.Now().Add(DevicePublishedPortTokenLifetime)
+
+	token, err := jwttoken.New().
+		WithMethod(jwt.SigningMethodRS256).
+		WithExpire(expiresAt).
+		WithClaims(&models.DevicePublishedPortClaims{
+			ID:      id,
+			Tenant:  tenant,
+			Address: address,
+			AuthClaims: models.AuthClaims{
+				Claims: "port",
+			},
+		}).
+		WithPrivateKey(signer.PrivateKey).
+		WithKeyID(signer.ID).
+		Sign()
+	if err != nil {
+		return nil, NewErrTokenSigned(err)
+	}
+
+	return &models.DevicePublishedPortToken{Token: token.String(), ExpiresAt: expiresAt}, nil
+}
+
+// CreateDevicePublishedPortCertificate signs a short-lived X.509 client certificate for the namespace's member
+// identified by id using the namespace's certificate authority. The certificate allows the member to connect to a
+// device's published port through TLS, without the HTTP upgrade required by the tokens.
+func (s *service) CreateDevicePublishedPortCertificate(ctx context.Context, tenant, id string, req requests.DevicePublishedPortCertificate) (*models.DevicePublishedPortCertificate, error) {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(req.UID), tenant)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.UID), err)
+	}
+
+	if _, ok := device.PublishedPort(req.Address); !ok {
+		return nil, NewErrDevicePublishedPortNotFound(req.Address, nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	if _, ok := namespace.FindMember(id); !ok {
+		return nil, NewErrNamespaceMemberNotFound(id, nil)
+	}
+
+	block, _ := pem.Decode([]byte(req.PublicKey))
+	if block == nil {
+		return nil, NewErrPublicKeyDataInvalid([]byte(req.PublicKey), nil)
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, NewErrPublicKeyDataInvalid([]byte(req.PublicKey), err)
+	}
+
+	ca, err := s.GetCertificateAuthority(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := certificateAuthoritySigner(ca)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	expiresAt := now.Add(time.Duration(req.Validity) * time.Minute)
+
+	// NOTICE: the certificate is bound to the member, the namespace and the published port, what is checked again, with
+	// the membership, on each connection.
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:         id,
+			Organization:       []string{tenant},
+			OrganizationalUnit: []string{req.Address},
+		},
+		NotBefore:   now.Add(-userCertificateBackdate),
+		NotAfter:    expiresAt,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	issuer := &x509.Certificate{Subject: pkix.Name{CommonName: ca.Fingerprint, Organization: []string{tenant}}}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, publicKey, signer)
+	if err != nil {
+		return nil, NewErrPublicKeyDataInvalid([]byte(req.PublicKey), err)
+	}
+
+	return &models.DevicePublishedPortCertificate{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// AuthorizeDevicePublishedPort checks the token or the client certificate sent to connect to a device's published
+// port, returning the device that publishes it.
+//
+// The credential must have been issued to the published port, and its user must still be a member of the device's
+// namespace.
+func (s *service) AuthorizeDevicePublishedPort(ctx context.Context, req requests.DevicePublishedPortAuthorize) (*models.Device, error) {
+	claims := new(models.DevicePublishedPortClaims)
+
+	var certificate *x509.Certificate
+	if req.Token != "" {
+		if _, err := jwt.ParseWithClaims(req.Token, claims, VerificationKeyFunc(ctx, s)); err != nil {
+			return nil, NewErrAuthUnathorized(err)
+		}
+
+		if claims.Claims != "port" {
+			return nil, NewErrAuthUnathorized(nil)
+		}
+	} else {
+		block, _ := pem.Decode([]byte(req.Certificate))
+		if block == nil {
+			return nil, NewErrAuthUnathorized(nil)
+		}
+
+		var err error
+		if certificate, err = x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, NewErrAuthUnathorized(err)
+		}
+	}
+
+	device, port, err := s.getDeviceByPublishedPort(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if certificate != nil {
+		if claims, err = s.verifyPublishedPortCertificate(ctx, device.TenantID, certificate); err != nil {
+			return nil, NewErrAuthUnathorized(err)
+		}
+	}
+
+	if device.TenantID != claims.Tenant || port.Address != claims.Address {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, device.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(device.TenantID, err)
+	}
+
+	if _, ok := namespace.FindMember(claims.ID); !ok {
+		return nil, NewErrForbidden(ErrDevicePublishedPortForbidden, nil)
+	}
+
+	return device, nil
+}
+
+// getDeviceByPublishedPort gets the device that owns the published port identified by its address or, when the address
+// is empty, by the gateway's port allocated to it.
+func (s *service) getDeviceByPublishedPort(ctx context.Context, req requests.DevicePublishedPortAuthorize) (*models.Device, *models.DevicePublishedPort, error) {
+	if req.Address != "" {
+		device, err := s.store.DeviceGetByPublishedPortAddress(ctx, req.Address)
+		if err != nil {
+			return nil, nil, NewErrDevicePublishedPortNotFound(req.Address, err)
+		}
+
+		port, ok := device.PublishedPort(req.Address)
+		if !ok {
+			return nil, nil, NewErrDevicePublishedPortNotFound(req.Address, nil)
+		}
+
+		return device, port, nil
+	}
+
+	device, err := s.store.DeviceGetByPublishedPortGatewayPort(ctx, req.GatewayPort)
+	if err != nil {
+		return nil, nil, NewErrDevicePublishedPortNotFound(strconv.Itoa(req.GatewayPort), err)
+	}
+
+	port, ok := device.PublishedPortByGatewayPort(req.GatewayPort)
+	if !ok {
+		return nil, nil, NewErrDevicePublishedPortNotFound(strconv.Itoa(req.GatewayPort), nil)
+	}
+
+	return device, port, nil
+}
+
+// verifyPublishedPortCertificate checks that the client certificate was signed by the certificate authority of the
+// tenant's namespace and that it is valid now, returning the claims it carries.
+func (s *service) verifyPublishedPortCertificate(ctx context.Context, tenant string, certificate *x509.Certificate) (*models.DevicePublishedPortClaims, error) {
+	ca, err := s.store.CertificateAuthorityGet(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := certificateAuthorityPublicKey(ca)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer := &x509.Certificate{PublicKey: publicKey}
+	if err := issuer.CheckSignature(certificate.SignatureAlgorithm, certificate.RawTBSCertificate, certificate.Signature); err != nil {
+		return nil, err
+	}
+
+	now := clock.Now()
+	if now.Before(certificate.NotBefore) || now.After(certificate.NotAfter) {
+		return nil, fmt.Errorf("the certificate is not valid at %s", now)
+	}
+
+	if len(certificate.ExtKeyUsage) != 1 || certificate.ExtKeyUsage[0] != x509.ExtKeyUsageClientAuth {
+		return nil, fmt.Errorf("the certificate is not a client certificate")
+	}
+
+	if len(certificate.Subject.Organization) != 1 || len(certificate.Subject.OrganizationalUnit) != 1 {
+		return nil, fmt.Errorf("the certificate was not issued to a published port")
+	}
+
+	return &models.DevicePublishedPortClaims{
+		ID:      certificate.Subject.CommonName,
+		Tenant:  certificate.Subject.Organization[0],
+		Address: certificate.Subject.OrganizationalUnit[0],
+	}, nil
+}
+
+// newPublicAddress generates a random address to reach a device's service through the gateway, so it cannot be guessed
+// from the device. The store's unique indexes refuse the unlikely collision.
+func newPublicAddress() string {
+	return strings.ReplaceAll(uuid.Generate(), "-", "")
+}
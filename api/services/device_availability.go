@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceAvailabilityMaxDevices is the maximum number of devices of a tag's availability report.
+const DeviceAvailabilityMaxDevices = 1000
+
+// DeviceAvailabilityPeriod is the period of the availability reports when it is not set.
+const DeviceAvailabilityPeriod = 30 * 24 * time.Hour
+
+// DeviceAvailabilityService contains the service's function to report the availability of the devices, computed from
+// their transitions between online and offline.
+type DeviceAvailabilityService interface {
+	GetDeviceAvailability(ctx context.Context, tenant string, uid models.UID, from, to time.Time) (*models.DeviceAvailability, error)
+	GetTagAvailability(ctx context.Context, tenant, tag string, from, to time.Time) (*models.TagAvailability, error)
+}
+
+// GetDeviceAvailability reports the availability, the outages and the MTTR of a device within from and to. When the
+// period is not set, the last 30 days are reported.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+// If from is not before to, a NewErrDeviceAvailabilityInvalid error will be returned.
+func (s *service) GetDeviceAvailability(ctx context.Context, tenant string, uid models.UID, from, to time.Time) (*models.DeviceAvailability, error) {
+	from, to, err := availabilityPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	return s.deviceAvailability(ctx, device, from, to)
+}
+
+// GetTagAvailability reports the availability of each accepted device with the tag within from and to, and of all of
+// them together. When the period is not set, the last 30 days are reported.
+//
+// If from is not before to, a NewErrDeviceAvailabilityInvalid error will be returned.
+// If the tag has more devices than DeviceAvailabilityMaxDevices, a NewErrDeviceAvailabilityLimit error will be returned.
+func (s *service) GetTagAvailability(ctx context.Context, tenant, tag string, from, to time.Time) (*models.TagAvailability, error) {
+	from, to, err := availabilityPeriod(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: tenant}},
+		{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: tag}},
+		{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+	}
+
+	devices, count, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: DeviceAvailabilityMaxDevices}, filters, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	if count > DeviceAvailabilityMaxDevices {
+		return nil, NewErrDeviceAvailabilityLimit(DeviceAvailabilityMaxDevices, nil)
+	}
+
+	report := &models.TagAvailability{
+		Tag:     tag,
+		From:    from,
+		To:      to,
+		Devices: make([]models.DeviceAvailability, 0, len(devices)),
+	}
+
+	var recovered int
+	var recovery int64
+	for i := range devices {
+		availability, err := s.deviceAvailability(ctx, &devices[i], from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Uptime += availability.Uptime
+		report.Downtime += availability.Downtime
+		report.Outages += len(availability.Outages)
+
+		for _, outage := range availability.Outages {
+			if !outage.Ongoing {
+				recovered++
+				recovery += outage.Duration
+			}
+		}
+
+		report.Devices = append(report.Devices, *availability)
+	}
+
+	if total := report.Uptime + report.Downtime; total > 0 {
+		report.Availability = float64(report.Uptime) / float64(total) * 100
+	}
+
+	if recovered > 0 {
+		report.MTTR = recovery / int64(recovered)
+	}
+
+	return report, nil
+}
+
+// availabilityPeriod returns the period of an availability report, defaulting to the last DeviceAvailabilityPeriod.
+func availabilityPeriod(from, to time.Time) (time.Time, time.Time, error) {
+	if to.IsZero() {
+		to = clock.Now()
+	}
+
+	if from.IsZero() {
+		from = to.Add(-DeviceAvailabilityPeriod)
+	}
+
+	if !from.Before(to) {
+		return from, to, NewErrDeviceAvailabilityInvalid(map[string]interface{}{"from": from, "to": to}, nil)
+	}
+
+	return from, to, nil
+}
+
+// deviceAvailability computes the availability of the device within from and to from its connectivity events.
+func (s *service) deviceAvailability(ctx context.Context, device *models.Device, from, to time.Time) (*models.DeviceAvailability, error) {
+	events, err := s.store.DeviceConnectivityList(ctx, models.UID(device.UID), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTICE: a device whose heartbeats stopped is only recorded offline at its next heartbeat, so, until then, it is
+	// considered offline since its last seen plus the online timeout.
+	if n := len(events); n > 0 && events[n-1].Online {
+		if offline := device.LastSeen.Add(models.DeviceOnlineTimeout); offline.After(events[n-1].Timestamp) && offline.Before(to) {
+			events = append(events, models.DeviceConnectivityEvent{UID: device.UID, TenantID: device.TenantID, Online: false, Timestamp: offline})
+		}
+	}
+
+	availability := &models.DeviceAvailability{
+		UID:     device.UID,
+		Name:    device.Name,
+		From:    from,
+		To:      to,
+		Outages: make([]models.DeviceOutage, 0),
+	}
+
+	var uptime, downtime, recovery time.Duration
+	var recovered int
+	for i, event := range events {
+		start := event.Timestamp
+		if start.Before(from) {
+			start = from
+		}
+
+		end := to
+		if i+1 < len(events) {
+			end = events[i+1].Timestamp
+		}
+
+		if !end.After(start) {
+			continue
+		}
+
+		if event.Online {
+			uptime += end.Sub(start)
+
+			continue
+		}
+
+		downtime += end.Sub(start)
+
+		outage := models.DeviceOutage{
+			Start:    start,
+			End:      end,
+			Duration: int64(end.Sub(start).Seconds()),
+			Ongoing:  i+1 == len(events),
+		}
+
+		if !outage.Ongoing {
+			recovered++
+			recovery += end.Sub(start)
+		}
+
+		availability.Outages = append(availability.Outages, outage)
+	}
+
+	availability.Uptime = int64(uptime.Seconds())
+	availability.Downtime = int64(downtime.Seconds())
+
+	if total := uptime + downtime; total > 0 {
+		availability.Availability = float64(uptime) / float64(total) * 100
+	}
+
+	if recovered > 0 {
+		availability.MTTR = int64((recovery / time.Duration(recovered)).Seconds())
+	}
+
+	return availability, nil
+}
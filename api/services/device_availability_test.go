@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeviceAvailability(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	device := &models.Device{UID: "uid", TenantID: "tenant", Name: "name", LastSeen: to.Add(-time.Hour)}
+
+	type Expected struct {
+		availability *models.DeviceAvailability
+		err          error
+	}
+
+	cases := []struct {
+		description   string
+		from          time.Time
+		to            time.Time
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the period is invalid",
+			from:          to,
+			to:            from,
+			requiredMocks: func() {},
+			expected: Expected{nil, NewErrDeviceAvailabilityInvalid(map[string]interface{}{
+				"from": to,
+				"to":   from,
+			}, nil)},
+		},
+		{
+			description: "fails when the device is not found",
+			from:        from,
+			to:          to,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound("uid", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds to report the last 30 days when the period is not set",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				mock.On("DeviceConnectivityList", ctx, models.UID("uid"), now.Add(-30*24*time.Hour), now).
+					Return([]models.DeviceConnectivityEvent{}, nil).Once()
+			},
+			expected: Expected{&models.DeviceAvailability{
+				UID:     "uid",
+				Name:    "name",
+				From:    now.Add(-30 * 24 * time.Hour),
+				To:      now,
+				Outages: []models.DeviceOutage{},
+			}, nil},
+		},
+		{
+			description: "succeeds to report the outages, including the one not recorded yet",
+			from:        from,
+			to:          to,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				mock.On("DeviceConnectivityList", ctx, models.UID("uid"), from, to).
+					Return([]models.DeviceConnectivityEvent{
+						{UID: "uid", TenantID: "tenant", Online: true, Timestamp: from.Add(-time.Hour)},
+						{UID: "uid", TenantID: "tenant", Online: false, Timestamp: from.Add(time.Hour)},
+						{UID: "uid", TenantID: "tenant", Online: true, Timestamp: from.Add(2 * time.Hour)},
+					}, nil).Once()
+			},
+			expected: Expected{&models.DeviceAvailability{
+				UID:          "uid",
+				Name:         "name",
+				From:         from,
+				To:           to,
+				Availability: float64(22*time.Hour+2*time.Minute) / float64(24*time.Hour) * 100,
+				Uptime:       int64((22*time.Hour + 2*time.Minute).Seconds()),
+				Downtime:     int64((time.Hour + 58*time.Minute).Seconds()),
+				Outages: []models.DeviceOutage{
+					{Start: from.Add(time.Hour), End: from.Add(2 * time.Hour), Duration: 3600, Ongoing: false},
+					{Start: to.Add(-58 * time.Minute), End: to, Duration: 3480, Ongoing: true},
+				},
+				MTTR: 3600,
+			}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			availability, err := service.GetDeviceAvailability(ctx, "tenant", models.UID("uid"), tc.from, tc.to)
+			assert.Equal(t, tc.expected, Expected{availability, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetTagAvailability(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * time.Hour)
+
+	filters := []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"}},
+		{Type: "property", Params: &models.PropertyParams{Name: "tags", Operator: "eq", Value: "tag"}},
+		{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+	}
+
+	query := paginator.Query{Page: 1, PerPage: DeviceAvailabilityMaxDevices}
+
+	type Expected struct {
+		availability *models.TagAvailability
+		err          error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the tag has too many devices",
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, query, filters, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{}, DeviceAvailabilityMaxDevices+1, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceAvailabilityLimit(DeviceAvailabilityMaxDevices, nil)},
+		},
+		{
+			description: "succeeds to report the devices with the tag",
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, query, filters, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{
+						{UID: "first", TenantID: "tenant", Name: "first", LastSeen: to},
+						{UID: "second", TenantID: "tenant", Name: "second", LastSeen: to},
+					}, 2, nil).Once()
+				mock.On("DeviceConnectivityList", ctx, models.UID("first"), from, to).
+					Return([]models.DeviceConnectivityEvent{
+						{UID: "first", TenantID: "tenant", Online: true, Timestamp: from},
+					}, nil).Once()
+				mock.On("DeviceConnectivityList", ctx, models.UID("second"), from, to).
+					Return([]models.DeviceConnectivityEvent{
+						{UID: "second", TenantID: "tenant", Online: true, Timestamp: from},
+						{UID: "second", TenantID: "tenant", Online: false, Timestamp: from.Add(4 * time.Hour)},
+						{UID: "second", TenantID: "tenant", Online: true, Timestamp: from.Add(6 * time.Hour)},
+					}, nil).Once()
+			},
+			expected: Expected{&models.TagAvailability{
+				Tag:          "tag",
+				From:         from,
+				To:           to,
+				Availability: 90,
+				Uptime:       18 * 3600,
+				Downtime:     2 * 3600,
+				Outages:      1,
+				MTTR:         2 * 3600,
+				Devices: []models.DeviceAvailability{
+					{
+						UID:          "first",
+						Name:         "first",
+						From:         from,
+						To:           to,
+						Availability: 100,
+						Uptime:       10 * 3600,
+						Outages:      []models.DeviceOutage{},
+					},
+					{
+						UID:          "second",
+						Name:         "second",
+						From:         from,
+						To:           to,
+						Availability: 80,
+						Uptime:       8 * 3600,
+						Downtime:     2 * 3600,
+						Outages: []models.DeviceOutage{
+							{Start: from.Add(4 * time.Hour), End: from.Add(6 * time.Hour), Duration: 2 * 3600, Ongoing: false},
+						},
+						MTTR: 2 * 3600,
+					},
+				},
+			}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			availability, err := service.GetTagAvailability(ctx, "tenant", "tag", from, to)
+			assert.Equal(t, tc.expected, Expected{availability, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
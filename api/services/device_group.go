@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceGroupService interface {
+	ListDeviceGroups(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error)
+	GetDeviceGroup(ctx context.Context, tenant, id string) (*models.DeviceGroup, error)
+	CreateDeviceGroup(ctx context.Context, tenant, userID string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error)
+	UpdateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error)
+	DeleteDeviceGroup(ctx context.Context, tenant, id string) error
+	// ListDeviceGroupDevices lists the devices matching the group's filter, sorted as the group is.
+	ListDeviceGroupDevices(ctx context.Context, tenant, id string, pagination paginator.Query) ([]models.Device, int, error)
+}
+
+func (s *service) ListDeviceGroups(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	return s.store.DeviceGroupList(ctx, pagination)
+}
+
+func (s *service) GetDeviceGroup(ctx context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	group, err := s.store.DeviceGroupGet(ctx, tenant, id)
+	if err != nil {
+		return nil, NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return group, nil
+}
+
+func (s *service) CreateDeviceGroup(ctx context.Context, tenant, userID string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error) {
+	if err := req.Filter.IsValid(); err != nil {
+		return nil, NewErrDeviceGroupInvalid(map[string]interface{}{"filter": req.Filter}, err)
+	}
+
+	now := clock.Now()
+
+	group := &models.DeviceGroup{
+		TenantID:  tenant,
+		CreatedBy: userID,
+		CreatedAt: now,
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name:      req.Name,
+			Filter:    req.Filter.Filters(),
+			SortBy:    req.SortBy,
+			OrderBy:   req.OrderBy,
+			UpdatedAt: now,
+		},
+	}
+
+	id, err := s.store.DeviceGroupCreate(ctx, group)
+	if err != nil {
+		if err == store.ErrDuplicate {
+			return nil, NewErrDeviceGroupDuplicated([]string{req.Name}, err)
+		}
+
+		return nil, err
+	}
+
+	group.ID = id
+
+	return group, nil
+}
+
+func (s *service) UpdateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	if err := req.Filter.IsValid(); err != nil {
+		return nil, NewErrDeviceGroupInvalid(map[string]interface{}{"filter": req.Filter}, err)
+	}
+
+	group, err := s.store.DeviceGroupUpdate(ctx, tenant, req.ID, &models.DeviceGroupUpdate{
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name:      req.Name,
+			Filter:    req.Filter.Filters(),
+			SortBy:    req.SortBy,
+			OrderBy:   req.OrderBy,
+			UpdatedAt: clock.Now(),
+		},
+	})
+	switch err {
+	case nil:
+		return group, nil
+	case store.ErrDuplicate:
+		return nil, NewErrDeviceGroupDuplicated([]string{req.Name}, err)
+	default:
+		return nil, NewErrDeviceGroupNotFound(req.ID, err)
+	}
+}
+
+func (s *service) DeleteDeviceGroup(ctx context.Context, tenant, id string) error {
+	if err := s.store.DeviceGroupDelete(ctx, tenant, id); err != nil {
+		return NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return nil
+}
+
+func (s *service) ListDeviceGroupDevices(ctx context.Context, tenant, id string, pagination paginator.Query) ([]models.Device, int, error) {
+	group, err := s.store.DeviceGroupGet(ctx, tenant, id)
+	if err != nil {
+		return nil, 0, NewErrDeviceGroupNotFound(id, err)
+	}
+
+	return s.store.DeviceList(ctx, pagination, group.Filter, "", group.SortBy, group.OrderBy, store.DeviceListModeDefault)
+}
+
+// deviceInGroup checks if the device matches the filter of the device group identified by id on the device's namespace.
+func (s *service) deviceInGroup(ctx context.Context, id string, device models.Device) (bool, error) {
+	group, err := s.store.DeviceGroupGet(ctx, device.TenantID, id)
+	switch {
+	case err == store.ErrNoDocuments:
+		// NOTICE: a removed group matches no device.
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	// NOTICE: the device's filters are matched before, and apart from, the group's ones, so the group cannot widen
+	// the match to devices other than this one.
+	filters := []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: device.TenantID}},
+		{Type: "property", Params: &models.PropertyParams{Name: "uid", Operator: "eq", Value: device.UID}},
+		{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+	}
+
+	_, count, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: 1}, append(filters, group.Filter...), "", "", "", store.DeviceListModeDefault)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
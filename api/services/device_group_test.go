@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/filter"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDeviceGroup(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	fields := requests.DeviceGroupFields{
+		Name: "arm64",
+		Filter: filter.FilterList{
+			{Type: "property", Params: &filter.FilterTypeProperty{Name: "info.arch", Operator: "eq", Value: "arm64"}},
+			{Type: "operator", Params: &filter.FilterTypeOperator{Name: "and"}},
+		},
+		SortBy:  "name",
+		OrderBy: "asc",
+	}
+
+	group := &models.DeviceGroup{
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		CreatedBy: "user",
+		CreatedAt: now,
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name: "arm64",
+			Filter: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "info.arch", Operator: "eq", Value: "arm64"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+			},
+			SortBy:    "name",
+			OrderBy:   "asc",
+			UpdatedAt: now,
+		},
+	}
+
+	type Expected struct {
+		group *models.DeviceGroup
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.DeviceGroupCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the filter is invalid",
+			req: requests.DeviceGroupCreate{DeviceGroupFields: requests.DeviceGroupFields{
+				Name:   "invalid",
+				Filter: filter.FilterList{{Type: "operator", Params: &filter.FilterTypeOperator{Name: "xor"}}},
+			}},
+			requiredMocks: func() {},
+			expected: Expected{nil, NewErrDeviceGroupInvalid(map[string]interface{}{
+				"filter": filter.FilterList{{Type: "operator", Params: &filter.FilterTypeOperator{Name: "xor"}}},
+			}, filter.ErrPropertyOperatorUnknow)},
+		},
+		{
+			description: "fails when a group with the same name exists",
+			req:         requests.DeviceGroupCreate{DeviceGroupFields: fields},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGroupCreate", ctx, group).Return("", store.ErrDuplicate).Once()
+			},
+			expected: Expected{nil, NewErrDeviceGroupDuplicated([]string{"arm64"}, store.ErrDuplicate)},
+		},
+		{
+			description: "succeeds to create the group",
+			req:         requests.DeviceGroupCreate{DeviceGroupFields: fields},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGroupCreate", ctx, group).Return("65a5f3ec2a5b4c0e9b3a0d71", nil).Once()
+			},
+			expected: Expected{&models.DeviceGroup{
+				ID:                "65a5f3ec2a5b4c0e9b3a0d71",
+				TenantID:          group.TenantID,
+				CreatedBy:         group.CreatedBy,
+				CreatedAt:         group.CreatedAt,
+				DeviceGroupFields: group.DeviceGroupFields,
+			}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			created, err := service.CreateDeviceGroup(ctx, "00000000-0000-4000-0000-000000000000", "user", tc.req)
+			assert.Equal(t, tc.expected, Expected{created, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteDeviceGroup(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		id            string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the group is not found",
+			id:          "65a5f3ec2a5b4c0e9b3a0d71",
+			requiredMocks: func() {
+				mock.On("DeviceGroupDelete", ctx, "00000000-0000-4000-0000-000000000000", "65a5f3ec2a5b4c0e9b3a0d71").
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceGroupNotFound("65a5f3ec2a5b4c0e9b3a0d71", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds to delete the group",
+			id:          "65a5f3ec2a5b4c0e9b3a0d71",
+			requiredMocks: func() {
+				mock.On("DeviceGroupDelete", ctx, "00000000-0000-4000-0000-000000000000", "65a5f3ec2a5b4c0e9b3a0d71").
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.DeleteDeviceGroup(ctx, "00000000-0000-4000-0000-000000000000", tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceGroupDevices(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	group := &models.DeviceGroup{
+		ID:       "65a5f3ec2a5b4c0e9b3a0d71",
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name: "online",
+			Filter: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "online", Operator: "bool", Value: "true"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+			},
+			SortBy:  "last_seen",
+			OrderBy: "desc",
+		},
+	}
+
+	pagination := paginator.Query{Page: 1, PerPage: 10}
+
+	type Expected struct {
+		devices []models.Device
+		count   int
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the group is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "00000000-0000-4000-0000-000000000000", "65a5f3ec2a5b4c0e9b3a0d71").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, 0, NewErrDeviceGroupNotFound("65a5f3ec2a5b4c0e9b3a0d71", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the devices cannot be listed",
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "00000000-0000-4000-0000-000000000000", "65a5f3ec2a5b4c0e9b3a0d71").
+					Return(group, nil).Once()
+				mock.On("DeviceList", ctx, pagination, group.Filter, models.DeviceStatus(""), "last_seen", "desc", store.DeviceListModeDefault).
+					Return(nil, 0, errors.New("error", "", 0)).Once()
+			},
+			expected: Expected{nil, 0, errors.New("error", "", 0)},
+		},
+		{
+			description: "succeeds to list the devices of the group",
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "00000000-0000-4000-0000-000000000000", "65a5f3ec2a5b4c0e9b3a0d71").
+					Return(group, nil).Once()
+				mock.On("DeviceList", ctx, pagination, group.Filter, models.DeviceStatus(""), "last_seen", "desc", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid"}}, 1, nil).Once()
+			},
+			expected: Expected{[]models.Device{{UID: "uid"}}, 1, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			devices, count, err := service.ListDeviceGroupDevices(ctx, "00000000-0000-4000-0000-000000000000", "65a5f3ec2a5b4c0e9b3a0d71", pagination)
+			assert.Equal(t, tc.expected, Expected{devices, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceInventoryService contains the service's function to manage the devices' inventories.
+type DeviceInventoryService interface {
+	ReportDeviceInventory(ctx context.Context, uid models.UID, req requests.DeviceInventoryReport) error
+	ListDeviceInventory(ctx context.Context, tenant string, uid models.UID, pagination paginator.Query) ([]models.DeviceInventory, int, error)
+}
+
+// ReportDeviceInventory stores the inventory reported by a device's agent as the device's latest one, keeping it in
+// the device's inventory history. UID is the device's UID, as authenticated by its token.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+func (s *service) ReportDeviceInventory(ctx context.Context, uid models.UID, req requests.DeviceInventoryReport) error {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	inventory := models.DeviceInventory{
+		ReportedAt: clock.Now(),
+		Kernel:     req.Kernel,
+		CPU:        models.DeviceInventoryCPU{Model: req.CPU.Model, Count: req.CPU.Count},
+		Memory:     models.DeviceInventoryMemory{Total: req.Memory.Total, Available: req.Memory.Available},
+		Disks:      make([]models.DeviceInventoryDisk, 0, len(req.Disks)),
+		Interfaces: make([]models.DeviceInventoryInterface, 0, len(req.Interfaces)),
+		Uptime:     req.Uptime,
+		Packages:   req.Packages,
+	}
+
+	for _, disk := range req.Disks {
+		inventory.Disks = append(inventory.Disks, models.DeviceInventoryDisk{
+			Device:     disk.Device,
+			Mountpoint: disk.Mountpoint,
+			Filesystem: disk.Filesystem,
+			Total:      disk.Total,
+			Used:       disk.Used,
+		})
+	}
+
+	for _, iface := range req.Interfaces {
+		inventory.Interfaces = append(inventory.Interfaces, models.DeviceInventoryInterface{
+			Name:      iface.Name,
+			MAC:       iface.MAC,
+			Addresses: iface.Addresses,
+			Primary:   iface.Primary,
+		})
+	}
+
+	snapshot := &models.DeviceInventorySnapshot{
+		UID:             device.UID,
+		TenantID:        device.TenantID,
+		DeviceInventory: inventory,
+	}
+
+	return s.store.DeviceInventoryCreate(ctx, snapshot)
+}
+
+// ListDeviceInventory lists the inventory history of a device, from the newest to the oldest. Inventories older than
+// the history retention are not kept.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+func (s *service) ListDeviceInventory(ctx context.Context, tenant string, uid models.UID, pagination paginator.Query) ([]models.DeviceInventory, int, error) {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return nil, 0, NewErrDeviceNotFound(uid, err)
+	}
+
+	return s.store.DeviceInventoryList(ctx, tenant, uid, pagination)
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportDeviceInventory(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	req := requests.DeviceInventoryReport{
+		Kernel:     "6.1.0",
+		CPU:        requests.DeviceInventoryCPU{Model: "ARMv7 Processor rev 4 (v7l)", Count: 4},
+		Memory:     requests.DeviceInventoryMemory{Total: 1024, Available: 512},
+		Disks:      []requests.DeviceInventoryDisk{{Device: "/dev/mmcblk0p2", Mountpoint: "/", Filesystem: "ext4", Total: 2048, Used: 1024}},
+		Interfaces: []requests.DeviceInventoryInterface{{Name: "eth0", MAC: "mac", Addresses: []string{"192.168.1.2/24"}, Primary: true}},
+		Uptime:     3600,
+		Packages:   512,
+	}
+
+	snapshot := &models.DeviceInventorySnapshot{
+		UID:      "uid",
+		TenantID: "tenant",
+		DeviceInventory: models.DeviceInventory{
+			ReportedAt: now,
+			Kernel:     "6.1.0",
+			CPU:        models.DeviceInventoryCPU{Model: "ARMv7 Processor rev 4 (v7l)", Count: 4},
+			Memory:     models.DeviceInventoryMemory{Total: 1024, Available: 512},
+			Disks:      []models.DeviceInventoryDisk{{Device: "/dev/mmcblk0p2", Mountpoint: "/", Filesystem: "ext4", Total: 2048, Used: 1024}},
+			Interfaces: []models.DeviceInventoryInterface{{Name: "eth0", MAC: "mac", Addresses: []string{"192.168.1.2/24"}, Primary: true}},
+			Uptime:     3600,
+			Packages:   512,
+		},
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the inventory cannot be stored",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceInventoryCreate", ctx, snapshot).Return(errors.New("error", "", 0)).Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+		{
+			description: "succeeds to store the inventory",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceInventoryCreate", ctx, snapshot).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.ReportDeviceInventory(ctx, models.UID("uid"), req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceInventory(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	pagination := paginator.Query{Page: 1, PerPage: 10}
+
+	type Expected struct {
+		inventories []models.DeviceInventory
+		count       int
+		err         error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, 0, NewErrDeviceNotFound("uid", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds to list the inventory history",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceInventoryList", ctx, "tenant", models.UID("uid"), pagination).
+					Return([]models.DeviceInventory{{Kernel: "6.1.0"}}, 1, nil).Once()
+			},
+			expected: Expected{[]models.DeviceInventory{{Kernel: "6.1.0"}}, 1, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			inventories, count, err := service.ListDeviceInventory(ctx, "tenant", models.UID("uid"), pagination)
+			assert.Equal(t, tc.expected, Expected{inventories, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/workers"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// DeviceJobMaxItems is the maximum number of devices of a device job.
+const DeviceJobMaxItems = 1000
+
+// TaskEnqueuer enqueues the tasks run by the API's workers.
+type TaskEnqueuer interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+type DeviceJobService interface {
+	// CreateDeviceJob creates a job that runs the operation on the devices listed or matched by the request, enqueuing
+	// it to be run by the workers.
+	CreateDeviceJob(ctx context.Context, tenant, userID, role string, req requests.DeviceJobCreate) (*models.DeviceJob, error)
+	GetDeviceJob(ctx context.Context, tenant, id string) (*models.DeviceJob, error)
+	// RunDeviceJob runs the job's operation on each device it did not run yet, evaluating the current role of the job's
+	// creator on each of them.
+	RunDeviceJob(ctx context.Context, id string) error
+}
+
+// deviceJobActions maps the device job's operations to the actions evaluated on each device.
+var deviceJobActions = map[models.DeviceJobOperation]int{
+	models.DeviceJobOperationAccept: guard.Actions.Device.Accept,
+	models.DeviceJobOperationReject: guard.Actions.Device.Reject,
+	models.DeviceJobOperationRemove: guard.Actions.Device.Remove,
+	models.DeviceJobOperationRename: guard.Actions.Device.Rename,
+	models.DeviceJobOperationTag:    guard.Actions.Device.CreateTag,
+	models.DeviceJobOperationUntag:  guard.Actions.Device.RemoveTag,
+}
+
+func (s *service) CreateDeviceJob(ctx context.Context, tenant, userID, role string, req requests.DeviceJobCreate) (*models.DeviceJob, error) {
+	operation := models.DeviceJobOperation(req.Operation)
+
+	if err := guard.EvaluatePermission(role, deviceJobActions[operation], func() error { return nil }); err != nil {
+		return nil, err
+	}
+
+	switch operation {
+	case models.DeviceJobOperationTag, models.DeviceJobOperationUntag:
+		if req.Tag == "" {
+			return nil, NewErrDeviceJobInvalid(map[string]interface{}{"tag": req.Tag}, nil)
+		}
+	case models.DeviceJobOperationRename:
+		// NOTICE: each device needs its own name, so the devices to rename cannot be matched by a filter.
+		if len(req.UIDs) == 0 {
+			return nil, NewErrDeviceJobInvalid(map[string]interface{}{"uids": req.UIDs}, nil)
+		}
+
+		for _, uid := range req.UIDs {
+			if req.Names[uid] == "" {
+				return nil, NewErrDeviceJobInvalid(map[string]interface{}{"names": req.Names}, nil)
+			}
+		}
+	}
+
+	uids := req.UIDs
+	if len(req.Filter) > 0 {
+		if err := req.Filter.IsValid(); err != nil {
+			return nil, NewErrDeviceJobInvalid(map[string]interface{}{"filter": req.Filter}, err)
+		}
+
+		devices, count, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: DeviceJobMaxItems}, req.Filter.Filters(), "", "", "", store.DeviceListModeDefault)
+		if err != nil {
+			return nil, err
+		}
+
+		if count > DeviceJobMaxItems {
+			return nil, NewErrDeviceJobLimit(DeviceJobMaxItems, nil)
+		}
+
+		for _, device := range devices {
+			uids = append(uids, device.UID)
+		}
+	}
+
+	if len(uids) == 0 {
+		return nil, NewErrDeviceJobInvalid(map[string]interface{}{"uids": uids}, nil)
+	}
+
+	if s.tasks == nil {
+		return nil, NewErrDeviceJobEnqueue(nil)
+	}
+
+	job := &models.DeviceJob{
+		ID:        uuid.Generate(),
+		TenantID:  tenant,
+		CreatedBy: userID,
+		Operation: operation,
+		Tag:       req.Tag,
+		Status:    models.DeviceJobStatusPending,
+		Items:     make([]models.DeviceJobItem, 0, len(uids)),
+		CreatedAt: clock.Now(),
+	}
+
+	for _, uid := range uids {
+		job.Items = append(job.Items, models.DeviceJobItem{
+			UID:    uid,
+			Name:   req.Names[uid],
+			Status: models.DeviceJobItemStatusPending,
+		})
+	}
+
+	if err := s.store.DeviceJobCreate(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.tasks.Enqueue(workers.NewDeviceJobTask(ctx, job.ID)); err != nil {
+		return nil, NewErrDeviceJobEnqueue(err)
+	}
+
+	return job, nil
+}
+
+func (s *service) GetDeviceJob(ctx context.Context, tenant, id string) (*models.DeviceJob, error) {
+	job, err := s.store.DeviceJobGet(ctx, id)
+	if err != nil {
+		return nil, NewErrDeviceJobNotFound(id, err)
+	}
+
+	if job.TenantID != tenant {
+		return nil, NewErrDeviceJobNotFound(id, nil)
+	}
+
+	return job, nil
+}
+
+func (s *service) RunDeviceJob(ctx context.Context, id string) error {
+	job, err := s.store.DeviceJobGet(ctx, id)
+	if err != nil {
+		return NewErrDeviceJobNotFound(id, err)
+	}
+
+	if job.Status == models.DeviceJobStatusFinished {
+		return nil
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, job.TenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(job.TenantID, err)
+	}
+
+	// NOTICE: when the job's creator is no longer a member of the namespace, its role is empty and the operation is
+	// forbidden on every device.
+	var role string
+	if member, ok := namespace.FindMember(job.CreatedBy); ok {
+		role = member.Role
+	}
+
+	if err := s.store.DeviceJobSetStatus(ctx, id, models.DeviceJobStatusRunning, nil); err != nil {
+		return err
+	}
+
+	for _, item := range job.Items {
+		if item.Status != models.DeviceJobItemStatusPending {
+			continue
+		}
+
+		item.Status = models.DeviceJobItemStatusSucceeded
+		if err := guard.EvaluatePermission(role, deviceJobActions[job.Operation], func() error {
+			return s.runDeviceJobItem(ctx, job, item)
+		}); err != nil {
+			item.Status = models.DeviceJobItemStatusFailed
+			item.Error = err.Error()
+		}
+
+		if err := s.store.DeviceJobSetItem(ctx, id, item); err != nil {
+			return err
+		}
+	}
+
+	finishedAt := clock.Now()
+
+	return s.store.DeviceJobSetStatus(ctx, id, models.DeviceJobStatusFinished, &finishedAt)
+}
+
+// runDeviceJobItem runs the job's operation on the item's device.
+func (s *service) runDeviceJobItem(ctx context.Context, job *models.DeviceJob, item models.DeviceJobItem) error {
+	uid := models.UID(item.UID)
+
+	switch job.Operation {
+	case models.DeviceJobOperationAccept:
+		return s.UpdateDeviceStatus(ctx, job.TenantID, uid, models.DeviceStatusAccepted)
+	case models.DeviceJobOperationReject:
+		return s.UpdateDeviceStatus(ctx, job.TenantID, uid, models.DeviceStatusRejected)
+	case models.DeviceJobOperationRemove:
+		return s.DeleteDevice(ctx, uid, job.TenantID)
+	case models.DeviceJobOperationRename:
+		return s.RenameDevice(ctx, uid, item.Name, job.TenantID)
+	case models.DeviceJobOperationTag, models.DeviceJobOperationUntag:
+		// NOTICE: the tag's operations do not check the device's namespace, so it is checked here.
+		if _, err := s.store.DeviceGetByUID(ctx, uid, job.TenantID); err != nil {
+			return NewErrDeviceNotFound(uid, err)
+		}
+
+		if job.Operation == models.DeviceJobOperationTag {
+			return s.CreateDeviceTag(ctx, uid, job.Tag)
+		}
+
+		return s.RemoveDeviceTag(ctx, uid, job.Tag)
+	default:
+		return NewErrDeviceJobInvalid(map[string]interface{}{"operation": job.Operation}, nil)
+	}
+}
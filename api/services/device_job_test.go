@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/api/workers"
+	"github.com/shellhub-io/shellhub/pkg/api/filter"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// enqueuerFunc is a TaskEnqueuer that calls itself.
+type enqueuerFunc func(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+
+func (f enqueuerFunc) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	return f(task, opts...)
+}
+
+func TestCreateDeviceJob(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	var enqueued []string
+	enqueuer := enqueuerFunc(func(task *asynq.Task, _ ...asynq.Option) (*asynq.TaskInfo, error) {
+		var payload workers.DeviceJobPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			return nil, err
+		}
+
+		enqueued = append(enqueued, payload.ID)
+
+		return &asynq.TaskInfo{}, nil
+	})
+
+	online := filter.FilterList{
+		{Type: "property", Params: &filter.FilterTypeProperty{Name: "online", Operator: "bool", Value: "true"}},
+		{Type: "operator", Params: &filter.FilterTypeOperator{Name: "and"}},
+	}
+
+	type Expected struct {
+		job *models.DeviceJob
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		role          string
+		req           requests.DeviceJobCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the role cannot run the operation",
+			role:          guard.RoleObserver,
+			req:           requests.DeviceJobCreate{Operation: "remove", UIDs: []string{"uid"}},
+			requiredMocks: func() {},
+			expected:      Expected{nil, guard.ErrForbidden},
+		},
+		{
+			description:   "fails when the tag is missing",
+			role:          guard.RoleOperator,
+			req:           requests.DeviceJobCreate{Operation: "tag", UIDs: []string{"uid"}},
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrDeviceJobInvalid(map[string]interface{}{"tag": ""}, nil)},
+		},
+		{
+			description: "fails when a device to rename has no name",
+			role:        guard.RoleOperator,
+			req: requests.DeviceJobCreate{
+				Operation: "rename",
+				UIDs:      []string{"uid", "uid2"},
+				Names:     map[string]string{"uid": "name"},
+			},
+			requiredMocks: func() {},
+			expected: Expected{nil, NewErrDeviceJobInvalid(map[string]interface{}{
+				"names": map[string]string{"uid": "name"},
+			}, nil)},
+		},
+		{
+			description: "fails when the filter matches more devices than allowed",
+			role:        guard.RoleOperator,
+			req:         requests.DeviceJobCreate{Operation: "accept", Filter: online},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: DeviceJobMaxItems}, online.Filters(), models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid"}}, DeviceJobMaxItems+1, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceJobLimit(DeviceJobMaxItems, nil)},
+		},
+		{
+			description: "fails when the filter matches no devices",
+			role:        guard.RoleOperator,
+			req:         requests.DeviceJobCreate{Operation: "accept", Filter: online},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: DeviceJobMaxItems}, online.Filters(), models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{}, 0, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceJobInvalid(map[string]interface{}{"uids": []string(nil)}, nil)},
+		},
+		{
+			description: "fails when the job cannot be stored",
+			role:        guard.RoleOperator,
+			req:         requests.DeviceJobCreate{Operation: "tag", UIDs: []string{"uid"}, Tag: "production"},
+			requiredMocks: func() {
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000001").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceJobCreate", ctx, &models.DeviceJob{
+					ID:        "00000000-0000-4000-0000-000000000001",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					CreatedBy: "user",
+					Operation: models.DeviceJobOperationTag,
+					Tag:       "production",
+					Status:    models.DeviceJobStatusPending,
+					Items:     []models.DeviceJobItem{{UID: "uid", Status: models.DeviceJobItemStatusPending}},
+					CreatedAt: now,
+				}).Return(errors.New("error", "", 0)).Once()
+			},
+			expected: Expected{nil, errors.New("error", "", 0)},
+		},
+		{
+			description: "succeeds to create the job from the devices matched by the filter",
+			role:        guard.RoleOperator,
+			req:         requests.DeviceJobCreate{Operation: "accept", Filter: online},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: DeviceJobMaxItems}, online.Filters(), models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid"}, {UID: "uid2"}}, 2, nil).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000002").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceJobCreate", ctx, &models.DeviceJob{
+					ID:        "00000000-0000-4000-0000-000000000002",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					CreatedBy: "user",
+					Operation: models.DeviceJobOperationAccept,
+					Status:    models.DeviceJobStatusPending,
+					Items: []models.DeviceJobItem{
+						{UID: "uid", Status: models.DeviceJobItemStatusPending},
+						{UID: "uid2", Status: models.DeviceJobItemStatusPending},
+					},
+					CreatedAt: now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{&models.DeviceJob{
+				ID:        "00000000-0000-4000-0000-000000000002",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				CreatedBy: "user",
+				Operation: models.DeviceJobOperationAccept,
+				Status:    models.DeviceJobStatusPending,
+				Items: []models.DeviceJobItem{
+					{UID: "uid", Status: models.DeviceJobItemStatusPending},
+					{UID: "uid2", Status: models.DeviceJobItemStatusPending},
+				},
+				CreatedAt: now,
+			}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithTaskEnqueuer(enqueuer))
+			job, err := service.CreateDeviceJob(ctx, "00000000-0000-4000-0000-000000000000", "user", tc.role, tc.req)
+			assert.Equal(t, tc.expected, Expected{job, err})
+		})
+	}
+
+	assert.Equal(t, []string{"00000000-0000-4000-0000-000000000002"}, enqueued)
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestGetDeviceJob(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	job := &models.DeviceJob{ID: "00000000-0000-4000-0000-000000000001", TenantID: "00000000-0000-4000-0000-000000000000"}
+
+	type Expected struct {
+		job *models.DeviceJob
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the job is not found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {
+				mock.On("DeviceJobGet", ctx, "00000000-0000-4000-0000-000000000001").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrDeviceJobNotFound("00000000-0000-4000-0000-000000000001", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the job is from another namespace",
+			tenant:      "00000000-0000-4000-0000-000000000009",
+			requiredMocks: func() {
+				mock.On("DeviceJobGet", ctx, "00000000-0000-4000-0000-000000000001").Return(job, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceJobNotFound("00000000-0000-4000-0000-000000000001", nil)},
+		},
+		{
+			description: "succeeds to get the job",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			requiredMocks: func() {
+				mock.On("DeviceJobGet", ctx, "00000000-0000-4000-0000-000000000001").Return(job, nil).Once()
+			},
+			expected: Expected{job, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			job, err := service.GetDeviceJob(ctx, tc.tenant, "00000000-0000-4000-0000-000000000001")
+			assert.Equal(t, tc.expected, Expected{job, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRunDeviceJob(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		TenantID:     "00000000-0000-4000-0000-000000000000",
+		Members:      []models.Member{{ID: "user", Role: guard.RoleOperator}},
+		MaxDevices:   3,
+		DevicesCount: 3,
+	}
+
+	cases := []struct {
+		description   string
+		job           *models.DeviceJob
+		requiredMocks func(job *models.DeviceJob)
+		expected      error
+	}{
+		{
+			description: "succeeds without running a finished job",
+			job: &models.DeviceJob{
+				ID:     "00000000-0000-4000-0000-000000000001",
+				Status: models.DeviceJobStatusFinished,
+			},
+			requiredMocks: func(job *models.DeviceJob) {
+				mock.On("DeviceJobGet", ctx, job.ID).Return(job, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails every device when the creator is no longer a member",
+			job: &models.DeviceJob{
+				ID:        "00000000-0000-4000-0000-000000000001",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				CreatedBy: "removed",
+				Operation: models.DeviceJobOperationRemove,
+				Status:    models.DeviceJobStatusPending,
+				Items:     []models.DeviceJobItem{{UID: "uid", Status: models.DeviceJobItemStatusPending}},
+			},
+			requiredMocks: func(job *models.DeviceJob) {
+				mock.On("DeviceJobGet", ctx, job.ID).Return(job, nil).Once()
+				mock.On("NamespaceGet", ctx, job.TenantID).Return(namespace, nil).Once()
+				mock.On("DeviceJobSetStatus", ctx, job.ID, models.DeviceJobStatusRunning, (*time.Time)(nil)).Return(nil).Once()
+				mock.On("DeviceJobSetItem", ctx, job.ID, models.DeviceJobItem{
+					UID:    "uid",
+					Status: models.DeviceJobItemStatusFailed,
+					Error:  guard.ErrForbidden.Error(),
+				}).Return(nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceJobSetStatus", ctx, job.ID, models.DeviceJobStatusFinished, &now).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "records the result of each device",
+			job: &models.DeviceJob{
+				ID:        "00000000-0000-4000-0000-000000000001",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				CreatedBy: "user",
+				Operation: models.DeviceJobOperationAccept,
+				Status:    models.DeviceJobStatusRunning,
+				Items: []models.DeviceJobItem{
+					{UID: "done", Status: models.DeviceJobItemStatusSucceeded},
+					{UID: "uid", Status: models.DeviceJobItemStatusPending},
+					{UID: "uid2", Status: models.DeviceJobItemStatusPending},
+				},
+			},
+			requiredMocks: func(job *models.DeviceJob) {
+				mock.On("DeviceJobGet", ctx, job.ID).Return(job, nil).Once()
+				mock.On("NamespaceGet", ctx, job.TenantID).Return(namespace, nil).Once()
+				mock.On("DeviceJobSetStatus", ctx, job.ID, models.DeviceJobStatusRunning, (*time.Time)(nil)).Return(nil).Once()
+
+				mock.On("NamespaceGet", ctx, job.TenantID).Return(namespace, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), job.TenantID).Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceJobSetItem", ctx, job.ID, models.DeviceJobItem{
+					UID:    "uid",
+					Status: models.DeviceJobItemStatusFailed,
+					Error:  NewErrDeviceNotFound("uid", store.ErrNoDocuments).Error(),
+				}).Return(nil).Once()
+
+				mock.On("NamespaceGet", ctx, job.TenantID).Return(namespace, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid2"), job.TenantID).
+					Return(&models.Device{UID: "uid2", Name: "name", TenantID: job.TenantID, Status: "pending", Identity: &models.DeviceIdentity{MAC: "mac"}}, nil).Once()
+				mock.On("DeviceGetByMac", ctx, "mac", job.TenantID, models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceGetByName", ctx, "name", job.TenantID, models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+				envMock.On("Get", "SHELLHUB_ENTERPRISE").Return("false").Once()
+				mock.On("DeviceJobSetItem", ctx, job.ID, models.DeviceJobItem{
+					UID:    "uid2",
+					Status: models.DeviceJobItemStatusFailed,
+					Error:  NewErrDeviceMaxDevicesReached(3).Error(),
+				}).Return(nil).Once()
+
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceJobSetStatus", ctx, job.ID, models.DeviceJobStatusFinished, &now).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks(tc.job)
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.RunDeviceJob(ctx, tc.job.ID))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
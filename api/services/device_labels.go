@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceLabels contains the service's function to manage device labels.
+type DeviceLabels interface {
+	UpdateDeviceLabels(ctx context.Context, tenant string, uid models.UID, labels map[string]string) error
+	RemoveDeviceLabel(ctx context.Context, tenant string, uid models.UID, key string) error
+}
+
+// DeviceMaxLabels is the number of labels that a device can have.
+const DeviceMaxLabels = 16
+
+// UpdateDeviceLabels sets labels to a device, keeping its labels with other keys. UID is the device's UID and labels
+// maps each label's key to its value.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+// If the device would have more than the maximum number of labels, a NewErrDeviceLabelLimit error will be returned.
+func (s *service) UpdateDeviceLabels(ctx context.Context, tenant string, uid models.UID, labels map[string]string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	count := len(device.Labels)
+	for key := range labels {
+		if _, ok := device.Labels[key]; !ok {
+			count++
+		}
+	}
+
+	if count > DeviceMaxLabels {
+		return NewErrDeviceLabelLimit(DeviceMaxLabels, nil)
+	}
+
+	return s.store.DeviceSetLabels(ctx, uid, labels)
+}
+
+// RemoveDeviceLabel removes a label from a device. UID is the device's UID and key is the label's key.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+// If the label does not exist, a NewErrDeviceLabelNotFound error will be returned.
+func (s *service) RemoveDeviceLabel(ctx context.Context, tenant string, uid models.UID, key string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if _, ok := device.Labels[key]; !ok {
+		return NewErrDeviceLabelNotFound(key, nil)
+	}
+
+	return s.store.DeviceUnsetLabel(ctx, uid, key)
+}
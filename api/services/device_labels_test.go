@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDeviceLabels(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	full := make(map[string]string, DeviceMaxLabels)
+	for i := 0; i < DeviceMaxLabels; i++ {
+		full[string(rune('a'+i))] = "value"
+	}
+
+	cases := []struct {
+		description   string
+		labels        map[string]string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			labels:      map[string]string{"site": "lisbon"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device would have more labels than allowed",
+			labels:      map[string]string{"site": "lisbon"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", Labels: full}, nil).Once()
+			},
+			expected: NewErrDeviceLabelLimit(DeviceMaxLabels, nil),
+		},
+		{
+			description: "succeeds to replace a label when the device has the maximum of labels",
+			labels:      map[string]string{"a": "other"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid", Labels: full}, nil).Once()
+				mock.On("DeviceSetLabels", ctx, models.UID("uid"), map[string]string{"a": "other"}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the labels cannot be set",
+			labels:      map[string]string{"site": "lisbon"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceSetLabels", ctx, models.UID("uid"), map[string]string{"site": "lisbon"}).
+					Return(errors.New("error", "", 0)).Once()
+			},
+			expected: errors.New("error", "", 0),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.UpdateDeviceLabels(ctx, "tenant", models.UID("uid"), tc.labels)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRemoveDeviceLabel(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		key           string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			key:         "site",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the device does not have the label",
+			key:         "owner",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Labels: map[string]string{"site": "lisbon"}}, nil).Once()
+			},
+			expected: NewErrDeviceLabelNotFound("owner", nil),
+		},
+		{
+			description: "succeeds to remove the label",
+			key:         "site",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", Labels: map[string]string{"site": "lisbon"}}, nil).Once()
+				mock.On("DeviceUnsetLabel", ctx, models.UID("uid"), "site").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.RemoveDeviceLabel(ctx, "tenant", models.UID("uid"), tc.key)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeviceMetricsService contains the service's function to manage the devices' health metrics and their alerts.
+type DeviceMetricsService interface {
+	ReportDeviceMetrics(ctx context.Context, uid models.UID, req requests.DeviceMetricsReport) error
+	ListDeviceMetrics(ctx context.Context, tenant string, uid models.UID, resolution models.DeviceMetricsResolution, from, to time.Time) ([]models.DeviceMetricsPoint, error)
+	ListDeviceAlerts(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAlert, int, error)
+}
+
+// ReportDeviceMetrics aggregates the metrics pushed by a device's agent into the buckets of each resolution, and raises
+// or resolves the device's alerts according to the namespace's thresholds. UID is the device's UID, as authenticated by
+// its token.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+func (s *service) ReportDeviceMetrics(ctx context.Context, uid models.UID, req requests.DeviceMetricsReport) error {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil || device == nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	metrics := &models.DeviceMetrics{
+		Load1:       req.Load1,
+		Load5:       req.Load5,
+		Load15:      req.Load15,
+		Memory:      req.Memory,
+		Disk:        req.Disk,
+		Temperature: req.Temperature,
+	}
+
+	now := clock.Now().UTC()
+
+	rollups := make([]models.DeviceMetricsRollup, 0, len(models.DeviceMetricsResolutions))
+	for _, resolution := range models.DeviceMetricsResolutions {
+		timestamp := now.Truncate(resolution.Period())
+
+		rollups = append(rollups, models.DeviceMetricsRollup{
+			Resolution: resolution,
+			Timestamp:  timestamp,
+			ExpiresAt:  timestamp.Add(resolution.Retention()),
+		})
+	}
+
+	if err := s.store.DeviceMetricsAdd(ctx, uid, device.TenantID, metrics, rollups); err != nil {
+		return err
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, device.TenantID)
+	if err != nil || namespace == nil {
+		return NewErrNamespaceNotFound(device.TenantID, err)
+	}
+
+	var thresholds []models.DeviceMetricsThreshold
+	if namespace.Settings != nil {
+		thresholds = namespace.Settings.MetricsThresholds
+	}
+
+	return s.evaluateDeviceAlerts(ctx, device, metrics, thresholds, now)
+}
+
+// evaluateDeviceAlerts raises an alert for each metric above its threshold, unless the device has already an active
+// alert for it, and resolves the active alerts whose metric went back below its threshold or has no threshold anymore.
+func (s *service) evaluateDeviceAlerts(ctx context.Context, device *models.Device, metrics *models.DeviceMetrics, thresholds []models.DeviceMetricsThreshold, now time.Time) error {
+	active, err := s.store.DeviceAlertListActive(ctx, models.UID(device.UID))
+	if err != nil {
+		return err
+	}
+
+	raised := make(map[models.DeviceMetric]bool, len(active))
+	for _, alert := range active {
+		raised[alert.Metric] = true
+	}
+
+	limits := make(map[models.DeviceMetric]float64, len(thresholds))
+	for _, threshold := range thresholds {
+		limits[threshold.Metric] = threshold.Value
+
+		value, ok := metrics.Value(threshold.Metric)
+		if !ok || value <= threshold.Value || raised[threshold.Metric] {
+			continue
+		}
+
+		alert := &models.DeviceAlert{
+			ID:        uuid.Generate(),
+			UID:       device.UID,
+			TenantID:  device.TenantID,
+			Metric:    threshold.Metric,
+			Value:     value,
+			Threshold: threshold.Value,
+			RaisedAt:  now,
+		}
+
+		if err := s.store.DeviceAlertCreate(ctx, alert); err != nil {
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"uid":       device.UID,
+			"tenant_id": device.TenantID,
+			"metric":    threshold.Metric,
+			"value":     value,
+			"threshold": threshold.Value,
+		}).Warn("device alert raised")
+	}
+
+	for _, alert := range active {
+		limit, ok := limits[alert.Metric]
+		if ok {
+			value, reported := metrics.Value(alert.Metric)
+			if !reported || value > limit {
+				continue
+			}
+		}
+
+		if err := s.store.DeviceAlertResolve(ctx, alert.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListDeviceMetrics lists the time series of a device's metrics, with a point for each bucket of the resolution started
+// within from and to. When the resolution is empty, hour is used, and when the period is not set, the last 24 hours
+// are listed.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+// If from is after to, a NewErrDeviceMetricsInvalid error will be returned.
+func (s *service) ListDeviceMetrics(ctx context.Context, tenant string, uid models.UID, resolution models.DeviceMetricsResolution, from, to time.Time) ([]models.DeviceMetricsPoint, error) {
+	if resolution == "" {
+		resolution = models.DeviceMetricsResolutionHour
+	}
+
+	if to.IsZero() {
+		to = clock.Now()
+	}
+
+	if from.IsZero() {
+		from = to.Add(-24 * time.Hour)
+	}
+
+	if from.After(to) {
+		return nil, NewErrDeviceMetricsInvalid(map[string]interface{}{"from": from, "to": to}, nil)
+	}
+
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil || device == nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	buckets, err := s.store.DeviceMetricsList(ctx, tenant, uid, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]models.DeviceMetricsPoint, 0, len(buckets))
+	for _, bucket := range buckets {
+		points = append(points, bucket.Point())
+	}
+
+	return points, nil
+}
+
+// ListDeviceAlerts lists the alerts of the namespace's devices, from the newest to the oldest.
+func (s *service) ListDeviceAlerts(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAlert, int, error) {
+	return s.store.DeviceAlertList(ctx, tenant, pagination)
+}
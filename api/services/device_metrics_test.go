@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestReportDeviceMetrics(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	temperature := 85.0
+	req := requests.DeviceMetricsReport{Load1: 0.5, Load5: 0.25, Load15: 0.1, Memory: 40, Disk: 95, Temperature: &temperature}
+	metrics := &models.DeviceMetrics{Load1: 0.5, Load5: 0.25, Load15: 0.1, Memory: 40, Disk: 95, Temperature: &temperature}
+
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+
+	utc := now.UTC()
+	rollups := []models.DeviceMetricsRollup{
+		{Resolution: models.DeviceMetricsResolutionMinute, Timestamp: utc.Truncate(time.Minute), ExpiresAt: utc.Truncate(time.Minute).Add(24 * time.Hour)},
+		{Resolution: models.DeviceMetricsResolutionHour, Timestamp: utc.Truncate(time.Hour), ExpiresAt: utc.Truncate(time.Hour).Add(30 * 24 * time.Hour)},
+		{Resolution: models.DeviceMetricsResolutionDay, Timestamp: utc.Truncate(24 * time.Hour), ExpiresAt: utc.Truncate(24 * time.Hour).Add(365 * 24 * time.Hour)},
+	}
+
+	namespace := func(thresholds ...models.DeviceMetricsThreshold) *models.Namespace {
+		return &models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{MetricsThresholds: thresholds}}
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds to aggregate the metrics when the namespace has no thresholds",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceMetricsAdd", ctx, models.UID("uid"), "tenant", metrics, rollups).Return(nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace(), nil).Once()
+				mock.On("DeviceAlertListActive", ctx, models.UID("uid")).Return([]models.DeviceAlert{}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds to raise an alert when a metric is above its threshold",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceMetricsAdd", ctx, models.UID("uid"), "tenant", metrics, rollups).Return(nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(
+						models.DeviceMetricsThreshold{Metric: models.DeviceMetricDisk, Value: 90},
+						models.DeviceMetricsThreshold{Metric: models.DeviceMetricMemory, Value: 90},
+					), nil).Once()
+				mock.On("DeviceAlertListActive", ctx, models.UID("uid")).Return([]models.DeviceAlert{}, nil).Once()
+				uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000000").Once()
+				mock.On("DeviceAlertCreate", ctx, testifymock.MatchedBy(func(alert *models.DeviceAlert) bool {
+					return alert.ID == "00000000-0000-4000-0000-000000000000" && alert.UID == "uid" && alert.TenantID == "tenant" && alert.Metric == models.DeviceMetricDisk &&
+						alert.Value == 95 && alert.Threshold == 90 && alert.RaisedAt.Equal(utc) && alert.ResolvedAt == nil
+				})).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds to keep the active alert when the metric is still above its threshold",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceMetricsAdd", ctx, models.UID("uid"), "tenant", metrics, rollups).Return(nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(models.DeviceMetricsThreshold{Metric: models.DeviceMetricDisk, Value: 90}), nil).Once()
+				mock.On("DeviceAlertListActive", ctx, models.UID("uid")).
+					Return([]models.DeviceAlert{{ID: "disk", Metric: models.DeviceMetricDisk}}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds to resolve the alerts below or without their thresholds",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(device, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceMetricsAdd", ctx, models.UID("uid"), "tenant", metrics, rollups).Return(nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(models.DeviceMetricsThreshold{Metric: models.DeviceMetricTemperature, Value: 90}), nil).Once()
+				mock.On("DeviceAlertListActive", ctx, models.UID("uid")).
+					Return([]models.DeviceAlert{
+						{ID: "temperature", Metric: models.DeviceMetricTemperature},
+						{ID: "disk", Metric: models.DeviceMetricDisk},
+					}, nil).Once()
+				mock.On("DeviceAlertResolve", ctx, "temperature", utc).Return(nil).Once()
+				mock.On("DeviceAlertResolve", ctx, "disk", utc).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.ReportDeviceMetrics(ctx, models.UID("uid"), req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestListDeviceMetrics(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	temperature := 100.0
+
+	type Expected struct {
+		points []models.DeviceMetricsPoint
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		resolution    models.DeviceMetricsResolution
+		from          time.Time
+		to            time.Time
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the period is invalid",
+			resolution:    models.DeviceMetricsResolutionHour,
+			from:          to,
+			to:            from,
+			requiredMocks: func() {},
+			expected: Expected{nil, NewErrDeviceMetricsInvalid(map[string]interface{}{
+				"from": to,
+				"to":   from,
+			}, nil)},
+		},
+		{
+			description: "fails when the device is not found",
+			resolution:  models.DeviceMetricsResolutionHour,
+			from:        from,
+			to:          to,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound("uid", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds to list the last 24 hours by hour when the period is not set",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceMetricsList", ctx, "tenant", models.UID("uid"), models.DeviceMetricsResolutionHour, now.Add(-24*time.Hour), now).
+					Return([]models.DeviceMetricsBucket{}, nil).Once()
+			},
+			expected: Expected{[]models.DeviceMetricsPoint{}, nil},
+		},
+		{
+			description: "succeeds to average the buckets",
+			resolution:  models.DeviceMetricsResolutionMinute,
+			from:        from,
+			to:          to,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(&models.Device{UID: "uid"}, nil).Once()
+				mock.On("DeviceMetricsList", ctx, "tenant", models.UID("uid"), models.DeviceMetricsResolutionMinute, from, to).
+					Return([]models.DeviceMetricsBucket{
+						{
+							Timestamp:          from,
+							Samples:            4,
+							TemperatureSamples: 2,
+							Sum:                models.DeviceMetrics{Load1: 2, Memory: 200, Temperature: &temperature},
+							Max:                models.DeviceMetrics{Load1: 1, Memory: 80},
+						},
+					}, nil).Once()
+			},
+			expected: Expected{[]models.DeviceMetricsPoint{
+				{
+					Timestamp: from,
+					Samples:   4,
+					Average: models.DeviceMetrics{Load1: 0.5, Memory: 50, Temperature: func() *float64 {
+						average := 50.0
+
+						return &average
+					}()},
+					Max: models.DeviceMetrics{Load1: 1, Memory: 80},
+				},
+			}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			points, err := service.ListDeviceMetrics(ctx, "tenant", models.UID("uid"), tc.resolution, tc.from, tc.to)
+			assert.Equal(t, tc.expected, Expected{points, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -7,15 +7,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/pkg/keyring"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
 	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -781,6 +786,7 @@ func TestOffineDevice(t *testing.T) {
 			uid:  models.UID("uid"),
 			requiredMocks: func() {
 				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceConnectivityRecord", ctx, models.UID("uid"), false, now).Return(nil).Once()
 				mock.On("DeviceSetOnline", ctx, models.UID("uid"), now, false).
 					Return(errors.New("error", "", 0)).Once()
 			},
@@ -793,6 +799,7 @@ func TestOffineDevice(t *testing.T) {
 			requiredMocks: func() {
 				online := true
 				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceConnectivityRecord", ctx, models.UID("uid"), online, now).Return(nil).Once()
 				mock.On("DeviceSetOnline", ctx, models.UID("uid"), now, online).
 					Return(errors.New("error", "", 0)).Once()
 			},
@@ -2446,6 +2453,7 @@ func TestDeviceHeartbeat(t *testing.T) {
 
 	clockMock.On("Now").Return(now).Once()
 
+	mock.On("DeviceConnectivityRecord", ctx, uid, true, now).Return(nil).Once()
 	mock.On("DeviceSetOnline", ctx, uid, now, true).Return(nil).Once()
 
 	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
@@ -2862,3 +2870,702 @@ func TestUpdateDeviceStatus_other_than_accepted(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestCreateDevicePublicURLService(t *testing.T) {
+	mock := new(mocks.Store)
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	const (
+		uid    = "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"
+		tenant = "00000000-0000-0000-0000-000000000000"
+	)
+
+	type Expected struct {
+		service *models.DevicePublicURLService
+		err     error
+	}
+
+	tests := []struct {
+		description   string
+		req           requests.DevicePublicURLServiceFields
+		requiredMocks func(ctx context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when could not get the device by UID",
+			req:         requests.DevicePublicURLServiceFields{Port: 8443, Scheme: "https"},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID(uid), errors.New("error", "", 0))},
+		},
+		{
+			description: "fails when the device already publishes a service on the target",
+			req:         requests.DevicePublicURLServiceFields{Port: 8443, Scheme: "https"},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(&models.Device{
+						UID: uid,
+						PublicURLServices: []models.DevicePublicURLService{
+							{Address: "dfa7de756ec8049aff4f19822ce4bea5", Port: 8443, Scheme: "http"},
+						},
+					}, nil).Once()
+			},
+			expected: Expected{nil, NewErrDevicePublicURLDuplicated(":8443", nil)},
+		},
+		{
+			description: "success when the service is published",
+			req:         requests.DevicePublicURLServiceFields{Port: 8443, Scheme: "https", InsecureSkipVerify: true},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(&models.Device{
+						UID: uid,
+						PublicURLServices: []models.DevicePublicURLService{
+							{Address: "address", Port: 3000, Scheme: "http"},
+						},
+					}, nil).Once()
+
+				uuidMock.On("Generate").Return("dfa7de75-6ec8-049a-ff4f-19822ce4bea5").Once()
+
+				mock.On("DevicePublicURLServiceCreate", ctx, models.UID(uid), &models.DevicePublicURLService{
+					Address:            "dfa7de756ec8049aff4f19822ce4bea5",
+					Port:               8443,
+					Scheme:             "https",
+					InsecureSkipVerify: true,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				service: &models.DevicePublicURLService{
+					Address:            "dfa7de756ec8049aff4f19822ce4bea5",
+					Port:               8443,
+					Scheme:             "https",
+					InsecureSkipVerify: true,
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "success when the service is published with the default scheme",
+			req:         requests.DevicePublicURLServiceFields{Port: 8443, InsecureSkipVerify: true},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(&models.Device{UID: uid}, nil).Once()
+
+				uuidMock.On("Generate").Return("dfa7de75-6ec8-049a-ff4f-19822ce4bea5").Once()
+
+				mock.On("DevicePublicURLServiceCreate", ctx, models.UID(uid), &models.DevicePublicURLService{
+					Address: "dfa7de756ec8049aff4f19822ce4bea5",
+					Port:    8443,
+					Scheme:  "http",
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				service: &models.DevicePublicURLService{
+					Address: "dfa7de756ec8049aff4f19822ce4bea5",
+					Port:    8443,
+					Scheme:  "http",
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx := context.Background()
+			test.requiredMocks(ctx)
+
+			published, err := service.CreateDevicePublicURLService(ctx, tenant, models.UID(uid), test.req)
+			assert.Equal(t, test.expected, Expected{published, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestUpdateDevicePublicURLService(t *testing.T) {
+	mock := new(mocks.Store)
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	const (
+		uid    = "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"
+		tenant = "00000000-0000-0000-0000-000000000000"
+	)
+
+	device := &models.Device{
+		UID: uid,
+		PublicURLServices: []models.DevicePublicURLService{
+			{Address: "grafana", Port: 3000, Scheme: "http"},
+			{Address: "admin", Port: 8443, Scheme: "https"},
+		},
+	}
+
+	type Expected struct {
+		service *models.DevicePublicURLService
+		err     error
+	}
+
+	tests := []struct {
+		description   string
+		address       string
+		req           requests.DevicePublicURLServiceFields
+		requiredMocks func(ctx context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when the service is not found",
+			address:     "unknown",
+			req:         requests.DevicePublicURLServiceFields{Port: 8080},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(device, nil).Once()
+			},
+			expected: Expected{nil, NewErrDevicePublicURLNotFound("unknown", nil)},
+		},
+		{
+			description: "fails when other service is published on the target",
+			address:     "grafana",
+			req:         requests.DevicePublicURLServiceFields{Port: 8443},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(device, nil).Once()
+			},
+			expected: Expected{nil, NewErrDevicePublicURLDuplicated(":8443", nil)},
+		},
+		{
+			description: "success when the service is updated",
+			address:     "admin",
+			req:         requests.DevicePublicURLServiceFields{Host: "192.168.0.10", Port: 443, Scheme: "https", InsecureSkipVerify: true},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(device, nil).Once()
+				mock.On("DevicePublicURLServiceUpdate", ctx, models.UID(uid), "admin", &models.DevicePublicURLService{
+					Address:            "admin",
+					Host:               "192.168.0.10",
+					Port:               443,
+					Scheme:             "https",
+					InsecureSkipVerify: true,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				service: &models.DevicePublicURLService{
+					Address:            "admin",
+					Host:               "192.168.0.10",
+					Port:               443,
+					Scheme:             "https",
+					InsecureSkipVerify: true,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx := context.Background()
+			test.requiredMocks(ctx)
+
+			updated, err := service.UpdateDevicePublicURLService(ctx, tenant, models.UID(uid), test.address, test.req)
+			assert.Equal(t, test.expected, Expected{updated, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteDevicePublicURLService(t *testing.T) {
+	mock := new(mocks.Store)
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	const (
+		uid    = "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"
+		tenant = "00000000-0000-0000-0000-000000000000"
+	)
+
+	tests := []struct {
+		description   string
+		address       string
+		requiredMocks func(ctx context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when could not get the device by UID",
+			address:     "admin",
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID(uid), errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when the service is not found",
+			address:     "unknown",
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(&models.Device{UID: uid}, nil).Once()
+				mock.On("DevicePublicURLServiceDelete", ctx, models.UID(uid), "unknown").Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDevicePublicURLNotFound("unknown", store.ErrNoDocuments),
+		},
+		{
+			description: "success when the service is deleted",
+			address:     "admin",
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(&models.Device{UID: uid}, nil).Once()
+				mock.On("DevicePublicURLServiceDelete", ctx, models.UID(uid), "admin").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx := context.Background()
+			test.requiredMocks(ctx)
+
+			err := service.DeleteDevicePublicURLService(ctx, tenant, models.UID(uid), test.address)
+			assert.Equal(t, test.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthorizeDevicePublicURL(t *testing.T) {
+	mock := new(mocks.Store)
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	sign := func(claims *models.UserAuthClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = keyring.Thumbprint(publicKey)
+
+		signed, err := token.SignedString(privateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return signed
+	}
+
+	device := &models.Device{
+		UID:      "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+		TenantID: "00000000-0000-0000-0000-000000000000",
+		PublicURLServices: []models.DevicePublicURLService{
+			{
+				Address: "public",
+				Port:    80,
+				Scheme:  "http",
+			},
+			{
+				Address: "basic",
+				Port:    3000,
+				Scheme:  "http",
+				Access: models.DevicePublicURLAccess{
+					Mode:     models.DevicePublicURLAccessBasic,
+					Username: "admin",
+					Password: models.NewUserPassword("secret").HashedPassword,
+				},
+			},
+			{
+				Address: "login",
+				Port:    8443,
+				Scheme:  "https",
+				Access: models.DevicePublicURLAccess{
+					Mode: models.DevicePublicURLAccessLogin,
+				},
+			},
+		},
+	}
+
+	namespace := &models.Namespace{
+		TenantID: "00000000-0000-0000-0000-000000000000",
+		Members:  []models.Member{{ID: "member", Role: guard.RoleObserver}},
+	}
+
+	claims := func(id string, mfa models.MFA) *models.UserAuthClaims {
+		return &models.UserAuthClaims{
+			ID:         id,
+			MFA:        mfa,
+			AuthClaims: models.AuthClaims{Claims: "user"},
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			},
+		}
+	}
+
+	tests := []struct {
+		description   string
+		req           requests.DevicePublicURLAuthorize
+		requiredMocks func(ctx context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			req:         requests.DevicePublicURLAuthorize{DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "unknown"}},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByPublicURLAddress", ctx, "unknown").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("unknown"), store.ErrNoDocuments),
+		},
+		{
+			description: "success when the service is public",
+			req:         requests.DevicePublicURLAuthorize{DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "public"}},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByPublicURLAddress", ctx, "public").Return(device, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the basic authentication password is wrong",
+			req: requests.DevicePublicURLAuthorize{
+				DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "basic"},
+				Username:               "admin",
+				Password:               "wrong",
+			},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByPublicURLAddress", ctx, "basic").Return(device, nil).Once()
+			},
+			expected: NewErrAuthUnathorized(nil),
+		},
+		{
+			description: "success when the basic authentication credentials match",
+			req: requests.DevicePublicURLAuthorize{
+				DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "basic"},
+				Username:               "admin",
+				Password:               "secret",
+			},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByPublicURLAddress", ctx, "basic").Return(device, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the token is missing",
+			req:         requests.DevicePublicURLAuthorize{DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "login"}},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByPublicURLAddress", ctx, "login").Return(device, nil).Once()
+			},
+			expected: ErrAuthUnathorized,
+		},
+		{
+			description: "fails when the token MFA is not validated",
+			req: requests.DevicePublicURLAuthorize{
+				DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "login"},
+				Token:                  sign(claims("member", models.MFA{Enable: true, Validate: false})),
+			},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByPublicURLAddress", ctx, "login").Return(device, nil).Once()
+			},
+			expected: NewErrAuthUnathorized(nil),
+		},
+		{
+			description: "fails when the user is not a member of the device's namespace",
+			req: requests.DevicePublicURLAuthorize{
+				DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "login"},
+				Token:                  sign(claims("stranger", models.MFA{})),
+			},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByPublicURLAddress", ctx, "login").Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expected: NewErrForbidden(ErrDevicePublicURLForbidden, nil),
+		},
+		{
+			description: "success when the user is a member of the device's namespace",
+			req: requests.DevicePublicURLAuthorize{
+				DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "login"},
+				Token:                  sign(claims("member", models.MFA{})),
+			},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByPublicURLAddress", ctx, "login").Return(device, nil).Once()
+				mock.On("NamespaceGet", ctx, "00000000-0000-0000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx := context.Background()
+			test.requiredMocks(ctx)
+
+			err := service.AuthorizeDevicePublicURL(ctx, test.req)
+			if test.expected == ErrAuthUnathorized {
+				assert.ErrorIs(t, err, ErrAuthUnathorized)
+
+				return
+			}
+
+			assert.Equal(t, test.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateDevicePublishedPort(t *testing.T) {
+	mock := new(mocks.Store)
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	const (
+		uid    = "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"
+		tenant = "00000000-0000-0000-0000-000000000000"
+	)
+
+	type Expected struct {
+		port *models.DevicePublishedPort
+		err  error
+	}
+
+	tests := []struct {
+		description   string
+		req           requests.DevicePublishedPortCreate
+		requiredMocks func(ctx context.Context)
+		expected      Expected
+	}{
+		{
+			description: "fails when could not get the device by UID",
+			req:         requests.DevicePublishedPortCreate{Port: 5432},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID(uid), errors.New("error", "", 0))},
+		},
+		{
+			description: "fails when the device already publishes the port",
+			req:         requests.DevicePublishedPortCreate{Port: 5432},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(&models.Device{
+						UID:            uid,
+						PublishedPorts: []models.DevicePublishedPort{{Address: "e3a6f6402891a1ef6bf69da657423d38", Port: 5432}},
+					}, nil).Once()
+			},
+			expected: Expected{nil, NewErrDevicePublishedPortDuplicated(":5432", nil)},
+		},
+		{
+			description: "success when the port is published",
+			req:         requests.DevicePublishedPortCreate{Port: 5432},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(&models.Device{
+						UID:            uid,
+						PublishedPorts: []models.DevicePublishedPort{{Address: "address", Host: "db", Port: 5432}},
+					}, nil).Once()
+
+				uuidMock.On("Generate").Return("e3a6f640-2891-a1ef-6bf6-9da657423d38").Once()
+				envMock.On("Get", "SHELLHUB_PUBLISHED_PORTS_RANGE").Return("").Once()
+
+				mock.On("DevicePublishedPortCreate", ctx, models.UID(uid), &models.DevicePublishedPort{
+					Address: "e3a6f6402891a1ef6bf69da657423d38",
+					Port:    5432,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				port: &models.DevicePublishedPort{Address: "e3a6f6402891a1ef6bf69da657423d38", Port: 5432},
+				err:  nil,
+			},
+		},
+		{
+			description: "fails when every gateway's port is allocated",
+			req:         requests.DevicePublishedPortCreate{Port: 5432},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(&models.Device{UID: uid}, nil).Once()
+
+				uuidMock.On("Generate").Return("e3a6f640-2891-a1ef-6bf6-9da657423d38").Once()
+				envMock.On("Get", "SHELLHUB_PUBLISHED_PORTS_RANGE").Return("20000-20001").Once()
+
+				mock.On("DevicePublishedPortListGatewayPorts", ctx).Return([]int{20000, 20001}, nil).Once()
+			},
+			expected: Expected{nil, NewErrDevicePublishedPortLimit(2, nil)},
+		},
+		{
+			description: "success when the port is published on an allocated gateway's port",
+			req:         requests.DevicePublishedPortCreate{Port: 5432},
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(&models.Device{UID: uid}, nil).Once()
+
+				uuidMock.On("Generate").Return("e3a6f640-2891-a1ef-6bf6-9da657423d38").Once()
+				envMock.On("Get", "SHELLHUB_PUBLISHED_PORTS_RANGE").Return("20000-20099").Once()
+
+				mock.On("DevicePublishedPortListGatewayPorts", ctx).Return([]int{20000}, nil).Once()
+				mock.On("DevicePublishedPortCreate", ctx, models.UID(uid), &models.DevicePublishedPort{
+					Address:     "e3a6f6402891a1ef6bf69da657423d38",
+					Port:        5432,
+					GatewayPort: 20001,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				port: &models.DevicePublishedPort{Address: "e3a6f6402891a1ef6bf69da657423d38", Port: 5432, GatewayPort: 20001},
+				err:  nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx := context.Background()
+			test.requiredMocks(ctx)
+
+			port, err := service.CreateDevicePublishedPort(ctx, tenant, models.UID(uid), test.req)
+			assert.Equal(t, test.expected, Expected{port, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestDeleteDevicePublishedPort(t *testing.T) {
+	mock := new(mocks.Store)
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	const (
+		uid    = "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"
+		tenant = "00000000-0000-0000-0000-000000000000"
+	)
+
+	tests := []struct {
+		description   string
+		address       string
+		requiredMocks func(ctx context.Context)
+		expected      error
+	}{
+		{
+			description: "fails when could not get the device by UID",
+			address:     "address",
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).
+					Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID(uid), errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when the port is not found",
+			address:     "unknown",
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(&models.Device{UID: uid}, nil).Once()
+				mock.On("DevicePublishedPortDelete", ctx, models.UID(uid), "unknown").Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDevicePublishedPortNotFound("unknown", store.ErrNoDocuments),
+		},
+		{
+			description: "success when the port is unpublished",
+			address:     "address",
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID(uid), tenant).Return(&models.Device{UID: uid}, nil).Once()
+				mock.On("DevicePublishedPortDelete", ctx, models.UID(uid), "address").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			ctx := context.Background()
+			test.requiredMocks(ctx)
+
+			err := service.DeleteDevicePublishedPort(ctx, tenant, models.UID(uid), test.address)
+			assert.Equal(t, test.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDevicePublishedPortToken(t *testing.T) {
+	mock := new(mocks.Store)
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	device := &models.Device{
+		UID:            "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+		TenantID:       "00000000-0000-0000-0000-000000000000",
+		PublishedPorts: []models.DevicePublishedPort{{Address: "address", Port: 5432}},
+	}
+
+	namespace := &models.Namespace{
+		TenantID: "00000000-0000-0000-0000-000000000000",
+		Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+	}
+
+	ctx := context.Background()
+
+	t.Run("fails when the port is not found", func(t *testing.T) {
+		mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).Return(device, nil).Once()
+
+		token, err := service.CreateDevicePublishedPortToken(ctx, device.TenantID, "member", models.UID(device.UID), "unknown")
+		assert.Nil(t, token)
+		assert.Equal(t, NewErrDevicePublishedPortNotFound("unknown", nil), err)
+	})
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).Return(device, nil).Once()
+	clockMock.On("Now").Return(now).Twice()
+	uuidMock.On("Generate").Return("00000000-0000-4000-0000-000000000000").Once()
+
+	token, err := service.CreateDevicePublishedPortToken(ctx, device.TenantID, "member", models.UID(device.UID), "address")
+	assert.NoError(t, err)
+
+	t.Run("fails when the token was issued to another port", func(t *testing.T) {
+		other := &models.Device{
+			UID:            device.UID,
+			TenantID:       device.TenantID,
+			PublishedPorts: []models.DevicePublishedPort{{Address: "other", Port: 22}},
+		}
+
+		mock.On("DeviceGetByPublishedPortAddress", ctx, "other").Return(other, nil).Once()
+
+		authorized, err := service.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{Address: "other", Token: token.Token})
+		assert.Nil(t, authorized)
+		assert.Equal(t, NewErrAuthUnathorized(nil), err)
+	})
+
+	t.Run("fails when the token is invalid", func(t *testing.T) {
+		authorized, err := service.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{Address: "address", Token: "invalid"})
+		assert.Nil(t, authorized)
+		assert.ErrorIs(t, err, ErrAuthUnathorized)
+	})
+
+	t.Run("fails when the user is no longer a member of the namespace", func(t *testing.T) {
+		mock.On("DeviceGetByPublishedPortAddress", ctx, "address").Return(device, nil).Once()
+		mock.On("NamespaceGet", ctx, device.TenantID).Return(&models.Namespace{TenantID: device.TenantID}, nil).Once()
+
+		authorized, err := service.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{Address: "address", Token: token.Token})
+		assert.Nil(t, authorized)
+		assert.Equal(t, NewErrForbidden(ErrDevicePublishedPortForbidden, nil), err)
+	})
+
+	t.Run("success when the token was issued to the port", func(t *testing.T) {
+		mock.On("DeviceGetByPublishedPortAddress", ctx, "address").Return(device, nil).Once()
+		mock.On("NamespaceGet", ctx, device.TenantID).Return(namespace, nil).Once()
+
+		authorized, err := service.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{Address: "address", Token: token.Token})
+		assert.NoError(t, err)
+		assert.Equal(t, device, authorized)
+	})
+
+	t.Run("success when the port is reached through its gateway's port", func(t *testing.T) {
+		allocated := &models.Device{
+			UID:            device.UID,
+			TenantID:       device.TenantID,
+			PublishedPorts: []models.DevicePublishedPort{{Address: "address", Port: 5432, GatewayPort: 20000}},
+		}
+
+		mock.On("DeviceGetByPublishedPortGatewayPort", ctx, 20000).Return(allocated, nil).Once()
+		mock.On("NamespaceGet", ctx, device.TenantID).Return(namespace, nil).Once()
+
+		authorized, err := service.AuthorizeDevicePublishedPort(ctx, requests.DevicePublishedPortAuthorize{GatewayPort: 20000, Token: token.Token})
+		assert.NoError(t, err)
+		assert.Equal(t, allocated, authorized)
+	})
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
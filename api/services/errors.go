@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -32,6 +33,9 @@ const (
 	// ErrCodeNoContentChange is the error that occurs when the store function does not change any resource. Generally used in
 	// update methods.
 	ErrCodeNoContentChange
+	// ErrCodeTooManyRequests is the error code for when too many requests were made and the client must wait before a
+	// new one.
+	ErrCodeTooManyRequests
 )
 
 // ErrDataNotFound structure should be used to add errors.Data to an error when the resource is not found.
@@ -59,6 +63,12 @@ type ErrDataInvalid struct {
 	Data map[string]interface{}
 }
 
+// ErrDataRetryAfter structure should be used to add errors.Data to an error when the request must be retried later.
+type ErrDataRetryAfter struct {
+	// RetryAfter is how long the client must wait before a new request.
+	RetryAfter time.Duration
+}
+
 var (
 	ErrReport                       = errors.New("report error", ErrLayer, ErrCodeInvalid)
 	ErrPaymentRequired              = errors.New("payment required", ErrLayer, ErrCodePayment)
@@ -103,17 +113,42 @@ var (
 	ErrDeviceSetOnline              = errors.New("device set online", ErrLayer, ErrCodeStore)
 	ErrMaxDeviceCountReached        = errors.New("maximum number of accepted devices reached", ErrLayer, ErrCodeLimit)
 	ErrDuplicatedDeviceName         = errors.New("device name duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrDevicePublicURLNotFound      = errors.New("device public url service not found", ErrLayer, ErrCodeNotFound)
+	ErrDevicePublicURLDuplicated    = errors.New("device public url service duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrDevicePublicURLForbidden     = errors.New("device public url service forbidden", ErrLayer, ErrCodeForbidden)
+	ErrDevicePublishedPortNotFound  = errors.New("device published port not found", ErrLayer, ErrCodeNotFound)
+	ErrDevicePublishedPortDuplicate = errors.New("device published port duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrDevicePublishedPortForbidden = errors.New("device published port forbidden", ErrLayer, ErrCodeForbidden)
+	ErrDevicePublishedPortLimit     = errors.New("device published port limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceGroupNotFound          = errors.New("device group not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceGroupDuplicated        = errors.New("device group duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrDeviceLabelLimit             = errors.New("device label limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceLabelNotFound          = errors.New("device label not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceGroupInvalid           = errors.New("device group invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceJobNotFound            = errors.New("device job not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceJobInvalid             = errors.New("device job invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceJobLimit               = errors.New("device job limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceJobEnqueue             = errors.New("device job enqueue", ErrLayer, ErrCodeStore)
+	ErrDeviceMetricsInvalid         = errors.New("device metrics period invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceAvailabilityInvalid    = errors.New("device availability period invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceAvailabilityLimit      = errors.New("device availability limit reached", ErrLayer, ErrCodeLimit)
 	ErrPublicKeyDuplicated          = errors.New("public key duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrPublicKeyNotFound            = errors.New("public key not found", ErrLayer, ErrCodeNotFound)
 	ErrPublicKeyInvalid             = errors.New("public key invalid", ErrLayer, ErrCodeInvalid)
 	ErrPublicKeyNoTags              = errors.New("public key has no tags", ErrLayer, ErrCodeInvalid)
 	ErrPublicKeyDataInvalid         = errors.New("public key data invalid", ErrLayer, ErrCodeInvalid)
 	ErrPublicKeyFilter              = errors.New("public key cannot have more than one filter at same time", ErrLayer, ErrCodeInvalid)
+	ErrCertificatePrincipal         = errors.New("certificate principal not allowed by the public key", ErrLayer, ErrCodeForbidden)
 	ErrTokenSigned                  = errors.New("token signed", ErrLayer, ErrCodeInvalid)
 	ErrTypeAssertion                = errors.New("type assertion failed", ErrLayer, ErrCodeInvalid)
 	ErrSessionNotFound              = errors.New("session not found", ErrLayer, ErrCodeNotFound)
+	ErrSessionFilterInvalid         = errors.New("session filter invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthInvalid                  = errors.New("auth invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthUnathorized              = errors.New("auth unauthorized", ErrLayer, ErrCodeUnauthorized)
+	ErrAuthLocked                   = errors.New("auth locked", ErrLayer, ErrCodeTooManyRequests)
+	ErrUserSessionNotFound          = errors.New("user session not found", ErrLayer, ErrCodeNotFound)
+	ErrWebAuthnCredentialNotFound   = errors.New("webauthn credential not found", ErrLayer, ErrCodeNotFound)
+	ErrWebAuthnDisabled             = errors.New("webauthn disabled", ErrLayer, ErrCodeForbidden)
 	ErrNamespaceLimitReached        = errors.New("namespace limit reached", ErrLayer, ErrCodeLimit)
 	ErrDeviceRemovedCount           = errors.New("device removed count", ErrLayer, ErrCodeNotFound)
 	ErrDeviceRemovedInsert          = errors.New("device removed insert", ErrLayer, ErrCodeStore)
@@ -239,6 +274,12 @@ func NewErrPublicKeyNotFound(id string, next error) error {
 	return NewErrNotFound(ErrPublicKeyNotFound, id, next)
 }
 
+// NewErrCertificatePrincipal returns an error when a certificate's principal is not allowed by the username of the
+// public key to be signed.
+func NewErrCertificatePrincipal(next error) error {
+	return NewErrForbidden(ErrCertificatePrincipal, next)
+}
+
 // NewErrPublicKeyInvalid returns an error when the public key is invalid.
 func NewErrPublicKeyInvalid(data map[string]interface{}, next error) error {
 	return NewErrInvalid(ErrPublicKeyInvalid, data, next)
@@ -290,6 +331,11 @@ func NewErrSessionNotFound(id models.UID, next error) error {
 	return NewErrNotFound(ErrSessionNotFound, string(id), next)
 }
 
+// NewErrSessionFilterInvalid returns an error when a filter of the session list is invalid.
+func NewErrSessionFilterInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrSessionFilterInvalid, data, next)
+}
+
 // NewErrNamespaceList return an error to be used when cannot list namespaces.
 func NewErrNamespaceList(next error) error {
 	return NewErrInvalid(ErrNamespaceList, nil, next)
@@ -341,6 +387,32 @@ func NewErrDeviceDuplicated(name string, next error) error {
 	return NewErrDuplicated(ErrDeviceDuplicated, []string{name}, next)
 }
 
+// NewErrDevicePublicURLNotFound returns an error to be used when the device's public URL service is not found.
+func NewErrDevicePublicURLNotFound(address string, next error) error {
+	return NewErrNotFound(ErrDevicePublicURLNotFound, address, next)
+}
+
+// NewErrDevicePublicURLDuplicated returns an error to be used when the device already publishes a service on target.
+func NewErrDevicePublicURLDuplicated(target string, next error) error {
+	return NewErrDuplicated(ErrDevicePublicURLDuplicated, []string{target}, next)
+}
+
+// NewErrDevicePublishedPortNotFound returns an error to be used when the device's published port is not found.
+func NewErrDevicePublishedPortNotFound(address string, next error) error {
+	return NewErrNotFound(ErrDevicePublishedPortNotFound, address, next)
+}
+
+// NewErrDevicePublishedPortDuplicated returns an error to be used when the device already publishes the target.
+func NewErrDevicePublishedPortDuplicated(target string, next error) error {
+	return NewErrDuplicated(ErrDevicePublishedPortDuplicate, []string{target}, next)
+}
+
+// NewErrDevicePublishedPortLimit returns an error to be used when every gateway's port of the range is already
+// allocated to a published port.
+func NewErrDevicePublishedPortLimit(limit int, next error) error {
+	return NewErrLimit(ErrDevicePublishedPortLimit, limit, next)
+}
+
 // NewErrDeviceLookupNotFound returns an error to be used when the device lookup is not found.
 func NewErrDeviceLookupNotFound(namespace, name string, next error) error {
 	return NewErrNotFound(ErrDeviceLookupNotFound, fmt.Sprintf("device %s on namespace %s", name, namespace), next)
@@ -398,6 +470,11 @@ func NewErrAuthUnathorized(err error) error {
 	return NewErrUnathorized(ErrAuthUnathorized, err)
 }
 
+// NewErrAuthLocked returns a error to be used when the auth is temporarily locked due to many failed attempts.
+func NewErrAuthLocked(retryAfter time.Duration, err error) error {
+	return errors.Wrap(errors.WithData(ErrAuthLocked, ErrDataRetryAfter{RetryAfter: retryAfter}), err)
+}
+
 // NewErrBadRequest returns a error to be used when the auth is unauthorized.
 func NewErrBadRequest(err error) error {
 	return NewErrRequest(ErrBadRequest, err)
@@ -443,3 +520,63 @@ func NewErrBillingEvaluate(next error) error {
 func NewErrDeviceMaxDevicesReached(count int) error {
 	return NewErrLimit(ErrMaxDeviceCountReached, count, nil)
 }
+
+// NewErrDeviceGroupNotFound returns an error when the device group is not found.
+func NewErrDeviceGroupNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceGroupNotFound, id, next)
+}
+
+// NewErrDeviceGroupDuplicated returns an error when a device group with the same name already exists on the namespace.
+func NewErrDeviceGroupDuplicated(values []string, next error) error {
+	return NewErrDuplicated(ErrDeviceGroupDuplicated, values, next)
+}
+
+// NewErrDeviceGroupInvalid returns an error when the device group's filter is invalid.
+func NewErrDeviceGroupInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrDeviceGroupInvalid, data, next)
+}
+
+// NewErrDeviceJobNotFound returns an error when the device job is not found.
+func NewErrDeviceJobNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceJobNotFound, id, next)
+}
+
+// NewErrDeviceJobInvalid returns an error when the device job's operation or devices are invalid.
+func NewErrDeviceJobInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrDeviceJobInvalid, data, next)
+}
+
+// NewErrDeviceJobLimit returns an error when the device job has more devices than allowed.
+func NewErrDeviceJobLimit(limit int, next error) error {
+	return NewErrLimit(ErrDeviceJobLimit, limit, next)
+}
+
+// NewErrDeviceJobEnqueue returns an error when the device job cannot be enqueued to the workers.
+func NewErrDeviceJobEnqueue(next error) error {
+	return errors.Wrap(ErrDeviceJobEnqueue, next)
+}
+
+// NewErrDeviceLabelLimit returns an error when the device's label limit is reached.
+func NewErrDeviceLabelLimit(limit int, next error) error {
+	return NewErrLimit(ErrDeviceLabelLimit, limit, next)
+}
+
+// NewErrDeviceLabelNotFound returns an error when the device's label is not found.
+func NewErrDeviceLabelNotFound(key string, next error) error {
+	return NewErrNotFound(ErrDeviceLabelNotFound, key, next)
+}
+
+// NewErrDeviceMetricsInvalid returns an error when the period of the device's metrics is invalid.
+func NewErrDeviceMetricsInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrDeviceMetricsInvalid, data, next)
+}
+
+// NewErrDeviceAvailabilityInvalid returns an error when the period of the availability report is invalid.
+func NewErrDeviceAvailabilityInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrDeviceAvailabilityInvalid, data, next)
+}
+
+// NewErrDeviceAvailabilityLimit returns an error when the availability report has more devices than allowed.
+func NewErrDeviceAvailabilityLimit(limit int, next error) error {
+	return NewErrLimit(ErrDeviceAvailabilityLimit, limit, next)
+}
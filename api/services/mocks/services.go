@@ -6,12 +6,16 @@ import (
 	context "context"
 
 	internalclient "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+
+	keyring "github.com/shellhub-io/shellhub/api/pkg/keyring"
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/shellhub-io/shellhub/pkg/models"
 
 	paginator "github.com/shellhub-io/shellhub/pkg/api/paginator"
 
+	protocol "github.com/go-webauthn/webauthn/protocol"
+
 	requests "github.com/shellhub-io/shellhub/pkg/api/requests"
 
 	responses "github.com/shellhub-io/shellhub/pkg/api/responses"
@@ -19,6 +23,8 @@ import (
 	rsa "crypto/rsa"
 
 	template "text/template"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -208,6 +214,24 @@ func (_m *Service) AuthMFA(ctx context.Context, id string) (bool, error) {
 	return r0, r1
 }
 
+// AuthMFAAttempt provides a mock function with given fields: ctx, id
+func (_m *Service) AuthMFAAttempt(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthMFAAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AuthPublicKey provides a mock function with given fields: ctx, req
 func (_m *Service) AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth) (*models.PublicKeyAuthResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -238,29 +262,51 @@ func (_m *Service) AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth
 	return r0, r1
 }
 
-// AuthSwapToken provides a mock function with given fields: ctx, ID, tenant
-func (_m *Service) AuthSwapToken(ctx context.Context, ID string, tenant string) (*models.UserAuthResponse, error) {
-	ret := _m.Called(ctx, ID, tenant)
+// AuthRefreshToken provides a mock function with given fields: ctx, refreshToken
+func (_m *Service) AuthRefreshToken(ctx context.Context, refreshToken string) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, refreshToken)
 
-	if len(ret) == 0 {
-		panic("no return value specified for AuthSwapToken")
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
 	}
 
+	return r0, r1
+}
+
+// AuthSwapToken provides a mock function with given fields: ctx, ID, tenant, session
+func (_m *Service) AuthSwapToken(ctx context.Context, ID string, tenant string, session string) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, ID, tenant, session)
+
 	var r0 *models.UserAuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.UserAuthResponse, error)); ok {
-		return rf(ctx, ID, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, ID, tenant, session)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.UserAuthResponse); ok {
-		r0 = rf(ctx, ID, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, ID, tenant, session)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.UserAuthResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, ID, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, ID, tenant, session)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -286,9 +332,9 @@ func (_m *Service) AuthUncacheToken(ctx context.Context, tenant string, id strin
 	return r0
 }
 
-// AuthUser provides a mock function with given fields: ctx, model
-func (_m *Service) AuthUser(ctx context.Context, model *models.UserAuthRequest) (*models.UserAuthResponse, error) {
-	ret := _m.Called(ctx, model)
+// AuthUser provides a mock function with given fields: ctx, model, sourceIP
+func (_m *Service) AuthUser(ctx context.Context, model *models.UserAuthRequest, sourceIP string) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, model, sourceIP)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AuthUser")
@@ -296,19 +342,19 @@ func (_m *Service) AuthUser(ctx context.Context, model *models.UserAuthRequest)
 
 	var r0 *models.UserAuthResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.UserAuthRequest) (*models.UserAuthResponse, error)); ok {
-		return rf(ctx, model)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserAuthRequest, string) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, model, sourceIP)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.UserAuthRequest) *models.UserAuthResponse); ok {
-		r0 = rf(ctx, model)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserAuthRequest, string) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, model, sourceIP)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.UserAuthResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *models.UserAuthRequest) error); ok {
-		r1 = rf(ctx, model)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.UserAuthRequest, string) error); ok {
+		r1 = rf(ctx, model, sourceIP)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -346,6 +392,165 @@ func (_m *Service) AuthUserInfo(ctx context.Context, username string, tenant str
 	return r0, r1
 }
 
+// AuthorizeDevicePublicURL provides a mock function with given fields: ctx, req
+func (_m *Service) AuthorizeDevicePublicURL(ctx context.Context, req requests.DevicePublicURLAuthorize) error {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizeDevicePublicURL")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.DevicePublicURLAuthorize) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthorizeDevicePublishedPort provides a mock function with given fields: ctx, req
+func (_m *Service) AuthorizeDevicePublishedPort(ctx context.Context, req requests.DevicePublishedPortAuthorize) (*models.Device, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizeDevicePublishedPort")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, requests.DevicePublishedPortAuthorize) (*models.Device, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, requests.DevicePublishedPortAuthorize) *models.Device); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, requests.DevicePublishedPortAuthorize) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BeginWebAuthnLogin provides a mock function with given fields: ctx, userID
+func (_m *Service) BeginWebAuthnLogin(ctx context.Context, userID string) (string, *protocol.CredentialAssertion, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginWebAuthnLogin")
+	}
+
+	var r0 string
+	var r1 *protocol.CredentialAssertion
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, *protocol.CredentialAssertion, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *protocol.CredentialAssertion); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*protocol.CredentialAssertion)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// BeginWebAuthnPasswordlessLogin provides a mock function with given fields: ctx
+func (_m *Service) BeginWebAuthnPasswordlessLogin(ctx context.Context) (string, *protocol.CredentialAssertion, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginWebAuthnPasswordlessLogin")
+	}
+
+	var r0 string
+	var r1 *protocol.CredentialAssertion
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (string, *protocol.CredentialAssertion, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) *protocol.CredentialAssertion); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*protocol.CredentialAssertion)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// BeginWebAuthnRegistration provides a mock function with given fields: ctx, userID
+func (_m *Service) BeginWebAuthnRegistration(ctx context.Context, userID string) (string, *protocol.CredentialCreation, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginWebAuthnRegistration")
+	}
+
+	var r0 string
+	var r1 *protocol.CredentialCreation
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, *protocol.CredentialCreation, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *protocol.CredentialCreation); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*protocol.CredentialCreation)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // BillingEvaluate provides a mock function with given fields: _a0, _a1
 func (_m *Service) BillingEvaluate(_a0 internalclient.Client, _a1 string) (bool, error) {
 	ret := _m.Called(_a0, _a1)
@@ -392,6 +597,186 @@ func (_m *Service) BillingReport(_a0 internalclient.Client, _a1 string, _a2 stri
 	return r0
 }
 
+// CreateDeviceGroup provides a mock function with given fields: ctx, tenant, userID, req
+func (_m *Service) CreateDeviceGroup(ctx context.Context, tenant string, userID string, req requests.DeviceGroupCreate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeviceGroup")
+	}
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.DeviceGroupCreate) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.DeviceGroupCreate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.DeviceGroupCreate) error); ok {
+		r1 = rf(ctx, tenant, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDeviceJob provides a mock function with given fields: ctx, tenant, userID, role, req
+func (_m *Service) CreateDeviceJob(ctx context.Context, tenant string, userID string, role string, req requests.DeviceJobCreate) (*models.DeviceJob, error) {
+	ret := _m.Called(ctx, tenant, userID, role, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeviceJob")
+	}
+
+	var r0 *models.DeviceJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, requests.DeviceJobCreate) (*models.DeviceJob, error)); ok {
+		return rf(ctx, tenant, userID, role, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, requests.DeviceJobCreate) *models.DeviceJob); ok {
+		r0 = rf(ctx, tenant, userID, role, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, requests.DeviceJobCreate) error); ok {
+		r1 = rf(ctx, tenant, userID, role, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDevicePublicURLService provides a mock function with given fields: ctx, tenant, uid, req
+func (_m *Service) CreateDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, req requests.DevicePublicURLServiceFields) (*models.DevicePublicURLService, error) {
+	ret := _m.Called(ctx, tenant, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDevicePublicURLService")
+	}
+
+	var r0 *models.DevicePublicURLService
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, requests.DevicePublicURLServiceFields) (*models.DevicePublicURLService, error)); ok {
+		return rf(ctx, tenant, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, requests.DevicePublicURLServiceFields) *models.DevicePublicURLService); ok {
+		r0 = rf(ctx, tenant, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DevicePublicURLService)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, requests.DevicePublicURLServiceFields) error); ok {
+		r1 = rf(ctx, tenant, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDevicePublishedPort provides a mock function with given fields: ctx, tenant, uid, req
+func (_m *Service) CreateDevicePublishedPort(ctx context.Context, tenant string, uid models.UID, req requests.DevicePublishedPortCreate) (*models.DevicePublishedPort, error) {
+	ret := _m.Called(ctx, tenant, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDevicePublishedPort")
+	}
+
+	var r0 *models.DevicePublishedPort
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, requests.DevicePublishedPortCreate) (*models.DevicePublishedPort, error)); ok {
+		return rf(ctx, tenant, uid, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, requests.DevicePublishedPortCreate) *models.DevicePublishedPort); ok {
+		r0 = rf(ctx, tenant, uid, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DevicePublishedPort)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, requests.DevicePublishedPortCreate) error); ok {
+		r1 = rf(ctx, tenant, uid, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDevicePublishedPortCertificate provides a mock function with given fields: ctx, tenant, id, req
+func (_m *Service) CreateDevicePublishedPortCertificate(ctx context.Context, tenant string, id string, req requests.DevicePublishedPortCertificate) (*models.DevicePublishedPortCertificate, error) {
+	ret := _m.Called(ctx, tenant, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDevicePublishedPortCertificate")
+	}
+
+	var r0 *models.DevicePublishedPortCertificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.DevicePublishedPortCertificate) (*models.DevicePublishedPortCertificate, error)); ok {
+		return rf(ctx, tenant, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.DevicePublishedPortCertificate) *models.DevicePublishedPortCertificate); ok {
+		r0 = rf(ctx, tenant, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DevicePublishedPortCertificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.DevicePublishedPortCertificate) error); ok {
+		r1 = rf(ctx, tenant, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDevicePublishedPortToken provides a mock function with given fields: ctx, tenant, id, uid, address
+func (_m *Service) CreateDevicePublishedPortToken(ctx context.Context, tenant string, id string, uid models.UID, address string) (*models.DevicePublishedPortToken, error) {
+	ret := _m.Called(ctx, tenant, id, uid, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDevicePublishedPortToken")
+	}
+
+	var r0 *models.DevicePublishedPortToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.UID, string) (*models.DevicePublishedPortToken, error)); ok {
+		return rf(ctx, tenant, id, uid, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.UID, string) *models.DevicePublishedPortToken); ok {
+		r0 = rf(ctx, tenant, id, uid, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DevicePublishedPortToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.UID, string) error); ok {
+		r1 = rf(ctx, tenant, id, uid, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -530,8 +915,38 @@ func (_m *Service) CreateSession(ctx context.Context, session requests.SessionCr
 	return r0, r1
 }
 
-// DeactivateSession provides a mock function with given fields: ctx, uid
-func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
+// CreateUserCertificate provides a mock function with given fields: ctx, tenant, id, req
+func (_m *Service) CreateUserCertificate(ctx context.Context, tenant string, id string, req requests.UserCertificateCreate) (*models.UserCertificate, error) {
+	ret := _m.Called(ctx, tenant, id, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUserCertificate")
+	}
+
+	var r0 *models.UserCertificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.UserCertificateCreate) (*models.UserCertificate, error)); ok {
+		return rf(ctx, tenant, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.UserCertificateCreate) *models.UserCertificate); ok {
+		r0 = rf(ctx, tenant, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserCertificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.UserCertificateCreate) error); ok {
+		r1 = rf(ctx, tenant, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateSession provides a mock function with given fields: ctx, uid
+func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
 
 	if len(ret) == 0 {
@@ -566,6 +981,60 @@ func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant stri
 	return r0
 }
 
+// DeleteDeviceGroup provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteDeviceGroup(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeviceGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDevicePublicURLService provides a mock function with given fields: ctx, tenant, uid, address
+func (_m *Service) DeleteDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, address string) error {
+	ret := _m.Called(ctx, tenant, uid, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDevicePublicURLService")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) error); ok {
+		r0 = rf(ctx, tenant, uid, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteDevicePublishedPort provides a mock function with given fields: ctx, tenant, uid, address
+func (_m *Service) DeleteDevicePublishedPort(ctx context.Context, tenant string, uid models.UID, address string) error {
+	ret := _m.Called(ctx, tenant, uid, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDevicePublishedPort")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) error); ok {
+		r0 = rf(ctx, tenant, uid, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteNamespace provides a mock function with given fields: ctx, tenantID
 func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
 	ret := _m.Called(ctx, tenantID)
@@ -620,6 +1089,24 @@ func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) err
 	return r0
 }
 
+// DeleteWebAuthnCredential provides a mock function with given fields: ctx, userID, id
+func (_m *Service) DeleteWebAuthnCredential(ctx context.Context, userID string, id string) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebAuthnCredential")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceHeartbeat provides a mock function with given fields: ctx, uid
 func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -638,6 +1125,42 @@ func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
+// EditAgentForwardingStatus provides a mock function with given fields: ctx, agentForwarding, tenantID
+func (_m *Service) EditAgentForwardingStatus(ctx context.Context, agentForwarding bool, tenantID string) error {
+	ret := _m.Called(ctx, agentForwarding, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditAgentForwardingStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
+		r0 = rf(ctx, agentForwarding, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditMetricsThresholds provides a mock function with given fields: ctx, thresholds, tenantID
+func (_m *Service) EditMetricsThresholds(ctx context.Context, thresholds []requests.MetricsThreshold, tenantID string) error {
+	ret := _m.Called(ctx, thresholds, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditMetricsThresholds")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []requests.MetricsThreshold, string) error); ok {
+		r0 = rf(ctx, thresholds, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EditNamespace provides a mock function with given fields: ctx, tenantID, name
 func (_m *Service) EditNamespace(ctx context.Context, tenantID string, name string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID, name)
@@ -704,6 +1227,34 @@ func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bo
 	return r0
 }
 
+// EvaluateAgentForwarding provides a mock function with given fields: ctx, tenantID, username
+func (_m *Service) EvaluateAgentForwarding(ctx context.Context, tenantID string, username string) (bool, error) {
+	ret := _m.Called(ctx, tenantID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateAgentForwarding")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, tenantID, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, tenantID, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenantID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
 func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
 	ret := _m.Called(ctx, key, dev)
@@ -760,6 +1311,126 @@ func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKe
 	return r0, r1
 }
 
+// FinishWebAuthnLogin provides a mock function with given fields: ctx, userID, ceremony, response
+func (_m *Service) FinishWebAuthnLogin(ctx context.Context, userID string, ceremony string, response []byte) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, userID, ceremony, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishWebAuthnLogin")
+	}
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, userID, ceremony, response)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, userID, ceremony, response)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, userID, ceremony, response)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishWebAuthnPasswordlessLogin provides a mock function with given fields: ctx, ceremony, response, sourceIP
+func (_m *Service) FinishWebAuthnPasswordlessLogin(ctx context.Context, ceremony string, response []byte, sourceIP string) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, ceremony, response, sourceIP)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishWebAuthnPasswordlessLogin")
+	}
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, string) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, ceremony, response, sourceIP)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, string) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, ceremony, response, sourceIP)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []byte, string) error); ok {
+		r1 = rf(ctx, ceremony, response, sourceIP)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishWebAuthnRegistration provides a mock function with given fields: ctx, userID, ceremony, name, response
+func (_m *Service) FinishWebAuthnRegistration(ctx context.Context, userID string, ceremony string, name string, response []byte) (*models.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, userID, ceremony, name, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishWebAuthnRegistration")
+	}
+
+	var r0 *models.WebAuthnCredential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []byte) (*models.WebAuthnCredential, error)); ok {
+		return rf(ctx, userID, ceremony, name, response)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []byte) *models.WebAuthnCredential); ok {
+		r0 = rf(ctx, userID, ceremony, name, response)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebAuthnCredential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []byte) error); ok {
+		r1 = rf(ctx, userID, ceremony, name, response)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCertificateAuthority provides a mock function with given fields: ctx, tenant
+func (_m *Service) GetCertificateAuthority(ctx context.Context, tenant string) (*models.CertificateAuthority, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificateAuthority")
+	}
+
+	var r0 *models.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CertificateAuthority, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CertificateAuthority); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: ctx, uid
 func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
 	ret := _m.Called(ctx, uid)
@@ -790,6 +1461,36 @@ func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Devic
 	return r0, r1
 }
 
+// GetDeviceAvailability provides a mock function with given fields: ctx, tenant, uid, from, to
+func (_m *Service) GetDeviceAvailability(ctx context.Context, tenant string, uid models.UID, from time.Time, to time.Time) (*models.DeviceAvailability, error) {
+	ret := _m.Called(ctx, tenant, uid, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceAvailability")
+	}
+
+	var r0 *models.DeviceAvailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, time.Time, time.Time) (*models.DeviceAvailability, error)); ok {
+		return rf(ctx, tenant, uid, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, time.Time, time.Time) *models.DeviceAvailability); ok {
+		r0 = rf(ctx, tenant, uid, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceAvailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, tenant, uid, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDeviceByPublicURLAddress provides a mock function with given fields: ctx, address
 func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
 	ret := _m.Called(ctx, address)
@@ -820,6 +1521,66 @@ func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address stri
 	return r0, r1
 }
 
+// GetDeviceGroup provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetDeviceGroup(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceGroup")
+	}
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceJob provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetDeviceJob(ctx context.Context, tenant string, id string) (*models.DeviceJob, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceJob")
+	}
+
+	var r0 *models.DeviceJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceJob, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceJob); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNamespace provides a mock function with given fields: ctx, tenantID
 func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID)
@@ -968,6 +1729,36 @@ func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
 	return r0, r1
 }
 
+// GetTagAvailability provides a mock function with given fields: ctx, tenant, tag, from, to
+func (_m *Service) GetTagAvailability(ctx context.Context, tenant string, tag string, from time.Time, to time.Time) (*models.TagAvailability, error) {
+	ret := _m.Called(ctx, tenant, tag, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagAvailability")
+	}
+
+	var r0 *models.TagAvailability
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) (*models.TagAvailability, error)); ok {
+		return rf(ctx, tenant, tag, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) *models.TagAvailability); ok {
+		r0 = rf(ctx, tenant, tag, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TagAvailability)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, tenant, tag, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTags provides a mock function with given fields: ctx, tenant
 func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, error) {
 	ret := _m.Called(ctx, tenant)
@@ -1005,22 +1796,244 @@ func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, e
 	return r0, r1, r2
 }
 
-// KeepAliveSession provides a mock function with given fields: ctx, uid
-func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// IsUserSessionRevoked provides a mock function with given fields: ctx, id
+func (_m *Service) IsUserSessionRevoked(ctx context.Context, id string) (bool, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JWKS provides a mock function with given fields: ctx
+func (_m *Service) JWKS(ctx context.Context) *keyring.JWKS {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JWKS")
+	}
+
+	var r0 *keyring.JWKS
+	if rf, ok := ret.Get(0).(func(context.Context) *keyring.JWKS); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*keyring.JWKS)
+		}
+	}
+
+	return r0
+}
+
+// KeepAliveSession provides a mock function with given fields: ctx, uid
+func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KeepAliveSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListDeviceAlerts provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListDeviceAlerts(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAlert, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceAlerts")
+	}
+
+	var r0 []models.DeviceAlert
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.DeviceAlert, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.DeviceAlert); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceAlert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceGroupDevices provides a mock function with given fields: ctx, tenant, id, pagination
+func (_m *Service) ListDeviceGroupDevices(ctx context.Context, tenant string, id string, pagination paginator.Query) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, tenant, id, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceGroupDevices")
+	}
+
+	var r0 []models.Device
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.Device, int, error)); ok {
+		return rf(ctx, tenant, id, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.Device); ok {
+		r0 = rf(ctx, tenant, id, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, id, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, id, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceGroups provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListDeviceGroups(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceGroups")
+	}
+
+	var r0 []models.DeviceGroup
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.DeviceGroup, int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.DeviceGroup); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceInventory provides a mock function with given fields: ctx, tenant, uid, pagination
+func (_m *Service) ListDeviceInventory(ctx context.Context, tenant string, uid models.UID, pagination paginator.Query) ([]models.DeviceInventory, int, error) {
+	ret := _m.Called(ctx, tenant, uid, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceInventory")
+	}
+
+	var r0 []models.DeviceInventory
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, paginator.Query) ([]models.DeviceInventory, int, error)); ok {
+		return rf(ctx, tenant, uid, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, paginator.Query) []models.DeviceInventory); ok {
+		r0 = rf(ctx, tenant, uid, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceInventory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, uid, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, uid, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceMetrics provides a mock function with given fields: ctx, tenant, uid, resolution, from, to
+func (_m *Service) ListDeviceMetrics(ctx context.Context, tenant string, uid models.UID, resolution models.DeviceMetricsResolution, from time.Time, to time.Time) ([]models.DeviceMetricsPoint, error) {
+	ret := _m.Called(ctx, tenant, uid, resolution, from, to)
 
 	if len(ret) == 0 {
-		panic("no return value specified for KeepAliveSession")
+		panic("no return value specified for ListDeviceMetrics")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	var r0 []models.DeviceMetricsPoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, models.DeviceMetricsResolution, time.Time, time.Time) ([]models.DeviceMetricsPoint, error)); ok {
+		return rf(ctx, tenant, uid, resolution, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, models.DeviceMetricsResolution, time.Time, time.Time) []models.DeviceMetricsPoint); ok {
+		r0 = rf(ctx, tenant, uid, resolution, from, to)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceMetricsPoint)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, models.DeviceMetricsResolution, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, tenant, uid, resolution, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // ListDevices provides a mock function with given fields: ctx, tenant, pagination, filter, status, sort, order
@@ -1134,9 +2147,39 @@ func (_m *Service) ListPublicKeys(ctx context.Context, pagination paginator.Quer
 	return r0, r1, r2
 }
 
-// ListSessions provides a mock function with given fields: ctx, pagination
-func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, pagination)
+// ListPublicKeysByFingerprint provides a mock function with given fields: ctx, fingerprint
+func (_m *Service) ListPublicKeysByFingerprint(ctx context.Context, fingerprint string) ([]models.PublicKey, error) {
+	ret := _m.Called(ctx, fingerprint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublicKeysByFingerprint")
+	}
+
+	var r0 []models.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.PublicKey, error)); ok {
+		return rf(ctx, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.PublicKey); ok {
+		r0 = rf(ctx, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSessions provides a mock function with given fields: ctx, pagination, filter, sort, order
+func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query, filter []models.Filter, sort string, order string) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, pagination, filter, sort, order)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListSessions")
@@ -1145,25 +2188,25 @@ func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query)
 	var r0 []models.Session
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.Session, int, error)); ok {
-		return rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, []models.Filter, string, string) ([]models.Session, int, error)); ok {
+		return rf(ctx, pagination, filter, sort, order)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Session); ok {
-		r0 = rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, []models.Filter, string, string) []models.Session); ok {
+		r0 = rf(ctx, pagination, filter, sort, order)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
-		r1 = rf(ctx, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, []models.Filter, string, string) int); ok {
+		r1 = rf(ctx, pagination, filter, sort, order)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
-		r2 = rf(ctx, pagination)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, []models.Filter, string, string) error); ok {
+		r2 = rf(ctx, pagination, filter, sort, order)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1171,6 +2214,62 @@ func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query)
 	return r0, r1, r2
 }
 
+// ListUserSessions provides a mock function with given fields: ctx, userID, current
+func (_m *Service) ListUserSessions(ctx context.Context, userID string, current string) ([]models.UserSession, error) {
+	ret := _m.Called(ctx, userID, current)
+
+	var r0 []models.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.UserSession, error)); ok {
+		return rf(ctx, userID, current)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.UserSession); ok {
+		r0 = rf(ctx, userID, current)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, current)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebAuthnCredentials provides a mock function with given fields: ctx, userID
+func (_m *Service) ListWebAuthnCredentials(ctx context.Context, userID string) ([]models.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebAuthnCredentials")
+	}
+
+	var r0 []models.WebAuthnCredential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.WebAuthnCredential, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.WebAuthnCredential); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebAuthnCredential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LookupDevice provides a mock function with given fields: ctx, namespace, name
 func (_m *Service) LookupDevice(ctx context.Context, namespace string, name string) (*models.Device, error) {
 	ret := _m.Called(ctx, namespace, name)
@@ -1201,6 +2300,36 @@ func (_m *Service) LookupDevice(ctx context.Context, namespace string, name stri
 	return r0, r1
 }
 
+// LookupNamespace provides a mock function with given fields: ctx, name
+func (_m *Service) LookupNamespace(ctx context.Context, name string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupNamespace")
+	}
+
+	var r0 *models.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Namespace, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Namespace); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OffineDevice provides a mock function with given fields: ctx, uid, online
 func (_m *Service) OffineDevice(ctx context.Context, uid models.UID, online bool) error {
 	ret := _m.Called(ctx, uid, online)
@@ -1219,21 +2348,19 @@ func (_m *Service) OffineDevice(ctx context.Context, uid models.UID, online bool
 	return r0
 }
 
-// PublicKey provides a mock function with given fields:
-func (_m *Service) PublicKey() *rsa.PublicKey {
-	ret := _m.Called()
+// RemoveDeviceLabel provides a mock function with given fields: ctx, tenant, uid, key
+func (_m *Service) RemoveDeviceLabel(ctx context.Context, tenant string, uid models.UID, key string) error {
+	ret := _m.Called(ctx, tenant, uid, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PublicKey")
+		panic("no return value specified for RemoveDeviceLabel")
 	}
 
-	var r0 *rsa.PublicKey
-	if rf, ok := ret.Get(0).(func() *rsa.PublicKey); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string) error); ok {
+		r0 = rf(ctx, tenant, uid, key)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*rsa.PublicKey)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
@@ -1341,6 +2468,106 @@ func (_m *Service) RenameTag(ctx context.Context, tenant string, oldTag string,
 	return r0
 }
 
+// RenameWebAuthnCredential provides a mock function with given fields: ctx, userID, id, name
+func (_m *Service) RenameWebAuthnCredential(ctx context.Context, userID string, id string, name string) error {
+	ret := _m.Called(ctx, userID, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameWebAuthnCredential")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, id, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReportDeviceInventory provides a mock function with given fields: ctx, uid, req
+func (_m *Service) ReportDeviceInventory(ctx context.Context, uid models.UID, req requests.DeviceInventoryReport) error {
+	ret := _m.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportDeviceInventory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, requests.DeviceInventoryReport) error); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReportDeviceMetrics provides a mock function with given fields: ctx, uid, req
+func (_m *Service) ReportDeviceMetrics(ctx context.Context, uid models.UID, req requests.DeviceMetricsReport) error {
+	ret := _m.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportDeviceMetrics")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, requests.DeviceMetricsReport) error); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserSession provides a mock function with given fields: ctx, userID, id
+func (_m *Service) RevokeUserSession(ctx context.Context, userID string, id string) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserSessions provides a mock function with given fields: ctx, userID, except
+func (_m *Service) RevokeUserSessions(ctx context.Context, userID string, except string) error {
+	ret := _m.Called(ctx, userID, except)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, except)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RunDeviceJob provides a mock function with given fields: ctx, id
+func (_m *Service) RunDeviceJob(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunDeviceJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDevicePosition provides a mock function with given fields: ctx, uid, ip
 func (_m *Service) SetDevicePosition(ctx context.Context, uid models.UID, ip string) error {
 	ret := _m.Called(ctx, uid, ip)
@@ -1512,6 +2739,84 @@ func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.U
 	return r0
 }
 
+// UpdateDeviceGroup provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) UpdateDeviceGroup(ctx context.Context, tenant string, req requests.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceGroup")
+	}
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.DeviceGroupUpdate) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.DeviceGroupUpdate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.DeviceGroupUpdate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDeviceLabels provides a mock function with given fields: ctx, tenant, uid, labels
+func (_m *Service) UpdateDeviceLabels(ctx context.Context, tenant string, uid models.UID, labels map[string]string) error {
+	ret := _m.Called(ctx, tenant, uid, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceLabels")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, tenant, uid, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDevicePublicURLService provides a mock function with given fields: ctx, tenant, uid, address, req
+func (_m *Service) UpdateDevicePublicURLService(ctx context.Context, tenant string, uid models.UID, address string, req requests.DevicePublicURLServiceFields) (*models.DevicePublicURLService, error) {
+	ret := _m.Called(ctx, tenant, uid, address, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDevicePublicURLService")
+	}
+
+	var r0 *models.DevicePublicURLService
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string, requests.DevicePublicURLServiceFields) (*models.DevicePublicURLService, error)); ok {
+		return rf(ctx, tenant, uid, address, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string, requests.DevicePublicURLServiceFields) *models.DevicePublicURLService); ok {
+		r0 = rf(ctx, tenant, uid, address, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DevicePublicURLService)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, string, requests.DevicePublicURLServiceFields) error); ok {
+		r1 = rf(ctx, tenant, uid, address, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDeviceStatus provides a mock function with given fields: ctx, tenant, uid, status
 func (_m *Service) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
 	ret := _m.Called(ctx, tenant, uid, status)
@@ -1548,17 +2853,13 @@ func (_m *Service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []s
 	return r0
 }
 
-// UpdatePasswordUser provides a mock function with given fields: ctx, id, currentPassword, newPassword
-func (_m *Service) UpdatePasswordUser(ctx context.Context, id string, currentPassword string, newPassword string) error {
-	ret := _m.Called(ctx, id, currentPassword, newPassword)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdatePasswordUser")
-	}
+// UpdatePasswordUser provides a mock function with given fields: ctx, id, session, currentPassword, newPassword
+func (_m *Service) UpdatePasswordUser(ctx context.Context, id string, session string, currentPassword string, newPassword string) error {
+	ret := _m.Called(ctx, id, session, currentPassword, newPassword)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, id, currentPassword, newPassword)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, id, session, currentPassword, newPassword)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1614,6 +2915,36 @@ func (_m *Service) UpdatePublicKeyTags(ctx context.Context, tenant string, finge
 	return r0
 }
 
+// VerificationKey provides a mock function with given fields: ctx, kid
+func (_m *Service) VerificationKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	ret := _m.Called(ctx, kid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerificationKey")
+	}
+
+	var r0 *rsa.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*rsa.PublicKey, error)); ok {
+		return rf(ctx, kid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *rsa.PublicKey); ok {
+		r0 = rf(ctx, kid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*rsa.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, kid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
@@ -18,6 +18,7 @@ type NamespaceService interface {
 	ListNamespaces(ctx context.Context, pagination paginator.Query, filter []models.Filter, export bool) ([]models.Namespace, int, error)
 	CreateNamespace(ctx context.Context, namespace requests.NamespaceCreate, userID string) (*models.Namespace, error)
 	GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error)
+	LookupNamespace(ctx context.Context, name string) (*models.Namespace, error)
 	DeleteNamespace(ctx context.Context, tenantID string) error
 	EditNamespace(ctx context.Context, tenantID, name string) (*models.Namespace, error)
 	AddNamespaceUser(ctx context.Context, memberUsername, memberRole, tenantID, userID string) (*models.Namespace, error)
@@ -25,6 +26,9 @@ type NamespaceService interface {
 	EditNamespaceUser(ctx context.Context, tenantID, userID, memberID, memberNewRole string) error
 	EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error
 	GetSessionRecord(ctx context.Context, tenantID string) (bool, error)
+	EditAgentForwardingStatus(ctx context.Context, agentForwarding bool, tenantID string) error
+	EvaluateAgentForwarding(ctx context.Context, tenantID, username string) (bool, error)
+	EditMetricsThresholds(ctx context.Context, thresholds []requests.MetricsThreshold, tenantID string) error
 }
 
 // ListNamespaces lists selected namespaces from a user.
@@ -132,6 +136,19 @@ func (s *service) GetNamespace(ctx context.Context, tenantID string) (*models.Na
 	return namespace, nil
 }
 
+// LookupNamespace gets a namespace by its name.
+//
+// Unlike GetNamespace, it doesn't fill the members' data, as it is used by the internal services only to resolve the
+// namespace's tenant.
+func (s *service) LookupNamespace(ctx context.Context, name string) (*models.Namespace, error) {
+	namespace, err := s.store.NamespaceGetByName(ctx, name)
+	if err != nil || namespace == nil {
+		return nil, NewErrNamespaceNotFound(name, err)
+	}
+
+	return namespace, nil
+}
+
 // DeleteNamespace deletes a namespace.
 //
 // It receives a context, used to "control" the request flow and the tenant ID from models.Namespace.
@@ -385,3 +402,77 @@ func (s *service) GetSessionRecord(ctx context.Context, tenantID string) (bool,
 
 	return s.store.NamespaceGetSessionRecord(ctx, tenantID)
 }
+
+// EditAgentForwardingStatus defines if the namespace's members can forward their SSH agent to the devices.
+//
+// It receives a context, used to "control" the request flow, a boolean to define if the agent forwarding is allowed and
+// the tenant ID from models.Namespace.
+func (s *service) EditAgentForwardingStatus(ctx context.Context, agentForwarding bool, tenantID string) error {
+	return s.store.NamespaceSetAgentForwarding(ctx, agentForwarding, tenantID)
+}
+
+// EvaluateAgentForwarding checks if a client can forward its SSH agent to the namespace's devices, what the SSH server
+// checks on each connection, so a change on the namespace's setting or on the member's role applies to the next ones.
+//
+// It receives a context, used to "control" the request flow, the tenant ID from models.Namespace and the username of
+// the member the client authenticated as, the one of the certificate's key ID. When the username is empty, the client
+// authenticated with a public key registered on the namespace, what isn't bound to a member, so only the namespace's
+// setting is checked, as the roles that can register them are allowed to forward the agent.
+func (s *service) EvaluateAgentForwarding(ctx context.Context, tenantID, username string) (bool, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil || namespace == nil {
+		return false, NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	if username == "" {
+		return agentForwardingEnabled(namespace), nil
+	}
+
+	user, err := s.store.UserGetByUsername(ctx, username)
+	if err != nil || user == nil {
+		return false, NewErrUserNotFound(username, err)
+	}
+
+	member, ok := namespace.FindMember(user.ID)
+	if !ok {
+		return false, nil
+	}
+
+	return canForwardAgent(namespace, member), nil
+}
+
+// agentForwardingEnabled checks if the namespace has enabled the agent forwarding.
+func agentForwardingEnabled(namespace *models.Namespace) bool {
+	return namespace.Settings != nil && namespace.Settings.AgentForwarding
+}
+
+// canForwardAgent checks if the namespace has enabled the agent forwarding and if the member's role is allowed to
+// forward its agent to the devices.
+func canForwardAgent(namespace *models.Namespace, member *models.Member) bool {
+	if !agentForwardingEnabled(namespace) {
+		return false
+	}
+
+	return guard.EvaluatePermission(member.Role, guard.Actions.Device.ForwardAgent, func() error { return nil }) == nil
+}
+
+// EditMetricsThresholds replaces the thresholds of the devices' metrics that raise alerts on the namespace.
+//
+// It receives a context, used to "control" the request flow, the thresholds, at most one for each metric, and the
+// tenant ID from models.Namespace.
+func (s *service) EditMetricsThresholds(ctx context.Context, thresholds []requests.MetricsThreshold, tenantID string) error {
+	list := make([]models.DeviceMetricsThreshold, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		list = append(list, models.DeviceMetricsThreshold{
+			Metric: models.DeviceMetric(threshold.Metric),
+			Value:  threshold.Value,
+		})
+	}
+
+	err := s.store.NamespaceSetMetricsThresholds(ctx, list, tenantID)
+	if err == store.ErrNoDocuments {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	return err
+}
@@ -339,6 +339,189 @@ func TestGetNamespace(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestLookupNamespace(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		namespace *models.Namespace
+		err       error
+	}
+
+	cases := []struct {
+		description   string
+		name          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when could not get the namespace",
+			name:        "group1",
+			requiredMocks: func() {
+				mock.On("NamespaceGetByName", ctx, "group1").Return(nil, errors.New("error")).Once()
+			},
+			expected: Expected{
+				namespace: nil,
+				err:       NewErrNamespaceNotFound("group1", errors.New("error")),
+			},
+		},
+		{
+			description: "succeeds",
+			name:        "group1",
+			requiredMocks: func() {
+				mock.On("NamespaceGetByName", ctx, "group1").Return(&models.Namespace{Name: "group1", TenantID: "a736a52b-5777-4f92-b0b8-e359bf484713"}, nil).Once()
+			},
+			expected: Expected{
+				namespace: &models.Namespace{Name: "group1", TenantID: "a736a52b-5777-4f92-b0b8-e359bf484713"},
+				err:       nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			namespace, err := service.LookupNamespace(ctx, tc.name)
+			assert.Equal(t, tc.expected, Expected{namespace, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEvaluateAgentForwarding(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Settings: &models.NamespaceSettings{AgentForwarding: true},
+		Members: []models.Member{
+			{ID: "1", Role: guard.RoleOperator},
+			{ID: "2", Role: guard.RoleObserver},
+		},
+	}
+
+	type Expected struct {
+		allowed bool
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		username      string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when could not get the namespace",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			username:    "operator",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(nil, errors.New("error")).Once()
+			},
+			expected: Expected{
+				allowed: false,
+				err:     NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", errors.New("error")),
+			},
+		},
+		{
+			description: "fails when the namespace has not enabled the agent forwarding",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			username:    "",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{TenantID: "00000000-0000-4000-0000-000000000000", Settings: &models.NamespaceSettings{}}, nil).Once()
+			},
+			expected: Expected{
+				allowed: false,
+				err:     nil,
+			},
+		},
+		{
+			description: "fails when could not get the user",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			username:    "nonexistent",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("UserGetByUsername", ctx, "nonexistent").Return(nil, errors.New("error")).Once()
+			},
+			expected: Expected{
+				allowed: false,
+				err:     NewErrUserNotFound("nonexistent", errors.New("error")),
+			},
+		},
+		{
+			description: "fails when the user is not a member of the namespace",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			username:    "other",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("UserGetByUsername", ctx, "other").Return(&models.User{ID: "3"}, nil).Once()
+			},
+			expected: Expected{
+				allowed: false,
+				err:     nil,
+			},
+		},
+		{
+			description: "fails when the member's role is not allowed to forward the agent",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			username:    "observer",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("UserGetByUsername", ctx, "observer").Return(&models.User{ID: "2"}, nil).Once()
+			},
+			expected: Expected{
+				allowed: false,
+				err:     nil,
+			},
+		},
+		{
+			description: "succeeds when the member's role is allowed to forward the agent",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			username:    "operator",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+				mock.On("UserGetByUsername", ctx, "operator").Return(&models.User{ID: "1"}, nil).Once()
+			},
+			expected: Expected{
+				allowed: true,
+				err:     nil,
+			},
+		},
+		{
+			description: "succeeds when the namespace has enabled the agent forwarding for its public keys",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			username:    "",
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expected: Expected{
+				allowed: true,
+				err:     nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			allowed, err := service.EvaluateAgentForwarding(ctx, tc.tenant, tc.username)
+			assert.Equal(t, tc.expected, Expected{allowed, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestSetMemberData(t *testing.T) {
 	mock := new(mocks.Store)
 
@@ -2084,3 +2267,91 @@ func TestEditSessionRecord(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestEditAgentForwardingStatus(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description     string
+		tenantID        string
+		agentForwarding bool
+		requiredMocks   func()
+		expected        error
+	}{
+		{
+			description:     "fails when store fails to set the agent forwarding",
+			tenantID:        "xxxx",
+			agentForwarding: true,
+			requiredMocks: func() {
+				mock.On("NamespaceSetAgentForwarding", ctx, true, "xxxx").Return(errors.New("error")).Once()
+			},
+			expected: errors.New("error"),
+		},
+		{
+			description:     "succeeds",
+			tenantID:        "xxxx",
+			agentForwarding: true,
+			requiredMocks: func() {
+				mock.On("NamespaceSetAgentForwarding", ctx, true, "xxxx").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.EditAgentForwardingStatus(ctx, tc.agentForwarding, tc.tenantID)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEditMetricsThresholds(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	thresholds := []requests.MetricsThreshold{{Metric: "disk", Value: 90}}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			requiredMocks: func() {
+				mock.On("NamespaceSetMetricsThresholds", ctx, []models.DeviceMetricsThreshold{{Metric: models.DeviceMetricDisk, Value: 90}}, "xxxx").
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrNamespaceNotFound("xxxx", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("NamespaceSetMetricsThresholds", ctx, []models.DeviceMetricsThreshold{{Metric: models.DeviceMetricDisk, Value: 90}}, "xxxx").
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.EditMetricsThresholds(ctx, thresholds, "xxxx")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -3,6 +3,9 @@ package services
 import (
 	"crypto/rsa"
 
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/shellhub-io/shellhub/api/pkg/keyring"
+	"github.com/shellhub-io/shellhub/api/pkg/lockout"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
@@ -17,12 +20,17 @@ var _ Service = (*APIService)(nil)
 
 type service struct {
 	store     store.Store
-	privKey   *rsa.PrivateKey
-	pubKey    *rsa.PublicKey
+	keys      *keyring.Keyring
 	cache     cache.Cache
 	client    interface{}
 	locator   geoip.Locator
 	validator *validator.Validator
+	// lockout limits the failed authentication attempts.
+	lockout *lockout.Lockout
+	// webauthn is the relying party of the users' WebAuthn credentials. It is nil when the WebAuthn is disabled.
+	webauthn *webauthn.WebAuthn
+	// tasks enqueues the tasks run by the workers. It is nil when the workers are unavailable.
+	tasks TaskEnqueuer
 }
 
 //go:generate mockery --name Service --filename services.go
@@ -31,6 +39,12 @@ type Service interface {
 	TagsService
 	DeviceService
 	DeviceTags
+	DeviceLabels
+	DeviceInventoryService
+	DeviceMetricsService
+	DeviceAvailabilityService
+	DeviceGroupService
+	DeviceJobService
 	UserService
 	SSHKeysService
 	SSHKeysTagsService
@@ -40,16 +54,62 @@ type Service interface {
 	StatsService
 	SetupService
 	SystemService
+	CertificateService
+	UserSessionService
+	WebAuthnService
 }
 
-func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator) *APIService {
-	if privKey == nil || pubKey == nil {
-		var err error
-		privKey, pubKey, err = LoadKeys()
-		if err != nil {
-			panic(err)
+// Option configures the service.
+type Option func(*service)
+
+// WithKeyring sets the keyring that signs and verifies the API's tokens. Without it, the service uses only the private
+// key received by [NewService], loading it from the key file when nil.
+func WithKeyring(keys *keyring.Keyring) Option {
+	return func(s *service) {
+		s.keys = keys
+	}
+}
+
+// WithWebAuthn sets the WebAuthn's relying party used to register and assert the users' credentials. Without it, the
+// WebAuthn is disabled.
+func WithWebAuthn(relyingParty *webauthn.WebAuthn) Option {
+	return func(s *service) {
+		s.webauthn = relyingParty
+	}
+}
+
+// WithTaskEnqueuer sets the enqueuer of the tasks run by the workers. Without it, the device jobs cannot be created.
+func WithTaskEnqueuer(tasks TaskEnqueuer) Option {
+	return func(s *service) {
+		s.tasks = tasks
+	}
+}
+
+func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator, options ...Option) *APIService {
+	s := &service{
+		store:     store,
+		cache:     cache,
+		client:    c,
+		locator:   l,
+		validator: validator.New(),
+		lockout:   lockout.New(cache),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	if s.keys == nil {
+		if privKey == nil || pubKey == nil {
+			var err error
+			privKey, _, err = LoadKeys()
+			if err != nil {
+				panic(err)
+			}
 		}
+
+		s.keys = keyring.New(nil, privKey)
 	}
 
-	return &APIService{service: &service{store, privKey, pubKey, cache, c, l, validator.New()}}
+	return &APIService{service: s}
 }
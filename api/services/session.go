@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"net"
+	"strings"
 
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
@@ -11,7 +12,7 @@ import (
 )
 
 type SessionService interface {
-	ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error)
+	ListSessions(ctx context.Context, pagination paginator.Query, filter []models.Filter, sort string, order string) ([]models.Session, int, error)
 	GetSession(ctx context.Context, uid models.UID) (*models.Session, error)
 	CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error)
 	DeactivateSession(ctx context.Context, uid models.UID) error
@@ -19,8 +20,22 @@ type SessionService interface {
 	SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error
 }
 
-func (s *service) ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
-	return s.store.SessionList(ctx, pagination)
+func (s *service) ListSessions(ctx context.Context, pagination paginator.Query, filter []models.Filter, sort string, order string) ([]models.Session, int, error) {
+	// NOTICE: the source IP address can be filtered by a network in the CIDR notation, that must be valid.
+	for _, f := range filter {
+		params, ok := f.Params.(*models.PropertyParams)
+		if !ok || params.Name != "ip_address" {
+			continue
+		}
+
+		if value, ok := params.Value.(string); ok && strings.Contains(value, "/") {
+			if _, _, err := net.ParseCIDR(value); err != nil {
+				return nil, 0, NewErrSessionFilterInvalid(map[string]interface{}{"ip_address": value}, err)
+			}
+		}
+	}
+
+	return s.store.SessionList(ctx, pagination, filter, sort, order)
 }
 
 func (s *service) GetSession(ctx context.Context, uid models.UID) (*models.Session, error) {
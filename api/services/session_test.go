@@ -32,14 +32,34 @@ func TestListSessions(t *testing.T) {
 	cases := []struct {
 		name          string
 		pagination    paginator.Query
+		filters       []models.Filter
 		requiredMocks func(query paginator.Query)
 		expected      Expected
 	}{
+		{
+			name:       "fails when the source network is invalid",
+			pagination: paginator.Query{Page: 1, PerPage: 10},
+			filters: []models.Filter{
+				{
+					Type:   "property",
+					Params: &models.PropertyParams{Name: "ip_address", Operator: "eq", Value: "10.0.0.0/64"},
+				},
+			},
+			requiredMocks: func(_ paginator.Query) {},
+			expected: Expected{
+				sessions: nil,
+				count:    0,
+				err: NewErrSessionFilterInvalid(
+					map[string]interface{}{"ip_address": "10.0.0.0/64"},
+					&net.ParseError{Type: "CIDR address", Text: "10.0.0.0/64"},
+				),
+			},
+		},
 		{
 			name:       "fails",
 			pagination: paginator.Query{Page: 1, PerPage: 10},
 			requiredMocks: func(query paginator.Query) {
-				mock.On("SessionList", ctx, query).
+				mock.On("SessionList", ctx, query, []models.Filter(nil), "", "").
 					Return(nil, 0, goerrors.New("error")).Once()
 			},
 			expected: Expected{
@@ -57,7 +77,7 @@ func TestListSessions(t *testing.T) {
 					{UID: "uid2"},
 					{UID: "uid3"},
 				}
-				mock.On("SessionList", ctx, query).
+				mock.On("SessionList", ctx, query, []models.Filter(nil), "", "").
 					Return(sessions, len(sessions), nil).Once()
 			},
 			expected: Expected{
@@ -81,7 +101,7 @@ func TestListSessions(t *testing.T) {
 			tc.requiredMocks(tc.pagination)
 
 			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
-			returnedSessions, count, err := service.ListSessions(ctx, tc.pagination)
+			returnedSessions, count, err := service.ListSessions(ctx, tc.pagination, tc.filters, "", "")
 			assert.Equal(t, tc.expected, Expected{returnedSessions, count, err})
 		})
 	}
@@ -22,6 +22,7 @@ type SSHKeysService interface {
 	EvaluateKeyUsername(ctx context.Context, key *models.PublicKey, username string) (bool, error)
 	ListPublicKeys(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error)
 	GetPublicKey(ctx context.Context, fingerprint, tenant string) (*models.PublicKey, error)
+	ListPublicKeysByFingerprint(ctx context.Context, fingerprint string) ([]models.PublicKey, error)
 	CreatePublicKey(ctx context.Context, req requests.PublicKeyCreate, tenant string) (*responses.PublicKeyCreate, error)
 	UpdatePublicKey(ctx context.Context, fingerprint, tenant string, key requests.PublicKeyUpdate) (*models.PublicKey, error)
 	DeletePublicKey(ctx context.Context, fingerprint, tenant string) error
@@ -32,7 +33,7 @@ type Request struct {
 	Namespace string
 }
 
-func (s *service) EvaluateKeyFilter(_ context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
+func (s *service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
 	if key.Filter.Hostname != "" {
 		ok, err := regexp.MatchString(key.Filter.Hostname, dev.Name)
 		if err != nil {
@@ -48,6 +49,16 @@ func (s *service) EvaluateKeyFilter(_ context.Context, key *models.PublicKey, de
 		}
 
 		return false, nil
+	} else if key.Filter.Group != "" {
+		return s.deviceInGroup(ctx, key.Filter.Group, dev)
+	} else if len(key.Filter.Labels) > 0 {
+		for label, value := range key.Filter.Labels {
+			if v, ok := dev.Labels[label]; !ok || v != value {
+				return false, nil
+			}
+		}
+
+		return true, nil
 	}
 
 	return true, nil
@@ -75,6 +86,12 @@ func (s *service) GetPublicKey(ctx context.Context, fingerprint, tenant string)
 	return s.store.PublicKeyGet(ctx, fingerprint, tenant)
 }
 
+// ListPublicKeysByFingerprint lists the public keys identified by fingerprint on every namespace, what tells the
+// namespaces where the key's owner is allowed to connect.
+func (s *service) ListPublicKeysByFingerprint(ctx context.Context, fingerprint string) ([]models.PublicKey, error) {
+	return s.store.PublicKeyListByFingerprint(ctx, fingerprint)
+}
+
 func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCreate, tenant string) (*responses.PublicKeyCreate, error) {
 	// Checks if public key filter type is Tags.
 	// If it is, checks if there are, at least, one tag on the public key filter and if the all tags exist on database.
@@ -91,6 +108,12 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 		}
 	}
 
+	if req.Filter.Group != "" {
+		if _, err := s.store.DeviceGroupGet(ctx, tenant, req.Filter.Group); err != nil {
+			return nil, NewErrDeviceGroupNotFound(req.Filter.Group, err)
+		}
+	}
+
 	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(req.Data) //nolint:dogsled
 	if err != nil {
 		return nil, NewErrPublicKeyDataInvalid(req.Data, nil)
@@ -118,6 +141,8 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 			Filter: models.PublicKeyFilter{
 				Hostname: req.Filter.Hostname,
 				Tags:     req.Filter.Tags,
+				Group:    req.Filter.Group,
+				Labels:   req.Filter.Labels,
 			},
 		},
 	}
@@ -157,6 +182,12 @@ func (s *service) UpdatePublicKey(ctx context.Context, fingerprint, tenant strin
 		}
 	}
 
+	if key.Filter.Group != "" {
+		if _, err := s.store.DeviceGroupGet(ctx, tenant, key.Filter.Group); err != nil {
+			return nil, NewErrDeviceGroupNotFound(key.Filter.Group, err)
+		}
+	}
+
 	model := models.PublicKeyUpdate{
 		PublicKeyFields: models.PublicKeyFields{
 			Name:     key.Name,
@@ -164,6 +195,8 @@ func (s *service) UpdatePublicKey(ctx context.Context, fingerprint, tenant strin
 			Filter: models.PublicKeyFilter{
 				Hostname: key.Filter.Hostname,
 				Tags:     key.Filter.Tags,
+				Group:    key.Filter.Group,
+				Labels:   key.Filter.Labels,
 			},
 		},
 	}
@@ -30,6 +30,18 @@ func TestEvaluateKeyFilter(t *testing.T) {
 
 	ctx := context.TODO()
 
+	group := &models.DeviceGroup{
+		ID:       "group",
+		TenantID: "tenant",
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name: "arm64",
+			Filter: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "info.arch", Operator: "eq", Value: "arm64"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+			},
+		},
+	}
+
 	type Expected struct {
 		bool
 		error
@@ -106,6 +118,102 @@ func TestEvaluateKeyFilter(t *testing.T) {
 			},
 			expected: Expected{true, nil},
 		},
+		{
+			description: "fail to evaluate filter group when group does not exist",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Group: "group",
+					},
+				},
+			},
+			device: models.Device{UID: "uid", TenantID: "tenant"},
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "fail to evaluate filter group when device does not match the group",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Group: "group",
+					},
+				},
+			},
+			device: models.Device{UID: "uid", TenantID: "tenant"},
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				mock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: 1}, append([]models.Filter{
+						{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"}},
+						{Type: "property", Params: &models.PropertyParams{Name: "uid", Operator: "eq", Value: "uid"}},
+						{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+					}, group.Filter...), models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{}, 0, nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "success to evaluate filter group",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Group: "group",
+					},
+				},
+			},
+			device: models.Device{UID: "uid", TenantID: "tenant"},
+			requiredMocks: func() {
+				mock.On("DeviceGroupGet", ctx, "tenant", "group").Return(group, nil).Once()
+				mock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: 1}, append([]models.Filter{
+						{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"}},
+						{Type: "property", Params: &models.PropertyParams{Name: "uid", Operator: "eq", Value: "uid"}},
+						{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+					}, group.Filter...), models.DeviceStatus(""), "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid"}}, 1, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "fail to evaluate filter labels when device misses a label",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Labels: map[string]string{"site": "lisbon", "owner": "team-x"},
+					},
+				},
+			},
+			device:        models.Device{Labels: map[string]string{"site": "lisbon"}},
+			requiredMocks: func() {},
+			expected:      Expected{false, nil},
+		},
+		{
+			description: "fail to evaluate filter labels when a label value differs",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Labels: map[string]string{"site": "lisbon"},
+					},
+				},
+			},
+			device:        models.Device{Labels: map[string]string{"site": "porto"}},
+			requiredMocks: func() {},
+			expected:      Expected{false, nil},
+		},
+		{
+			description: "success to evaluate filter labels",
+			key: &models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Labels: map[string]string{"site": "lisbon"},
+					},
+				},
+			},
+			device:        models.Device{Labels: map[string]string{"site": "lisbon", "owner": "team-x"}},
+			requiredMocks: func() {},
+			expected:      Expected{true, nil},
+		},
 		{
 			description: "success to evaluate when key has no filter",
 			key: &models.PublicKey{
@@ -267,6 +375,55 @@ func TestGetPublicKeys(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestListPublicKeysByFingerprint(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	type Expected struct {
+		keys []models.PublicKey
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		fingerprint   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when could not list the keys",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				mock.On("PublicKeyListByFingerprint", ctx, "fingerprint").Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: Expected{nil, errors.New("error", "", 0)},
+		},
+		{
+			description: "succeeds when the keys are listed",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				mock.On("PublicKeyListByFingerprint", ctx, "fingerprint").
+					Return([]models.PublicKey{{Fingerprint: "fingerprint", TenantID: "tenant1"}, {Fingerprint: "fingerprint", TenantID: "tenant2"}}, nil).
+					Once()
+			},
+			expected: Expected{[]models.PublicKey{{Fingerprint: "fingerprint", TenantID: "tenant1"}, {Fingerprint: "fingerprint", TenantID: "tenant2"}}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+			keys, err := s.ListPublicKeysByFingerprint(ctx, tc.fingerprint)
+			assert.Equal(t, tc.expected, Expected{keys, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestUpdatePublicKeys(t *testing.T) {
 	mock := new(mocks.Store)
 
@@ -8,7 +8,9 @@ import (
 
 type UserService interface {
 	UpdateDataUser(ctx context.Context, id string, userData models.UserData) ([]string, error)
-	UpdatePasswordUser(ctx context.Context, id string, currentPassword, newPassword string) error
+	// UpdatePasswordUser updates the user's password, revoking every user's session except the one identified by
+	// session, when not empty.
+	UpdatePasswordUser(ctx context.Context, id, session string, currentPassword, newPassword string) error
 }
 
 // UpdateDataUser update user data.
@@ -50,7 +52,7 @@ func (s *service) UpdateDataUser(ctx context.Context, id string, userData models
 	})
 }
 
-func (s *service) UpdatePasswordUser(ctx context.Context, id, currentPassword, newPassword string) error {
+func (s *service) UpdatePasswordUser(ctx context.Context, id, session, currentPassword, newPassword string) error {
 	user, _, err := s.store.UserGetByID(ctx, id, false)
 	if user == nil {
 		return NewErrUserNotFound(id, err)
@@ -73,5 +75,10 @@ func (s *service) UpdatePasswordUser(ctx context.Context, id, currentPassword, n
 		return nil
 	}
 
-	return s.store.UserUpdatePassword(ctx, neo.HashedPassword, id)
+	if err := s.store.UserUpdatePassword(ctx, neo.HashedPassword, id); err != nil {
+		return err
+	}
+
+	// NOTICE: who knew the previous password must not keep logged in.
+	return s.RevokeUserSessions(ctx, id, session)
 }
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// AccessTokenLifetime is how long an user's access token is valid.
+	AccessTokenLifetime = 15 * time.Minute
+	// RefreshTokenLifetime is how long an user's session could be refreshed before a new login is required.
+	RefreshTokenLifetime = 30 * 24 * time.Hour
+)
+
+type UserSessionService interface {
+	// ListUserSessions lists the user's active sessions, marking the one identified by current.
+	ListUserSessions(ctx context.Context, userID, current string) ([]models.UserSession, error)
+	// RevokeUserSession revokes the user's session identified by id.
+	RevokeUserSession(ctx context.Context, userID, id string) error
+	// RevokeUserSessions revokes every user's session, except the one identified by except, when not empty.
+	RevokeUserSessions(ctx context.Context, userID, except string) error
+	// IsUserSessionRevoked checks if the session was revoked while its access tokens are still valid.
+	IsUserSessionRevoked(ctx context.Context, id string) (bool, error)
+}
+
+// newUserSession creates a session for the user, returning it with its refresh token.
+func (s *service) newUserSession(ctx context.Context, userID, tenant string, mfa bool, ip, userAgent string) (*models.UserSession, string, error) {
+	secret, hash, err := newRefreshSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := clock.Now()
+	session := &models.UserSession{
+		ID:               uuid.Generate(),
+		UserID:           userID,
+		TenantID:         tenant,
+		RefreshTokenHash: hash,
+		MFA:              mfa,
+		IPAddress:        ip,
+		UserAgent:        userAgent,
+		CreatedAt:        now,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(RefreshTokenLifetime),
+	}
+
+	if err := s.store.UserSessionCreate(ctx, session); err != nil {
+		return nil, "", err
+	}
+
+	return session, refreshToken(session.ID, secret), nil
+}
+
+// rotateUserSession checks the refresh token of a session and replaces it by a new one.
+//
+// When the refresh token is one already replaced by the rotation, it was used twice, what means it was stolen by
+// someone else or by the user. Either way, the session is revoked. Any other refresh token is only refused, so the
+// session's ID alone cannot be used to revoke it.
+func (s *service) rotateUserSession(ctx context.Context, token string) (*models.UserSession, string, error) {
+	id, secret, ok := strings.Cut(token, ".")
+	if !ok || id == "" || secret == "" {
+		return nil, "", NewErrAuthUnathorized(nil)
+	}
+
+	session, err := s.store.UserSessionGet(ctx, id)
+	if err != nil {
+		return nil, "", NewErrAuthUnathorized(err)
+	}
+
+	if clock.Now().After(session.ExpiresAt) {
+		return nil, "", NewErrAuthUnathorized(nil)
+	}
+
+	hash := hashRefreshSecret(secret)
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(session.RefreshTokenHash)) != 1 {
+		for _, rotated := range session.RotatedRefreshTokenHashes {
+			if subtle.ConstantTimeCompare([]byte(hash), []byte(rotated)) == 1 {
+				logrus.WithFields(logrus.Fields{
+					"session": session.ID,
+					"user":    session.UserID,
+				}).Warn("refresh token reused; revoking the session")
+
+				s.revokeUserSession(ctx, session.ID) // nolint: errcheck
+
+				break
+			}
+		}
+
+		return nil, "", NewErrAuthUnathorized(nil)
+	}
+
+	newSecret, newHash, err := newRefreshSecret()
+	if err != nil {
+		return nil, "", err
+	}
+
+	// NOTICE: the rotation only succeeds when the hash is still the current one, so a concurrent use of the same
+	// refresh token fails here.
+	if err := s.store.UserSessionRotate(ctx, session.ID, hash, newHash, clock.Now()); err != nil {
+		return nil, "", NewErrAuthUnathorized(err)
+	}
+
+	return session, refreshToken(session.ID, newSecret), nil
+}
+
+// revokeUserSession deletes the session and marks it as revoked while its access tokens are valid.
+func (s *service) revokeUserSession(ctx context.Context, id string) error {
+	if err := s.store.UserSessionDelete(ctx, id); err != nil {
+		return err
+	}
+
+	return s.cache.Set(ctx, "user_session_revoked_"+id, true, AccessTokenLifetime)
+}
+
+// revokeUnvalidatedUserSessions revokes the user's sessions whose MFA wasn't validated, like the ones started by the
+// password before the second factor.
+func (s *service) revokeUnvalidatedUserSessions(ctx context.Context, userID string) error {
+	sessions, err := s.store.UserSessionList(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.MFA {
+			continue
+		}
+
+		if err := s.revokeUserSession(ctx, session.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) ListUserSessions(ctx context.Context, userID, current string) ([]models.UserSession, error) {
+	sessions, err := s.store.UserSessionList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == current
+	}
+
+	return sessions, nil
+}
+
+func (s *service) RevokeUserSession(ctx context.Context, userID, id string) error {
+	session, err := s.store.UserSessionGet(ctx, id)
+	if err != nil || session.UserID != userID {
+		return NewErrNotFound(ErrUserSessionNotFound, id, err)
+	}
+
+	return s.revokeUserSession(ctx, session.ID)
+}
+
+func (s *service) RevokeUserSessions(ctx context.Context, userID, except string) error {
+	sessions, err := s.store.UserSessionList(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.ID == except {
+			continue
+		}
+
+		if err := s.revokeUserSession(ctx, session.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *service) IsUserSessionRevoked(ctx context.Context, id string) (bool, error) {
+	var revoked bool
+	if err := s.cache.Get(ctx, "user_session_revoked_"+id, &revoked); err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// newRefreshSecret generates a random secret for a refresh token, returning it with its hash.
+func newRefreshSecret() (string, string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", "", err
+	}
+
+	secret := base64.RawURLEncoding.EncodeToString(data)
+
+	return secret, hashRefreshSecret(secret), nil
+}
+
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// refreshToken builds the opaque refresh token sent to the user from the session's ID and the secret.
+func refreshToken(id, secret string) string {
+	return id + "." + secret
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestAuthRefreshToken(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	session := func() *models.UserSession {
+		return &models.UserSession{
+			ID:               "session",
+			UserID:           "id",
+			TenantID:         "tenant",
+			RefreshTokenHash: hashRefreshSecret("secret"),
+			MFA:              true,
+			ExpiresAt:        now.Add(time.Hour),
+		}
+	}
+
+	cases := []struct {
+		description   string
+		refreshToken  string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "fails when the refresh token is malformed",
+			refreshToken:  "invalid",
+			requiredMocks: func() {},
+			expected:      NewErrAuthUnathorized(nil),
+		},
+		{
+			description:  "fails when the session is not found",
+			refreshToken: "session.secret",
+			requiredMocks: func() {
+				mock.On("UserSessionGet", ctx, "session").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrAuthUnathorized(store.ErrNoDocuments),
+		},
+		{
+			description:  "fails when the session is expired",
+			refreshToken: "session.secret",
+			requiredMocks: func() {
+				expired := session()
+				expired.ExpiresAt = now.Add(-time.Hour)
+
+				mock.On("UserSessionGet", ctx, "session").Return(expired, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: NewErrAuthUnathorized(nil),
+		},
+		{
+			description:  "fails without revoking the session when the refresh token was never issued",
+			refreshToken: "session.garbage",
+			requiredMocks: func() {
+				mock.On("UserSessionGet", ctx, "session").Return(session(), nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: NewErrAuthUnathorized(nil),
+		},
+		{
+			description:  "fails and revokes the session when the refresh token was already used",
+			refreshToken: "session.used",
+			requiredMocks: func() {
+				used := session()
+				used.RotatedRefreshTokenHashes = []string{hashRefreshSecret("older"), hashRefreshSecret("used")}
+
+				mock.On("UserSessionGet", ctx, "session").Return(used, nil).Once()
+				mock.On("UserSessionDelete", ctx, "session").Return(nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: NewErrAuthUnathorized(nil),
+		},
+		{
+			description:  "fails when the refresh token is used concurrently",
+			refreshToken: "session.secret",
+			requiredMocks: func() {
+				mock.On("UserSessionGet", ctx, "session").Return(session(), nil).Once()
+				mock.On("UserSessionRotate", ctx, "session", hashRefreshSecret("secret"), gomock.AnythingOfType("string"), now).
+					Return(store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Twice()
+			},
+			expected: NewErrAuthUnathorized(store.ErrNoDocuments),
+		},
+		{
+			description:  "succeeds rotating the refresh token",
+			refreshToken: "session.secret",
+			requiredMocks: func() {
+				mock.On("UserSessionGet", ctx, "session").Return(session(), nil).Once()
+				mock.On("UserSessionRotate", ctx, "session", hashRefreshSecret("secret"), gomock.AnythingOfType("string"), now).
+					Return(nil).Once()
+				mock.On("UserGetByID", ctx, "id", false).Return(&models.User{
+					ID:       "id",
+					UserData: models.UserData{Username: "user"},
+				}, 1, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{
+					TenantID: "tenant",
+					Members:  []models.Member{{ID: "id", Role: "owner"}},
+				}, nil).Once()
+				mock.On("GetStatusMFA", ctx, "id").Return(true, nil).Once()
+				uuidMock.On("Generate").Return("random_uuid").Once()
+				clockMock.On("Now").Return(now).Times(4)
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			res, err := service.AuthRefreshToken(ctx, tc.refreshToken)
+			assert.Equal(t, tc.expected, err)
+
+			if tc.expected == nil {
+				assert.NotEmpty(t, res.Token)
+				assert.True(t, strings.HasPrefix(res.RefreshToken, "session."))
+				assert.NotEqual(t, tc.refreshToken, res.RefreshToken)
+				assert.Equal(t, "tenant", res.Tenant)
+				assert.Equal(t, "owner", res.Role)
+				assert.Equal(t, models.MFA{Enable: true, Validate: true}, res.MFA)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestRevokeUserSession(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		user          string
+		session       string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the session is not found",
+			user:        "id",
+			session:     "session",
+			requiredMocks: func() {
+				mock.On("UserSessionGet", ctx, "session").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrNotFound(ErrUserSessionNotFound, "session", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the session belongs to another user",
+			user:        "id",
+			session:     "session",
+			requiredMocks: func() {
+				mock.On("UserSessionGet", ctx, "session").Return(&models.UserSession{ID: "session", UserID: "other"}, nil).Once()
+			},
+			expected: NewErrNotFound(ErrUserSessionNotFound, "session", nil),
+		},
+		{
+			description: "succeeds to revoke the session",
+			user:        "id",
+			session:     "session",
+			requiredMocks: func() {
+				mock.On("UserSessionGet", ctx, "session").Return(&models.UserSession{ID: "session", UserID: "id"}, nil).Once()
+				mock.On("UserSessionDelete", ctx, "session").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.RevokeUserSession(ctx, tc.user, tc.session))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -245,6 +245,7 @@ func TestUpdatePasswordUser(t *testing.T) {
 	cases := []struct {
 		description     string
 		id              string
+		session         string
 		currentPassword string
 		newPassword     string
 		requiredMocks   func()
@@ -286,6 +287,27 @@ func TestUpdatePasswordUser(t *testing.T) {
 
 				mock.On("UserGetByID", ctx, "1", false).Return(user, 1, nil).Once()
 				mock.On("UserUpdatePassword", ctx, password.HashedPassword, "1").Return(nil).Once()
+				mock.On("UserSessionList", ctx, "1").Return([]models.UserSession{}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description:     "Success to update user's password revoking the other sessions",
+			id:              "1",
+			session:         "current",
+			currentPassword: "password",
+			newPassword:     "newPassword",
+			requiredMocks: func() {
+				user := &models.User{
+					UserPassword: models.NewUserPassword("password"),
+				}
+
+				password := models.NewUserPassword("newPassword")
+
+				mock.On("UserGetByID", ctx, "1", false).Return(user, 1, nil).Once()
+				mock.On("UserUpdatePassword", ctx, password.HashedPassword, "1").Return(nil).Once()
+				mock.On("UserSessionList", ctx, "1").Return([]models.UserSession{{ID: "current"}, {ID: "other"}}, nil).Once()
+				mock.On("UserSessionDelete", ctx, "other").Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -296,7 +318,7 @@ func TestUpdatePasswordUser(t *testing.T) {
 			tc.requiredMocks()
 
 			services := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
-			err := services.UpdatePasswordUser(ctx, tc.id, tc.currentPassword, tc.newPassword)
+			err := services.UpdatePasswordUser(ctx, tc.id, tc.session, tc.currentPassword, tc.newPassword)
 			assert.Equal(t, tc.expected, err)
 		})
 	}
@@ -0,0 +1,443 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/shellhub-io/shellhub/api/pkg/lockout"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// WebAuthnCeremonyTimeout is how long the user has to complete a WebAuthn registration or assertion.
+const WebAuthnCeremonyTimeout = 5 * time.Minute
+
+type WebAuthnService interface {
+	// BeginWebAuthnRegistration starts the registration of a new WebAuthn credential to the user, returning the
+	// ceremony's ID, to be sent back with the response, and the options to be sent to the authenticator.
+	BeginWebAuthnRegistration(ctx context.Context, userID string) (string, *protocol.CredentialCreation, error)
+	// FinishWebAuthnRegistration validates the authenticator's response to the registration and stores the credential
+	// with the name chosen by the user.
+	FinishWebAuthnRegistration(ctx context.Context, userID, ceremony, name string, response []byte) (*models.WebAuthnCredential, error)
+	ListWebAuthnCredentials(ctx context.Context, userID string) ([]models.WebAuthnCredential, error)
+	RenameWebAuthnCredential(ctx context.Context, userID, id, name string) error
+	DeleteWebAuthnCredential(ctx context.Context, userID, id string) error
+	// BeginWebAuthnLogin starts an assertion with the user's credentials, used as the second factor after the
+	// password. It returns the ceremony's ID, to be sent back with the assertion.
+	BeginWebAuthnLogin(ctx context.Context, userID string) (string, *protocol.CredentialAssertion, error)
+	// FinishWebAuthnLogin validates the authenticator's assertion and, like [AuthService.AuthGetToken] after the TOTP,
+	// issues a token with the MFA validated.
+	//
+	// The failed assertions are limited like the TOTP's attempts, returning [ErrAuthLocked] when the user must wait.
+	FinishWebAuthnLogin(ctx context.Context, userID, ceremony string, response []byte) (*models.UserAuthResponse, error)
+	// BeginWebAuthnPasswordlessLogin starts an assertion with any discoverable credential, like a passkey, to login
+	// without the password. It returns the ceremony's ID, to be sent back with the assertion.
+	BeginWebAuthnPasswordlessLogin(ctx context.Context) (string, *protocol.CredentialAssertion, error)
+	// FinishWebAuthnPasswordlessLogin validates the authenticator's assertion and identifies the user by the
+	// credential. As the authenticator verifies the user, the token is issued with the MFA validated.
+	//
+	// The failed assertions are limited per sourceIP and user, like [AuthService.AuthUser], returning [ErrAuthLocked]
+	// when they must wait.
+	FinishWebAuthnPasswordlessLogin(ctx context.Context, ceremony string, response []byte, sourceIP string) (*models.UserAuthResponse, error)
+}
+
+// webauthnUser is a user as seen by the WebAuthn's relying party.
+type webauthnUser struct {
+	user        *models.User
+	credentials []models.WebAuthnCredential
+}
+
+func (u *webauthnUser) WebAuthnID() []byte {
+	return []byte(u.user.ID)
+}
+
+func (u *webauthnUser) WebAuthnName() string {
+	return u.user.Username
+}
+
+func (u *webauthnUser) WebAuthnDisplayName() string {
+	if u.user.Name != "" {
+		return u.user.Name
+	}
+
+	return u.user.Username
+}
+
+func (u *webauthnUser) WebAuthnIcon() string {
+	return ""
+}
+
+func (u *webauthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.credentials))
+	for _, credential := range u.credentials {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(credential.Transports))
+		for _, transport := range credential.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+
+		credentials = append(credentials, webauthn.Credential{
+			ID:              credential.CredentialID,
+			PublicKey:       credential.PublicKey,
+			AttestationType: credential.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: credential.BackupEligible,
+				BackupState:    credential.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    credential.AAGUID,
+				SignCount: credential.SignCount,
+			},
+		})
+	}
+
+	return credentials
+}
+
+// find returns the user's credential identified by the authenticator's credential ID.
+func (u *webauthnUser) find(id []byte) *models.WebAuthnCredential {
+	for i := range u.credentials {
+		if bytes.Equal(u.credentials[i].CredentialID, id) {
+			return &u.credentials[i]
+		}
+	}
+
+	return nil
+}
+
+func (u *webauthnUser) descriptors() []protocol.CredentialDescriptor {
+	descriptors := make([]protocol.CredentialDescriptor, 0, len(u.credentials))
+	for _, credential := range u.WebAuthnCredentials() {
+		descriptors = append(descriptors, credential.Descriptor())
+	}
+
+	return descriptors
+}
+
+func (s *service) getWebAuthnUser(ctx context.Context, userID string) (*webauthnUser, error) {
+	user, _, err := s.store.UserGetByID(ctx, userID, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(userID, err)
+	}
+
+	credentials, err := s.store.WebAuthnCredentialList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webauthnUser{user: user, credentials: credentials}, nil
+}
+
+// saveWebAuthnCeremony keeps the ceremony's data until the authenticator's response under a random ID, returned to be
+// sent back with the response. The ID is random, so a new ceremony doesn't replace other ones of the same user.
+func (s *service) saveWebAuthnCeremony(ctx context.Context, kind string, data *webauthn.SessionData) (string, error) {
+	ceremony := uuid.Generate()
+	if err := s.cache.Set(ctx, "webauthn_"+kind+"_"+ceremony, data, WebAuthnCeremonyTimeout); err != nil {
+		return "", err
+	}
+
+	return ceremony, nil
+}
+
+// loadWebAuthnCeremony gets the ceremony's data, removing it, as each challenge is valid for a single response.
+//
+// NOTICE: the relying party refuses the response when the ceremony was started to another user.
+func (s *service) loadWebAuthnCeremony(ctx context.Context, kind, ceremony string) (*webauthn.SessionData, error) {
+	if ceremony == "" {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	key := "webauthn_" + kind + "_" + ceremony
+
+	data := new(webauthn.SessionData)
+	if err := s.cache.Get(ctx, key, data); err != nil {
+		return nil, err
+	}
+
+	s.cache.Delete(ctx, key) // nolint: errcheck
+
+	if data.Challenge == "" {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	return data, nil
+}
+
+// useWebAuthnCredential records the assertion of a credential, refusing it when the signature counter indicates a
+// cloned authenticator.
+func (s *service) useWebAuthnCredential(ctx context.Context, user *webauthnUser, validated *webauthn.Credential) error {
+	credential := user.find(validated.ID)
+	if credential == nil {
+		return NewErrAuthUnathorized(nil)
+	}
+
+	if validated.Authenticator.CloneWarning {
+		logrus.WithFields(logrus.Fields{
+			"user":       user.user.ID,
+			"credential": credential.ID,
+		}).Warn("webauthn signature counter did not increase; the authenticator could be cloned")
+
+		return NewErrAuthUnathorized(nil)
+	}
+
+	return s.store.WebAuthnCredentialUse(ctx, credential.ID, validated.Authenticator.SignCount, clock.Now())
+}
+
+func (s *service) BeginWebAuthnRegistration(ctx context.Context, userID string) (string, *protocol.CredentialCreation, error) {
+	if s.webauthn == nil {
+		return "", nil, NewErrForbidden(ErrWebAuthnDisabled, nil)
+	}
+
+	user, err := s.getWebAuthnUser(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// NOTICE: the discoverable credentials, like passkeys, are preferred, as they can also be used to login without
+	// the password.
+	creation, data, err := s.webauthn.BeginRegistration(user,
+		webauthn.WithExclusions(user.descriptors()),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+	)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ceremony, err := s.saveWebAuthnCeremony(ctx, "registration", data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return ceremony, creation, nil
+}
+
+func (s *service) FinishWebAuthnRegistration(ctx context.Context, userID, ceremony, name string, response []byte) (*models.WebAuthnCredential, error) {
+	if s.webauthn == nil {
+		return nil, NewErrForbidden(ErrWebAuthnDisabled, nil)
+	}
+
+	data, err := s.loadWebAuthnCeremony(ctx, "registration", ceremony)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, NewErrBadRequest(err)
+	}
+
+	user, err := s.getWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := s.webauthn.CreateCredential(user, *data, parsed)
+	if err != nil {
+		return nil, NewErrBadRequest(err)
+	}
+
+	transports := make([]string, 0, len(created.Transport))
+	for _, transport := range created.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	credential := &models.WebAuthnCredential{
+		ID:              uuid.Generate(),
+		UserID:          userID,
+		Name:            name,
+		CredentialID:    created.ID,
+		PublicKey:       created.PublicKey,
+		AttestationType: created.AttestationType,
+		Transports:      transports,
+		AAGUID:          created.Authenticator.AAGUID,
+		SignCount:       created.Authenticator.SignCount,
+		BackupEligible:  created.Flags.BackupEligible,
+		BackupState:     created.Flags.BackupState,
+		CreatedAt:       clock.Now(),
+	}
+
+	if err := s.store.WebAuthnCredentialCreate(ctx, credential); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+func (s *service) ListWebAuthnCredentials(ctx context.Context, userID string) ([]models.WebAuthnCredential, error) {
+	return s.store.WebAuthnCredentialList(ctx, userID)
+}
+
+func (s *service) RenameWebAuthnCredential(ctx context.Context, userID, id, name string) error {
+	if err := s.store.WebAuthnCredentialRename(ctx, userID, id, name); err != nil {
+		return NewErrNotFound(ErrWebAuthnCredentialNotFound, id, err)
+	}
+
+	return nil
+}
+
+func (s *service) DeleteWebAuthnCredential(ctx context.Context, userID, id string) error {
+	if err := s.store.WebAuthnCredentialDelete(ctx, userID, id); err != nil {
+		return NewErrNotFound(ErrWebAuthnCredentialNotFound, id, err)
+	}
+
+	return nil
+}
+
+func (s *service) BeginWebAuthnLogin(ctx context.Context, userID string) (string, *protocol.CredentialAssertion, error) {
+	if s.webauthn == nil {
+		return "", nil, NewErrForbidden(ErrWebAuthnDisabled, nil)
+	}
+
+	user, err := s.getWebAuthnUser(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(user.credentials) == 0 {
+		return "", nil, NewErrNotFound(ErrWebAuthnCredentialNotFound, userID, nil)
+	}
+
+	assertion, data, err := s.webauthn.BeginLogin(user)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ceremony, err := s.saveWebAuthnCeremony(ctx, "login", data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return ceremony, assertion, nil
+}
+
+func (s *service) FinishWebAuthnLogin(ctx context.Context, userID, ceremony string, response []byte) (*models.UserAuthResponse, error) {
+	if s.webauthn == nil {
+		return nil, NewErrForbidden(ErrWebAuthnDisabled, nil)
+	}
+
+	if wait, err := s.lockout.Check(ctx, lockout.MFAKey(userID)); err != nil {
+		logrus.WithError(err).Warn("failed to check the MFA attempts")
+	} else if wait > 0 {
+		return nil, NewErrAuthLocked(wait, nil)
+	}
+
+	data, err := s.loadWebAuthnCeremony(ctx, "login", ceremony)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	user, err := s.getWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	validated, err := s.webauthn.ValidateLogin(user, *data, parsed)
+	if err != nil {
+		s.lockout.Fail(ctx, lockout.MFAKey(userID)) // nolint: errcheck
+
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	if err := s.useWebAuthnCredential(ctx, user, validated); err != nil {
+		return nil, err
+	}
+
+	return s.AuthGetToken(ctx, userID, true)
+}
+
+func (s *service) BeginWebAuthnPasswordlessLogin(ctx context.Context) (string, *protocol.CredentialAssertion, error) {
+	if s.webauthn == nil {
+		return "", nil, NewErrForbidden(ErrWebAuthnDisabled, nil)
+	}
+
+	assertion, data, err := s.webauthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return "", nil, err
+	}
+
+	ceremony, err := s.saveWebAuthnCeremony(ctx, "passwordless", data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return ceremony, assertion, nil
+}
+
+func (s *service) FinishWebAuthnPasswordlessLogin(ctx context.Context, ceremony string, response []byte, sourceIP string) (*models.UserAuthResponse, error) {
+	if s.webauthn == nil {
+		return nil, NewErrForbidden(ErrWebAuthnDisabled, nil)
+	}
+
+	keys := []lockout.Key{}
+	if sourceIP != "" {
+		keys = append(keys, lockout.IPKey(sourceIP))
+	}
+
+	if wait, err := s.lockout.Check(ctx, keys...); err != nil {
+		logrus.WithError(err).Warn("failed to check the authentication attempts")
+	} else if wait > 0 {
+		return nil, NewErrAuthLocked(wait, nil)
+	}
+
+	data, err := s.loadWebAuthnCeremony(ctx, "passwordless", ceremony)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(response))
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	// NOTICE: the user is identified by the user handle returned by the authenticator, which is the user's ID set on
+	// the registration.
+	var user *webauthnUser
+	validated, err := s.webauthn.ValidateDiscoverableLogin(func(_, handle []byte) (webauthn.User, error) {
+		user, err = s.getWebAuthnUser(ctx, string(handle))
+
+		return user, err
+	}, *data, parsed)
+
+	// NOTICE: the user's key shares the failed attempts with the password's login.
+	if user != nil {
+		keys = append(keys, lockout.UserKey(user.user.ID))
+	}
+
+	if err != nil {
+		s.lockout.Fail(ctx, keys...) // nolint: errcheck
+
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	if wait, err := s.lockout.Check(ctx, lockout.UserKey(user.user.ID)); err != nil {
+		logrus.WithError(err).Warn("failed to check the authentication attempts")
+	} else if wait > 0 {
+		return nil, NewErrAuthLocked(wait, nil)
+	}
+
+	if !user.user.Confirmed {
+		return nil, NewErrUserNotConfirmed(nil)
+	}
+
+	if err := s.useWebAuthnCredential(ctx, user, validated); err != nil {
+		return nil, err
+	}
+
+	user.user.LastLogin = clock.Now()
+
+	if err := s.store.UserUpdateData(ctx, user.user.ID, *user.user); err != nil {
+		return nil, NewErrUserUpdate(user.user, err)
+	}
+
+	return s.AuthGetToken(ctx, user.user.ID, true)
+}
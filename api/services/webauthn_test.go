@@ -0,0 +1,452 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	clockmocks "github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is a minimal in-memory cache to keep the WebAuthn's ceremonies during the tests.
+type memoryCache map[string][]byte
+
+func (m memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	if data, ok := m[key]; ok {
+		return json.Unmarshal(data, value)
+	}
+
+	return nil
+}
+
+func (m memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	m[key] = data
+
+	return err
+}
+
+func (m memoryCache) Delete(_ context.Context, key string) error {
+	delete(m, key)
+
+	return nil
+}
+
+func (m memoryCache) Increment(_ context.Context, key string, _ time.Duration) (int64, error) {
+	var counter int64
+	if data, ok := m[key]; ok {
+		if err := json.Unmarshal(data, &counter); err != nil {
+			return 0, err
+		}
+	}
+
+	counter++
+
+	data, err := json.Marshal(counter)
+	m[key] = data
+
+	return counter, err
+}
+
+// authenticator is a software WebAuthn authenticator, with a single ES256 credential and no attestation.
+type authenticator struct {
+	t       *testing.T
+	id      []byte
+	key     *ecdsa.PrivateKey
+	user    []byte
+	counter uint32
+}
+
+func newAuthenticator(t *testing.T) *authenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	id := make([]byte, 16)
+	_, err = rand.Read(id)
+	require.NoError(t, err)
+
+	return &authenticator{t: t, id: id, key: key}
+}
+
+func (a *authenticator) authData(attested bool) []byte {
+	hash := sha256.Sum256([]byte("localhost"))
+	data := append([]byte{}, hash[:]...)
+
+	// NOTICE: the user is present and verified.
+	flags := byte(0x01 | 0x04)
+	if attested {
+		flags |= 0x40
+	}
+
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.counter)
+
+	if attested {
+		key, err := webauthncbor.Marshal(webauthncose.EC2PublicKeyData{
+			PublicKeyData: webauthncose.PublicKeyData{KeyType: int64(webauthncose.EllipticKey), Algorithm: int64(webauthncose.AlgES256)},
+			Curve:         1,
+			XCoord:        a.key.X.FillBytes(make([]byte, 32)),
+			YCoord:        a.key.Y.FillBytes(make([]byte, 32)),
+		})
+		require.NoError(a.t, err)
+
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.id)))
+		data = append(data, a.id...)
+		data = append(data, key...)
+	}
+
+	return data
+}
+
+func (a *authenticator) clientData(ceremony string, challenge protocol.URLEncodedBase64) []byte {
+	data, err := json.Marshal(map[string]string{
+		"type":      ceremony,
+		"challenge": challenge.String(),
+		"origin":    "https://localhost",
+	})
+	require.NoError(a.t, err)
+
+	return data
+}
+
+// create answers a registration like `navigator.credentials.create`.
+func (a *authenticator) create(creation *protocol.CredentialCreation) []byte {
+	a.user = creation.Response.User.ID.(protocol.URLEncodedBase64)
+
+	attestation, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": a.authData(true),
+	})
+	require.NoError(a.t, err)
+
+	return a.response(map[string]string{
+		"clientDataJSON":    encode(a.clientData("webauthn.create", creation.Response.Challenge)),
+		"attestationObject": encode(attestation),
+	})
+}
+
+// get answers an assertion like `navigator.credentials.get`.
+func (a *authenticator) get(assertion *protocol.CredentialAssertion) []byte {
+	a.counter++
+
+	authData := a.authData(false)
+	clientData := a.clientData("webauthn.get", assertion.Response.Challenge)
+
+	hash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), hash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	require.NoError(a.t, err)
+
+	return a.response(map[string]string{
+		"clientDataJSON":    encode(clientData),
+		"authenticatorData": encode(authData),
+		"signature":         encode(signature),
+		"userHandle":        encode(a.user),
+	})
+}
+
+func (a *authenticator) response(response map[string]string) []byte {
+	data, err := json.Marshal(map[string]interface{}{
+		"id":       encode(a.id),
+		"rawId":    encode(a.id),
+		"type":     "public-key",
+		"response": response,
+	})
+	require.NoError(a.t, err)
+
+	return data
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func newTestWebAuthn(t *testing.T) *webauthn.WebAuthn {
+	relyingParty, err := webauthn.New(&webauthn.Config{
+		RPID:          "localhost",
+		RPDisplayName: "ShellHub",
+		RPOrigins:     []string{"https://localhost"},
+	})
+	require.NoError(t, err)
+
+	return relyingParty
+}
+
+func TestAuthMFA(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      bool
+	}{
+		{
+			description: "succeeds when the user has the TOTP enabled",
+			requiredMocks: func() {
+				mock.On("GetStatusMFA", ctx, "id").Return(true, nil).Once()
+			},
+			expected: true,
+		},
+		{
+			description: "succeeds when the user has a WebAuthn credential",
+			requiredMocks: func() {
+				mock.On("GetStatusMFA", ctx, "id").Return(false, nil).Once()
+				mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{{ID: "credential"}}, nil).Once()
+			},
+			expected: true,
+		},
+		{
+			description: "succeeds when the user has no second factor",
+			requiredMocks: func() {
+				mock.On("GetStatusMFA", ctx, "id").Return(false, nil).Once()
+				mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{}, nil).Once()
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, memoryCache{}, clientMock, nil)
+
+			status, err := service.AuthMFA(ctx, "id")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestWebAuthnDisabled(t *testing.T) {
+	service := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, memoryCache{}, clientMock, nil)
+
+	_, _, err := service.BeginWebAuthnRegistration(context.TODO(), "id")
+	assert.Equal(t, NewErrForbidden(ErrWebAuthnDisabled, nil), err)
+
+	_, _, err = service.BeginWebAuthnPasswordlessLogin(context.TODO())
+	assert.Equal(t, NewErrForbidden(ErrWebAuthnDisabled, nil), err)
+}
+
+func TestBeginWebAuthnLogin(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	user := &models.User{ID: "id", UserData: models.UserData{Username: "user"}}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the user has no credentials",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+				mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{}, nil).Once()
+			},
+			expected: NewErrNotFound(ErrWebAuthnCredentialNotFound, "id", nil),
+		},
+		{
+			description: "succeeds allowing the user's credentials",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+				mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{
+					{ID: "credential", CredentialID: []byte("credential")},
+				}, nil).Once()
+				uuidMock.On("Generate").Return("ceremony").Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			cache := memoryCache{}
+			service := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil, WithWebAuthn(newTestWebAuthn(t)))
+
+			ceremony, assertion, err := service.BeginWebAuthnLogin(ctx, "id")
+			assert.Equal(t, tc.expected, err)
+
+			if tc.expected == nil {
+				require.Len(t, assertion.Response.AllowedCredentials, 1)
+				assert.Equal(t, []byte("credential"), []byte(assertion.Response.AllowedCredentials[0].CredentialID))
+				assert.Equal(t, "ceremony", ceremony)
+				assert.Contains(t, cache, "webauthn_login_ceremony")
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestFinishWebAuthnLoginWithoutCeremony(t *testing.T) {
+	service := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, memoryCache{}, clientMock, nil, WithWebAuthn(newTestWebAuthn(t)))
+
+	_, err := service.FinishWebAuthnLogin(context.TODO(), "id", "", []byte("{}"))
+	assert.Equal(t, NewErrAuthUnathorized(nil), err)
+
+	_, err = service.FinishWebAuthnLogin(context.TODO(), "id", "unknown", []byte("{}"))
+	assert.Equal(t, NewErrAuthUnathorized(nil), err)
+}
+
+func TestFinishWebAuthnLoginLocked(t *testing.T) {
+	ctx := context.TODO()
+
+	cache := memoryCache{}
+	require.NoError(t, cache.Set(ctx, "lockout_mfa_id_until", now.Add(time.Minute), time.Minute))
+
+	service := NewService(store.Store(new(mocks.Store)), privateKey, publicKey, cache, clientMock, nil, WithWebAuthn(newTestWebAuthn(t)))
+
+	clockMock.On("Now").Return(now).Once()
+
+	_, err := service.FinishWebAuthnLogin(ctx, "id", "ceremony", []byte("{}"))
+	assert.Equal(t, NewErrAuthLocked(time.Minute, nil), err)
+}
+
+func TestWebAuthnCeremonies(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	// NOTICE: the ceremonies read the clock many times, so it doesn't count them.
+	clockBackend := clock.DefaultBackend
+	clockMock := &clockmocks.Clock{}
+	clockMock.On("Now").Return(now)
+	clock.DefaultBackend = clockMock
+	t.Cleanup(func() { clock.DefaultBackend = clockBackend })
+
+	ctx := context.TODO()
+
+	user := &models.User{ID: "id", Confirmed: true, UserData: models.UserData{Username: "user"}}
+	namespace := &models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "id", Role: "owner"}}}
+
+	service := NewService(store.Store(mock), privateKey, publicKey, memoryCache{}, clientMock, nil, WithWebAuthn(newTestWebAuthn(t)))
+	key := newAuthenticator(t)
+
+	var credential models.WebAuthnCredential
+
+	// Registration.
+	mock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil)
+	mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{}, nil).Twice()
+	uuidMock.On("Generate").Return("registration").Once()
+	uuidMock.On("Generate").Return("credential").Once()
+	mock.On("WebAuthnCredentialCreate", ctx, gomock.AnythingOfType("*models.WebAuthnCredential")).
+		Run(func(args gomock.Arguments) {
+			credential = *args.Get(1).(*models.WebAuthnCredential)
+		}).Return(nil).Once()
+
+	ceremony, creation, err := service.BeginWebAuthnRegistration(ctx, "id")
+	require.NoError(t, err)
+	assert.Equal(t, "registration", ceremony)
+	assert.Equal(t, protocol.ResidentKeyRequirementPreferred, creation.Response.AuthenticatorSelection.ResidentKey)
+
+	// NOTICE: the response must be sent with the ceremony's ID.
+	_, err = service.FinishWebAuthnRegistration(ctx, "id", "login", "key", key.create(creation))
+	assert.Equal(t, NewErrAuthUnathorized(nil), err)
+
+	created, err := service.FinishWebAuthnRegistration(ctx, "id", ceremony, "key", key.create(creation))
+	require.NoError(t, err)
+	assert.Equal(t, "key", created.Name)
+	assert.Equal(t, key.id, created.CredentialID)
+	assert.Equal(t, credential, *created)
+
+	// NOTICE: the registration's challenge is used only once.
+	_, err = service.FinishWebAuthnRegistration(ctx, "id", ceremony, "key", key.create(creation))
+	assert.Equal(t, NewErrAuthUnathorized(nil), err)
+
+	// Second factor.
+	mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{credential}, nil).Times(3)
+	mock.On("WebAuthnCredentialUse", ctx, "credential", uint32(1), now).Return(nil).Once()
+	mock.On("NamespaceGetFirst", ctx, "id").Return(namespace, nil).Once()
+	mock.On("GetStatusMFA", ctx, "id").Return(false, nil).Once()
+	mock.On("UserSessionCreate", ctx, gomock.AnythingOfType("*models.UserSession")).Return(nil).Once()
+	mock.On("UserSessionList", ctx, "id").Return([]models.UserSession{}, nil).Once()
+	uuidMock.On("Generate").Return("login").Once()
+	uuidMock.On("Generate").Return("session").Once()
+	uuidMock.On("Generate").Return("jti").Once()
+
+	ceremony, assertion, err := service.BeginWebAuthnLogin(ctx, "id")
+	require.NoError(t, err)
+
+	res, err := service.FinishWebAuthnLogin(ctx, "id", ceremony, key.get(assertion))
+	require.NoError(t, err)
+	assert.Equal(t, models.MFA{Enable: true, Validate: true}, res.MFA)
+	assert.Equal(t, "tenant", res.Tenant)
+	assert.NotEmpty(t, res.RefreshToken)
+
+	// Passwordless.
+	credential.SignCount = 1
+
+	mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{credential}, nil).Twice()
+	mock.On("WebAuthnCredentialUse", ctx, "credential", uint32(2), now).Return(nil).Once()
+	mock.On("UserUpdateData", ctx, "id", gomock.AnythingOfType("models.User")).Return(nil).Once()
+	mock.On("NamespaceGetFirst", ctx, "id").Return(namespace, nil).Once()
+	mock.On("GetStatusMFA", ctx, "id").Return(false, nil).Once()
+	mock.On("UserSessionCreate", ctx, gomock.AnythingOfType("*models.UserSession")).Return(nil).Once()
+	mock.On("UserSessionList", ctx, "id").Return([]models.UserSession{}, nil).Once()
+	uuidMock.On("Generate").Return("ceremony").Once()
+	uuidMock.On("Generate").Return("session").Once()
+	uuidMock.On("Generate").Return("jti").Once()
+
+	ceremony, assertion, err = service.BeginWebAuthnPasswordlessLogin(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ceremony", ceremony)
+	assert.Empty(t, assertion.Response.AllowedCredentials)
+
+	res, err = service.FinishWebAuthnPasswordlessLogin(ctx, ceremony, key.get(assertion), "127.0.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, "id", res.ID)
+	assert.Equal(t, models.MFA{Enable: true, Validate: true}, res.MFA)
+
+	// NOTICE: an assertion whose counter didn't increase could come from a cloned authenticator.
+	credential.SignCount = 5
+
+	mock.On("WebAuthnCredentialList", ctx, "id").Return([]models.WebAuthnCredential{credential}, nil).Twice()
+
+	uuidMock.On("Generate").Return("cloned").Once()
+
+	ceremony, assertion, err = service.BeginWebAuthnLogin(ctx, "id")
+	require.NoError(t, err)
+
+	_, err = service.FinishWebAuthnLogin(ctx, "id", ceremony, key.get(assertion))
+	assert.Equal(t, NewErrAuthUnathorized(nil), err)
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
@@ -0,0 +1,12 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type CertificateAuthorityStore interface {
+	CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error
+	CertificateAuthorityGet(ctx context.Context, tenantID string) (*models.CertificateAuthority, error)
+}
@@ -42,5 +42,18 @@ type DeviceStore interface {
 	DeviceRemovedDelete(ctx context.Context, tenant string, uid models.UID) error
 	DeviceRemovedList(ctx context.Context, tenant string, pagination paginator.Query, filters []models.Filter, sort string, order string) ([]models.DeviceRemoved, int, error)
 	DeviceCreatePublicURLAddress(ctx context.Context, uid models.UID) error
+	// DeviceGetByPublicURLAddress gets the device that owns the public URL address, either the device's own address or
+	// one of its public URL services' addresses.
 	DeviceGetByPublicURLAddress(ctx context.Context, address string) (*models.Device, error)
+	DevicePublicURLServiceCreate(ctx context.Context, uid models.UID, service *models.DevicePublicURLService) error
+	DevicePublicURLServiceUpdate(ctx context.Context, uid models.UID, address string, service *models.DevicePublicURLService) error
+	DevicePublicURLServiceDelete(ctx context.Context, uid models.UID, address string) error
+	DeviceGetByPublishedPortAddress(ctx context.Context, address string) (*models.Device, error)
+	// DeviceGetByPublishedPortGatewayPort gets the device that owns the published port reached through the gateway's
+	// port.
+	DeviceGetByPublishedPortGatewayPort(ctx context.Context, port int) (*models.Device, error)
+	// DevicePublishedPortListGatewayPorts lists the gateway's ports already allocated to the published ports.
+	DevicePublishedPortListGatewayPorts(ctx context.Context) ([]int, error)
+	DevicePublishedPortCreate(ctx context.Context, uid models.UID, port *models.DevicePublishedPort) error
+	DevicePublishedPortDelete(ctx context.Context, uid models.UID, address string) error
 }
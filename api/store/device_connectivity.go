@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceConnectivityStore interface {
+	// DeviceConnectivityRecord records the transition of a device to online or offline at the timestamp, unless the
+	// device is already in that state. As the device's last seen is used to know if it went offline without notice,
+	// it must be called before the device's last seen is updated.
+	// Returns ErrNoDocuments when the device is not found.
+	DeviceConnectivityRecord(ctx context.Context, uid models.UID, online bool, timestamp time.Time) error
+
+	// DeviceConnectivityList lists the connectivity events of a device from the last one before from, which is the
+	// device's state at from, to the last one before to, from the oldest to the newest.
+	DeviceConnectivityList(ctx context.Context, uid models.UID, from, to time.Time) ([]models.DeviceConnectivityEvent, error)
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceGroupStore interface {
+	// DeviceGroupList lists the device groups, sorted by name, of the namespace in the context.
+	DeviceGroupList(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error)
+	DeviceGroupGet(ctx context.Context, tenant, id string) (*models.DeviceGroup, error)
+	// DeviceGroupCreate creates the device group, returning its ID.
+	DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) (string, error)
+	DeviceGroupUpdate(ctx context.Context, tenant, id string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error)
+	DeviceGroupDelete(ctx context.Context, tenant, id string) error
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceInventoryStore interface {
+	// DeviceInventoryCreate adds the snapshot to the device's inventory history and sets it as the device's latest
+	// inventory.
+	// Returns an error if any issues occur during the creation or ErrNoDocuments when the device is not found.
+	DeviceInventoryCreate(ctx context.Context, snapshot *models.DeviceInventorySnapshot) error
+
+	// DeviceInventoryList lists the inventory history of a device with the specified UID, from the newest to the
+	// oldest. It returns the inventories, the total count of them and an error, if any.
+	DeviceInventoryList(ctx context.Context, tenant string, uid models.UID, pagination paginator.Query) ([]models.DeviceInventory, int, error)
+}
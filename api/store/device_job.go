@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceJobStore interface {
+	DeviceJobCreate(ctx context.Context, job *models.DeviceJob) error
+	DeviceJobGet(ctx context.Context, id string) (*models.DeviceJob, error)
+	// DeviceJobSetStatus sets the job's status, and when it finished, if it did.
+	DeviceJobSetStatus(ctx context.Context, id string, status models.DeviceJobStatus, finishedAt *time.Time) error
+	// DeviceJobSetItem sets the result of the job's operation on the item's device.
+	DeviceJobSetItem(ctx context.Context, id string, item models.DeviceJobItem) error
+}
@@ -0,0 +1,22 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceLabelsStore interface {
+	// DeviceSetLabels sets the labels for a device with the specified UID, keeping its other labels.
+	// Returns an error if any issues occur during the labels update or ErrNoDocuments when no matching documents are found.
+	DeviceSetLabels(ctx context.Context, uid models.UID, labels map[string]string) error
+
+	// DeviceSetAgentLabels replaces the labels reported by the agent of a device with the specified UID. They are kept
+	// apart from the labels set by the users.
+	// Returns an error if any issues occur during the labels update or ErrNoDocuments when no matching documents are found.
+	DeviceSetAgentLabels(ctx context.Context, uid models.UID, labels map[string]string) error
+
+	// DeviceUnsetLabel removes the label with the specified key from a device with the specified UID.
+	// Returns an error if any issues occur during the label removal or ErrNoDocuments when no matching documents are found.
+	DeviceUnsetLabel(ctx context.Context, uid models.UID, key string) error
+}
@@ -0,0 +1,34 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceMetricsStore interface {
+	// DeviceMetricsAdd aggregates the metrics sample of a device into the buckets of the rollups, creating them when
+	// they do not exist.
+	DeviceMetricsAdd(ctx context.Context, uid models.UID, tenant string, metrics *models.DeviceMetrics, rollups []models.DeviceMetricsRollup) error
+
+	// DeviceMetricsList lists the buckets of a device's metrics, of the resolution, started within from and to, from the
+	// oldest to the newest.
+	DeviceMetricsList(ctx context.Context, tenant string, uid models.UID, resolution models.DeviceMetricsResolution, from, to time.Time) ([]models.DeviceMetricsBucket, error)
+}
+
+type DeviceAlertStore interface {
+	DeviceAlertCreate(ctx context.Context, alert *models.DeviceAlert) error
+
+	// DeviceAlertListActive lists the alerts of a device that were not resolved yet.
+	DeviceAlertListActive(ctx context.Context, uid models.UID) ([]models.DeviceAlert, error)
+
+	// DeviceAlertResolve sets when the alert with the specified ID was resolved.
+	// Returns an error if any issues occur during the update or ErrNoDocuments when no active alert is found.
+	DeviceAlertResolve(ctx context.Context, id string, resolvedAt time.Time) error
+
+	// DeviceAlertList lists the alerts of the namespace's devices, from the newest to the oldest. It returns the
+	// alerts, the total count of them and an error, if any.
+	DeviceAlertList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAlert, int, error)
+}
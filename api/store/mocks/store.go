@@ -165,6 +165,46 @@ func (_m *Store) AnnouncementUpdate(ctx context.Context, announcement *models.An
 	return r0
 }
 
+// CertificateAuthorityCreate provides a mock function with given fields: ctx, ca
+func (_m *Store) CertificateAuthorityCreate(ctx context.Context, ca *models.CertificateAuthority) error {
+	ret := _m.Called(ctx, ca)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CertificateAuthority) error); ok {
+		r0 = rf(ctx, ca)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CertificateAuthorityGet provides a mock function with given fields: ctx, tenantID
+func (_m *Store) CertificateAuthorityGet(ctx context.Context, tenantID string) (*models.CertificateAuthority, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *models.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CertificateAuthority, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CertificateAuthority); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteCodes provides a mock function with given fields: ctx, username
 func (_m *Store) DeleteCodes(ctx context.Context, username string) error {
 	ret := _m.Called(ctx, username)
@@ -193,6 +233,93 @@ func (_m *Store) DeleteSecret(ctx context.Context, username string) error {
 	return r0
 }
 
+// DeviceAlertCreate provides a mock function with given fields: ctx, alert
+func (_m *Store) DeviceAlertCreate(ctx context.Context, alert *models.DeviceAlert) error {
+	ret := _m.Called(ctx, alert)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceAlert) error); ok {
+		r0 = rf(ctx, alert)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceAlertList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) DeviceAlertList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAlert, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.DeviceAlert
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.DeviceAlert, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.DeviceAlert); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceAlert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceAlertListActive provides a mock function with given fields: ctx, uid
+func (_m *Store) DeviceAlertListActive(ctx context.Context, uid models.UID) ([]models.DeviceAlert, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []models.DeviceAlert
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) ([]models.DeviceAlert, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) []models.DeviceAlert); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceAlert)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceAlertResolve provides a mock function with given fields: ctx, id, resolvedAt
+func (_m *Store) DeviceAlertResolve(ctx context.Context, id string, resolvedAt time.Time) error {
+	ret := _m.Called(ctx, id, resolvedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, resolvedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceBulkDeleteTag provides a mock function with given fields: ctx, tenant, tag
 func (_m *Store) DeviceBulkDeleteTag(ctx context.Context, tenant string, tag string) (int64, error) {
 	ret := _m.Called(ctx, tenant, tag)
@@ -255,6 +382,46 @@ func (_m *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []st
 	return r0
 }
 
+// DeviceConnectivityList provides a mock function with given fields: ctx, uid, from, to
+func (_m *Store) DeviceConnectivityList(ctx context.Context, uid models.UID, from time.Time, to time.Time) ([]models.DeviceConnectivityEvent, error) {
+	ret := _m.Called(ctx, uid, from, to)
+
+	var r0 []models.DeviceConnectivityEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time, time.Time) ([]models.DeviceConnectivityEvent, error)); ok {
+		return rf(ctx, uid, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time, time.Time) []models.DeviceConnectivityEvent); ok {
+		r0 = rf(ctx, uid, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceConnectivityEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, uid, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceConnectivityRecord provides a mock function with given fields: ctx, uid, online, timestamp
+func (_m *Store) DeviceConnectivityRecord(ctx context.Context, uid models.UID, online bool, timestamp time.Time) error {
+	ret := _m.Called(ctx, uid, online, timestamp)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, bool, time.Time) error); ok {
+		r0 = rf(ctx, uid, online, timestamp)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceCreate provides a mock function with given fields: ctx, d, hostname
 func (_m *Store) DeviceCreate(ctx context.Context, d models.Device, hostname string) error {
 	ret := _m.Called(ctx, d, hostname)
@@ -401,6 +568,58 @@ func (_m *Store) DeviceGetByPublicURLAddress(ctx context.Context, address string
 	return r0, r1
 }
 
+// DeviceGetByPublishedPortAddress provides a mock function with given fields: ctx, address
+func (_m *Store) DeviceGetByPublishedPortAddress(ctx context.Context, address string) (*models.Device, error) {
+	ret := _m.Called(ctx, address)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Device, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Device); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGetByPublishedPortGatewayPort provides a mock function with given fields: ctx, port
+func (_m *Store) DeviceGetByPublishedPortGatewayPort(ctx context.Context, port int) (*models.Device, error) {
+	ret := _m.Called(ctx, port)
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Device, error)); ok {
+		return rf(ctx, port)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Device); ok {
+		r0 = rf(ctx, port)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, port)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceGetByUID provides a mock function with given fields: ctx, uid, tenantID
 func (_m *Store) DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error) {
 	ret := _m.Called(ctx, uid, tenantID)
@@ -460,6 +679,244 @@ func (_m *Store) DeviceGetTags(ctx context.Context, tenant string) ([]string, in
 	return r0, r1, r2
 }
 
+// DeviceGroupCreate provides a mock function with given fields: ctx, group
+func (_m *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) (string, error) {
+	ret := _m.Called(ctx, group)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceGroup) (string, error)); ok {
+		return rf(ctx, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceGroup) string); ok {
+		r0 = rf(ctx, group)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.DeviceGroup) error); ok {
+		r1 = rf(ctx, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGroupDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceGroupDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceGroupGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceGroupGet(ctx context.Context, tenant string, id string) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceGroupList provides a mock function with given fields: ctx, pagination
+func (_m *Store) DeviceGroupList(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.DeviceGroup
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.DeviceGroup, int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.DeviceGroup); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceGroupUpdate provides a mock function with given fields: ctx, tenant, id, group
+func (_m *Store) DeviceGroupUpdate(ctx context.Context, tenant string, id string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	ret := _m.Called(ctx, tenant, id, group)
+
+	var r0 *models.DeviceGroup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.DeviceGroupUpdate) (*models.DeviceGroup, error)); ok {
+		return rf(ctx, tenant, id, group)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.DeviceGroupUpdate) *models.DeviceGroup); ok {
+		r0 = rf(ctx, tenant, id, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceGroup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *models.DeviceGroupUpdate) error); ok {
+		r1 = rf(ctx, tenant, id, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceInventoryCreate provides a mock function with given fields: ctx, snapshot
+func (_m *Store) DeviceInventoryCreate(ctx context.Context, snapshot *models.DeviceInventorySnapshot) error {
+	ret := _m.Called(ctx, snapshot)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceInventorySnapshot) error); ok {
+		r0 = rf(ctx, snapshot)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceInventoryList provides a mock function with given fields: ctx, tenant, uid, pagination
+func (_m *Store) DeviceInventoryList(ctx context.Context, tenant string, uid models.UID, pagination paginator.Query) ([]models.DeviceInventory, int, error) {
+	ret := _m.Called(ctx, tenant, uid, pagination)
+
+	var r0 []models.DeviceInventory
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, paginator.Query) ([]models.DeviceInventory, int, error)); ok {
+		return rf(ctx, tenant, uid, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, paginator.Query) []models.DeviceInventory); ok {
+		r0 = rf(ctx, tenant, uid, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceInventory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, uid, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, models.UID, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, uid, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceJobCreate provides a mock function with given fields: ctx, job
+func (_m *Store) DeviceJobCreate(ctx context.Context, job *models.DeviceJob) error {
+	ret := _m.Called(ctx, job)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceJobGet provides a mock function with given fields: ctx, id
+func (_m *Store) DeviceJobGet(ctx context.Context, id string) (*models.DeviceJob, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.DeviceJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.DeviceJob, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.DeviceJob); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceJobSetItem provides a mock function with given fields: ctx, id, item
+func (_m *Store) DeviceJobSetItem(ctx context.Context, id string, item models.DeviceJobItem) error {
+	ret := _m.Called(ctx, id, item)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceJobItem) error); ok {
+		r0 = rf(ctx, id, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceJobSetStatus provides a mock function with given fields: ctx, id, status, finishedAt
+func (_m *Store) DeviceJobSetStatus(ctx context.Context, id string, status models.DeviceJobStatus, finishedAt *time.Time) error {
+	ret := _m.Called(ctx, id, status, finishedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.DeviceJobStatus, *time.Time) error); ok {
+		r0 = rf(ctx, id, status, finishedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceList provides a mock function with given fields: ctx, pagination, filters, status, sort, _a5, mode
 func (_m *Store) DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status models.DeviceStatus, sort string, _a5 string, mode store.DeviceListMode) ([]models.Device, int, error) {
 	ret := _m.Called(ctx, pagination, filters, status, sort, _a5, mode)
@@ -528,16 +985,152 @@ func (_m *Store) DeviceLookup(ctx context.Context, namespace string, hostname st
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
 		return rf(ctx, namespace, hostname)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
-		r0 = rf(ctx, namespace, hostname)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
+		r0 = rf(ctx, namespace, hostname)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, hostname)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceMetricsAdd provides a mock function with given fields: ctx, uid, tenant, metrics, rollups
+func (_m *Store) DeviceMetricsAdd(ctx context.Context, uid models.UID, tenant string, metrics *models.DeviceMetrics, rollups []models.DeviceMetricsRollup) error {
+	ret := _m.Called(ctx, uid, tenant, metrics, rollups)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, *models.DeviceMetrics, []models.DeviceMetricsRollup) error); ok {
+		r0 = rf(ctx, uid, tenant, metrics, rollups)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceMetricsList provides a mock function with given fields: ctx, tenant, uid, resolution, from, to
+func (_m *Store) DeviceMetricsList(ctx context.Context, tenant string, uid models.UID, resolution models.DeviceMetricsResolution, from time.Time, to time.Time) ([]models.DeviceMetricsBucket, error) {
+	ret := _m.Called(ctx, tenant, uid, resolution, from, to)
+
+	var r0 []models.DeviceMetricsBucket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, models.DeviceMetricsResolution, time.Time, time.Time) ([]models.DeviceMetricsBucket, error)); ok {
+		return rf(ctx, tenant, uid, resolution, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, models.DeviceMetricsResolution, time.Time, time.Time) []models.DeviceMetricsBucket); ok {
+		r0 = rf(ctx, tenant, uid, resolution, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceMetricsBucket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, models.DeviceMetricsResolution, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, tenant, uid, resolution, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DevicePublicURLServiceCreate provides a mock function with given fields: ctx, uid, service
+func (_m *Store) DevicePublicURLServiceCreate(ctx context.Context, uid models.UID, service *models.DevicePublicURLService) error {
+	ret := _m.Called(ctx, uid, service)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.DevicePublicURLService) error); ok {
+		r0 = rf(ctx, uid, service)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DevicePublicURLServiceDelete provides a mock function with given fields: ctx, uid, address
+func (_m *Store) DevicePublicURLServiceDelete(ctx context.Context, uid models.UID, address string) error {
+	ret := _m.Called(ctx, uid, address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DevicePublicURLServiceUpdate provides a mock function with given fields: ctx, uid, address, service
+func (_m *Store) DevicePublicURLServiceUpdate(ctx context.Context, uid models.UID, address string, service *models.DevicePublicURLService) error {
+	ret := _m.Called(ctx, uid, address, service)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, *models.DevicePublicURLService) error); ok {
+		r0 = rf(ctx, uid, address, service)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DevicePublishedPortCreate provides a mock function with given fields: ctx, uid, port
+func (_m *Store) DevicePublishedPortCreate(ctx context.Context, uid models.UID, port *models.DevicePublishedPort) error {
+	ret := _m.Called(ctx, uid, port)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.DevicePublishedPort) error); ok {
+		r0 = rf(ctx, uid, port)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DevicePublishedPortDelete provides a mock function with given fields: ctx, uid, address
+func (_m *Store) DevicePublishedPortDelete(ctx context.Context, uid models.UID, address string) error {
+	ret := _m.Called(ctx, uid, address)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DevicePublishedPortListGatewayPorts provides a mock function with given fields: ctx
+func (_m *Store) DevicePublishedPortListGatewayPorts(ctx context.Context) ([]int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []int); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).([]int)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, hostname)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -698,6 +1291,34 @@ func (_m *Store) DeviceRename(ctx context.Context, uid models.UID, hostname stri
 	return r0
 }
 
+// DeviceSetAgentLabels provides a mock function with given fields: ctx, uid, labels
+func (_m *Store) DeviceSetAgentLabels(ctx context.Context, uid models.UID, labels map[string]string) error {
+	ret := _m.Called(ctx, uid, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, uid, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetLabels provides a mock function with given fields: ctx, uid, labels
+func (_m *Store) DeviceSetLabels(ctx context.Context, uid models.UID, labels map[string]string) error {
+	ret := _m.Called(ctx, uid, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, uid, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceSetOnline provides a mock function with given fields: ctx, uid, timestamp, online
 func (_m *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) error {
 	ret := _m.Called(ctx, uid, timestamp, online)
@@ -757,6 +1378,20 @@ func (_m *Store) DeviceSetTags(ctx context.Context, uid models.UID, tags []strin
 	return r0, r1, r2
 }
 
+// DeviceUnsetLabel provides a mock function with given fields: ctx, uid, key
+func (_m *Store) DeviceUnsetLabel(ctx context.Context, uid models.UID, key string) error {
+	ret := _m.Called(ctx, uid, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceUpdate provides a mock function with given fields: ctx, tenant, uid, name, publicURL
 func (_m *Store) DeviceUpdate(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
 	ret := _m.Called(ctx, tenant, uid, name, publicURL)
@@ -1456,6 +2091,34 @@ func (_m *Store) NamespaceRename(ctx context.Context, tenantID string, name stri
 	return r0, r1
 }
 
+// NamespaceSetAgentForwarding provides a mock function with given fields: ctx, agentForwarding, tenantID
+func (_m *Store) NamespaceSetAgentForwarding(ctx context.Context, agentForwarding bool, tenantID string) error {
+	ret := _m.Called(ctx, agentForwarding, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
+		r0 = rf(ctx, agentForwarding, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NamespaceSetMetricsThresholds provides a mock function with given fields: ctx, thresholds, tenantID
+func (_m *Store) NamespaceSetMetricsThresholds(ctx context.Context, thresholds []models.DeviceMetricsThreshold, tenantID string) error {
+	ret := _m.Called(ctx, thresholds, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.DeviceMetricsThreshold, string) error); ok {
+		r0 = rf(ctx, thresholds, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NamespaceSetSessionRecord provides a mock function with given fields: ctx, sessionRecord, tenantID
 func (_m *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
 	ret := _m.Called(ctx, sessionRecord, tenantID)
@@ -1692,6 +2355,32 @@ func (_m *Store) PublicKeyList(ctx context.Context, pagination paginator.Query)
 	return r0, r1, r2
 }
 
+// PublicKeyListByFingerprint provides a mock function with given fields: ctx, fingerprint
+func (_m *Store) PublicKeyListByFingerprint(ctx context.Context, fingerprint string) ([]models.PublicKey, error) {
+	ret := _m.Called(ctx, fingerprint)
+
+	var r0 []models.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.PublicKey, error)); ok {
+		return rf(ctx, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.PublicKey); ok {
+		r0 = rf(ctx, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PublicKeyPullTag provides a mock function with given fields: ctx, tenant, fingerprint, tag
 func (_m *Store) PublicKeyPullTag(ctx context.Context, tenant string, fingerprint string, tag string) error {
 	ret := _m.Called(ctx, tenant, fingerprint, tag)
@@ -1935,32 +2624,32 @@ func (_m *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]m
 	return r0, r1, r2
 }
 
-// SessionList provides a mock function with given fields: ctx, pagination
-func (_m *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, pagination)
+// SessionList provides a mock function with given fields: ctx, pagination, filters, sort, order
+func (_m *Store) SessionList(ctx context.Context, pagination paginator.Query, filters []models.Filter, sort string, order string) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, pagination, filters, sort, order)
 
 	var r0 []models.Session
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.Session, int, error)); ok {
-		return rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, []models.Filter, string, string) ([]models.Session, int, error)); ok {
+		return rf(ctx, pagination, filters, sort, order)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.Session); ok {
-		r0 = rf(ctx, pagination)
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query, []models.Filter, string, string) []models.Session); ok {
+		r0 = rf(ctx, pagination, filters, sort, order)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
-		r1 = rf(ctx, pagination)
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query, []models.Filter, string, string) int); ok {
+		r1 = rf(ctx, pagination, filters, sort, order)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
-		r2 = rf(ctx, pagination)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query, []models.Filter, string, string) error); ok {
+		r2 = rf(ctx, pagination, filters, sort, order)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -2024,6 +2713,60 @@ func (_m *Store) SessionUpdateDeviceUID(ctx context.Context, oldUID models.UID,
 	return r0
 }
 
+// SigningKeyCreate provides a mock function with given fields: ctx, key
+func (_m *Store) SigningKeyCreate(ctx context.Context, key *models.SigningKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SigningKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SigningKeyList provides a mock function with given fields: ctx
+func (_m *Store) SigningKeyList(ctx context.Context) ([]models.SigningKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []models.SigningKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.SigningKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.SigningKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SigningKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SigningKeyRetire provides a mock function with given fields: ctx, id, retiredAt, expiresAt
+func (_m *Store) SigningKeyRetire(ctx context.Context, id string, retiredAt time.Time, expiresAt time.Time) error {
+	ret := _m.Called(ctx, id, retiredAt, expiresAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, id, retiredAt, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // TagsDelete provides a mock function with given fields: ctx, tenant, tag
 func (_m *Store) TagsDelete(ctx context.Context, tenant string, tag string) (int64, error) {
 	ret := _m.Called(ctx, tenant, tag)
@@ -2345,6 +3088,114 @@ func (_m *Store) UserList(ctx context.Context, pagination paginator.Query, filte
 	return r0, r1, r2
 }
 
+// UserSessionCreate provides a mock function with given fields: ctx, session
+func (_m *Store) UserSessionCreate(ctx context.Context, session *models.UserSession) error {
+	ret := _m.Called(ctx, session)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserSession) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionDelete provides a mock function with given fields: ctx, id
+func (_m *Store) UserSessionDelete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionGet provides a mock function with given fields: ctx, id
+func (_m *Store) UserSessionGet(ctx context.Context, id string) (*models.UserSession, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UserSession, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UserSession); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserSessionList provides a mock function with given fields: ctx, userID
+func (_m *Store) UserSessionList(ctx context.Context, userID string) ([]models.UserSession, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []models.UserSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.UserSession, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.UserSession); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserSessionRotate provides a mock function with given fields: ctx, id, hash, newHash, lastUsedAt
+func (_m *Store) UserSessionRotate(ctx context.Context, id string, hash string, newHash string, lastUsedAt time.Time) error {
+	ret := _m.Called(ctx, id, hash, newHash, lastUsedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, hash, newHash, lastUsedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserSessionUpdateTenant provides a mock function with given fields: ctx, id, tenantID
+func (_m *Store) UserSessionUpdateTenant(ctx context.Context, id string, tenantID string) error {
+	ret := _m.Called(ctx, id, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserUpdateAccountStatus provides a mock function with given fields: ctx, id
 func (_m *Store) UserUpdateAccountStatus(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -2401,6 +3252,88 @@ func (_m *Store) UserUpdatePassword(ctx context.Context, newPassword string, id
 	return r0
 }
 
+// WebAuthnCredentialCreate provides a mock function with given fields: ctx, credential
+func (_m *Store) WebAuthnCredentialCreate(ctx context.Context, credential *models.WebAuthnCredential) error {
+	ret := _m.Called(ctx, credential)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebAuthnCredential) error); ok {
+		r0 = rf(ctx, credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebAuthnCredentialDelete provides a mock function with given fields: ctx, userID, id
+func (_m *Store) WebAuthnCredentialDelete(ctx context.Context, userID string, id string) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebAuthnCredentialList provides a mock function with given fields: ctx, userID
+func (_m *Store) WebAuthnCredentialList(ctx context.Context, userID string) ([]models.WebAuthnCredential, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []models.WebAuthnCredential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.WebAuthnCredential, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.WebAuthnCredential); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebAuthnCredential)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebAuthnCredentialRename provides a mock function with given fields: ctx, userID, id, name
+func (_m *Store) WebAuthnCredentialRename(ctx context.Context, userID string, id string, name string) error {
+	ret := _m.Called(ctx, userID, id, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, userID, id, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebAuthnCredentialUse provides a mock function with given fields: ctx, id, signCount, usedAt
+func (_m *Store) WebAuthnCredentialUse(ctx context.Context, id string, signCount uint32, usedAt time.Time) error {
+	ret := _m.Called(ctx, id, signCount, usedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint32, time.Time) error); ok {
+		r0 = rf(ctx, id, signCount, usedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStore(t interface {
@@ -0,0 +1,119 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertificateAuthorityCreate(t *testing.T) {
+	cases := []struct {
+		description string
+		ca          *models.CertificateAuthority
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "succeeds when data is valid",
+			ca: &models.CertificateAuthority{
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Data:        []byte("test"),
+				PublicKey:   "public_key",
+				Fingerprint: "fingerprint",
+				CreatedAt:   time.Now(),
+			},
+			fixtures: []string{},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.CertificateAuthorityCreate(context.TODO(), tc.ca)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestCertificateAuthorityGet(t *testing.T) {
+	type Expected struct {
+		ca  *models.CertificateAuthority
+		err error
+	}
+
+	cases := []struct {
+		description string
+		tenant      string
+		setup       func(context.Context, store.Store) error
+		expected    Expected
+	}{
+		{
+			description: "fails when certificate authority is not found",
+			tenant:      "nonexistent",
+			setup: func(context.Context, store.Store) error {
+				return nil
+			},
+			expected: Expected{
+				ca:  nil,
+				err: store.ErrNoDocuments,
+			},
+		},
+		{
+			description: "succeeds when certificate authority is found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			setup: func(ctx context.Context, s store.Store) error {
+				return s.CertificateAuthorityCreate(ctx, &models.CertificateAuthority{
+					TenantID:    "00000000-0000-4000-0000-000000000000",
+					Data:        []byte("test"),
+					PublicKey:   "public_key",
+					Fingerprint: "fingerprint",
+					CreatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				})
+			},
+			expected: Expected{
+				ca: &models.CertificateAuthority{
+					TenantID:    "00000000-0000-4000-0000-000000000000",
+					Data:        []byte("test"),
+					PublicKey:   "public_key",
+					Fingerprint: "fingerprint",
+					CreatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				},
+				err: nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.TODO()
+
+			assert.NoError(t, tc.setup(ctx, mongostore))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			ca, err := mongostore.CertificateAuthorityGet(ctx, tc.tenant)
+			assert.Equal(t, tc.expected, Expected{ca: ca, err: err})
+		})
+	}
+}
@@ -682,9 +682,130 @@ func (s *Store) DeviceCreatePublicURLAddress(ctx context.Context, uid models.UID
 
 func (s *Store) DeviceGetByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
 	device := new(models.Device)
-	if err := s.db.Collection("devices").FindOne(ctx, bson.M{"public_url_address": address}).Decode(&device); err != nil {
+	filter := bson.M{
+		"$or": []bson.M{
+			{"public_url_address": address},
+			{"public_url_services.address": address},
+		},
+	}
+
+	if err := s.db.Collection("devices").FindOne(ctx, filter).Decode(&device); err != nil {
 		return nil, FromMongoError(err)
 	}
 
 	return device, nil
 }
+
+func (s *Store) DevicePublicURLServiceCreate(ctx context.Context, uid models.UID, service *models.DevicePublicURLService) error {
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$push": bson.M{"public_url_services": service}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DevicePublicURLServiceUpdate(ctx context.Context, uid models.UID, address string, service *models.DevicePublicURLService) error {
+	res, err := s.db.Collection("devices").UpdateOne(
+		ctx,
+		bson.M{"uid": uid, "public_url_services.address": address},
+		bson.M{"$set": bson.M{"public_url_services.$": service}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DevicePublicURLServiceDelete(ctx context.Context, uid models.UID, address string) error {
+	res, err := s.db.Collection("devices").UpdateOne(
+		ctx,
+		bson.M{"uid": uid, "public_url_services.address": address},
+		bson.M{"$pull": bson.M{"public_url_services": bson.M{"address": address}}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGetByPublishedPortAddress(ctx context.Context, address string) (*models.Device, error) {
+	device := new(models.Device)
+	if err := s.db.Collection("devices").FindOne(ctx, bson.M{"published_ports.address": address}).Decode(&device); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return device, nil
+}
+
+func (s *Store) DeviceGetByPublishedPortGatewayPort(ctx context.Context, port int) (*models.Device, error) {
+	device := new(models.Device)
+	if err := s.db.Collection("devices").FindOne(ctx, bson.M{"published_ports.gateway_port": port}).Decode(&device); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return device, nil
+}
+
+func (s *Store) DevicePublishedPortListGatewayPorts(ctx context.Context) ([]int, error) {
+	list, err := s.db.Collection("devices").Distinct(ctx, "published_ports.gateway_port", bson.M{})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	ports := make([]int, 0, len(list))
+	for _, item := range list {
+		switch port := item.(type) {
+		case int32:
+			ports = append(ports, int(port))
+		case int64:
+			ports = append(ports, int(port))
+		}
+	}
+
+	return ports, nil
+}
+
+func (s *Store) DevicePublishedPortCreate(ctx context.Context, uid models.UID, port *models.DevicePublishedPort) error {
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$push": bson.M{"published_ports": port}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DevicePublishedPortDelete(ctx context.Context, uid models.UID, address string) error {
+	res, err := s.db.Collection("devices").UpdateOne(
+		ctx,
+		bson.M{"uid": uid, "published_ports.address": address},
+		bson.M{"$pull": bson.M{"published_ports": bson.M{"address": address}}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceConnectivityRecord(ctx context.Context, uid models.UID, online bool, timestamp time.Time) error {
+	// NOTICE: the device keeps the state of its last recorded transition, so the transition is derived from the
+	// device's state before this update, atomically and without loading the whole device.
+	device := new(struct {
+		UID          string    `bson:"uid"`
+		TenantID     string    `bson:"tenant_id"`
+		LastSeen     time.Time `bson:"last_seen"`
+		Connectivity struct {
+			Online bool `bson:"online"`
+		} `bson:"connectivity"`
+	})
+
+	if err := s.db.Collection("devices").FindOneAndUpdate(
+		ctx,
+		bson.M{"uid": uid},
+		bson.M{"$set": bson.M{"connectivity.online": online}},
+		options.FindOneAndUpdate().
+			SetReturnDocument(options.Before).
+			SetProjection(bson.M{"uid": 1, "tenant_id": 1, "last_seen": 1, "connectivity": 1}),
+	).Decode(device); err != nil {
+		return FromMongoError(err)
+	}
+
+	// NOTICE: a device without a recorded transition is offline.
+	was := device.Connectivity.Online
+
+	events := make([]interface{}, 0, 2)
+	switch {
+	case online && was:
+		// NOTICE: when the device stops sending heartbeats, it goes offline without notice as soon as it is removed
+		// from the connected devices, so that transition is recorded at the next heartbeat.
+		if timestamp.Sub(device.LastSeen) <= models.DeviceOnlineTimeout {
+			return nil
+		}
+
+		events = append(events,
+			&models.DeviceConnectivityEvent{UID: device.UID, TenantID: device.TenantID, Online: false, Timestamp: device.LastSeen.Add(models.DeviceOnlineTimeout)},
+			&models.DeviceConnectivityEvent{UID: device.UID, TenantID: device.TenantID, Online: true, Timestamp: timestamp},
+		)
+	case online:
+		events = append(events, &models.DeviceConnectivityEvent{UID: device.UID, TenantID: device.TenantID, Online: true, Timestamp: timestamp})
+	case was:
+		events = append(events, &models.DeviceConnectivityEvent{UID: device.UID, TenantID: device.TenantID, Online: false, Timestamp: timestamp})
+	default:
+		return nil
+	}
+
+	if _, err := s.db.Collection("device_connectivity_events").InsertMany(ctx, events); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceConnectivityList(ctx context.Context, uid models.UID, from, to time.Time) ([]models.DeviceConnectivityEvent, error) {
+	events := make([]models.DeviceConnectivityEvent, 0)
+
+	initial := new(models.DeviceConnectivityEvent)
+	err := s.db.Collection("device_connectivity_events").
+		FindOne(ctx, bson.M{"uid": uid, "timestamp": bson.M{"$lte": from}}, options.FindOne().SetSort(bson.M{"timestamp": -1})).
+		Decode(initial)
+	switch {
+	case err == nil:
+		events = append(events, *initial)
+	case err != mongo.ErrNoDocuments:
+		return nil, FromMongoError(err)
+	}
+
+	filter := bson.M{"uid": uid, "timestamp": bson.M{"$gt": from, "$lte": to}}
+
+	cursor, err := s.db.Collection("device_connectivity_events").Find(ctx, filter, options.Find().SetSort(bson.M{"timestamp": 1}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	listed := make([]models.DeviceConnectivityEvent, 0)
+	if err := cursor.All(ctx, &listed); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return append(events, listed...), nil
+}
@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDeviceConnectivityRecord(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	ctx := context.TODO()
+
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceConnectivityRecord(ctx, models.UID("nonexistent"), true, start))
+
+	_, err := db.Client().Database("test").Collection("devices").InsertOne(ctx, &models.Device{UID: "uid", TenantID: "tenant", LastSeen: start})
+	assert.NoError(t, err)
+
+	// NOTICE: an offline device going offline is not a transition.
+	assert.NoError(t, mongostore.DeviceConnectivityRecord(ctx, models.UID("uid"), false, start))
+	assert.NoError(t, mongostore.DeviceConnectivityRecord(ctx, models.UID("uid"), true, start))
+	// NOTICE: a heartbeat within the timeout keeps the device online.
+	assert.NoError(t, mongostore.DeviceConnectivityRecord(ctx, models.UID("uid"), true, start.Add(time.Minute)))
+	// NOTICE: a heartbeat after the timeout means the device went offline without notice.
+	assert.NoError(t, mongostore.DeviceConnectivityRecord(ctx, models.UID("uid"), true, start.Add(time.Hour)))
+	assert.NoError(t, mongostore.DeviceConnectivityRecord(ctx, models.UID("uid"), false, start.Add(2*time.Hour)))
+
+	// NOTICE: the device keeps the state of its last recorded transition.
+	device := make(bson.M)
+	assert.NoError(t, db.Client().Database("test").Collection("devices").FindOne(ctx, bson.M{"uid": "uid"}).Decode(&device))
+	assert.Equal(t, bson.M{"online": false}, device["connectivity"])
+
+	events, err := mongostore.DeviceConnectivityList(ctx, models.UID("uid"), start.Add(-time.Hour), start.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []models.DeviceConnectivityEvent{
+		{UID: "uid", TenantID: "tenant", Online: true, Timestamp: start},
+		{UID: "uid", TenantID: "tenant", Online: false, Timestamp: start.Add(models.DeviceOnlineTimeout)},
+		{UID: "uid", TenantID: "tenant", Online: true, Timestamp: start.Add(time.Hour)},
+		{UID: "uid", TenantID: "tenant", Online: false, Timestamp: start.Add(2 * time.Hour)},
+	}, events)
+
+	// NOTICE: the last event before the range is the device's state at its start.
+	events, err = mongostore.DeviceConnectivityList(ctx, models.UID("uid"), start.Add(90*time.Minute), start.Add(3*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []models.DeviceConnectivityEvent{
+		{UID: "uid", TenantID: "tenant", Online: true, Timestamp: start.Add(time.Hour)},
+		{UID: "uid", TenantID: "tenant", Online: false, Timestamp: start.Add(2 * time.Hour)},
+	}, events)
+}
@@ -0,0 +1,157 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// decodeDeviceGroupFilters restores the params of the group's filters, decoded as documents, to the structures of their
+// types.
+func decodeDeviceGroupFilters(group *models.DeviceGroup) error {
+	for i, filter := range group.Filter {
+		var params interface{}
+		switch filter.Type {
+		case "property":
+			params = new(models.PropertyParams)
+		case "operator":
+			params = new(models.OperatorParams)
+		default:
+			continue
+		}
+
+		data, err := bson.Marshal(filter.Params)
+		if err != nil {
+			return err
+		}
+
+		if err := bson.Unmarshal(data, params); err != nil {
+			return err
+		}
+
+		group.Filter[i].Params = params
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGroupList(ctx context.Context, pagination paginator.Query) ([]models.DeviceGroup, int, error) {
+	query := []bson.M{}
+
+	// Only match for the respective tenant if requested
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		query = append(query, bson.M{
+			"$match": bson.M{
+				"tenant_id": tenant.ID,
+			},
+		})
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_groups"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"name": 1}})
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	groups := make([]models.DeviceGroup, 0)
+	cursor, err := s.db.Collection("device_groups").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		group := new(models.DeviceGroup)
+		if err := cursor.Decode(group); err != nil {
+			return groups, count, FromMongoError(err)
+		}
+
+		if err := decodeDeviceGroupFilters(group); err != nil {
+			return groups, count, err
+		}
+
+		groups = append(groups, *group)
+	}
+
+	return groups, count, FromMongoError(cursor.Err())
+}
+
+func (s *Store) DeviceGroupGet(ctx context.Context, tenant, id string) (*models.DeviceGroup, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	group := new(models.DeviceGroup)
+	if err := s.db.Collection("device_groups").FindOne(ctx, bson.M{"_id": objID, "tenant_id": tenant}).Decode(group); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	if err := decodeDeviceGroupFilters(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+func (s *Store) DeviceGroupCreate(ctx context.Context, group *models.DeviceGroup) (string, error) {
+	result, err := s.db.Collection("device_groups").InsertOne(ctx, group)
+	if err != nil {
+		return "", FromMongoError(err)
+	}
+
+	return result.InsertedID.(primitive.ObjectID).Hex(), nil
+}
+
+func (s *Store) DeviceGroupUpdate(ctx context.Context, tenant, id string, group *models.DeviceGroupUpdate) (*models.DeviceGroup, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	updateOpts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	result := s.db.Collection("device_groups").FindOneAndUpdate(ctx, bson.M{"_id": objID, "tenant_id": tenant}, bson.M{"$set": group}, updateOpts)
+	if result.Err() != nil {
+		return nil, FromMongoError(result.Err())
+	}
+
+	updated := new(models.DeviceGroup)
+	if err := result.Decode(updated); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	if err := decodeDeviceGroupFilters(updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (s *Store) DeviceGroupDelete(ctx context.Context, tenant, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	result, err := s.db.Collection("device_groups").DeleteOne(ctx, bson.M{"_id": objID, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceGroupCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	group := &models.DeviceGroup{
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		CreatedBy: "507f1f77bcf86cd799439011",
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name: "arm64",
+			Filter: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "info.arch", Operator: "eq", Value: "arm64"}},
+				{Type: "property", Params: &models.PropertyParams{Name: "online", Operator: "bool", Value: true}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+			},
+			SortBy:    "name",
+			OrderBy:   "asc",
+			UpdatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	ctx := context.TODO()
+
+	id, err := mongostore.DeviceGroupCreate(ctx, group)
+	assert.NoError(t, err)
+
+	created, err := mongostore.DeviceGroupGet(ctx, group.TenantID, id)
+	assert.NoError(t, err)
+
+	group.ID = id
+	assert.Equal(t, group, created)
+
+	_, err = mongostore.DeviceGroupGet(ctx, "nonexistent", id)
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
+
+func TestDeviceGroupUpdate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	ctx := context.TODO()
+
+	id, err := mongostore.DeviceGroupCreate(ctx, &models.DeviceGroup{
+		TenantID:          "00000000-0000-4000-0000-000000000000",
+		DeviceGroupFields: models.DeviceGroupFields{Name: "arm64"},
+	})
+	assert.NoError(t, err)
+
+	update := &models.DeviceGroupUpdate{
+		DeviceGroupFields: models.DeviceGroupFields{
+			Name: "online",
+			Filter: []models.Filter{
+				{Type: "property", Params: &models.PropertyParams{Name: "online", Operator: "bool", Value: "true"}},
+				{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+			},
+			SortBy:    "last_seen",
+			OrderBy:   "desc",
+			UpdatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	_, err = mongostore.DeviceGroupUpdate(ctx, "nonexistent", id, update)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	updated, err := mongostore.DeviceGroupUpdate(ctx, "00000000-0000-4000-0000-000000000000", id, update)
+	assert.NoError(t, err)
+	assert.Equal(t, update.DeviceGroupFields, updated.DeviceGroupFields)
+}
+
+func TestDeviceGroupDelete(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	ctx := context.TODO()
+
+	id, err := mongostore.DeviceGroupCreate(ctx, &models.DeviceGroup{
+		TenantID:          "00000000-0000-4000-0000-000000000000",
+		DeviceGroupFields: models.DeviceGroupFields{Name: "arm64"},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceGroupDelete(ctx, "nonexistent", id))
+	assert.NoError(t, mongostore.DeviceGroupDelete(ctx, "00000000-0000-4000-0000-000000000000", id))
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceGroupDelete(ctx, "00000000-0000-4000-0000-000000000000", id))
+}
@@ -0,0 +1,71 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) DeviceInventoryCreate(ctx context.Context, snapshot *models.DeviceInventorySnapshot) error {
+	result, err := s.db.Collection("devices").UpdateOne(
+		ctx,
+		bson.M{"uid": snapshot.UID, "tenant_id": snapshot.TenantID},
+		bson.M{"$set": bson.M{"inventory": snapshot.DeviceInventory}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if _, err := s.db.Collection("device_inventories").InsertOne(ctx, snapshot); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceInventoryList(ctx context.Context, tenant string, uid models.UID, pagination paginator.Query) ([]models.DeviceInventory, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"uid":       uid,
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_inventories"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"reported_at": -1}})
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	inventories := make([]models.DeviceInventory, 0)
+	cursor, err := s.db.Collection("device_inventories").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		inventory := new(models.DeviceInventory)
+		if err := cursor.Decode(inventory); err != nil {
+			return inventories, count, FromMongoError(err)
+		}
+
+		inventories = append(inventories, *inventory)
+	}
+
+	return inventories, count, FromMongoError(cursor.Err())
+}
@@ -0,0 +1,93 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceInventoryCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureDevices))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	ctx := context.TODO()
+	uid := "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"
+
+	inventory := models.DeviceInventory{
+		ReportedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		Kernel:     "6.1.0",
+		CPU:        models.DeviceInventoryCPU{Model: "ARMv7 Processor rev 4 (v7l)", Count: 4},
+		Memory:     models.DeviceInventoryMemory{Total: 1024, Available: 512},
+		Disks:      []models.DeviceInventoryDisk{{Device: "/dev/mmcblk0p2", Mountpoint: "/", Filesystem: "ext4", Total: 2048, Used: 1024}},
+		Interfaces: []models.DeviceInventoryInterface{{Name: "eth0", MAC: "mac-1", Addresses: []string{"192.168.1.2/24"}, Primary: true}},
+		Uptime:     3600,
+		Packages:   512,
+	}
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceInventoryCreate(ctx, &models.DeviceInventorySnapshot{
+		UID:             uid,
+		TenantID:        "nonexistent",
+		DeviceInventory: inventory,
+	}))
+
+	assert.NoError(t, mongostore.DeviceInventoryCreate(ctx, &models.DeviceInventorySnapshot{
+		UID:             uid,
+		TenantID:        "00000000-0000-4000-0000-000000000000",
+		DeviceInventory: inventory,
+	}))
+
+	device, err := mongostore.DeviceGet(ctx, models.UID(uid))
+	assert.NoError(t, err)
+	assert.Equal(t, &inventory, device.Inventory)
+}
+
+func TestDeviceInventoryList(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureDevices))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	ctx := context.TODO()
+	uid := "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"
+
+	for _, day := range []int{1, 2, 3} {
+		assert.NoError(t, mongostore.DeviceInventoryCreate(ctx, &models.DeviceInventorySnapshot{
+			UID:      uid,
+			TenantID: "00000000-0000-4000-0000-000000000000",
+			DeviceInventory: models.DeviceInventory{
+				ReportedAt: time.Date(2023, 1, day, 12, 0, 0, 0, time.UTC),
+				Uptime:     uint64(day),
+			},
+		}))
+	}
+
+	inventories, count, err := mongostore.DeviceInventoryList(ctx, "00000000-0000-4000-0000-000000000000", models.UID(uid), paginator.Query{Page: 1, PerPage: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 2, len(inventories))
+	assert.Equal(t, uint64(3), inventories[0].Uptime)
+	assert.Equal(t, uint64(2), inventories[1].Uptime)
+
+	inventories, count, err = mongostore.DeviceInventoryList(ctx, "nonexistent", models.UID(uid), paginator.Query{Page: 1, PerPage: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, 0, len(inventories))
+}
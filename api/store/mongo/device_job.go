@@ -0,0 +1,62 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) DeviceJobCreate(ctx context.Context, job *models.DeviceJob) error {
+	if _, err := s.db.Collection("device_jobs").InsertOne(ctx, job); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceJobGet(ctx context.Context, id string) (*models.DeviceJob, error) {
+	job := new(models.DeviceJob)
+	if err := s.db.Collection("device_jobs").FindOne(ctx, bson.M{"_id": id}).Decode(job); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Store) DeviceJobSetStatus(ctx context.Context, id string, status models.DeviceJobStatus, finishedAt *time.Time) error {
+	set := bson.M{"status": status}
+	if finishedAt != nil {
+		set["finished_at"] = finishedAt
+	}
+
+	result, err := s.db.Collection("device_jobs").UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": set})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceJobSetItem(ctx context.Context, id string, item models.DeviceJobItem) error {
+	result, err := s.db.Collection("device_jobs").UpdateOne(
+		ctx,
+		bson.M{"_id": id, "items.uid": item.UID},
+		bson.M{"$set": bson.M{"items.$": item}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
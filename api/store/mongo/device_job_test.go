@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceJob(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	ctx := context.TODO()
+
+	job := &models.DeviceJob{
+		ID:        "00000000-0000-4000-0000-000000000001",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		CreatedBy: "507f1f77bcf86cd799439011",
+		Operation: models.DeviceJobOperationTag,
+		Tag:       "production",
+		Status:    models.DeviceJobStatusPending,
+		Items: []models.DeviceJobItem{
+			{UID: "uid", Status: models.DeviceJobItemStatusPending},
+			{UID: "uid2", Status: models.DeviceJobItemStatusPending},
+		},
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	assert.NoError(t, mongostore.DeviceJobCreate(ctx, job))
+
+	created, err := mongostore.DeviceJobGet(ctx, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, job, created)
+
+	assert.NoError(t, mongostore.DeviceJobSetStatus(ctx, job.ID, models.DeviceJobStatusRunning, nil))
+	assert.NoError(t, mongostore.DeviceJobSetItem(ctx, job.ID, models.DeviceJobItem{
+		UID:    "uid2",
+		Status: models.DeviceJobItemStatusFailed,
+		Error:  "device not found",
+	}))
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceJobSetItem(ctx, job.ID, models.DeviceJobItem{UID: "nonexistent"}))
+
+	finishedAt := time.Date(2023, 1, 1, 12, 5, 0, 0, time.UTC)
+	assert.NoError(t, mongostore.DeviceJobSetStatus(ctx, job.ID, models.DeviceJobStatusFinished, &finishedAt))
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceJobSetStatus(ctx, "nonexistent", models.DeviceJobStatusFinished, nil))
+
+	finished, err := mongostore.DeviceJobGet(ctx, job.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, models.DeviceJobStatusFinished, finished.Status)
+	assert.Equal(t, &finishedAt, finished.FinishedAt)
+	assert.Equal(t, []models.DeviceJobItem{
+		{UID: "uid", Status: models.DeviceJobItemStatusPending},
+		{UID: "uid2", Status: models.DeviceJobItemStatusFailed, Error: "device not found"},
+	}, finished.Items)
+
+	_, err = mongostore.DeviceJobGet(ctx, "nonexistent")
+	assert.Equal(t, store.ErrNoDocuments, err)
+}
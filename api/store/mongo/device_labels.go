@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) DeviceSetLabels(ctx context.Context, uid models.UID, labels map[string]string) error {
+	set := bson.M{}
+	for key, value := range labels {
+		set["labels."+key] = value
+	}
+
+	l, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": set})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if l.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetAgentLabels(ctx context.Context, uid models.UID, labels map[string]string) error {
+	l, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"agent_labels": labels}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if l.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceUnsetLabel(ctx context.Context, uid models.UID, key string) error {
+	l, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$unset": bson.M{"labels." + key: ""}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if l.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceSetLabels(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureDevices))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	ctx := context.TODO()
+	uid := models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceSetLabels(ctx, models.UID("nonexistent"), map[string]string{"site": "lisbon"}))
+
+	assert.NoError(t, mongostore.DeviceSetLabels(ctx, uid, map[string]string{"site": "lisbon", "owner": "team-x"}))
+	assert.NoError(t, mongostore.DeviceSetLabels(ctx, uid, map[string]string{"site": "porto"}))
+
+	device, err := mongostore.DeviceGet(ctx, uid)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"site": "porto", "owner": "team-x"}, device.Labels)
+}
+
+func TestDeviceSetAgentLabels(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureDevices))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	ctx := context.TODO()
+	uid := models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceSetAgentLabels(ctx, models.UID("nonexistent"), map[string]string{"site": "lisbon"}))
+
+	assert.NoError(t, mongostore.DeviceSetLabels(ctx, uid, map[string]string{"site": "lisbon"}))
+	assert.NoError(t, mongostore.DeviceSetAgentLabels(ctx, uid, map[string]string{"site": "porto", "arch": "arm64"}))
+	assert.NoError(t, mongostore.DeviceSetAgentLabels(ctx, uid, map[string]string{"site": "porto"}))
+
+	device, err := mongostore.DeviceGet(ctx, uid)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"site": "lisbon"}, device.Labels)
+	assert.Equal(t, map[string]string{"site": "porto"}, device.AgentLabels)
+}
+
+func TestDeviceUnsetLabel(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureDevices))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	ctx := context.TODO()
+	uid := models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceUnsetLabel(ctx, models.UID("nonexistent"), "site"))
+
+	assert.NoError(t, mongostore.DeviceSetLabels(ctx, uid, map[string]string{"site": "lisbon", "owner": "team-x"}))
+	assert.NoError(t, mongostore.DeviceUnsetLabel(ctx, uid, "site"))
+
+	device, err := mongostore.DeviceGet(ctx, uid)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "team-x"}, device.Labels)
+}
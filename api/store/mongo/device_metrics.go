@@ -0,0 +1,163 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) DeviceMetricsAdd(ctx context.Context, uid models.UID, tenant string, metrics *models.DeviceMetrics, rollups []models.DeviceMetricsRollup) error {
+	if len(rollups) == 0 {
+		return nil
+	}
+
+	inc := bson.M{
+		"samples":    1,
+		"sum.load1":  metrics.Load1,
+		"sum.load5":  metrics.Load5,
+		"sum.load15": metrics.Load15,
+		"sum.memory": metrics.Memory,
+		"sum.disk":   metrics.Disk,
+	}
+
+	maxes := bson.M{
+		"max.load1":  metrics.Load1,
+		"max.load5":  metrics.Load5,
+		"max.load15": metrics.Load15,
+		"max.memory": metrics.Memory,
+		"max.disk":   metrics.Disk,
+	}
+
+	// NOTICE: the temperature is only aggregated when reported, so its average considers just the samples with it.
+	if metrics.Temperature != nil {
+		inc["temperature_samples"] = 1
+		inc["sum.temperature"] = *metrics.Temperature
+		maxes["max.temperature"] = *metrics.Temperature
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(rollups))
+	for _, rollup := range rollups {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"uid": uid, "resolution": rollup.Resolution, "timestamp": rollup.Timestamp}).
+			SetUpdate(bson.M{
+				"$setOnInsert": bson.M{"tenant_id": tenant, "expires_at": rollup.ExpiresAt},
+				"$inc":         inc,
+				"$max":         maxes,
+			}).
+			SetUpsert(true),
+		)
+	}
+
+	if _, err := s.db.Collection("device_metrics").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceMetricsList(ctx context.Context, tenant string, uid models.UID, resolution models.DeviceMetricsResolution, from, to time.Time) ([]models.DeviceMetricsBucket, error) {
+	filter := bson.M{
+		"uid":        uid,
+		"tenant_id":  tenant,
+		"resolution": resolution,
+		"timestamp":  bson.M{"$gte": from, "$lte": to},
+	}
+
+	cursor, err := s.db.Collection("device_metrics").Find(ctx, filter, options.Find().SetSort(bson.M{"timestamp": 1}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	buckets := make([]models.DeviceMetricsBucket, 0)
+	if err := cursor.All(ctx, &buckets); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return buckets, nil
+}
+
+func (s *Store) DeviceAlertCreate(ctx context.Context, alert *models.DeviceAlert) error {
+	if _, err := s.db.Collection("device_alerts").InsertOne(ctx, alert); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceAlertListActive(ctx context.Context, uid models.UID) ([]models.DeviceAlert, error) {
+	cursor, err := s.db.Collection("device_alerts").Find(ctx, bson.M{"uid": uid, "resolved_at": nil})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	alerts := make([]models.DeviceAlert, 0)
+	if err := cursor.All(ctx, &alerts); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return alerts, nil
+}
+
+func (s *Store) DeviceAlertResolve(ctx context.Context, id string, resolvedAt time.Time) error {
+	result, err := s.db.Collection("device_alerts").UpdateOne(
+		ctx,
+		bson.M{"_id": id, "resolved_at": nil},
+		bson.M{"$set": bson.M{"resolved_at": resolvedAt}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceAlertList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAlert, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_alerts"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"raised_at": -1}})
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	alerts := make([]models.DeviceAlert, 0)
+	cursor, err := s.db.Collection("device_alerts").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		alert := new(models.DeviceAlert)
+		if err := cursor.Decode(alert); err != nil {
+			return alerts, count, FromMongoError(err)
+		}
+
+		alerts = append(alerts, *alert)
+	}
+
+	return alerts, count, FromMongoError(cursor.Err())
+}
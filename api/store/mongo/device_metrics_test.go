@@ -0,0 +1,84 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceMetricsAdd(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	ctx := context.TODO()
+
+	timestamp := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	rollups := []models.DeviceMetricsRollup{
+		{Resolution: models.DeviceMetricsResolutionMinute, Timestamp: timestamp, ExpiresAt: timestamp.Add(24 * time.Hour)},
+		{Resolution: models.DeviceMetricsResolutionHour, Timestamp: timestamp, ExpiresAt: timestamp.Add(30 * 24 * time.Hour)},
+	}
+
+	temperature := 60.0
+
+	assert.NoError(t, mongostore.DeviceMetricsAdd(ctx, "uid", "tenant", &models.DeviceMetrics{Load1: 1, Memory: 40, Disk: 50, Temperature: &temperature}, rollups))
+	assert.NoError(t, mongostore.DeviceMetricsAdd(ctx, "uid", "tenant", &models.DeviceMetrics{Load1: 3, Memory: 60, Disk: 50}, rollups))
+
+	buckets, err := mongostore.DeviceMetricsList(ctx, "tenant", "uid", models.DeviceMetricsResolutionHour, timestamp.Add(-time.Hour), timestamp.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(buckets))
+	assert.Equal(t, 2, buckets[0].Samples)
+	assert.Equal(t, 1, buckets[0].TemperatureSamples)
+	assert.Equal(t, 4.0, buckets[0].Sum.Load1)
+	assert.Equal(t, 3.0, buckets[0].Max.Load1)
+	assert.Equal(t, 60.0, buckets[0].Max.Memory)
+	assert.Equal(t, &temperature, buckets[0].Max.Temperature)
+
+	buckets, err = mongostore.DeviceMetricsList(ctx, "nonexistent", "uid", models.DeviceMetricsResolutionHour, timestamp.Add(-time.Hour), timestamp.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(buckets))
+}
+
+func TestDeviceAlerts(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	ctx := context.TODO()
+
+	raisedAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, id := range []string{"disk", "memory"} {
+		assert.NoError(t, mongostore.DeviceAlertCreate(ctx, &models.DeviceAlert{
+			ID:        id,
+			UID:       "uid",
+			TenantID:  "tenant",
+			Metric:    models.DeviceMetric(id),
+			Value:     95,
+			Threshold: 90,
+			RaisedAt:  raisedAt,
+		}))
+	}
+
+	assert.NoError(t, mongostore.DeviceAlertResolve(ctx, "disk", raisedAt.Add(time.Hour)))
+	assert.Equal(t, store.ErrNoDocuments, mongostore.DeviceAlertResolve(ctx, "disk", raisedAt.Add(time.Hour)))
+
+	active, err := mongostore.DeviceAlertListActive(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(active))
+	assert.Equal(t, "memory", active[0].ID)
+
+	alerts, count, err := mongostore.DeviceAlertList(ctx, "tenant", paginator.Query{Page: 1, PerPage: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 2, len(alerts))
+}
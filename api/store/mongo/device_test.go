@@ -1415,3 +1415,218 @@ func TestDeviceDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestDevicePublicURLServiceCreate(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		service     *models.DevicePublicURLService
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("nonexistent"),
+			service:     &models.DevicePublicURLService{Address: "address", Port: 3000, Scheme: "http"},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			service:     &models.DevicePublicURLService{Address: "address", Port: 3000, Scheme: "http"},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DevicePublicURLServiceCreate(context.TODO(), tc.uid, tc.service)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := mongostore.DeviceGetByPublicURLAddress(context.TODO(), tc.service.Address)
+				assert.NoError(t, err)
+				assert.Equal(t, string(tc.uid), device.UID)
+				assert.Equal(t, []models.DevicePublicURLService{*tc.service}, device.PublicURLServices)
+			}
+		})
+	}
+}
+
+func TestDevicePublicURLServiceDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		address     string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the service is not found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			address:     "nonexistent",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the service is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			address:     "address",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			service := &models.DevicePublicURLService{Address: "address", Port: 3000, Scheme: "http"}
+			assert.NoError(t, mongostore.DevicePublicURLServiceCreate(context.TODO(), tc.uid, service))
+
+			err := mongostore.DevicePublicURLServiceDelete(context.TODO(), tc.uid, tc.address)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDevicePublishedPortCreate(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		port        *models.DevicePublishedPort
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("nonexistent"),
+			port:        &models.DevicePublishedPort{Address: "address", Port: 5432},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			port:        &models.DevicePublishedPort{Address: "address", Port: 5432},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DevicePublishedPortCreate(context.TODO(), tc.uid, tc.port)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := mongostore.DeviceGetByPublishedPortAddress(context.TODO(), tc.port.Address)
+				assert.NoError(t, err)
+				assert.Equal(t, string(tc.uid), device.UID)
+				assert.Equal(t, []models.DevicePublishedPort{*tc.port}, device.PublishedPorts)
+			}
+		})
+	}
+}
+
+func TestDevicePublishedPortDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		address     string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the port is not found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			address:     "nonexistent",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the port is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			address:     "address",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			port := &models.DevicePublishedPort{Address: "address", Port: 5432}
+			assert.NoError(t, mongostore.DevicePublishedPortCreate(context.TODO(), tc.uid, port))
+
+			err := mongostore.DevicePublishedPortDelete(context.TODO(), tc.uid, tc.address)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDevicePublishedPortGatewayPorts(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureDevices))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	uid := models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c")
+
+	ports, err := mongostore.DevicePublishedPortListGatewayPorts(context.TODO())
+	assert.NoError(t, err)
+	assert.Empty(t, ports)
+
+	_, err = mongostore.DeviceGetByPublishedPortGatewayPort(context.TODO(), 20000)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	assert.NoError(t, mongostore.DevicePublishedPortCreate(context.TODO(), uid, &models.DevicePublishedPort{Address: "address", Port: 5432, GatewayPort: 20000}))
+	assert.NoError(t, mongostore.DevicePublishedPortCreate(context.TODO(), uid, &models.DevicePublishedPort{Address: "other", Port: 22}))
+
+	ports, err = mongostore.DevicePublishedPortListGatewayPorts(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []int{20000}, ports)
+
+	device, err := mongostore.DeviceGetByPublishedPortGatewayPort(context.TODO(), 20000)
+	assert.NoError(t, err)
+	assert.Equal(t, string(uid), device.UID)
+}
@@ -0,0 +1,15 @@
+package mongo
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+)
+
+var commandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "mongo",
+	Name:      "command_duration_seconds",
+	Help:      "Duration of the commands sent to Mongo by command and status.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"command", "status"})
@@ -73,6 +73,20 @@ func GenerateMigrations() []migrate.Migration {
 		migration61,
 		migration62,
 		migration63,
+		migration64,
+		migration65,
+		migration66,
+		migration67,
+		migration68,
+		migration69,
+		migration70,
+		migration71,
+		migration72,
+		migration73,
+		migration74,
+		migration75,
+		migration76,
+		migration77,
 	}
 }
 
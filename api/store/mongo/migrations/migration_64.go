@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration64 = migrate.Migration{
+	Version:     64,
+	Description: "create unique index for tenant_id on certificate_authorities",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   64,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		_, err := database.Collection("certificate_authorities").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.M{
+				"tenant_id": 1,
+			},
+			Options: options.Index().SetName("tenant_id").SetUnique(true),
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   64,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 64")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   64,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 64")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   64,
+			"action":    "Down",
+		}).Info("Applying migration down")
+		if _, err := database.Collection("certificate_authorities").Indexes().DropOne(context.Background(), "tenant_id"); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
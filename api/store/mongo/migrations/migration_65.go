@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration65 = migrate.Migration{
+	Version:     65,
+	Description: "create indexes for id, user_id and expires_at on user_sessions",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		_, err := database.Collection("user_sessions").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys:    bson.M{"id": 1},
+				Options: options.Index().SetName("id").SetUnique(true),
+			},
+			{
+				Keys:    bson.M{"user_id": 1},
+				Options: options.Index().SetName("user_id"),
+			},
+			{
+				// NOTICE: expired sessions are removed by the database.
+				Keys:    bson.M{"expires_at": 1},
+				Options: options.Index().SetName("expires_at").SetExpireAfterSeconds(0),
+			},
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   65,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 65")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 65")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		for _, name := range []string{"id", "user_id", "expires_at"} {
+			if _, err := database.Collection("user_sessions").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,126 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envMocks "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration65Up(t *testing.T) {
+	logrus.Info("Testing Migration 65")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	cases := []struct {
+		description string
+		mocks       func()
+		expected    func() error
+	}{
+		{
+			description: "Success to apply up on migration 65",
+			mocks:       func() {},
+			expected: func() error {
+				cursor, err := db.Client().Database("test").Collection("user_sessions").Indexes().List(context.Background())
+				if err != nil {
+					return err
+				}
+
+				var found bool
+				for cursor.Next(context.Background()) {
+					var index bson.M
+					if err := cursor.Decode(&index); err != nil {
+						return err
+					}
+
+					if index["name"] == "expires_at" {
+						found = true
+					}
+				}
+
+				if !found {
+					return errors.New("index not created")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mocks()
+
+			migrations := GenerateMigrations()[64:65]
+			migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+			assert.NoError(t, migrates.Up(migrate.AllAvailable))
+
+			assert.NoError(t, tc.expected())
+		})
+	}
+}
+
+func TestMigration65Down(t *testing.T) {
+	logrus.Info("Testing Migration 65")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mock := &envMocks.Backend{}
+	envs.DefaultBackend = mock
+
+	cases := []struct {
+		description string
+		mocks       func()
+		expected    func() error
+	}{
+		{
+			description: "Success to apply down on migration 65",
+			mocks:       func() {},
+			expected: func() error {
+				cursor, err := db.Client().Database("test").Collection("user_sessions").Indexes().List(context.Background())
+				if err != nil {
+					return errors.New("index not dropped")
+				}
+
+				var found bool
+				for cursor.Next(context.Background()) {
+					var index bson.M
+					if err := cursor.Decode(&index); err != nil {
+						return err
+					}
+
+					if index["name"] == "expires_at" {
+						found = true
+					}
+				}
+
+				if found {
+					return errors.New("index not dropped")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.mocks()
+
+			migrations := GenerateMigrations()[64:65]
+			migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+			assert.NoError(t, migrates.Down(migrate.AllAvailable))
+
+			assert.NoError(t, tc.expected())
+		})
+	}
+}
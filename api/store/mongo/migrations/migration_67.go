@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration67 = migrate.Migration{
+	Version:     67,
+	Description: "create indexes for id, user_id and credential_id on webauthn_credentials",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   67,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		_, err := database.Collection("webauthn_credentials").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys:    bson.M{"id": 1},
+				Options: options.Index().SetName("id").SetUnique(true),
+			},
+			{
+				Keys:    bson.M{"user_id": 1},
+				Options: options.Index().SetName("user_id"),
+			},
+			{
+				Keys:    bson.M{"credential_id": 1},
+				Options: options.Index().SetName("credential_id").SetUnique(true),
+			},
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   67,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 67")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   67,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 67")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   67,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		for _, name := range []string{"id", "user_id", "credential_id"} {
+			if _, err := database.Collection("webauthn_credentials").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
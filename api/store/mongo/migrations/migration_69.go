@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration69 = migrate.Migration{
+	Version:     69,
+	Description: "create index for published_ports.address on devices",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   69,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		_, err := database.Collection("devices").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys:    bson.M{"published_ports.address": 1},
+			Options: options.Index().SetName("published_ports.address").SetUnique(true).SetSparse(true),
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   69,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 69")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   69,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 69")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   69,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		if _, err := database.Collection("devices").Indexes().DropOne(context.Background(), "published_ports.address"); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
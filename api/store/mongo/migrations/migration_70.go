@@ -0,0 +1,118 @@
+package migrations
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// migration70Indexes are the indexes that back the filters and the sorting of the session list.
+var migration70Indexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "started_at", Value: -1}},
+		Options: options.Index().SetName("tenant_id_started_at"),
+	},
+	{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "username", Value: 1}, {Key: "started_at", Value: -1}},
+		Options: options.Index().SetName("tenant_id_username_started_at"),
+	},
+	{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "device_uid", Value: 1}, {Key: "started_at", Value: -1}},
+		Options: options.Index().SetName("tenant_id_device_uid_started_at"),
+	},
+	{
+		Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "ip_number", Value: 1}},
+		Options: options.Index().SetName("tenant_id_ip_number"),
+	},
+}
+
+// migration70IPNumber converts an IP address to a fixed-width hexadecimal number of its 16 bytes form, what sorts the
+// addresses as numbers to filter the sessions by a network. It returns an empty string when the address is invalid.
+func migration70IPNumber(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(ip.To16())
+}
+
+var migration70 = migrate.Migration{
+	Version:     70,
+	Description: "set the sessions' IP number and create indexes to filter and sort the sessions",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   70,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		ctx := context.Background()
+
+		cursor, err := database.Collection("sessions").Find(ctx, bson.M{"ip_number": bson.M{"$exists": false}})
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			session := new(models.Session)
+			if err := cursor.Decode(session); err != nil {
+				return err
+			}
+
+			number := migration70IPNumber(session.IPAddress)
+			if number == "" {
+				continue
+			}
+
+			if _, err := database.Collection("sessions").UpdateOne(ctx, bson.M{"uid": session.UID}, bson.M{"$set": bson.M{"ip_number": number}}); err != nil {
+				return err
+			}
+		}
+
+		if _, err := database.Collection("sessions").Indexes().CreateMany(ctx, migration70Indexes); err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   70,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 70")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   70,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 70")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   70,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		for _, index := range migration70Indexes {
+			if _, err := database.Collection("sessions").Indexes().DropOne(context.Background(), *index.Options.Name); err != nil {
+				return err
+			}
+		}
+
+		if _, err := database.Collection("sessions").UpdateMany(context.Background(), bson.M{}, bson.M{"$unset": bson.M{"ip_number": ""}}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
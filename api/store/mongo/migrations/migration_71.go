@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration71 = migrate.Migration{
+	Version:     71,
+	Description: "create an unique index on the device group's name per namespace",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   71,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetName("tenant_id_name").SetUnique(true),
+		}
+
+		if _, err := database.Collection("device_groups").Indexes().CreateOne(context.Background(), index); err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   71,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 71")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   71,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 71")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   71,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		_, err := database.Collection("device_groups").Indexes().DropOne(context.Background(), "tenant_id_name")
+
+		return err
+	},
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration72 = migrate.Migration{
+	Version:     72,
+	Description: "expire the device jobs a week after they finish",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   72,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "finished_at", Value: 1}},
+			Options: options.Index().SetName("finished_at").SetExpireAfterSeconds(int32((7 * 24 * time.Hour).Seconds())),
+		}
+
+		if _, err := database.Collection("device_jobs").Indexes().CreateOne(context.Background(), index); err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   72,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 72")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   72,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 72")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   72,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		_, err := database.Collection("device_jobs").Indexes().DropOne(context.Background(), "finished_at")
+
+		return err
+	},
+}
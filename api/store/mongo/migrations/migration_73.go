@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration73 = migrate.Migration{
+	Version:     73,
+	Description: "create the wildcard index of the devices' labels",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   73,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "labels.$**", Value: 1}},
+			Options: options.Index().SetName("labels"),
+		}
+
+		if _, err := database.Collection("devices").Indexes().CreateOne(context.Background(), index); err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   73,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 73")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   73,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 73")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   73,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		_, err := database.Collection("devices").Indexes().DropOne(context.Background(), "labels")
+
+		return err
+	},
+}
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration74 = migrate.Migration{
+	Version:     74,
+	Description: "create indexes for uid and reported_at on device_inventories",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   74,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		_, err := database.Collection("device_inventories").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "uid", Value: 1}, {Key: "reported_at", Value: -1}},
+				Options: options.Index().SetName("uid_reported_at"),
+			},
+			{
+				// NOTICE: the inventories older than 30 days are removed from the history by the database.
+				Keys:    bson.M{"reported_at": 1},
+				Options: options.Index().SetName("reported_at").SetExpireAfterSeconds(30 * 24 * 60 * 60),
+			},
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   74,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 74")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   74,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 74")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   74,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		for _, name := range []string{"uid_reported_at", "reported_at"} {
+			if _, err := database.Collection("device_inventories").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
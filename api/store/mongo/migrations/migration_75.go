@@ -0,0 +1,93 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration75 = migrate.Migration{
+	Version:     75,
+	Description: "create indexes for device_metrics and device_alerts",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   75,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		_, err := database.Collection("device_metrics").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "uid", Value: 1}, {Key: "resolution", Value: 1}, {Key: "timestamp", Value: 1}},
+				Options: options.Index().SetName("uid_resolution_timestamp").SetUnique(true),
+			},
+			{
+				// NOTICE: each bucket is removed by the database when the retention of its resolution is reached.
+				Keys:    bson.M{"expires_at": 1},
+				Options: options.Index().SetName("expires_at").SetExpireAfterSeconds(0),
+			},
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   75,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 75")
+
+			return err
+		}
+
+		_, err = database.Collection("device_alerts").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "uid", Value: 1}, {Key: "resolved_at", Value: 1}},
+				Options: options.Index().SetName("uid_resolved_at"),
+			},
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "raised_at", Value: -1}},
+				Options: options.Index().SetName("tenant_id_raised_at"),
+			},
+		})
+		if err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   75,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 75")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   75,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 75")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   75,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		for _, name := range []string{"uid_resolution_timestamp", "expires_at"} {
+			if _, err := database.Collection("device_metrics").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		for _, name := range []string{"uid_resolved_at", "tenant_id_raised_at"} {
+			if _, err := database.Collection("device_alerts").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
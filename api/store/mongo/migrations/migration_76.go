@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration76 = migrate.Migration{
+	Version:     76,
+	Description: "create index for device_connectivity_events",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   76,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "uid", Value: 1}, {Key: "timestamp", Value: 1}},
+			Options: options.Index().SetName("uid_timestamp"),
+		}
+
+		if _, err := database.Collection("device_connectivity_events").Indexes().CreateOne(context.Background(), index); err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   76,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 76")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   76,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 76")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   76,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		if _, err := database.Collection("device_connectivity_events").Indexes().DropOne(context.Background(), "uid_timestamp"); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
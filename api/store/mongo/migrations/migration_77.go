@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration77 = migrate.Migration{
+	Version:     77,
+	Description: "create index for published_ports.gateway_port on devices",
+	Up: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   77,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		index := mongo.IndexModel{
+			Keys:    bson.M{"published_ports.gateway_port": 1},
+			Options: options.Index().SetName("published_ports.gateway_port").SetSparse(true),
+		}
+
+		if _, err := database.Collection("devices").Indexes().CreateOne(context.Background(), index); err != nil {
+			log.WithFields(log.Fields{
+				"component": "migration",
+				"version":   77,
+				"action":    "Up",
+			}).WithError(err).Info("Error while trying to apply migration 77")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   77,
+			"action":    "Up",
+		}).Info("Succeeds to to apply migration 77")
+
+		return nil
+	},
+	Down: func(database *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   77,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		if _, err := database.Collection("devices").Indexes().DropOne(context.Background(), "published_ports.gateway_port"); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,60 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/shellhub-io/shellhub/pkg/tracing"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// commandMonitor observes the duration of the commands sent to Mongo and traces the commands sent within a trace, like
+// the ones of the API's requests.
+func commandMonitor() *event.CommandMonitor {
+	// spans are the spans of the commands not finished yet, by the request's ID.
+	spans := new(sync.Map)
+
+	finish := func(id int64, err error) {
+		value, ok := spans.LoadAndDelete(id)
+		if !ok {
+			return
+		}
+
+		span := value.(trace.Span) //nolint:forcetypeassert
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			if !trace.SpanContextFromContext(ctx).IsValid() {
+				return
+			}
+
+			_, span := tracing.Tracer().Start(ctx, "mongo."+evt.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(semconv.DBSystemMongoDB, semconv.DBName(evt.DatabaseName), semconv.DBOperation(evt.CommandName)),
+			)
+
+			spans.Store(evt.RequestID, span)
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			commandDuration.WithLabelValues(evt.CommandName, "success").Observe(evt.Duration.Seconds())
+
+			finish(evt.RequestID, nil)
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			commandDuration.WithLabelValues(evt.CommandName, "failure").Observe(evt.Duration.Seconds())
+
+			finish(evt.RequestID, errors.New(evt.Failure))
+		},
+	}
+}
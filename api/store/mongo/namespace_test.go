@@ -806,6 +806,93 @@ func TestNamespaceSetSessionRecord(t *testing.T) {
 	}
 }
 
+func TestNamespaceSetAgentForwarding(t *testing.T) {
+	cases := []struct {
+		description     string
+		tenant          string
+		agentForwarding bool
+		fixtures        []string
+		expected        error
+	}{
+		{
+			description:     "fails when tenant is not found",
+			tenant:          "nonexistent",
+			agentForwarding: true,
+			fixtures:        []string{fixtures.FixtureNamespaces},
+			expected:        store.ErrNoDocuments,
+		},
+		{
+			description:     "succeeds when tenant is found",
+			tenant:          "00000000-0000-4000-0000-000000000000",
+			agentForwarding: true,
+			fixtures:        []string{fixtures.FixtureNamespaces},
+			expected:        nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.NamespaceSetAgentForwarding(context.TODO(), tc.agentForwarding, tc.tenant)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestNamespaceSetMetricsThresholds(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when tenant is not found",
+			tenant:      "nonexistent",
+			fixtures:    []string{fixtures.FixtureNamespaces},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when tenant is found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			fixtures:    []string{fixtures.FixtureNamespaces},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	thresholds := []models.DeviceMetricsThreshold{{Metric: models.DeviceMetricDisk, Value: 90}}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.NamespaceSetMetricsThresholds(context.TODO(), thresholds, tc.tenant)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				ns, err := mongostore.NamespaceGet(context.TODO(), tc.tenant)
+				assert.NoError(t, err)
+				assert.Equal(t, thresholds, ns.Settings.MetricsThresholds)
+			}
+		})
+	}
+}
+
 func TestNamespaceGetSessionRecord(t *testing.T) {
 	type Expected struct {
 		set bool
@@ -21,6 +21,20 @@ func (s *Store) PublicKeyGet(ctx context.Context, fingerprint string, tenantID s
 	return pubKey, nil
 }
 
+func (s *Store) PublicKeyListByFingerprint(ctx context.Context, fingerprint string) ([]models.PublicKey, error) {
+	cursor, err := s.db.Collection("public_keys").Find(ctx, bson.M{"fingerprint": fingerprint})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	keys := make([]models.PublicKey, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return keys, nil
+}
+
 func (s *Store) PublicKeyList(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error) {
 	query := []bson.M{
 		{
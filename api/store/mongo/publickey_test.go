@@ -88,6 +88,69 @@ func TestPublicKeyGet(t *testing.T) {
 	}
 }
 
+func TestPublicKeyListByFingerprint(t *testing.T) {
+	type Expected struct {
+		keys []models.PublicKey
+		err  error
+	}
+
+	cases := []struct {
+		description string
+		fingerprint string
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when no public key is found",
+			fingerprint: "nonexistent",
+			fixtures:    []string{fixtures.FixturePublicKeys},
+			expected: Expected{
+				keys: []models.PublicKey{},
+				err:  nil,
+			},
+		},
+		{
+			description: "succeeds when public keys are found",
+			fingerprint: "fingerprint",
+			fixtures:    []string{fixtures.FixturePublicKeys},
+			expected: Expected{
+				keys: []models.PublicKey{
+					{
+						Data:        []byte("test"),
+						CreatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						Fingerprint: "fingerprint",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						PublicKeyFields: models.PublicKeyFields{
+							Name: "public_key",
+							Filter: models.PublicKeyFilter{
+								Hostname: ".*",
+								Tags:     []string{"tag-1"},
+							},
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			keys, err := mongostore.PublicKeyListByFingerprint(context.TODO(), tc.fingerprint)
+			assert.Equal(t, tc.expected, Expected{keys: keys, err: err})
+		})
+	}
+}
+
 func TestPublicKeyList(t *testing.T) {
 	type Expected struct {
 		pubKey []models.PublicKey
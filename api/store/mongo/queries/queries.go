@@ -3,6 +3,7 @@ package queries
 import (
 	"errors"
 	"strconv"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/order"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
@@ -15,6 +16,13 @@ var (
 	ErrFilterPropertyInvalid = errors.New("filter property is not valid")
 )
 
+// Range is the value of the between operator, what matches the values from From to To, inclusive. It is only set by
+// the store, as it cannot be decoded from a filter.
+type Range struct {
+	From interface{}
+	To   interface{}
+}
+
 // BuildFilterQuery creates a MongoDB's filter query from models.Filter for filtering a fields in a database.
 func BuildFilterQuery(filters []models.Filter) ([]bson.M, error) {
 	const (
@@ -51,19 +59,29 @@ func BuildFilterQuery(filters []models.Filter) ([]bson.M, error) {
 			return bson.M{"$eq": value}, nil
 		},
 		"gt": func(value interface{}) (bson.M, error) {
-			switch v := value.(type) {
-			case int:
-				value = v
-			case string:
-				var err error
-				value, err = strconv.Atoi(v)
-				if err != nil {
-					return nil, err
-				}
+			value, err := comparable(value)
+			if err != nil {
+				return nil, err
 			}
 
 			return bson.M{"$gt": value}, nil
 		},
+		"lt": func(value interface{}) (bson.M, error) {
+			value, err := comparable(value)
+			if err != nil {
+				return nil, err
+			}
+
+			return bson.M{"$lt": value}, nil
+		},
+		"between": func(value interface{}) (bson.M, error) {
+			r, ok := value.(Range)
+			if !ok {
+				return nil, ErrFilterPropertyInvalid
+			}
+
+			return bson.M{"$gte": r.From, "$lte": r.To}, nil
+		},
 	}
 
 	operations := map[string]func() (string, error){
@@ -130,17 +148,37 @@ func BuildFilterQuery(filters []models.Filter) ([]bson.M, error) {
 		}
 	}
 
+	// NOTICE: the properties without a following operator are matched by any of them, along with the previous matches.
 	if len(queryFilter) > 0 {
-		queryMatcher = []bson.M{
-			{
-				"$match": bson.M{"$or": queryFilter},
-			},
-		}
+		queryMatcher = append(queryMatcher, bson.M{
+			"$match": bson.M{"$or": queryFilter},
+		})
 	}
 
 	return queryMatcher, nil
 }
 
+// comparable converts a filter's value to be compared by the gt and lt operators. A string value is converted to a
+// number or, when it isn't a number, to a RFC3339 date.
+func comparable(value interface{}) (interface{}, error) {
+	v, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	number, err := strconv.Atoi(v)
+	if err == nil {
+		return number, nil
+	}
+
+	date, dateErr := time.Parse(time.RFC3339, v)
+	if dateErr != nil {
+		return nil, err
+	}
+
+	return date, nil
+}
+
 // BuildPaginationQuery creates a MongoDB's query from a paginator.Query with pagination to limit the number of returned results.
 func BuildPaginationQuery(pagination paginator.Query) []bson.M {
 	if pagination.PerPage == -1 {
@@ -2,6 +2,7 @@ package queries
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
@@ -91,6 +92,108 @@ func TestBuildQuery(t *testing.T) {
 				err:  nil,
 			},
 		},
+		{
+			description: "Success when comparing a device's label",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "labels.site",
+						Operator: "eq",
+						Value:    "lisbon",
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$or": []bson.M{{"labels.site": bson.M{"$eq": "lisbon"}}}}}},
+				err:  nil,
+			},
+		},
+		{
+			description: "Success when comparing a property with a date range",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "started_at",
+						Operator: "gt",
+						Value:    "2023-01-01T00:00:00Z",
+					},
+				},
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "started_at",
+						Operator: "lt",
+						Value:    "2023-01-08T00:00:00Z",
+					},
+				},
+				{
+					Type: "operator",
+					Params: &models.OperatorParams{
+						Name: "and",
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$and": []bson.M{
+					{"started_at": bson.M{"$gt": time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)}},
+					{"started_at": bson.M{"$lt": time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)}},
+				}}}},
+				err: nil,
+			},
+		},
+		{
+			description: "Success when comparing a property with a number",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "test",
+						Operator: "lt",
+						Value:    "10",
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$or": []bson.M{{"test": bson.M{"$lt": 10}}}}}},
+				err:  nil,
+			},
+		},
+		{
+			description: "Success when matching a property between a range",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "test",
+						Operator: "between",
+						Value:    Range{From: "a", To: "f"},
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$or": []bson.M{{"test": bson.M{"$gte": "a", "$lte": "f"}}}}}},
+				err:  nil,
+			},
+		},
+		{
+			description: "Fail when the value of the between operator is not a range",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "test",
+						Operator: "between",
+						Value:    "a",
+					},
+				},
+			},
+			expected: Expected{
+				data: nil,
+				err:  ErrFilterPropertyInvalid,
+			},
+		},
 		{
 			description: "Fail when operator in operator is invalid",
 			filters: []models.Filter{
@@ -211,6 +314,40 @@ func TestBuildQuery(t *testing.T) {
 				err:  nil,
 			},
 		},
+		{
+			description: "Success when properties follow the last operator",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "test",
+						Operator: "eq",
+						Value:    "test",
+					},
+				},
+				{
+					Type: "operator",
+					Params: &models.OperatorParams{
+						Name: "and",
+					},
+				},
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "other",
+						Operator: "eq",
+						Value:    "other",
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{
+					{"$match": bson.M{"$and": []bson.M{{"test": bson.M{"$eq": "test"}}}}},
+					{"$match": bson.M{"$or": []bson.M{{"other": bson.M{"$eq": "other"}}}}},
+				},
+				err: nil,
+			},
+		},
 	}
 
 	for _, tc := range cases {
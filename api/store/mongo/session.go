@@ -2,6 +2,9 @@ package mongo
 
 import (
 	"context"
+	"encoding/hex"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
@@ -15,7 +18,17 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (s *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
+func (s *Store) SessionList(ctx context.Context, pagination paginator.Query, filters []models.Filter, sort string, order string) ([]models.Session, int, error) {
+	filters, err := sessionFilters(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	queryMatch, err := queries.BuildFilterQuery(filters)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
 	query := []bson.M{
 		{
 			"$match": bson.M{
@@ -35,6 +48,34 @@ func (s *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]
 		})
 	}
 
+	// NOTICE: the active status and the device aren't fields of the session's document, so they must be looked up
+	// before the filters when filtered, and only then, to avoid looking them up for every session.
+	active := sessionFiltersReference(filters, "active")
+	if active {
+		query = append(query, sessionActiveLookup...)
+	}
+
+	if sessionFiltersReference(filters, "device.") {
+		query = append(query, []bson.M{
+			{
+				"$lookup": bson.M{
+					"from":         "devices",
+					"localField":   "device_uid",
+					"foreignField": "uid",
+					"as":           "device",
+				},
+			},
+			{
+				"$unwind": bson.M{
+					"path":                       "$device",
+					"preserveNullAndEmptyArrays": true,
+				},
+			},
+		}...)
+	}
+
+	query = append(query, queryMatch...)
+
 	queryCount := query
 	queryCount = append(queryCount, bson.M{"$count": "count"})
 	count, err := AggregateCount(ctx, s.db.Collection("sessions"), queryCount)
@@ -42,28 +83,30 @@ func (s *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]
 		return nil, 0, FromMongoError(err)
 	}
 
-	query = append(query, bson.M{
-		"$sort": bson.M{
-			"started_at": -1,
-		},
-	})
+	orderVal := map[string]int{
+		"asc":  1,
+		"desc": -1,
+	}
+
+	direction, ok := orderVal[order]
+	if !ok {
+		direction = -1
+	}
+
+	if sort != "" {
+		query = append(query, bson.M{
+			"$sort": bson.M{sort: direction},
+		})
+	} else {
+		query = append(query, bson.M{
+			"$sort": bson.M{"started_at": -1},
+		})
+	}
 
 	query = append(query, queries.BuildPaginationQuery(pagination)...)
-	query = append(query, []bson.M{
-		{
-			"$lookup": bson.M{
-				"from":         "active_sessions",
-				"localField":   "uid",
-				"foreignField": "uid",
-				"as":           "active",
-			},
-		},
-		{
-			"$addFields": bson.M{
-				"active": bson.M{"$anyElementTrue": []interface{}{"$active"}},
-			},
-		},
-	}...)
+	if !active {
+		query = append(query, sessionActiveLookup...)
+	}
 
 	sessions := make([]models.Session, 0)
 	cursor, err := s.db.Collection("sessions").Aggregate(ctx, query)
@@ -91,6 +134,105 @@ func (s *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]
 	return sessions, count, err
 }
 
+// sessionActiveLookup looks up the session on the active sessions to set if the session is active.
+var sessionActiveLookup = []bson.M{
+	{
+		"$lookup": bson.M{
+			"from":         "active_sessions",
+			"localField":   "uid",
+			"foreignField": "uid",
+			"as":           "active",
+		},
+	},
+	{
+		"$addFields": bson.M{
+			"active": bson.M{"$anyElementTrue": []interface{}{"$active"}},
+		},
+	},
+}
+
+// sessionFiltersReference checks if any property filter is applied to a property starting with prefix.
+func sessionFiltersReference(filters []models.Filter, prefix string) bool {
+	for _, filter := range filters {
+		if params, ok := filter.Params.(*models.PropertyParams); ok && strings.HasPrefix(params.Name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sessionFilters converts the filters of the session's IP address in the CIDR notation to a range of the IP numbers of
+// the network, as the addresses are stored as strings.
+func sessionFilters(filters []models.Filter) ([]models.Filter, error) {
+	converted := make([]models.Filter, 0, len(filters))
+	for _, filter := range filters {
+		params, ok := filter.Params.(*models.PropertyParams)
+		if !ok || params.Name != "ip_address" {
+			converted = append(converted, filter)
+
+			continue
+		}
+
+		value, ok := params.Value.(string)
+		if !ok || !strings.Contains(value, "/") {
+			converted = append(converted, filter)
+
+			continue
+		}
+
+		from, to, err := networkRange(value)
+		if err != nil {
+			return nil, err
+		}
+
+		converted = append(converted, models.Filter{
+			Type: filter.Type,
+			Params: &models.PropertyParams{
+				Name:     "ip_number",
+				Operator: "between",
+				Value:    queries.Range{From: from, To: to},
+			},
+		})
+	}
+
+	return converted, nil
+}
+
+// ipNumber converts an IP address to a fixed-width hexadecimal number of its 16 bytes form, what sorts the IPv4 and
+// IPv6 addresses as numbers. It returns an empty string when the address is invalid.
+func ipNumber(address string) string {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return ""
+	}
+
+	return hex.EncodeToString(ip.To16())
+}
+
+// networkRange converts a network in the CIDR notation to the IP numbers of its first and last addresses.
+func networkRange(cidr string) (string, string, error) {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", "", queries.ErrFilterPropertyInvalid
+	}
+
+	ones, bits := network.Mask.Size()
+	if bits == 8*net.IPv4len {
+		ones += 8 * (net.IPv6len - net.IPv4len)
+	}
+
+	mask := net.CIDRMask(ones, 8*net.IPv6len)
+
+	first := network.IP.To16()
+	last := make(net.IP, net.IPv6len)
+	for i := range first {
+		last[i] = first[i] | ^mask[i]
+	}
+
+	return hex.EncodeToString(first), hex.EncodeToString(last), nil
+}
+
 func (s *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session, error) {
 	query := []bson.M{
 		{
@@ -174,6 +316,7 @@ func (s *Store) SessionCreate(ctx context.Context, session models.Session) (*mod
 	session.StartedAt = clock.Now()
 	session.LastSeen = session.StartedAt
 	session.Recorded = false
+	session.IPNumber = ipNumber(session.IPAddress)
 
 	device, err := s.DeviceGet(ctx, session.DeviceUID)
 	if err != nil {
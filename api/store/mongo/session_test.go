@@ -9,6 +9,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
 	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
 	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -210,7 +211,7 @@ func TestSessionList(t *testing.T) {
 			assert.NoError(t, fixtures.Apply(tc.fixtures...))
 			defer fixtures.Teardown() // nolint: errcheck
 
-			s, count, err := mongostore.SessionList(context.TODO(), tc.page)
+			s, count, err := mongostore.SessionList(context.TODO(), tc.page, nil, "", "")
 			sort(tc.expected.s)
 			sort(s)
 			assert.Equal(t, tc.expected, Expected{s: s, count: count, err: err})
@@ -218,6 +219,180 @@ func TestSessionList(t *testing.T) {
 	}
 }
 
+func TestSessionListFilter(t *testing.T) {
+	type Expected struct {
+		uids  []string
+		count int
+		err   error
+	}
+
+	property := func(name, operator string, value interface{}) models.Filter {
+		return models.Filter{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: name, Operator: operator, Value: value},
+		}
+	}
+
+	and := models.Filter{Type: "operator", Params: &models.OperatorParams{Name: "and"}}
+
+	cases := []struct {
+		description string
+		page        paginator.Query
+		filters     []models.Filter
+		sort        string
+		order       string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when filtering by type",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			filters:     []models.Filter{property("type", "eq", "exec")},
+			expected: Expected{
+				uids:  []string{"fc2e1493d8b6a4c17bf6a2f7f9e55629e384b2d3a21e0c3d90f6e35b0c946178a"},
+				count: 1,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds when filtering by a started_at range",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			filters: []models.Filter{
+				property("started_at", "gt", "2023-01-02T00:00:00Z"),
+				property("started_at", "lt", "2023-01-03T00:00:00Z"),
+				and,
+			},
+			expected: Expected{
+				uids:  []string{"e7f3a56d8b9e1dc4c285c98c8ea9c33032a17bda5b6c6b05a6213c2a02f97824"},
+				count: 1,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds when filtering by active",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			filters:     []models.Filter{property("active", "bool", true)},
+			expected: Expected{
+				uids:  []string{"a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68"},
+				count: 1,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds when filtering by device name and source network",
+			page:        paginator.Query{Page: 1, PerPage: 1},
+			filters: []models.Filter{
+				property("device.name", "eq", "device-3"),
+				property("ip_address", "eq", "0.0.0.0/8"),
+				and,
+			},
+			sort:  "started_at",
+			order: "asc",
+			expected: Expected{
+				uids:  []string{"a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68"},
+				count: 4,
+				err:   nil,
+			},
+		},
+		{
+			description: "fails when source network is invalid",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			filters:     []models.Filter{property("ip_address", "eq", "0.0.0.0/64")},
+			expected: Expected{
+				uids:  nil,
+				count: 0,
+				err:   queries.ErrFilterPropertyInvalid,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(
+				fixtures.FixtureNamespaces,
+				fixtures.FixtureDevices,
+				fixtures.FixtureConnectedDevices,
+				fixtures.FixtureSessions,
+				fixtures.FixtureActiveSessions,
+			))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			s, count, err := mongostore.SessionList(context.TODO(), tc.page, tc.filters, tc.sort, tc.order)
+
+			var uids []string
+			for _, session := range s {
+				uids = append(uids, session.UID)
+			}
+
+			assert.Equal(t, tc.expected, Expected{uids: uids, count: count, err: err})
+		})
+	}
+}
+
+func TestNetworkRange(t *testing.T) {
+	type Expected struct {
+		from string
+		to   string
+		err  error
+	}
+
+	cases := []struct {
+		cidr     string
+		expected Expected
+	}{
+		{
+			cidr:     "192.168.1.0/24",
+			expected: Expected{from: "00000000000000000000ffffc0a80100", to: "00000000000000000000ffffc0a801ff", err: nil},
+		},
+		{
+			cidr:     "10.0.16.0/20",
+			expected: Expected{from: "00000000000000000000ffff0a001000", to: "00000000000000000000ffff0a001fff", err: nil},
+		},
+		{
+			cidr:     "203.0.113.7/32",
+			expected: Expected{from: "00000000000000000000ffffcb007107", to: "00000000000000000000ffffcb007107", err: nil},
+		},
+		{
+			cidr:     "2001:db8::/32",
+			expected: Expected{from: "20010db8000000000000000000000000", to: "20010db8ffffffffffffffffffffffff", err: nil},
+		},
+		{
+			cidr:     "0.0.0.0/64",
+			expected: Expected{from: "", to: "", err: queries.ErrFilterPropertyInvalid},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.cidr, func(t *testing.T) {
+			from, to, err := networkRange(tc.cidr)
+			assert.Equal(t, tc.expected, Expected{from: from, to: to, err: err})
+		})
+	}
+}
+
+func TestIPNumber(t *testing.T) {
+	assert.Equal(t, "00000000000000000000ffffc0a80101", ipNumber("192.168.1.1"))
+	assert.Equal(t, "20010db8000000000000000000000001", ipNumber("2001:db8::1"))
+	assert.Equal(t, "", ipNumber("invalid"))
+
+	// NOTICE: the numbers of the addresses in a network are between the numbers of its first and last addresses.
+	from, to, err := networkRange("10.0.16.0/20")
+	assert.NoError(t, err)
+
+	for _, ip := range []string{"10.0.16.1", "10.0.31.255"} {
+		assert.True(t, from <= ipNumber(ip) && ipNumber(ip) <= to, ip)
+	}
+
+	for _, ip := range []string{"10.0.15.1", "10.0.32.1", "10.0.160.1", "2001:db8::1"} {
+		assert.False(t, from <= ipNumber(ip) && ipNumber(ip) <= to, ip)
+	}
+}
+
 func TestSessionGet(t *testing.T) {
 	type Expected struct {
 		s   *models.Session
@@ -0,0 +1,48 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) SigningKeyCreate(ctx context.Context, key *models.SigningKey) error {
+	_, err := s.db.Collection("signing_keys").InsertOne(ctx, key)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) SigningKeyList(ctx context.Context) ([]models.SigningKey, error) {
+	// NOTICE: the expired keys are removed by the database, but it can take up to a minute.
+	filter := bson.M{
+		"$or": []bson.M{
+			{"expires_at": bson.M{"$exists": false}},
+			{"expires_at": bson.M{"$gt": clock.Now()}},
+		},
+	}
+
+	cursor, err := s.db.Collection("signing_keys").Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": -1}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	keys := make([]models.SigningKey, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return keys, nil
+}
+
+func (s *Store) SigningKeyRetire(ctx context.Context, id string, retiredAt, expiresAt time.Time) error {
+	_, err := s.db.Collection("signing_keys").UpdateMany(ctx,
+		bson.M{"id": bson.M{"$ne": id}, "retired_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"retired_at": retiredAt, "expires_at": expiresAt}},
+	)
+
+	return FromMongoError(err)
+}
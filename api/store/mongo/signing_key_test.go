@@ -0,0 +1,46 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigningKeyList(t *testing.T) {
+	past := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	future := time.Date(2099, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	ctx := context.TODO()
+	defer fixtures.Teardown() // nolint: errcheck
+
+	keys := []models.SigningKey{
+		{ID: "old", Data: []byte("old"), CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)},
+		{ID: "current", Data: []byte("current"), CreatedAt: time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{ID: "expired", Data: []byte("expired"), CreatedAt: time.Date(2023, 1, 1, 9, 0, 0, 0, time.UTC), RetiredAt: &past, ExpiresAt: &past},
+	}
+
+	for i := range keys {
+		assert.NoError(t, mongostore.SigningKeyCreate(ctx, &keys[i]))
+	}
+
+	assert.NoError(t, mongostore.SigningKeyRetire(ctx, "current", past, future))
+
+	list, err := mongostore.SigningKeyList(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.SigningKey{
+		{ID: "current", Data: []byte("current"), CreatedAt: time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{ID: "old", Data: []byte("old"), CreatedAt: time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), RetiredAt: &past, ExpiresAt: &future},
+	}, list)
+}
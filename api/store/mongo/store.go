@@ -50,7 +50,7 @@ func NewStoreMongo(ctx context.Context, cache cache.Cache, uri string) (store.St
 		return nil, errors.Join(ErrStoreParseURI, err)
 	}
 
-	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions := options.Client().ApplyURI(uri).SetMonitor(commandMonitor())
 	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
 		return nil, errors.Join(ErrStoreConnect, err)
@@ -0,0 +1,90 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) UserSessionCreate(ctx context.Context, session *models.UserSession) error {
+	_, err := s.db.Collection("user_sessions").InsertOne(ctx, session)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) UserSessionGet(ctx context.Context, id string) (*models.UserSession, error) {
+	session := new(models.UserSession)
+	if err := s.db.Collection("user_sessions").FindOne(ctx, bson.M{"id": id}).Decode(&session); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return session, nil
+}
+
+func (s *Store) UserSessionList(ctx context.Context, userID string) ([]models.UserSession, error) {
+	opts := options.Find().SetSort(bson.M{"last_used_at": -1})
+
+	cursor, err := s.db.Collection("user_sessions").Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	sessions := make([]models.UserSession, 0)
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return sessions, nil
+}
+
+// userSessionRotatedHashes is the number of rotated refresh token hashes kept on a session.
+const userSessionRotatedHashes = 100
+
+func (s *Store) UserSessionRotate(ctx context.Context, id, hash, newHash string, lastUsedAt time.Time) error {
+	res, err := s.db.Collection("user_sessions").UpdateOne(ctx,
+		bson.M{"id": id, "refresh_token_hash": hash},
+		bson.M{
+			"$set":  bson.M{"refresh_token_hash": newHash, "last_used_at": lastUsedAt},
+			"$push": bson.M{"rotated_refresh_token_hashes": bson.M{"$each": []string{hash}, "$slice": -userSessionRotatedHashes}},
+		},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionUpdateTenant(ctx context.Context, id, tenantID string) error {
+	res, err := s.db.Collection("user_sessions").UpdateOne(ctx, bson.M{"id": id}, bson.M{"$set": bson.M{"tenant_id": tenantID}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UserSessionDelete(ctx context.Context, id string) error {
+	res, err := s.db.Collection("user_sessions").DeleteOne(ctx, bson.M{"id": id})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newUserSession(id string) *models.UserSession {
+	return &models.UserSession{
+		ID:               id,
+		UserID:           "507f1f77bcf86cd799439011",
+		TenantID:         "00000000-0000-4000-0000-000000000000",
+		RefreshTokenHash: "hash",
+		IPAddress:        "127.0.0.1",
+		UserAgent:        "test",
+		CreatedAt:        time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		LastUsedAt:       time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+		ExpiresAt:        time.Date(2099, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestUserSessionGet(t *testing.T) {
+	type Expected struct {
+		session *models.UserSession
+		err     error
+	}
+
+	cases := []struct {
+		description string
+		id          string
+		setup       func(context.Context, store.Store) error
+		expected    Expected
+	}{
+		{
+			description: "fails when session is not found",
+			id:          "nonexistent",
+			setup: func(context.Context, store.Store) error {
+				return nil
+			},
+			expected: Expected{
+				session: nil,
+				err:     store.ErrNoDocuments,
+			},
+		},
+		{
+			description: "succeeds when session is found",
+			id:          "session",
+			setup: func(ctx context.Context, s store.Store) error {
+				return s.UserSessionCreate(ctx, newUserSession("session"))
+			},
+			expected: Expected{
+				session: newUserSession("session"),
+				err:     nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.TODO()
+
+			assert.NoError(t, tc.setup(ctx, mongostore))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			session, err := mongostore.UserSessionGet(ctx, tc.id)
+			assert.Equal(t, tc.expected, Expected{session: session, err: err})
+		})
+	}
+}
+
+func TestUserSessionList(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+	defer fixtures.Teardown() // nolint: errcheck
+
+	ctx := context.TODO()
+
+	assert.NoError(t, mongostore.UserSessionCreate(ctx, newUserSession("session")))
+
+	sessions, err := mongostore.UserSessionList(ctx, "507f1f77bcf86cd799439011")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.UserSession{*newUserSession("session")}, sessions)
+
+	sessions, err = mongostore.UserSessionList(ctx, "nonexistent")
+	assert.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestUserSessionRotate(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		hash        string
+		expected    error
+	}{
+		{
+			description: "fails when the hash does not match",
+			id:          "session",
+			hash:        "old",
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the hash matches",
+			id:          "session",
+			hash:        "hash",
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.TODO()
+
+			assert.NoError(t, mongostore.UserSessionCreate(ctx, newUserSession("session")))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.UserSessionRotate(ctx, tc.id, tc.hash, "new", time.Now())
+			assert.Equal(t, tc.expected, err)
+
+			if tc.expected == nil {
+				session, err := mongostore.UserSessionGet(ctx, tc.id)
+				assert.NoError(t, err)
+				assert.Equal(t, "new", session.RefreshTokenHash)
+				assert.Equal(t, []string{tc.hash}, session.RotatedRefreshTokenHashes)
+			}
+		})
+	}
+}
+
+func TestUserSessionDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		expected    error
+	}{
+		{
+			description: "fails when session is not found",
+			id:          "nonexistent",
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when session is found",
+			id:          "session",
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			ctx := context.TODO()
+
+			assert.NoError(t, mongostore.UserSessionCreate(ctx, newUserSession("session")))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.UserSessionDelete(ctx, tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
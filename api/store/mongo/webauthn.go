@@ -0,0 +1,78 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) WebAuthnCredentialCreate(ctx context.Context, credential *models.WebAuthnCredential) error {
+	_, err := s.db.Collection("webauthn_credentials").InsertOne(ctx, credential)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) WebAuthnCredentialList(ctx context.Context, userID string) ([]models.WebAuthnCredential, error) {
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	cursor, err := s.db.Collection("webauthn_credentials").Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	credentials := make([]models.WebAuthnCredential, 0)
+	if err := cursor.All(ctx, &credentials); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return credentials, nil
+}
+
+func (s *Store) WebAuthnCredentialUse(ctx context.Context, id string, signCount uint32, usedAt time.Time) error {
+	res, err := s.db.Collection("webauthn_credentials").UpdateOne(ctx,
+		bson.M{"id": id},
+		bson.M{"$set": bson.M{"sign_count": signCount, "last_used_at": usedAt}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) WebAuthnCredentialRename(ctx context.Context, userID, id, name string) error {
+	res, err := s.db.Collection("webauthn_credentials").UpdateOne(ctx,
+		bson.M{"id": id, "user_id": userID},
+		bson.M{"$set": bson.M{"name": name}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) WebAuthnCredentialDelete(ctx context.Context, userID, id string) error {
+	res, err := s.db.Collection("webauthn_credentials").DeleteOne(ctx, bson.M{"id": id, "user_id": userID})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWebAuthnCredential(id, userID string) *models.WebAuthnCredential {
+	return &models.WebAuthnCredential{
+		ID:           id,
+		UserID:       userID,
+		Name:         "key",
+		CredentialID: []byte(id),
+		PublicKey:    []byte("public key"),
+		Transports:   []string{"usb"},
+		CreatedAt:    time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebAuthnCredential(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	ctx := context.TODO()
+	defer fixtures.Teardown() // nolint: errcheck
+
+	assert.NoError(t, mongostore.WebAuthnCredentialCreate(ctx, newWebAuthnCredential("credential", "user")))
+	assert.NoError(t, mongostore.WebAuthnCredentialCreate(ctx, newWebAuthnCredential("other", "other")))
+
+	usedAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, mongostore.WebAuthnCredentialUse(ctx, "credential", 10, usedAt))
+	assert.NoError(t, mongostore.WebAuthnCredentialRename(ctx, "user", "credential", "renamed"))
+
+	// NOTICE: the credentials are only renamed and deleted by their owners.
+	assert.Equal(t, store.ErrNoDocuments, mongostore.WebAuthnCredentialRename(ctx, "user", "other", "renamed"))
+	assert.Equal(t, store.ErrNoDocuments, mongostore.WebAuthnCredentialDelete(ctx, "user", "other"))
+
+	expected := newWebAuthnCredential("credential", "user")
+	expected.Name = "renamed"
+	expected.SignCount = 10
+	expected.LastUsedAt = &usedAt
+
+	credentials, err := mongostore.WebAuthnCredentialList(ctx, "user")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.WebAuthnCredential{*expected}, credentials)
+
+	assert.NoError(t, mongostore.WebAuthnCredentialDelete(ctx, "user", "credential"))
+
+	credentials, err = mongostore.WebAuthnCredentialList(ctx, "user")
+	assert.NoError(t, err)
+	assert.Empty(t, credentials)
+}
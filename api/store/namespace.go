@@ -21,4 +21,6 @@ type NamespaceStore interface {
 	NamespaceGetFirst(ctx context.Context, id string) (*models.Namespace, error)
 	NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error
 	NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error)
+	NamespaceSetAgentForwarding(ctx context.Context, agentForwarding bool, tenantID string) error
+	NamespaceSetMetricsThresholds(ctx context.Context, thresholds []models.DeviceMetricsThreshold, tenantID string) error
 }
@@ -10,6 +10,8 @@ import (
 type PublicKeyStore interface {
 	PublicKeyList(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error)
 	PublicKeyGet(ctx context.Context, fingerprint string, tenantID string) (*models.PublicKey, error)
+	// PublicKeyListByFingerprint lists the public keys identified by fingerprint on every namespace.
+	PublicKeyListByFingerprint(ctx context.Context, fingerprint string) ([]models.PublicKey, error)
 	PublicKeyCreate(ctx context.Context, key *models.PublicKey) error
 	PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error)
 	PublicKeyDelete(ctx context.Context, fingerprint string, tenantID string) error
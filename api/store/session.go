@@ -9,7 +9,7 @@ import (
 )
 
 type SessionStore interface {
-	SessionList(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error)
+	SessionList(ctx context.Context, pagination paginator.Query, filters []models.Filter, sort string, order string) ([]models.Session, int, error)
 	SessionGet(ctx context.Context, uid models.UID) (*models.Session, error)
 	SessionCreate(ctx context.Context, session models.Session) (*models.Session, error)
 	SessionSetAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error
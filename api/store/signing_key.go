@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type SigningKeyStore interface {
+	SigningKeyCreate(ctx context.Context, key *models.SigningKey) error
+	// SigningKeyList lists the signing keys that were not expired yet.
+	SigningKeyList(ctx context.Context) ([]models.SigningKey, error)
+	// SigningKeyRetire retires every key, except the one identified by id, that is not retired yet.
+	SigningKeyRetire(ctx context.Context, id string, retiredAt, expiresAt time.Time) error
+}
@@ -6,6 +6,13 @@ type Store interface {
 	TagsStore
 	DeviceStore
 	DeviceTagsStore
+	DeviceLabelsStore
+	DeviceInventoryStore
+	DeviceMetricsStore
+	DeviceAlertStore
+	DeviceConnectivityStore
+	DeviceGroupStore
+	DeviceJobStore
 	SessionStore
 	UserStore
 	FirewallStore
@@ -17,4 +24,8 @@ type Store interface {
 	LicenseStore
 	StatsStore
 	MFAStore
+	CertificateAuthorityStore
+	UserSessionStore
+	SigningKeyStore
+	WebAuthnCredentialStore
 }
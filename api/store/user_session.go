@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type UserSessionStore interface {
+	UserSessionCreate(ctx context.Context, session *models.UserSession) error
+	UserSessionGet(ctx context.Context, id string) (*models.UserSession, error)
+	UserSessionList(ctx context.Context, userID string) ([]models.UserSession, error)
+	// UserSessionRotate replaces the session's refresh token hash only when it still matches hash, so a refresh token
+	// cannot be used twice, even by concurrent requests. The replaced hash is kept among the session's rotated ones.
+	UserSessionRotate(ctx context.Context, id, hash, newHash string, lastUsedAt time.Time) error
+	UserSessionUpdateTenant(ctx context.Context, id, tenantID string) error
+	UserSessionDelete(ctx context.Context, id string) error
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type WebAuthnCredentialStore interface {
+	WebAuthnCredentialCreate(ctx context.Context, credential *models.WebAuthnCredential) error
+	WebAuthnCredentialList(ctx context.Context, userID string) ([]models.WebAuthnCredential, error)
+	// WebAuthnCredentialUse records an assertion of the credential, updating its signature counter.
+	WebAuthnCredentialUse(ctx context.Context, id string, signCount uint32, usedAt time.Time) error
+	WebAuthnCredentialRename(ctx context.Context, userID, id, name string) error
+	WebAuthnCredentialDelete(ctx context.Context, userID, id string) error
+}
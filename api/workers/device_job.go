@@ -0,0 +1,96 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DeviceJobRunner runs the operation of a device job on each of its devices.
+type DeviceJobRunner interface {
+	RunDeviceJob(ctx context.Context, id string) error
+}
+
+// DeviceJobPayload is the payload of the task that runs a device job.
+type DeviceJobPayload struct {
+	// ID is the device job's ID.
+	ID string `json:"id"`
+	// Trace is the trace context of the request that created the device job, continued by the task.
+	Trace propagation.MapCarrier `json:"trace,omitempty"`
+}
+
+// NewDeviceJobTask creates the task that runs the device job identified by id, continuing the trace of ctx.
+func NewDeviceJobTask(ctx context.Context, id string) *asynq.Task {
+	payload := DeviceJobPayload{ID: id, Trace: propagation.MapCarrier{}}
+	tracing.Inject(ctx, payload.Trace)
+
+	data, _ := json.Marshal(payload) //nolint:errchkjson
+
+	return asynq.NewTask(TaskDeviceJob, data, asynq.TaskID(id), asynq.Queue("api"))
+}
+
+// registerDeviceJob worker runs the device jobs, the bulk operations on devices created through the API. A job only
+// runs the operation on the devices it did not run yet, so retrying it after a failure does not run it twice on a
+// device.
+func (w *Workers) registerDeviceJob() {
+	if w.jobs == nil {
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskDeviceJob,
+			}).
+			Info("Aborting device job worker due to no runner being set.")
+
+		return
+	}
+
+	w.mux.HandleFunc(TaskDeviceJob, func(ctx context.Context, task *asynq.Task) error {
+		var payload DeviceJobPayload
+		if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+			log.WithFields(
+				log.Fields{
+					"component": "worker",
+					"task":      TaskDeviceJob,
+				}).
+				WithError(err).
+				Error("Failed to decode the device job's payload.")
+
+			return errors.Join(asynq.SkipRetry, err)
+		}
+
+		id := payload.ID
+
+		// NOTICE: the device job runs in the trace of the request that created it, linked to the task's span.
+		ctx, span := tracing.Tracer().Start(tracing.Extract(ctx, payload.Trace), "device job", trace.WithLinks(trace.LinkFromContext(ctx)))
+		defer span.End()
+
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskDeviceJob,
+				"id":        id,
+			}).
+			Trace("Executing device job worker.")
+
+		if err := w.jobs.RunDeviceJob(ctx, id); err != nil {
+			log.WithFields(
+				log.Fields{
+					"component": "worker",
+					"task":      TaskDeviceJob,
+					"id":        id,
+				}).
+				WithError(err).
+				Error("Failed to run the device job.")
+
+			return err
+		}
+
+		return nil
+	})
+}
@@ -49,6 +49,19 @@ func (w *Workers) registerHeartbeat() {
 
 			timestamp := time.Unix(i, 0)
 
+			// NOTICE: the transition must be recorded before the device's last seen is updated, as it is used to know
+			// if the device went offline since the previous heartbeat.
+			if err := w.store.DeviceConnectivityRecord(ctx, models.UID(uid), true, timestamp); err != nil {
+				log.WithFields(
+					log.Fields{
+						"component": "worker",
+						"task":      TaskHeartbeat,
+						"uid":       uid,
+					}).
+					WithError(err).
+					Warn("Failed to record the device's connectivity.")
+			}
+
 			w.store.DeviceSetOnline(ctx, models.UID(uid), timestamp, true) //nolint:errcheck
 		}
 
@@ -0,0 +1,67 @@
+package workers
+
+import (
+	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+// queueCollector collects the number of tasks in each state of the asynq's queues when the metrics are scraped.
+type queueCollector struct {
+	inspector *asynq.Inspector
+	size      *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*queueCollector)(nil)
+
+func newQueueCollector(addr asynq.RedisConnOpt) *queueCollector {
+	return &queueCollector{
+		inspector: asynq.NewInspector(addr),
+		size: prometheus.NewDesc(
+			prometheus.BuildFQName(metrics.Namespace, "asynq", "queue_size"),
+			"Number of tasks in the queues by queue and state.",
+			[]string{"queue", "state"},
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.size
+}
+
+// Collect implements prometheus.Collector.
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	queues, err := c.inspector.Queues()
+	if err != nil {
+		log.WithFields(log.Fields{"component": "worker"}).
+			WithError(err).
+			Warn("Failed to list the queues to collect their metrics.")
+
+		return
+	}
+
+	for _, queue := range queues {
+		info, err := c.inspector.GetQueueInfo(queue)
+		if err != nil {
+			log.WithFields(log.Fields{"component": "worker", "queue": queue}).
+				WithError(err).
+				Warn("Failed to get the queue to collect its metrics.")
+
+			continue
+		}
+
+		for state, size := range map[string]int{
+			"pending":     info.Pending,
+			"active":      info.Active,
+			"scheduled":   info.Scheduled,
+			"retry":       info.Retry,
+			"archived":    info.Archived,
+			"aggregating": info.Aggregating,
+		} {
+			ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(size), queue, state)
+		}
+	}
+}
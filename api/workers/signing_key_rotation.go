@@ -0,0 +1,70 @@
+package workers
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+)
+
+// registerSigningKeyRotation worker generates a new key to sign the API's tokens and retires the current one, which
+// keeps verifying tokens for the window defined by `SHELLHUB_SIGNING_KEY_ROTATION_OVERLAP`. It uses a cron expression
+// from `SHELLHUB_SIGNING_KEY_ROTATION_SCHEDULE` to schedule its periodic execution. To disable this worker, leave
+// `SHELLHUB_SIGNING_KEY_ROTATION_SCHEDULE` empty (default behavior).
+func (w *Workers) registerSigningKeyRotation() {
+	if w.env.SigningKeyRotationSchedule == "" || w.keys == nil {
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskSigningKeyRotation,
+			}).
+			Info("Aborting signing key rotation worker due to SHELLHUB_SIGNING_KEY_ROTATION_SCHEDULE being empty.")
+
+		return
+	}
+
+	w.mux.HandleFunc(TaskSigningKeyRotation, func(ctx context.Context, _ *asynq.Task) error {
+		log.WithFields(
+			log.Fields{
+				"component":       "worker",
+				"cron_expression": w.env.SigningKeyRotationSchedule,
+				"task":            TaskSigningKeyRotation,
+			}).
+			Trace("Executing signing key rotation worker.")
+
+		key, err := w.keys.Rotate(ctx, w.env.SigningKeyRotationOverlap)
+		if err != nil {
+			log.WithFields(
+				log.Fields{
+					"component": "worker",
+					"task":      TaskSigningKeyRotation,
+				}).
+				WithError(err).
+				Error("Failed to rotate the signing key")
+
+			return err
+		}
+
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskSigningKeyRotation,
+				"kid":       key.ID,
+				"overlap":   w.env.SigningKeyRotationOverlap.String(),
+			}).
+			Info("Signing key rotated.")
+
+		return nil
+	})
+
+	task := asynq.NewTask(TaskSigningKeyRotation, nil, asynq.TaskID(TaskSigningKeyRotation), asynq.Queue("api"))
+	if _, err := w.scheduler.Register(w.env.SigningKeyRotationSchedule, task); err != nil {
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskSigningKeyRotation,
+			}).
+			WithError(err).
+			Error("Failed to register the scheduler.")
+	}
+}
@@ -3,4 +3,8 @@ package workers
 const (
 	TaskSessionCleanup = "session_record:cleanup"
 	TaskHeartbeat      = "api:heartbeat"
+	// TaskSigningKeyRotation rotates the key that signs the API's tokens.
+	TaskSigningKeyRotation = "api:signing_key_rotation"
+	// TaskDeviceJob runs a bulk operation on devices.
+	TaskDeviceJob = "api:device_job"
 )
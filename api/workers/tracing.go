@@ -0,0 +1,27 @@
+package workers
+
+import (
+	"context"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceTasks traces the asynq's tasks, naming the spans by their types.
+func traceTasks(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		ctx, span := tracing.Tracer().Start(ctx, task.Type(), trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
+		if err := next.ProcessTask(ctx, task); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return err
+		}
+
+		return nil
+	})
+}
@@ -1,6 +1,8 @@
 package workers
 
 import (
+	"time"
+
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	log "github.com/sirupsen/logrus"
 )
@@ -28,6 +30,12 @@ type Envs struct {
 	//
 	// Check [https://github.com/hibiken/asynq/wiki/Task-aggregation] for more information.
 	AsynqGroupMaxSize int `env:"ASYNQ_GROUP_MAX_SIZE,default=500"`
+	// SigningKeyRotationSchedule is the cron expression to rotate the key that signs the API's tokens. When empty,
+	// the key is never rotated and the API's key file signs every token.
+	SigningKeyRotationSchedule string `env:"SIGNING_KEY_ROTATION_SCHEDULE,default="`
+	// SigningKeyRotationOverlap is how long a retired key keeps verifying the tokens it signed. It must be longer than
+	// the tokens' lifetime and the devices' ping interval, as the devices get a new token on each ping.
+	SigningKeyRotationOverlap time.Duration `env:"SIGNING_KEY_ROTATION_OVERLAP,default=24h"`
 }
 
 func getEnvs() (*Envs, error) {
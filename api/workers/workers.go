@@ -7,12 +7,16 @@ import (
 	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shellhub-io/shellhub/api/pkg/keyring"
 	"github.com/shellhub-io/shellhub/api/store"
 	log "github.com/sirupsen/logrus"
 )
 
 type Workers struct {
 	store store.Store
+	keys  *keyring.Keyring
+	jobs  DeviceJobRunner
 
 	addr      asynq.RedisConnOpt
 	srv       *asynq.Server
@@ -21,9 +25,19 @@ type Workers struct {
 	scheduler *asynq.Scheduler
 }
 
-// New creates a new Workers instance with the provided store. It initializes
+// Option configures the workers.
+type Option func(*Workers)
+
+// WithDeviceJobRunner sets the runner of the device jobs. Without it, the device jobs are not run.
+func WithDeviceJobRunner(runner DeviceJobRunner) Option {
+	return func(w *Workers) {
+		w.jobs = runner
+	}
+}
+
+// New creates a new Workers instance with the provided store and keyring. It initializes
 // the worker's components, such as server, scheduler, and environment settings.
-func New(store store.Store) (*Workers, error) {
+func New(store store.Store, keys *keyring.Keyring, options ...Option) (*Workers, error) {
 	env, err := getEnvs()
 	if err != nil {
 		log.WithFields(log.Fields{"component": "worker"}).
@@ -43,6 +57,7 @@ func New(store store.Store) (*Workers, error) {
 	}
 
 	mux := asynq.NewServeMux()
+	mux.Use(traceTasks)
 	srv := asynq.NewServer(
 		addr,
 		asynq.Config{ //nolint:exhaustruct
@@ -79,6 +94,17 @@ func New(store store.Store) (*Workers, error) {
 		mux:       mux,
 		scheduler: scheduler,
 		store:     store,
+		keys:      keys,
+	}
+
+	for _, option := range options {
+		option(w)
+	}
+
+	if err := prometheus.Register(newQueueCollector(addr)); err != nil {
+		log.WithFields(log.Fields{"component": "worker"}).
+			WithError(err).
+			Warn("Failed to register the metrics of the queues.")
 	}
 
 	return w, nil
@@ -114,4 +140,6 @@ func (w *Workers) Start() {
 func (w *Workers) setupHandlers() {
 	w.registerSessionCleanup()
 	w.registerHeartbeat()
+	w.registerSigningKeyRotation()
+	w.registerDeviceJob()
 }
@@ -19,6 +19,7 @@ func UserCommands(service services.Services) *cobra.Command {
 	cmd.AddCommand(userCreate(service))
 	cmd.AddCommand(userResetPassword(service))
 	cmd.AddCommand(userDelete(service))
+	cmd.AddCommand(userUnlock(service))
 
 	return cmd
 }
@@ -103,3 +104,30 @@ func userDelete(service services.Services) *cobra.Command {
 		},
 	}
 }
+
+func userUnlock(service services.Services) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unlock <username> [ip]",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "Unlock a user",
+		Long: `Removes the failed login attempts of a user, unlocking it after too many failures.
+When the IP is provided, the failed login attempts from it are removed too.`,
+		Example: `cli user unlock john_doe 192.168.0.1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var input inputs.UserUnlock
+
+			if err := bind(args, &input); err != nil {
+				return err
+			}
+
+			if err := service.UserUnlock(cmd.Context(), &input); err != nil {
+				return err
+			}
+
+			cmd.Println("User unlocked successfully")
+			cmd.Println("Username:", input.Username)
+
+			return nil
+		},
+	}
+}
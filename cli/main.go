@@ -46,7 +46,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	service := services.NewService(mongo.NewStore(client.Database(connStr.Database), cache))
+	service := services.NewService(mongo.NewStore(client.Database(connStr.Database), cache), cache)
 
 	rootCmd := &cobra.Command{Use: "cli"}
 
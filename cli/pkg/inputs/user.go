@@ -18,6 +18,13 @@ type UserDelete struct {
 	Username string `validate:"required,username"`
 }
 
+// UserUnlock defines the structure for inputs when unlocking a user.
+type UserUnlock struct {
+	Username string `validate:"required,username"`
+	// IP is an optional source IP to unlock too.
+	IP string `validate:"omitempty,ip"`
+}
+
 // UserPassword the structure for validate passowrd.
 type UserPassword struct {
 	Password string `validate:"required,password"`
@@ -10,6 +10,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
 	"github.com/shellhub-io/shellhub/pkg/envs"
@@ -246,7 +247,7 @@ func TestNamespaceCreate(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			s := NewService(store.Store(mock))
+			s := NewService(store.Store(mock), storecache.NewNullCache())
 			ns, err := s.NamespaceCreate(ctx, &inputs.NamespaceCreate{Namespace: tc.namespace, Owner: tc.username, TenantID: tc.tenant})
 			assert.Equal(t, tc.expected, Expected{ns, err})
 		})
@@ -348,7 +349,7 @@ func TestNamespaceAddMember(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			s := NewService(store.Store(mock))
+			s := NewService(store.Store(mock), storecache.NewNullCache())
 			ns, err := s.NamespaceAddMember(ctx, &inputs.MemberAdd{Username: tc.username, Namespace: tc.namespace, Role: tc.role})
 			assert.Equal(t, tc.expected, Expected{ns, err})
 		})
@@ -475,7 +476,7 @@ func TestNamespaceRemoveMember(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			s := NewService(store.Store(mock))
+			s := NewService(store.Store(mock), storecache.NewNullCache())
 			ns, err := s.NamespaceRemoveMember(ctx, &inputs.MemberRemove{Username: tc.username, Namespace: tc.namespace})
 			assert.Equal(t, tc.expected, Expected{ns, err})
 		})
@@ -549,7 +550,7 @@ func TestNamespaceDelete(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			s := NewService(store.Store(mock))
+			s := NewService(store.Store(mock), storecache.NewNullCache())
 			err := s.NamespaceDelete(ctx, &inputs.NamespaceDelete{Namespace: tc.namespace})
 			assert.Equal(t, tc.expected, err)
 		})
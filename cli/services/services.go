@@ -3,8 +3,10 @@ package services
 import (
 	"context"
 
+	"github.com/shellhub-io/shellhub/api/pkg/lockout"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 )
@@ -23,6 +25,8 @@ type Services interface {
 	UserDelete(ctx context.Context, input *inputs.UserDelete) error
 	// UserUpdate updates a user's data based on the provided username.
 	UserUpdate(ctx context.Context, input *inputs.UserUpdate) error
+	// UserUnlock removes the failed login attempts of a user, unlocking it, and optionally of a source IP.
+	UserUnlock(ctx context.Context, input *inputs.UserUnlock) error
 	// NamespaceCreate initializes a new namespace, making the specified user its owner.
 	// The tenant defaults to a UUID if not provided.
 	// Max device limit is based on the envs.IsCloud() setting.
@@ -36,13 +40,15 @@ type Services interface {
 }
 
 // service is an internal struct that implements the Services interface.
-// It contains a store, which provides a mechanism to interact with the data store.
+// It contains a store, which provides a mechanism to interact with the data store, and a lockout, which keeps the failed
+// login attempts.
 type service struct {
 	store     store.Store
 	validator *validator.Validator
+	lockout   *lockout.Lockout
 }
 
-// NewService creates and returns a new instance of the service with the provided store.
-func NewService(store store.Store) Services {
-	return &service{store, validator.New()}
+// NewService creates and returns a new instance of the service with the provided store and cache.
+func NewService(store store.Store, cache cache.Cache) Services {
+	return &service{store, validator.New(), lockout.New(cache)}
 }
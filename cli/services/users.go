@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 
+	"github.com/shellhub-io/shellhub/api/pkg/lockout"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -125,3 +126,32 @@ func (s *service) UserUpdate(ctx context.Context, input *inputs.UserUpdate) erro
 
 	return nil
 }
+
+// UserUnlock removes the failed login attempts of a user, unlocking it, and optionally of a source IP.
+func (s *service) UserUnlock(ctx context.Context, input *inputs.UserUnlock) error {
+	if ok, err := s.validator.Struct(input); !ok || err != nil {
+		return ErrUserDataInvalid
+	}
+
+	user, err := s.store.UserGetByUsername(ctx, input.Username)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	keys := []lockout.Key{
+		lockout.IdentifierKey(user.Username),
+		lockout.IdentifierKey(user.Email),
+		lockout.UserKey(user.ID),
+		lockout.MFAKey(user.ID),
+	}
+
+	if input.IP != "" {
+		keys = append(keys, lockout.IPKey(input.IP))
+	}
+
+	if err := s.lockout.Reset(ctx, keys...); err != nil {
+		return ErrFailedUnlockUser
+	}
+
+	return nil
+}
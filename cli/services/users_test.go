@@ -9,6 +9,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/cli/pkg/inputs"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	clockmock "github.com/shellhub-io/shellhub/pkg/clock/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -247,7 +248,7 @@ func TestUserCreate(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			service := NewService(store.Store(mock))
+			service := NewService(store.Store(mock), storecache.NewNullCache())
 			user, err := service.UserCreate(ctx, &inputs.UserCreate{Username: tc.username, Password: tc.password, Email: tc.email})
 
 			assert.Equal(t, tc.expected, Expected{user, err})
@@ -379,7 +380,7 @@ func TestUserDelete(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
 
-			service := NewService(store.Store(mock))
+			service := NewService(store.Store(mock), storecache.NewNullCache())
 			err := service.UserDelete(ctx, &inputs.UserDelete{Username: tc.username})
 			assert.Equal(t, tc.expected, err)
 		})
@@ -461,7 +462,7 @@ func TestUserResetPassword(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.description, func(t *testing.T) {
 			tc.requiredMocks()
-			service := NewService(store.Store(mock))
+			service := NewService(store.Store(mock), storecache.NewNullCache())
 			err := service.UserUpdate(ctx, &inputs.UserUpdate{Username: tc.username, Password: tc.password})
 			assert.Equal(t, tc.expected, err)
 		})
@@ -469,3 +470,83 @@ func TestUserResetPassword(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+// deletedCache records the keys deleted from the cache.
+type deletedCache struct {
+	storecache.Cache
+	keys []string
+}
+
+func (c *deletedCache) Delete(_ context.Context, key string) error {
+	c.keys = append(c.keys, key)
+
+	return nil
+}
+
+func TestUserUnlock(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		input         *inputs.UserUnlock
+		requiredMocks func()
+		expected      error
+		deleted       []string
+	}{
+		{
+			description:   "fails when the IP is invalid",
+			input:         &inputs.UserUnlock{Username: "john_doe", IP: "invalid"},
+			requiredMocks: func() {},
+			expected:      ErrUserDataInvalid,
+			deleted:       nil,
+		},
+		{
+			description: "fails when could not find a user",
+			input:       &inputs.UserUnlock{Username: "john_doe"},
+			requiredMocks: func() {
+				mock.On("UserGetByUsername", ctx, "john_doe").Return(nil, errors.New("error")).Once()
+			},
+			expected: ErrUserNotFound,
+			deleted:  nil,
+		},
+		{
+			description: "successfully unlock the user",
+			input:       &inputs.UserUnlock{Username: "john_doe", IP: "192.168.0.1"},
+			requiredMocks: func() {
+				user := &models.User{
+					ID: "507f191e810c19729de860ea",
+					UserData: models.UserData{
+						Name:     "John Doe",
+						Email:    "john.doe@test.com",
+						Username: "john_doe",
+					},
+				}
+				mock.On("UserGetByUsername", ctx, "john_doe").Return(user, nil).Once()
+			},
+			expected: nil,
+			deleted: []string{
+				"lockout_identifier_john_doe", "lockout_identifier_john_doe_until",
+				"lockout_identifier_john.doe@test.com", "lockout_identifier_john.doe@test.com_until",
+				"lockout_user_507f191e810c19729de860ea", "lockout_user_507f191e810c19729de860ea_until",
+				"lockout_mfa_507f191e810c19729de860ea", "lockout_mfa_507f191e810c19729de860ea_until",
+				"lockout_ip_192.168.0.1", "lockout_ip_192.168.0.1_until",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			cache := &deletedCache{Cache: storecache.NewNullCache()}
+			service := NewService(store.Store(mock), cache)
+			err := service.UserUnlock(ctx, tc.input)
+			assert.Equal(t, tc.expected, err)
+			assert.Equal(t, tc.deleted, cache.keys)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
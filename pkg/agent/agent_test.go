@@ -1,5 +1,20 @@
 package agent
 
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
 func ExampleNewAgentWithConfig() {
 	_, err := NewAgentWithConfig(&Config{
 		ServerAddress: "http://localhost:80",
@@ -17,3 +32,327 @@ func ExampleNewAgent() {
 		panic(err)
 	}
 }
+
+func TestNewAgentWithConfigPAM(t *testing.T) {
+	if osauth.PAMSupported {
+		t.Skip("the agent was built with PAM support")
+	}
+
+	_, err := NewAgentWithConfig(&Config{
+		ServerAddress: "http://localhost:80",
+		TenantID:      "00000000-0000-4000-0000-000000000000",
+		PrivateKey:    "./shellhub.key",
+		PAM:           true,
+	}, new(HostMode))
+	assert.Error(t, err)
+}
+
+func TestHTTPHandler(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("X-Public-URL-Target"))
+		assert.Equal(t, "203.0.113.1", r.Header.Get("X-Forwarded-For"))
+
+		w.Write([]byte(r.URL.RequestURI())) // nolint:errcheck
+	})
+
+	plain := httptest.NewServer(handler)
+	defer plain.Close()
+
+	secure := httptest.NewTLSServer(handler)
+	defer secure.Close()
+
+	router := echo.New()
+	router.Any("/ssh/http", httpHandler())
+
+	tunnel := httptest.NewServer(router)
+	defer tunnel.Close()
+
+	type Expected struct {
+		status int
+		body   string
+	}
+
+	cases := []struct {
+		description string
+		method      string
+		header      http.Header
+		expected    Expected
+	}{
+		{
+			description: "succeeds when the service uses http",
+			method:      http.MethodGet,
+			header: http.Header{
+				"X-Path":              {"/dashboard?tab=1"},
+				"X-Public-Url-Target": {strings.TrimPrefix(plain.URL, "http://")},
+				"X-Public-Url-Scheme": {"http"},
+			},
+			expected: Expected{status: http.StatusOK, body: "/dashboard?tab=1"},
+		},
+		{
+			description: "succeeds when the request is not a GET",
+			method:      http.MethodPost,
+			header: http.Header{
+				"X-Path":              {"/form"},
+				"X-Public-Url-Target": {strings.TrimPrefix(plain.URL, "http://")},
+			},
+			expected: Expected{status: http.StatusOK, body: "/form"},
+		},
+		{
+			description: "succeeds when the service uses https skipping the verification",
+			method:      http.MethodGet,
+			header: http.Header{
+				"X-Path":                            {"/"},
+				"X-Public-Url-Target":               {strings.TrimPrefix(secure.URL, "https://")},
+				"X-Public-Url-Scheme":               {"https"},
+				"X-Public-Url-Insecure-Skip-Verify": {"true"},
+			},
+			expected: Expected{status: http.StatusOK, body: "/"},
+		},
+		{
+			description: "fails when the service certificate cannot be verified",
+			method:      http.MethodGet,
+			header: http.Header{
+				"X-Path":              {"/"},
+				"X-Public-Url-Target": {strings.TrimPrefix(secure.URL, "https://")},
+				"X-Public-Url-Scheme": {"https"},
+			},
+			expected: Expected{status: http.StatusBadGateway, body: ""},
+		},
+		{
+			description: "fails when the scheme is not supported",
+			method:      http.MethodGet,
+			header: http.Header{
+				"X-Path":              {"/"},
+				"X-Public-Url-Target": {strings.TrimPrefix(plain.URL, "http://")},
+				"X-Public-Url-Scheme": {"ftp"},
+			},
+			expected: Expected{status: http.StatusBadRequest, body: "failed to parse the service on device"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, tunnel.URL+"/ssh/http", nil)
+			assert.NoError(t, err)
+
+			req.Header = tc.header
+			req.Header.Set("X-Forwarded-For", "203.0.113.1")
+
+			res, err := http.DefaultClient.Do(req)
+			assert.NoError(t, err)
+
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expected, Expected{status: res.StatusCode, body: string(body)})
+		})
+	}
+}
+
+func TestHTTPHandlerUpgrade(t *testing.T) {
+	// NOTICE: the service upgrades the connection and echoes back what it receives, like a websocket would.
+	service := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "echo" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n") // nolint:errcheck
+		buf.Flush()                                                                                         // nolint:errcheck
+
+		io.Copy(conn, buf) // nolint:errcheck
+	}))
+	defer service.Close()
+
+	router := echo.New()
+	router.Any("/ssh/http", httpHandler())
+
+	tunnel := httptest.NewServer(router)
+	defer tunnel.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(tunnel.URL, "http://"))
+	assert.NoError(t, err)
+
+	defer conn.Close()
+
+	req, err := http.NewRequest(http.MethodGet, "/ssh/http", nil)
+	assert.NoError(t, err)
+
+	req.Host = "device"
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "echo")
+	req.Header.Set("X-Path", "/ws")
+	req.Header.Set("X-Public-URL-Target", strings.TrimPrefix(service.URL, "http://"))
+	assert.NoError(t, req.Write(conn))
+
+	reader := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(reader, req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+
+	_, err = conn.Write([]byte("ping\n"))
+	assert.NoError(t, err)
+
+	line, err := reader.ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "ping\n", line)
+}
+
+func TestReload(t *testing.T) {
+	defer logrus.SetLevel(logrus.GetLevel())
+
+	cases := []struct {
+		description string
+		config      *Config
+		expected    Config
+	}{
+		{
+			description: "keeps the current policy's settings when the policy cannot be loaded",
+			config: &Config{
+				ServerAddress:     "http://localhost",
+				LogLevel:          "debug",
+				AllowedUsers:      []string{"admin"},
+				DisableForwarding: true,
+				PolicyFile:        "/nonexistent/policy.yaml",
+			},
+			expected: Config{
+				ServerAddress: "http://localhost",
+				LogLevel:      "debug",
+				AllowedUsers:  []string{"root"},
+			},
+		},
+		{
+			description: "applies the reloadable settings and ignores the others",
+			config: &Config{
+				ServerAddress:     "http://shellhub",
+				LogLevel:          "debug",
+				AllowedUsers:      []string{"admin"},
+				DisableForwarding: true,
+			},
+			expected: Config{
+				ServerAddress:     "http://localhost",
+				LogLevel:          "debug",
+				AllowedUsers:      []string{"admin"},
+				DisableForwarding: true,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			agent := &Agent{config: &Config{ServerAddress: "http://localhost", AllowedUsers: []string{"root"}}}
+
+			// NOTICE: the forwarding setting is read by the tunnel's handlers while the configuration is reloaded.
+			handler := tcpHandler(agent)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+
+				rec := httptest.NewRecorder()
+				handler(echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/ssh/tcp", nil), rec)) // nolint:errcheck
+			}()
+
+			agent.Reload(tc.config)
+			<-done
+
+			assert.Equal(t, tc.expected, *agent.config)
+		})
+	}
+}
+
+func TestTCPHandler(t *testing.T) {
+	// NOTICE: the port on device echoes back what it receives.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	defer listener.Close()
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		io.Copy(conn, conn) // nolint:errcheck
+	}()
+
+	cases := []struct {
+		description string
+		config      *Config
+		target      string
+		expected    int
+	}{
+		{
+			description: "fails when the forwarding is disabled",
+			config:      &Config{DisableForwarding: true},
+			target:      listener.Addr().String(),
+			expected:    http.StatusForbidden,
+		},
+		{
+			description: "fails when the target is invalid",
+			config:      &Config{},
+			target:      "invalid",
+			expected:    http.StatusBadRequest,
+		},
+		{
+			description: "succeeds when the port is reachable",
+			config:      &Config{},
+			target:      listener.Addr().String(),
+			expected:    http.StatusSwitchingProtocols,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			router := echo.New()
+			router.GET("/ssh/tcp", tcpHandler(&Agent{config: tc.config}))
+
+			tunnel := httptest.NewServer(router)
+			defer tunnel.Close()
+
+			conn, err := net.Dial("tcp", strings.TrimPrefix(tunnel.URL, "http://"))
+			assert.NoError(t, err)
+
+			defer conn.Close()
+
+			req, err := http.NewRequest(http.MethodGet, "/ssh/tcp", nil)
+			assert.NoError(t, err)
+
+			req.Host = "device"
+			req.Header.Set("Connection", "Upgrade")
+			req.Header.Set("Upgrade", "tcp")
+			req.Header.Set("X-Published-Port-Target", tc.target)
+			assert.NoError(t, req.Write(conn))
+
+			reader := bufio.NewReader(conn)
+
+			res, err := http.ReadResponse(reader, req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, res.StatusCode)
+
+			if res.StatusCode != http.StatusSwitchingProtocols {
+				return
+			}
+
+			_, err = conn.Write([]byte("ping\n"))
+			assert.NoError(t, err)
+
+			line, err := reader.ReadString('\n')
+			assert.NoError(t, err)
+			assert.Equal(t, "ping\n", line)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrLoadConfig is returned when the agent's configuration could not be loaded.
+var ErrLoadConfig = errors.New("failed to load the agent configuration")
+
+// LoadConfig loads the agent's configuration from the environment variables, with or without the prefix, and, when
+// path is not empty, from the configuration file at path, a TOML one when its extension is .toml and a YAML one
+// otherwise.
+//
+// Each key of the configuration file is the name of an environment variable, without the prefix, in lower case. For
+// example, in YAML:
+//
+//	server_address: https://cloud.shellhub.io
+//	tenant_id: 00000000-0000-4000-0000-000000000000
+//	private_key: /etc/shellhub.key
+//	keepalive_interval: 30
+//	log_level: info
+//	allowed_users:
+//	  - root
+//	  - admin
+//	labels:
+//	  site: lisbon
+//	  owner: team-x
+//
+// Or in TOML:
+//
+//	server_address = "https://cloud.shellhub.io"
+//	tenant_id = "00000000-0000-4000-0000-000000000000"
+//	private_key = "/etc/shellhub.key"
+//	keepalive_interval = 30
+//	log_level = "info"
+//	allowed_users = ["root", "admin"]
+//
+//	[labels]
+//	site = "lisbon"
+//	owner = "team-x"
+//
+// The environment variables take precedence over the configuration file, and the configuration file over the default
+// values.
+func LoadConfig(prefix string, path string) (*Config, error) {
+	backend := envs.DefaultBackend
+	if path != "" {
+		values, err := readConfigFile(path)
+		if err != nil {
+			return nil, errors.Join(ErrLoadConfig, err)
+		}
+
+		backend = envs.NewBackendWithFallback(values)
+	}
+
+	cfg := new(Config)
+	if err := backend.Process(prefix, cfg); err != nil {
+		return nil, errors.Join(ErrLoadConfig, err)
+	}
+
+	return cfg, nil
+}
+
+// readConfigFile reads the TOML or YAML configuration file at path, converting its keys to the environment variables'
+// names and its values to the format expected by them.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	unmarshal := yaml.Unmarshal
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		unmarshal = toml.Unmarshal
+	}
+
+	var file map[string]interface{}
+	if err := unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(file))
+	for key, value := range file {
+		name := strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case []interface{}:
+			if len(v) == 0 {
+				continue
+			}
+
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+
+			values[name] = strings.Join(items, ",")
+		case map[string]interface{}:
+			if len(v) == 0 {
+				continue
+			}
+
+			items := make([]string, 0, len(v))
+			for k, item := range v {
+				items = append(items, fmt.Sprintf("%s=%v", k, item))
+			}
+
+			sort.Strings(items)
+
+			values[name] = strings.Join(items, ",")
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+
+	return values, nil
+}
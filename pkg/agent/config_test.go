@@ -0,0 +1,146 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	writeFile := func(t *testing.T, name string, content string) string {
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		return path
+	}
+
+	cases := []struct {
+		description string
+		envs        map[string]string
+		name        string
+		file        string
+		expected    func(*Config, error)
+	}{
+		{
+			description: "fails when required settings are missing",
+			envs:        map[string]string{},
+			name:        "agent.yaml",
+			file:        "keepalive_interval: 10\n",
+			expected: func(cfg *Config, err error) {
+				assert.Nil(t, cfg)
+				assert.ErrorIs(t, err, ErrLoadConfig)
+			},
+		},
+		{
+			description: "fails when the file is invalid",
+			envs:        map[string]string{},
+			name:        "agent.yaml",
+			file:        "server_address: [",
+			expected: func(cfg *Config, err error) {
+				assert.Nil(t, cfg)
+				assert.ErrorIs(t, err, ErrLoadConfig)
+			},
+		},
+		{
+			description: "succeeds loading the settings from the file",
+			envs:        map[string]string{},
+			name:        "agent.yaml",
+			file: `server_address: http://localhost
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+keepalive_interval: 10
+log_level: debug
+allowed_users:
+  - root
+  - admin
+labels:
+  site: lisbon
+  owner: team-x
+`,
+			expected: func(cfg *Config, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "http://localhost", cfg.ServerAddress)
+				assert.Equal(t, "00000000-0000-4000-0000-000000000000", cfg.TenantID)
+				assert.Equal(t, "/tmp/shellhub.key", cfg.PrivateKey)
+				assert.Equal(t, 10, cfg.KeepAliveInterval)
+				assert.Equal(t, "debug", cfg.LogLevel)
+				assert.Equal(t, []string{"root", "admin"}, cfg.AllowedUsers)
+				assert.Equal(t, map[string]string{"site": "lisbon", "owner": "team-x"}, cfg.Labels)
+				assert.False(t, cfg.DisableForwarding)
+			},
+		},
+		{
+			description: "fails when the TOML file is invalid",
+			envs:        map[string]string{},
+			name:        "agent.toml",
+			file:        "server_address: http://localhost\n",
+			expected: func(cfg *Config, err error) {
+				assert.Nil(t, cfg)
+				assert.ErrorIs(t, err, ErrLoadConfig)
+			},
+		},
+		{
+			description: "succeeds loading the settings from the TOML file",
+			envs:        map[string]string{},
+			name:        "agent.toml",
+			file: `server_address = "http://localhost"
+tenant_id = "00000000-0000-4000-0000-000000000000"
+private_key = "/tmp/shellhub.key"
+keepalive_interval = 10
+log_level = "debug"
+allowed_users = ["root", "admin"]
+disable_forwarding = true
+
+[labels]
+site = "lisbon"
+owner = "team-x"
+`,
+			expected: func(cfg *Config, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "http://localhost", cfg.ServerAddress)
+				assert.Equal(t, "00000000-0000-4000-0000-000000000000", cfg.TenantID)
+				assert.Equal(t, "/tmp/shellhub.key", cfg.PrivateKey)
+				assert.Equal(t, 10, cfg.KeepAliveInterval)
+				assert.Equal(t, "debug", cfg.LogLevel)
+				assert.Equal(t, []string{"root", "admin"}, cfg.AllowedUsers)
+				assert.Equal(t, map[string]string{"site": "lisbon", "owner": "team-x"}, cfg.Labels)
+				assert.True(t, cfg.DisableForwarding)
+			},
+		},
+		{
+			description: "succeeds giving precedence to the environment variables",
+			envs: map[string]string{
+				"SHELLHUB_SERVER_ADDRESS": "http://shellhub",
+				"SHELLHUB_ALLOWED_USERS":  "user",
+				"SHELLHUB_LABELS":         "site=porto,serial=A=1",
+			},
+			name: "agent.yaml",
+			file: `server_address: http://localhost
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+allowed_users: [root]
+`,
+			expected: func(cfg *Config, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "http://shellhub", cfg.ServerAddress)
+				assert.Equal(t, []string{"user"}, cfg.AllowedUsers)
+				assert.Equal(t, map[string]string{"site": "porto", "serial": "A=1"}, cfg.Labels)
+				assert.Equal(t, 30, cfg.KeepAliveInterval)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			for key, value := range tc.envs {
+				t.Setenv(key, value)
+			}
+
+			tc.expected(LoadConfig("SHELLHUB_", writeFile(t, tc.name, tc.file)))
+		})
+	}
+}
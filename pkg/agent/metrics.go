@@ -0,0 +1,14 @@
+package agent
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+)
+
+var reconnects = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "agent",
+	Name:      "reconnects_total",
+	Help:      "Number of connections to the server established after the first one.",
+})
@@ -5,6 +5,7 @@ import (
 	"os/exec"
 
 	dockerclient "github.com/docker/docker/client"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sysinfo"
 	"github.com/shellhub-io/shellhub/pkg/agent/server"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
@@ -43,6 +44,16 @@ type HostMode struct{}
 var _ Mode = new(HostMode)
 
 func (m *HostMode) Serve(agent *Agent) {
+	authenticator := host.NewAuthenticator(agent.cli, agent.authData, agent.config.SingleUserPassword, &agent.authData.Name)
+
+	if agent.config.PAM {
+		authenticator.SetPAM(osauth.NewPAM(agent.config.PAMService))
+	}
+
+	if agent.config.AuthorizedKeys {
+		authenticator.SetAuthorizedKeysFile(agent.config.AuthorizedKeysFile)
+	}
+
 	agent.server = server.NewServer(
 		agent.cli,
 		agent.authData,
@@ -50,7 +61,7 @@ func (m *HostMode) Serve(agent *Agent) {
 		agent.config.KeepAliveInterval,
 		agent.config.SingleUserPassword,
 		&host.Mode{
-			Authenticator: *host.NewAuthenticator(agent.cli, agent.authData, agent.config.SingleUserPassword, &agent.authData.Name),
+			Authenticator: *authenticator,
 			Sessioner:     *host.NewSessioner(&agent.authData.Name, make(map[string]*exec.Cmd)),
 		},
 	)
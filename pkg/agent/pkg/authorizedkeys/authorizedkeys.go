@@ -0,0 +1,146 @@
+// Package authorizedkeys evaluates OpenSSH authorized keys files.
+//
+// It is used by the agent, in host mode, as an alternative source of public keys to the ShellHub's API, honoring the
+// `from=` and `command=` options of each entry.
+package authorizedkeys
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path"
+	"strings"
+
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// ErrKeyNotFound is returned when the key is not present in the authorized keys file.
+var ErrKeyNotFound = errors.New("key not found in the authorized keys")
+
+// Entry is a key from an authorized keys file with the options that restrict its use.
+type Entry struct {
+	// Key is the authorized public key.
+	Key gossh.PublicKey
+	// Options is the raw list of options of the entry.
+	Options []string
+	// Command is the command forced by the `command=` option. It is empty when the option is not set.
+	Command string
+	// From is the list of patterns from the `from=` option. It is empty when the option is not set.
+	From []string
+}
+
+// Find reads an authorized keys file from r, searching for the entry of key.
+//
+// Invalid lines are skipped, like OpenSSH does. If key is not found, it returns [ErrKeyNotFound].
+func Find(r io.Reader, key gossh.PublicKey) (*Entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	marshaled := key.Marshal()
+	for len(data) > 0 {
+		authorized, _, options, rest, err := gossh.ParseAuthorizedKey(data)
+		if err != nil {
+			break
+		}
+
+		data = rest
+
+		if !bytes.Equal(authorized.Marshal(), marshaled) {
+			continue
+		}
+
+		entry := &Entry{
+			Key:     authorized,
+			Options: options,
+		}
+
+		for _, option := range options {
+			name, value, _ := strings.Cut(option, "=")
+			switch strings.ToLower(name) {
+			case "command":
+				entry.Command = unquote(value)
+			case "from":
+				entry.From = strings.Split(unquote(value), ",")
+			}
+		}
+
+		return entry, nil
+	}
+
+	return nil, ErrKeyNotFound
+}
+
+// FindFile opens the authorized keys file at path and searches for the entry of key.
+func FindFile(path string, key gossh.PublicKey) (*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Find(file, key)
+}
+
+// AllowsAddress checks if the remote address is allowed by the entry's `from=` option.
+//
+// Patterns are matched against the remote IP address, supporting the `*` and `?` wildcards, CIDR notation and negation
+// with a leading `!`. A negated match denies the address even when other pattern allows it. Host names are not
+// resolved. When the entry has no `from=` option, any address is allowed.
+func (e *Entry) AllowsAddress(remote net.Addr) bool {
+	if len(e.From) == 0 {
+		return true
+	}
+
+	if remote == nil {
+		return false
+	}
+
+	host, _, err := net.SplitHostPort(remote.String())
+	if err != nil {
+		host = remote.String()
+	}
+
+	ip := net.ParseIP(host)
+
+	var allowed bool
+	for _, pattern := range e.From {
+		negated := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+
+		if !match(pattern, host, ip) {
+			continue
+		}
+
+		if negated {
+			return false
+		}
+
+		allowed = true
+	}
+
+	return allowed
+}
+
+// match checks if the host, or its IP, matches a single `from=` pattern.
+func match(pattern, host string, ip net.IP) bool {
+	if _, network, err := net.ParseCIDR(pattern); err == nil {
+		return ip != nil && network.Contains(ip)
+	}
+
+	ok, err := path.Match(pattern, host)
+
+	return err == nil && ok
+}
+
+// unquote removes the surrounding double quotes from an option's value and unescapes inner quotes.
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	}
+
+	return strings.ReplaceAll(value, `\"`, `"`)
+}
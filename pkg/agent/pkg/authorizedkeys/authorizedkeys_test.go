@@ -0,0 +1,133 @@
+package authorizedkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func generateKey(t *testing.T) gossh.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := gossh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestFind(t *testing.T) {
+	key := generateKey(t)
+	other := generateKey(t)
+
+	authorized := func(options string, key gossh.PublicKey) string {
+		line := strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key)))
+		if options != "" {
+			line = options + " " + line
+		}
+
+		return line + " user@host\n"
+	}
+
+	cases := []struct {
+		description string
+		file        string
+		expected    func(*Entry, error)
+	}{
+		{
+			description: "fails when key is not in the file",
+			file:        authorized("", other),
+			expected: func(entry *Entry, err error) {
+				assert.Nil(t, entry)
+				assert.ErrorIs(t, err, ErrKeyNotFound)
+			},
+		},
+		{
+			description: "succeeds skipping comments and invalid lines",
+			file:        "# comment\ninvalid line\n" + authorized("", other) + authorized("", key),
+			expected: func(entry *Entry, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, key.Marshal(), entry.Key.Marshal())
+				assert.Empty(t, entry.Command)
+				assert.Empty(t, entry.From)
+			},
+		},
+		{
+			description: "succeeds parsing the from and command options",
+			file:        authorized(`from="10.0.0.0/8,!10.0.0.1",command="echo \"hello\""`, key),
+			expected: func(entry *Entry, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `echo "hello"`, entry.Command)
+				assert.Equal(t, []string{"10.0.0.0/8", "!10.0.0.1"}, entry.From)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.expected(Find(strings.NewReader(tc.file), key))
+		})
+	}
+}
+
+func TestAllowsAddress(t *testing.T) {
+	cases := []struct {
+		description string
+		from        []string
+		remote      net.Addr
+		expected    bool
+	}{
+		{
+			description: "allows any address when from is not set",
+			from:        nil,
+			remote:      &net.TCPAddr{IP: net.ParseIP("192.168.0.1"), Port: 22},
+			expected:    true,
+		},
+		{
+			description: "denies when remote address is unknown",
+			from:        []string{"*"},
+			remote:      nil,
+			expected:    false,
+		},
+		{
+			description: "allows when address matches a wildcard",
+			from:        []string{"192.168.0.*"},
+			remote:      &net.TCPAddr{IP: net.ParseIP("192.168.0.1"), Port: 22},
+			expected:    true,
+		},
+		{
+			description: "allows when address is within a CIDR",
+			from:        []string{"10.0.0.0/8"},
+			remote:      &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 22},
+			expected:    true,
+		},
+		{
+			description: "denies when address matches a negated pattern",
+			from:        []string{"10.0.0.0/8", "!10.1.2.3"},
+			remote:      &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 22},
+			expected:    false,
+		},
+		{
+			description: "denies when address does not match any pattern",
+			from:        []string{"172.16.0.0/12", "192.168.*"},
+			remote:      &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 22},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			entry := &Entry{From: tc.from}
+			assert.Equal(t, tc.expected, entry.AllowsAddress(tc.remote))
+		})
+	}
+}
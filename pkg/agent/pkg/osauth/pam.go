@@ -0,0 +1,39 @@
+package osauth
+
+import "errors"
+
+// DefaultPAMService is the PAM service used when none is configured.
+var DefaultPAMService = "sshd"
+
+// ErrPAMNotSupported is returned when the agent was built without PAM support.
+var ErrPAMNotSupported = errors.New("agent was built without PAM support")
+
+// PAMAuther authenticates users and checks their accounts through the Pluggable Authentication Modules, honoring the
+// policies configured on the host, like pam_faillock, LDAP/SSSD accounts and nologin.
+//
+// PAM support requires the agent to be built with the `pam` build tag and cgo enabled. Without it, every method returns
+// [ErrPAMNotSupported].
+//
+//go:generate mockery --name=PAMAuther --filename=pamauther.go
+type PAMAuther interface {
+	// AuthUser authenticates the user with the password and checks if the account is allowed to log in.
+	AuthUser(username, password string) error
+	// CheckAccount checks if the account is allowed to log in without authenticating it. It is used after the user is
+	// authenticated by other method, like public key.
+	CheckAccount(username string) error
+}
+
+// PAM is the default implementation of [PAMAuther].
+type PAM struct {
+	// Service is the PAM service, a file inside /etc/pam.d, used on the transactions.
+	Service string
+}
+
+// NewPAM creates a new [PAM] for the service. When service is empty, [DefaultPAMService] is used.
+func NewPAM(service string) *PAM {
+	if service == "" {
+		service = DefaultPAMService
+	}
+
+	return &PAM{Service: service}
+}
@@ -0,0 +1,14 @@
+//go:build !pam || !cgo
+
+package osauth
+
+// PAMSupported indicates if the agent was built with PAM support.
+const PAMSupported = false
+
+func (p *PAM) AuthUser(_, _ string) error {
+	return ErrPAMNotSupported
+}
+
+func (p *PAM) CheckAccount(_ string) error {
+	return ErrPAMNotSupported
+}
@@ -0,0 +1,45 @@
+//go:build pam && cgo
+
+package osauth
+
+import (
+	"errors"
+
+	"github.com/msteinert/pam"
+)
+
+// PAMSupported indicates if the agent was built with PAM support.
+const PAMSupported = true
+
+func (p *PAM) AuthUser(username, password string) error {
+	tx, err := pam.StartFunc(p.Service, username, func(style pam.Style, _ string) (string, error) {
+		switch style {
+		case pam.PromptEchoOff, pam.PromptEchoOn:
+			return password, nil
+		case pam.ErrorMsg, pam.TextInfo:
+			return "", nil
+		default:
+			return "", errors.New("unsupported PAM conversation style")
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Authenticate(pam.Silent | pam.DisallowNullAuthtok); err != nil {
+		return err
+	}
+
+	return tx.AcctMgmt(pam.Silent)
+}
+
+func (p *PAM) CheckAccount(username string) error {
+	tx, err := pam.StartFunc(p.Service, username, func(pam.Style, string) (string, error) {
+		return "", errors.New("PAM conversation is not allowed when checking an account")
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.AcctMgmt(pam.Silent)
+}
@@ -0,0 +1,165 @@
+// Package policy defines the agent-local policy that restricts which users and which features are reachable through
+// the agent.
+//
+// The policy is read from a file on the device, so it cannot be overridden by the ShellHub server, keeping the device
+// protected even when the ShellHub account is compromised.
+package policy
+
+import (
+	"os"
+	"path"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"gopkg.in/yaml.v3"
+)
+
+// Capability is a feature of the agent that could be disabled by the policy.
+type Capability string
+
+const (
+	// CapabilityShell is the capability to open an interactive shell, with or without a pty.
+	CapabilityShell Capability = "shell"
+	// CapabilityExec is the capability to execute a command.
+	CapabilityExec Capability = "exec"
+	// CapabilitySFTP is the capability to open a SFTP session.
+	CapabilitySFTP Capability = "sftp"
+	// CapabilityForwarding is the capability to forward TCP ports through the agent.
+	CapabilityForwarding Capability = "forwarding"
+)
+
+// ContextKeyRules is the key, on the SSH's context, of the [Rules] applied to the authenticated user.
+const ContextKeyRules = "policy_rules"
+
+// Rules are the restrictions applied to a user after the authentication.
+type Rules struct {
+	// DisableShell disables the interactive shell.
+	DisableShell bool `yaml:"disable_shell"`
+	// DisableExec disables the command execution.
+	DisableExec bool `yaml:"disable_exec"`
+	// DisableSFTP disables the SFTP subsystem.
+	DisableSFTP bool `yaml:"disable_sftp"`
+	// DisableForwarding disables the TCP port forwarding.
+	DisableForwarding bool `yaml:"disable_forwarding"`
+	// ForceCommand is the command executed instead of the shell or the command requested by the client.
+	ForceCommand string `yaml:"force_command"`
+}
+
+// Allows checks if the capability is allowed by the rules.
+func (r Rules) Allows(capability Capability) bool {
+	switch capability {
+	case CapabilityShell:
+		return !r.DisableShell
+	case CapabilityExec:
+		return !r.DisableExec
+	case CapabilitySFTP:
+		return !r.DisableSFTP
+	case CapabilityForwarding:
+		return !r.DisableForwarding
+	default:
+		return false
+	}
+}
+
+// merge combines the rules, keeping every capability disabled by any of them. The other's forced command takes
+// precedence when set.
+func (r Rules) merge(other Rules) Rules {
+	merged := Rules{
+		DisableShell:      r.DisableShell || other.DisableShell,
+		DisableExec:       r.DisableExec || other.DisableExec,
+		DisableSFTP:       r.DisableSFTP || other.DisableSFTP,
+		DisableForwarding: r.DisableForwarding || other.DisableForwarding,
+		ForceCommand:      r.ForceCommand,
+	}
+
+	if other.ForceCommand != "" {
+		merged.ForceCommand = other.ForceCommand
+	}
+
+	return merged
+}
+
+// Policy restricts which users can log in through the agent and what they can do.
+//
+// A nil Policy allows everything.
+type Policy struct {
+	// AllowUsers is the list of users allowed to log in. When empty, every user not denied is allowed. Each item could
+	// be a pattern with the `*` and `?` wildcards.
+	AllowUsers []string `yaml:"allow_users"`
+	// DenyUsers is the list of users denied to log in, even when they are in AllowUsers. Each item could be a pattern
+	// with the `*` and `?` wildcards.
+	DenyUsers []string `yaml:"deny_users"`
+	// DenyRoot denies the root user, and any other user with the UID 0, to log in.
+	DenyRoot bool `yaml:"deny_root"`
+	// Rules are the default rules applied to every user.
+	Rules `yaml:",inline"`
+	// Users are the rules applied to specific users, in addition to the default ones.
+	Users map[string]Rules `yaml:"users"`
+}
+
+// Load reads the policy from the YAML file at path.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := new(Policy)
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// IsUserAllowed checks if the user is allowed to log in.
+func (p *Policy) IsUserAllowed(user string) bool {
+	if p == nil {
+		return true
+	}
+
+	if p.DenyRoot && (user == "root" || isRoot(user)) {
+		return false
+	}
+
+	if matchAny(p.DenyUsers, user) {
+		return false
+	}
+
+	return len(p.AllowUsers) == 0 || matchAny(p.AllowUsers, user)
+}
+
+// RulesFor returns the rules applied to the user, merging the default rules with the user's ones.
+func (p *Policy) RulesFor(user string) Rules {
+	if p == nil {
+		return Rules{}
+	}
+
+	return p.Rules.merge(p.Users[user])
+}
+
+// RulesFromContext returns the rules stored on the SSH's context. When there are no rules, every capability is
+// allowed.
+func RulesFromContext(ctx gliderssh.Context) Rules {
+	rules, _ := ctx.Value(ContextKeyRules).(Rules)
+
+	return rules
+}
+
+// isRoot checks if the user has the root's UID on the device, like an alias of the root user.
+var isRoot = func(user string) bool {
+	u := new(osauth.OSAuth).LookupUser(user)
+
+	return u != nil && u.UID == 0
+}
+
+// matchAny checks if the user matches any of the patterns.
+func matchAny(patterns []string, user string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, user); err == nil && ok {
+			return true
+		}
+	}
+
+	return false
+}
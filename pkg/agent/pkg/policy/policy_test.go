@@ -0,0 +1,142 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUserAllowed(t *testing.T) {
+	isRootBackend := isRoot
+	isRoot = func(user string) bool { return user == "toor" }
+	t.Cleanup(func() { isRoot = isRootBackend })
+
+	cases := []struct {
+		description string
+		policy      *Policy
+		user        string
+		expected    bool
+	}{
+		{
+			description: "allows any user when policy is nil",
+			policy:      nil,
+			user:        "root",
+			expected:    true,
+		},
+		{
+			description: "denies root when root is denied",
+			policy:      &Policy{DenyRoot: true},
+			user:        "root",
+			expected:    false,
+		},
+		{
+			description: "denies a user with the root's UID when root is denied",
+			policy:      &Policy{DenyRoot: true},
+			user:        "toor",
+			expected:    false,
+		},
+		{
+			description: "allows a user without the root's UID when root is denied",
+			policy:      &Policy{DenyRoot: true},
+			user:        "admin",
+			expected:    true,
+		},
+		{
+			description: "denies user matching the deny list even when allowed",
+			policy:      &Policy{AllowUsers: []string{"*"}, DenyUsers: []string{"guest*"}},
+			user:        "guest1",
+			expected:    false,
+		},
+		{
+			description: "denies user not in the allow list",
+			policy:      &Policy{AllowUsers: []string{"admin", "dev-*"}},
+			user:        "user",
+			expected:    false,
+		},
+		{
+			description: "allows user matching the allow list",
+			policy:      &Policy{AllowUsers: []string{"admin", "dev-*"}},
+			user:        "dev-john",
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.IsUserAllowed(tc.user))
+		})
+	}
+}
+
+func TestRulesFor(t *testing.T) {
+	policy := &Policy{
+		Rules: Rules{
+			DisableForwarding: true,
+			ForceCommand:      "/bin/menu",
+		},
+		Users: map[string]Rules{
+			"backup": {
+				DisableShell: true,
+				ForceCommand: "/usr/bin/rsync --server",
+			},
+		},
+	}
+
+	cases := []struct {
+		description string
+		user        string
+		expected    Rules
+	}{
+		{
+			description: "returns the default rules when user has no rules",
+			user:        "admin",
+			expected: Rules{
+				DisableForwarding: true,
+				ForceCommand:      "/bin/menu",
+			},
+		},
+		{
+			description: "returns the default rules merged with the user's rules",
+			user:        "backup",
+			expected: Rules{
+				DisableShell:      true,
+				DisableForwarding: true,
+				ForceCommand:      "/usr/bin/rsync --server",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			rules := policy.RulesFor(tc.user)
+			assert.Equal(t, tc.expected, rules)
+			assert.False(t, rules.Allows(CapabilityForwarding))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(`deny_root: true
+allow_users: [admin, backup]
+disable_sftp: true
+users:
+  backup:
+    force_command: /usr/bin/backup
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &Policy{
+		AllowUsers: []string{"admin", "backup"},
+		DenyRoot:   true,
+		Rules:      Rules{DisableSFTP: true},
+		Users: map[string]Rules{
+			"backup": {ForceCommand: "/usr/bin/backup"},
+		},
+	}, policy)
+}
@@ -0,0 +1,261 @@
+package sysinfo
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// DefaultProcFilesystem is the mount point of the proc filesystem where the inventory is read from.
+var DefaultProcFilesystem = "/proc"
+
+// DefaultDpkgStatusFilename, DefaultApkInstalledFilename and DefaultPacmanLocalDirectory are the databases of the
+// package managers supported to count the installed packages.
+var (
+	DefaultDpkgStatusFilename   = "/var/lib/dpkg/status"
+	DefaultApkInstalledFilename = "/lib/apk/db/installed"
+	DefaultPacmanLocalDirectory = "/var/lib/pacman/local"
+)
+
+var ErrNoPackageManager = errors.New("no package manager found")
+
+type CPU struct {
+	Model string
+	Count int
+}
+
+// Memory is the system's memory, in bytes.
+type Memory struct {
+	Total     uint64
+	Available uint64
+}
+
+// Disk is a mounted block device, with its sizes in bytes.
+type Disk struct {
+	Device     string
+	Mountpoint string
+	Filesystem string
+	Total      uint64
+	Used       uint64
+}
+
+type Interface struct {
+	Name      string
+	MAC       string
+	Addresses []string
+	// Primary indicates the interface returned by [PrimaryInterface].
+	Primary bool
+}
+
+func readProcFs(file string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(DefaultProcFilesystem, file))
+}
+
+// KernelVersion returns the release of the running kernel.
+func KernelVersion() (string, error) {
+	data, err := readProcFs("sys/kernel/osrelease")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// GetCPU returns the model and the number of the system's processors.
+//
+// When the model is not found, like on some ARM kernels, the hardware name is used instead.
+func GetCPU() (*CPU, error) {
+	data, err := readProcFs("cpuinfo")
+	if err != nil {
+		return nil, err
+	}
+
+	cpu := new(CPU)
+	var hardware string
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "processor":
+			cpu.Count++
+		case "model name":
+			if cpu.Model == "" {
+				cpu.Model = strings.TrimSpace(value)
+			}
+		case "Hardware":
+			hardware = strings.TrimSpace(value)
+		}
+	}
+
+	if cpu.Model == "" {
+		cpu.Model = hardware
+	}
+
+	if cpu.Count == 0 {
+		cpu.Count = runtime.NumCPU()
+	}
+
+	return cpu, nil
+}
+
+// GetMemory returns the total and the available memory of the system.
+func GetMemory() (*Memory, error) {
+	data, err := readProcFs("meminfo")
+	if err != nil {
+		return nil, err
+	}
+
+	memory := new(Memory)
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		// NOTICE: the values are reported in kibibytes, even if the unit says kB.
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		switch fields[0] {
+		case "MemTotal:":
+			memory.Total = value * 1024
+		case "MemAvailable:":
+			memory.Available = value * 1024
+		}
+	}
+
+	return memory, nil
+}
+
+// GetDisks returns the block devices mounted on the system, each one once, with their usage.
+func GetDisks() ([]Disk, error) {
+	data, err := readProcFs("self/mounts")
+	if err != nil {
+		return nil, err
+	}
+
+	disks := make([]Disk, 0)
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], "/dev/") || seen[fields[0]] {
+			continue
+		}
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(fields[1], &stat); err != nil {
+			continue
+		}
+
+		seen[fields[0]] = true
+
+		disks = append(disks, Disk{
+			Device:     fields[0],
+			Mountpoint: fields[1],
+			Filesystem: fields[2],
+			Total:      stat.Blocks * uint64(stat.Bsize),
+			Used:       (stat.Blocks - stat.Bfree) * uint64(stat.Bsize),
+		})
+	}
+
+	return disks, nil
+}
+
+// Uptime returns the time, in seconds, since the system booted.
+func Uptime() (uint64, error) {
+	data, err := readProcFs("uptime")
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 1 {
+		return 0, errors.New("invalid uptime")
+	}
+
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(uptime), nil
+}
+
+// PackagesCount returns the number of packages installed through dpkg, apk or pacman, the first one found.
+//
+// If none of them is found, ErrNoPackageManager is returned.
+func PackagesCount() (int, error) {
+	if data, err := os.ReadFile(DefaultDpkgStatusFilename); err == nil {
+		return strings.Count(string(data), "Status: install ok installed"), nil
+	}
+
+	if data, err := os.ReadFile(DefaultApkInstalledFilename); err == nil {
+		return strings.Count("\n"+string(data), "\nP:"), nil
+	}
+
+	if entries, err := os.ReadDir(DefaultPacmanLocalDirectory); err == nil {
+		count := 0
+		for _, entry := range entries {
+			if entry.IsDir() {
+				count++
+			}
+		}
+
+		return count, nil
+	}
+
+	return 0, ErrNoPackageManager
+}
+
+// GetInterfaces returns the system's network interfaces, except the loopback ones, with their addresses.
+func GetInterfaces() ([]Interface, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var primary string
+	if iface, err := PrimaryInterface(); err == nil {
+		primary = iface.Name
+	}
+
+	result := make([]Interface, 0)
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback > 0 {
+			continue
+		}
+
+		addresses := make([]string, 0)
+		if addrs, err := iface.Addrs(); err == nil {
+			for _, addr := range addrs {
+				addresses = append(addresses, addr.String())
+			}
+		}
+
+		result = append(result, Interface{
+			Name:      iface.Name,
+			MAC:       iface.HardwareAddr.String(),
+			Addresses: addresses,
+			Primary:   iface.Name == primary,
+		})
+	}
+
+	return result, nil
+}
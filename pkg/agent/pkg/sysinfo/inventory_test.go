@@ -0,0 +1,119 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeProcFs(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	previous := DefaultProcFilesystem
+	DefaultProcFilesystem = dir
+	t.Cleanup(func() {
+		DefaultProcFilesystem = previous
+	})
+}
+
+func TestKernelVersion(t *testing.T) {
+	writeProcFs(t, map[string]string{"sys/kernel/osrelease": "6.1.0-18-amd64\n"})
+
+	kernel, err := KernelVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "6.1.0-18-amd64", kernel)
+}
+
+func TestGetCPU(t *testing.T) {
+	cases := []struct {
+		description string
+		cpuinfo     string
+		expected    *CPU
+	}{
+		{
+			description: "succeeds to read the model name",
+			cpuinfo:     "processor\t: 0\nmodel name\t: Intel(R) Core(TM) i7\n\nprocessor\t: 1\nmodel name\t: Intel(R) Core(TM) i7\n",
+			expected:    &CPU{Model: "Intel(R) Core(TM) i7", Count: 2},
+		},
+		{
+			description: "succeeds to fall back to the hardware name",
+			cpuinfo:     "processor\t: 0\nBogoMIPS\t: 38.40\n\nHardware\t: BCM2835\n",
+			expected:    &CPU{Model: "BCM2835", Count: 1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			writeProcFs(t, map[string]string{"cpuinfo": tc.cpuinfo})
+
+			cpu, err := GetCPU()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, cpu)
+		})
+	}
+}
+
+func TestGetMemory(t *testing.T) {
+	writeProcFs(t, map[string]string{"meminfo": "MemTotal:        2048 kB\nMemFree:          256 kB\nMemAvailable:    1024 kB\n"})
+
+	memory, err := GetMemory()
+	assert.NoError(t, err)
+	assert.Equal(t, &Memory{Total: 2048 * 1024, Available: 1024 * 1024}, memory)
+}
+
+func TestGetDisks(t *testing.T) {
+	writeProcFs(t, map[string]string{"self/mounts": "proc /proc proc rw 0 0\n/dev/root / ext4 rw 0 0\n/dev/root /etc/hosts ext4 rw 0 0\n"})
+
+	disks, err := GetDisks()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(disks))
+	assert.Equal(t, "/dev/root", disks[0].Device)
+	assert.Equal(t, "/", disks[0].Mountpoint)
+	assert.Equal(t, "ext4", disks[0].Filesystem)
+	assert.LessOrEqual(t, disks[0].Used, disks[0].Total)
+}
+
+func TestUptime(t *testing.T) {
+	writeProcFs(t, map[string]string{"uptime": "3600.52 7000.10\n"})
+
+	uptime, err := Uptime()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3600), uptime)
+}
+
+func TestPackagesCount(t *testing.T) {
+	dir := t.TempDir()
+
+	dpkg, apk, pacman := DefaultDpkgStatusFilename, DefaultApkInstalledFilename, DefaultPacmanLocalDirectory
+	defer func() {
+		DefaultDpkgStatusFilename, DefaultApkInstalledFilename, DefaultPacmanLocalDirectory = dpkg, apk, pacman
+	}()
+
+	DefaultDpkgStatusFilename = filepath.Join(dir, "status")
+	DefaultApkInstalledFilename = filepath.Join(dir, "installed")
+	DefaultPacmanLocalDirectory = filepath.Join(dir, "local")
+
+	_, err := PackagesCount()
+	assert.Equal(t, ErrNoPackageManager, err)
+
+	assert.NoError(t, os.WriteFile(DefaultApkInstalledFilename, []byte("C:Q1\nP:musl\nV:1.2.4\n\nC:Q2\nP:busybox\nV:1.36.1\n"), 0o600))
+
+	count, err := PackagesCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.NoError(t, os.WriteFile(DefaultDpkgStatusFilename, []byte("Package: bash\nStatus: install ok installed\n\nPackage: vim\nStatus: deinstall ok config-files\n"), 0o600))
+
+	count, err = PackagesCount()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,93 @@
+package sysinfo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// DefaultThermalDirectory is the directory of the thermal zones where the temperature is read from.
+var DefaultThermalDirectory = "/sys/class/thermal"
+
+var ErrNoTemperature = errors.New("no temperature found")
+
+type LoadAverage struct {
+	Load1  float64
+	Load5  float64
+	Load15 float64
+}
+
+// GetLoadAverage returns the system's load average over the last 1, 5 and 15 minutes.
+func GetLoadAverage() (*LoadAverage, error) {
+	data, err := readProcFs("loadavg")
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return nil, errors.New("invalid load average")
+	}
+
+	values := make([]float64, 3)
+	for i := range values {
+		if values[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return nil, err
+		}
+	}
+
+	return &LoadAverage{Load1: values[0], Load5: values[1], Load15: values[2]}, nil
+}
+
+// DiskUsage returns the percentage in use of the filesystem mounted on path.
+func DiskUsage(path string) (float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+
+	if stat.Blocks == 0 {
+		return 0, nil
+	}
+
+	return float64(stat.Blocks-stat.Bfree) / float64(stat.Blocks) * 100, nil
+}
+
+// Temperature returns the highest temperature, in Celsius, of the system's thermal zones.
+//
+// If there is no thermal zone, like on most virtual machines, ErrNoTemperature is returned.
+func Temperature() (float64, error) {
+	zones, err := filepath.Glob(filepath.Join(DefaultThermalDirectory, "thermal_zone*", "temp"))
+	if err != nil {
+		return 0, err
+	}
+
+	found := false
+	highest := 0.0
+	for _, zone := range zones {
+		data, err := os.ReadFile(zone)
+		if err != nil {
+			continue
+		}
+
+		// NOTICE: the temperature is reported in millidegrees Celsius.
+		value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if temperature := float64(value) / 1000; !found || temperature > highest {
+			highest = temperature
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, ErrNoTemperature
+	}
+
+	return highest, nil
+}
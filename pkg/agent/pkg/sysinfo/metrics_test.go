@@ -0,0 +1,49 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLoadAverage(t *testing.T) {
+	writeProcFs(t, map[string]string{"loadavg": "0.52 0.38 0.21 1/234 5678\n"})
+
+	load, err := GetLoadAverage()
+	assert.NoError(t, err)
+	assert.Equal(t, &LoadAverage{Load1: 0.52, Load5: 0.38, Load15: 0.21}, load)
+}
+
+func TestDiskUsage(t *testing.T) {
+	usage, err := DiskUsage(t.TempDir())
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, usage, 0.0)
+	assert.LessOrEqual(t, usage, 100.0)
+
+	_, err = DiskUsage(filepath.Join(t.TempDir(), "nonexistent"))
+	assert.Error(t, err)
+}
+
+func TestTemperature(t *testing.T) {
+	dir := t.TempDir()
+
+	previous := DefaultThermalDirectory
+	DefaultThermalDirectory = dir
+	defer func() {
+		DefaultThermalDirectory = previous
+	}()
+
+	_, err := Temperature()
+	assert.Equal(t, ErrNoTemperature, err)
+
+	for zone, temp := range map[string]string{"thermal_zone0": "45000\n", "thermal_zone1": "52500\n"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, zone), 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, zone, "temp"), []byte(temp), 0o600))
+	}
+
+	temperature, err := Temperature()
+	assert.NoError(t, err)
+	assert.Equal(t, 52.5, temperature)
+}
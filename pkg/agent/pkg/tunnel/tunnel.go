@@ -7,14 +7,19 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
 )
 
 type Tunnel struct {
 	router       *echo.Echo
 	srv          *http.Server
 	HTTPHandler  func(e echo.Context) error
+	TCPHandler   func(e echo.Context) error
 	ConnHandler  func(e echo.Context) error
 	CloseHandler func(e echo.Context) error
+	// AuthorizedKeysHandler checks if the client's public key, forwarded by the ShellHub's SSH server, is authorized
+	// for the user by the agent.
+	AuthorizedKeysHandler func(e echo.Context) error
 }
 
 type Builder struct {
@@ -33,6 +38,12 @@ func (t *Builder) WithHTTPHandler(handler func(e echo.Context) error) *Builder {
 	return t
 }
 
+func (t *Builder) WithTCPHandler(handler func(e echo.Context) error) *Builder {
+	t.tunnel.TCPHandler = handler
+
+	return t
+}
+
 func (t *Builder) WithConnHandler(handler func(e echo.Context) error) *Builder {
 	t.tunnel.ConnHandler = handler
 
@@ -45,6 +56,12 @@ func (t *Builder) WithCloseHandler(handler func(e echo.Context) error) *Builder
 	return t
 }
 
+func (t *Builder) WithAuthorizedKeysHandler(handler func(e echo.Context) error) *Builder {
+	t.tunnel.AuthorizedKeysHandler = handler
+
+	return t
+}
+
 func (t *Builder) Build() *Tunnel {
 	return t.tunnel
 }
@@ -63,22 +80,37 @@ func NewTunnel() *Tunnel {
 		HTTPHandler: func(e echo.Context) error {
 			panic("HTTPHandler can not be nil")
 		},
+		TCPHandler: func(e echo.Context) error {
+			panic("TCPHandler can not be nil")
+		},
 		ConnHandler: func(e echo.Context) error {
 			panic("connHandler can not be nil")
 		},
 		CloseHandler: func(e echo.Context) error {
 			panic("closeHandler can not be nil")
 		},
+		AuthorizedKeysHandler: func(e echo.Context) error {
+			panic("authorizedKeysHandler can not be nil")
+		},
 	}
-	e.GET("/ssh/http", func(e echo.Context) error {
+	// NOTICE: the requests of the SSH server carry its trace context, continued by the agent.
+	e.Use(tracing.Middleware)
+
+	e.Any("/ssh/http", func(e echo.Context) error {
 		return t.HTTPHandler(e)
 	})
+	e.GET("/ssh/tcp", func(e echo.Context) error {
+		return t.TCPHandler(e)
+	})
 	e.GET("/ssh/:id", func(e echo.Context) error {
 		return t.ConnHandler(e)
 	})
 	e.GET("/ssh/close/:id", func(e echo.Context) error {
 		return t.CloseHandler(e)
 	})
+	e.GET("/ssh/authorized-keys/:user", func(e echo.Context) error {
+		return t.AuthorizedKeysHandler(e)
+	})
 
 	return t
 }
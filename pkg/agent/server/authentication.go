@@ -2,12 +2,62 @@ package server
 
 import (
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/policy"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
+	log "github.com/sirupsen/logrus"
 )
 
 func (s *Server) passwordHandler(ctx gliderssh.Context, pass string) bool {
-	return s.mode.Password(ctx, ctx.User(), pass)
+	if !s.getPolicy().IsUserAllowed(ctx.User()) {
+		log.WithField("username", ctx.User()).Warn("user denied by the agent policy")
+
+		return false
+	}
+
+	if !s.mode.Password(ctx, ctx.User(), pass) {
+		return false
+	}
+
+	s.applyPolicy(ctx)
+
+	return true
 }
 
 func (s *Server) publicKeyHandler(ctx gliderssh.Context, key gliderssh.PublicKey) bool {
-	return s.mode.PublicKey(ctx, ctx.User(), key)
+	if !s.getPolicy().IsUserAllowed(ctx.User()) {
+		log.WithField("username", ctx.User()).Warn("user denied by the agent policy")
+
+		return false
+	}
+
+	if !s.mode.PublicKey(ctx, ctx.User(), key) {
+		return false
+	}
+
+	s.applyPolicy(ctx)
+
+	return true
+}
+
+// AuthorizedKey checks if the client's public key is authorized for the user by the server's mode, when it evaluates
+// an alternative source of public keys. It is false when the user is denied by the agent policy.
+func (s *Server) AuthorizedKey(user string, client *modes.Client) bool {
+	if !s.getPolicy().IsUserAllowed(user) {
+		return false
+	}
+
+	keyer, ok := s.mode.(modes.AuthorizedKeyer)
+
+	return ok && keyer.AuthorizedKey(user, client)
+}
+
+// applyPolicy stores on the context the policy's rules for the authenticated user. The policy's forced command takes
+// precedence over the one set by the authentication, like the authorized keys' `command=` option.
+func (s *Server) applyPolicy(ctx gliderssh.Context) {
+	rules := s.getPolicy().RulesFor(ctx.User())
+
+	ctx.SetValue(policy.ContextKeyRules, rules)
+	if rules.ForceCommand != "" {
+		ctx.SetValue(modes.ContextKeyForcedCommand, rules.ForceCommand)
+	}
 }
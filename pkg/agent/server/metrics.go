@@ -0,0 +1,33 @@
+package server
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+)
+
+var (
+	sessionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "agent",
+		Name:      "sessions_total",
+		Help:      "Number of sessions handled by the agent by type.",
+	}, []string{"type"})
+
+	sessionsActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "agent",
+		Name:      "sessions_active",
+		Help:      "Number of sessions being handled by the agent by type.",
+	}, []string{"type"})
+)
+
+// observeSession counts a session of the type as started, returning the function to count it as finished.
+func observeSession(kind string) func() {
+	sessionsTotal.WithLabelValues(kind).Inc()
+	sessionsActive.WithLabelValues(kind).Inc()
+
+	return func() {
+		sessionsActive.WithLabelValues(kind).Dec()
+	}
+}
@@ -0,0 +1,28 @@
+package modes
+
+import (
+	"net"
+
+	"github.com/shellhub-io/shellhub/pkg/api/client"
+	"github.com/shellhub-io/shellhub/pkg/sshcert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// CheckCertificate checks if a user certificate, the one the client authenticated with on the ShellHub's SSH server,
+// was signed by the SSH user certificate authority of the device's namespace and if it allows the user at the current
+// time and from the client's address.
+//
+// The token is the device's token, used to get the certificate authority from the ShellHub's API.
+func CheckCertificate(api client.Client, token string, cert *gossh.Certificate, user string, remote net.Addr) error {
+	ca, err := api.GetCertificateAuthority(token)
+	if err != nil {
+		return err
+	}
+
+	authority, err := sshcert.ParseAuthority(ca.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	return sshcert.Check(authority, cert, user, remote)
+}
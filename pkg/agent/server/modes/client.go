@@ -0,0 +1,57 @@
+package modes
+
+import (
+	"net"
+	"net/http"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Client is the client connected to the device through the ShellHub's SSH server.
+//
+// As the ShellHub's SSH server authenticates on the agent with its own key, the key the client authenticated with on
+// the server and the client's address are forwarded by the server on the request that opens the connection, to be
+// evaluated by the agent on its own authentication.
+type Client struct {
+	// PublicKey is the public key, or the certificate, the client authenticated with. It is nil when the client
+	// authenticated with a password.
+	PublicKey gossh.PublicKey
+	// Address is the client's address. It is nil when the server doesn't forward it.
+	Address net.Addr
+}
+
+// ContextKeyClient is the key, on the SSH's context, of the client forwarded by the ShellHub's SSH server.
+const ContextKeyClient = "client"
+
+// ParseClient parses the client forwarded by the ShellHub's SSH server on the X-Client-Public-Key and X-Client-Address
+// headers of the request that opens the connection.
+func ParseClient(header http.Header) *Client {
+	client := new(Client)
+
+	if value := header.Get("X-Client-Public-Key"); value != "" {
+		key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(value)) //nolint:dogsled
+		if err != nil {
+			log.WithError(err).Warn("failed to parse the client's public key forwarded by the server")
+		}
+
+		client.PublicKey = key
+	}
+
+	if ip := net.ParseIP(header.Get("X-Client-Address")); ip != nil {
+		client.Address = &net.IPAddr{IP: ip}
+	}
+
+	return client
+}
+
+// ClientFromContext returns the client forwarded by the ShellHub's SSH server. It returns an empty client when the
+// server doesn't forward it.
+func ClientFromContext(ctx gliderssh.Context) *Client {
+	if client, ok := ctx.Value(ContextKeyClient).(*Client); ok && client != nil {
+		return client
+	}
+
+	return new(Client)
+}
@@ -138,6 +138,24 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 		return false
 	}
 
+	// NOTICE: when the client authenticated on the ShellHub's SSH server with a certificate, forwarded by the server, it
+	// is checked against the namespace's certificate authority as well.
+	client := modes.ClientFromContext(ctx)
+	if cert, ok := client.PublicKey.(*gossh.Certificate); ok {
+		if err := modes.CheckCertificate(a.api, a.authData.Token, cert, username, client.Address); err != nil {
+			log.WithFields(
+				log.Fields{
+					"container": *a.container,
+					"username":  username,
+					"key_id":    cert.KeyId,
+					"serial":    cert.Serial,
+				},
+			).WithError(err).Error("failed to authenticate the user via certificate")
+
+			return false
+		}
+	}
+
 	// NOTICE: set the osauth.User to the context to be obtained later on.
 	ctx.SetValue("user", user)
 
@@ -0,0 +1,76 @@
+package host
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+	log "github.com/sirupsen/logrus"
+)
+
+// agentSocket is the name of the Unix socket where the client's agent is exposed to the session's processes.
+const agentSocket = "agent.sock"
+
+// forwardAgent exposes the client's agent, forwarded through the session, on a temporary Unix socket owned by the
+// session's user.
+//
+// It returns the socket's path, as seen by the session's processes, to be set on SSH_AUTH_SOCK, and a function that
+// stops the forwarding and removes the socket.
+func forwardAgent(session gliderssh.Session, u *osauth.User) (string, func(), error) {
+	dir, err := os.MkdirTemp(filepath.Join(command.HostRoot, os.TempDir()), "auth-agent")
+	if err != nil {
+		return "", nil, err
+	}
+
+	path := filepath.Join(dir, agentSocket)
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		os.RemoveAll(dir) // nolint: errcheck
+
+		return "", nil, err
+	}
+
+	closer := func() {
+		listener.Close()
+		os.RemoveAll(dir) // nolint: errcheck
+	}
+
+	for _, name := range []string{dir, path} {
+		if err := os.Chown(name, int(u.UID), int(u.GID)); err != nil {
+			closer()
+
+			return "", nil, err
+		}
+	}
+
+	go gliderssh.ForwardAgentConnections(listener, session)
+
+	return strings.TrimPrefix(path, command.HostRoot), closer, nil
+}
+
+// setAgentSocket exposes the client's agent to the command through SSH_AUTH_SOCK, when the client requested the agent
+// forwarding on the session. It returns a function that stops the forwarding, to be called when the command ends.
+func setAgentSocket(session gliderssh.Session, u *osauth.User, cmd *exec.Cmd) func() {
+	if !gliderssh.AgentRequested(session) {
+		return func() {}
+	}
+
+	sock, closer, err := forwardAgent(session, u)
+	if err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{"user": session.User()}).
+			Warn("failed to forward the agent to the session")
+
+		return func() {}
+	}
+
+	cmd.Env = append(cmd.Env, "SSH_AUTH_SOCK="+sock)
+
+	return closer
+}
@@ -0,0 +1,91 @@
+package host
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"os"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestForwardAgent(t *testing.T) {
+	keys := make(chan int, 1)
+
+	server := &gliderssh.Server{
+		Handler: func(session gliderssh.Session) {
+			u := &osauth.User{UID: uint32(os.Getuid()), GID: uint32(os.Getgid())}
+
+			sock, closer, err := forwardAgent(session, u)
+			if !assert.NoError(t, err) {
+				keys <- -1
+
+				return
+			}
+			defer closer()
+
+			conn, err := net.Dial("unix", sock)
+			if !assert.NoError(t, err) {
+				keys <- -1
+
+				return
+			}
+			defer conn.Close()
+
+			list, err := agent.NewClient(conn).List()
+			if !assert.NoError(t, err) {
+				keys <- -1
+
+				return
+			}
+
+			keys <- len(list)
+		},
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	signer, err := gossh.NewSignerFromKey(key)
+	assert.NoError(t, err)
+
+	server.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	go server.Serve(listener) // nolint: errcheck
+	defer server.Close()
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	keyring := agent.NewKeyring()
+	assert.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: priv}))
+
+	client, err := gossh.Dial("tcp", listener.Addr().String(), &gossh.ClientConfig{
+		User:            "root",
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint:gosec
+	})
+	assert.NoError(t, err)
+
+	defer client.Close()
+
+	assert.NoError(t, agent.ForwardToAgent(client, keyring))
+
+	session, err := client.NewSession()
+	assert.NoError(t, err)
+
+	defer session.Close()
+
+	assert.NoError(t, agent.RequestAgentForwarding(session))
+	assert.NoError(t, session.Run(""))
+
+	assert.Equal(t, 1, <-keys)
+}
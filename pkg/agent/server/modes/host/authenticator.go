@@ -6,8 +6,10 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
+	"path/filepath"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/authorizedkeys"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/api/client"
@@ -34,6 +36,12 @@ type Authenticator struct {
 	deviceName *string
 	// osauth is an instance of the OSAuth interface to authenticate the user on the Operating System.
 	osauth osauth.OSAuther
+	// pam is an instance of the PAMAuther interface to authenticate passwords and check accounts through PAM.
+	// When it is nil, PAM is disabled.
+	pam osauth.PAMAuther
+	// authorizedKeysFile is the path of the authorized keys file, relative to the user's home directory when not
+	// absolute, used as an alternative source of public keys. When it is empty, only the ShellHub's API is used.
+	authorizedKeysFile string
 }
 
 // NewAuthenticator creates a new instance of Authenticator for the host mode.
@@ -53,6 +61,20 @@ func NewAuthenticator(api client.Client, authData *models.DeviceAuthResponse, si
 	}
 }
 
+// SetPAM enables the authentication of passwords and the account checks through PAM.
+//
+// When PAM is enabled, passwords are authenticated by the PAM's conversation instead of the `/etc/shadow` file, and
+// every user authenticated by public key has its account checked. It has no effect on single user mode.
+func (a *Authenticator) SetPAM(pam osauth.PAMAuther) {
+	a.pam = pam
+}
+
+// SetAuthorizedKeysFile enables the evaluation of the user's authorized keys file as an alternative source of public
+// keys. The file is relative to the user's home directory when it is not an absolute path.
+func (a *Authenticator) SetAuthorizedKeysFile(file string) {
+	a.authorizedKeysFile = file
+}
+
 // Password handles the server's SSH password authentication when server is running in host mode.
 func (a *Authenticator) Password(ctx gliderssh.Context, _ string, pass string) bool {
 	log := log.WithFields(log.Fields{
@@ -60,9 +82,16 @@ func (a *Authenticator) Password(ctx gliderssh.Context, _ string, pass string) b
 	})
 	var ok bool
 
-	if a.singleUserPassword == "" {
+	switch {
+	case a.singleUserPassword == "" && a.pam != nil:
+		if err := a.pam.AuthUser(ctx.User(), pass); err != nil {
+			log.WithError(err).Debug("Failed to authenticate using PAM")
+		} else {
+			ok = true
+		}
+	case a.singleUserPassword == "":
 		ok = a.osauth.AuthUser(ctx.User(), pass)
-	} else {
+	default:
 		ok = a.osauth.VerifyPasswordHash(a.singleUserPassword, pass)
 	}
 
@@ -76,11 +105,123 @@ func (a *Authenticator) Password(ctx gliderssh.Context, _ string, pass string) b
 }
 
 // PublicKey handles the server's SSH public key authentication when server is running in host mode.
+//
+// The key is evaluated by the ShellHub's API, as the connections are proxied by the ShellHub's SSH server, which
+// authenticates on the agent with its own key. When the client authenticated on the server with a certificate, it is
+// checked against the namespace's certificate authority as well, and, when it authenticated with a key found on the
+// authorized keys file, the entry's options are applied. If PAM is enabled, the user's account is checked after the
+// authentication.
 func (a *Authenticator) PublicKey(ctx gliderssh.Context, _ string, key gliderssh.PublicKey) bool {
-	if a.osauth.LookupUser(ctx.User()) == nil {
+	user := a.osauth.LookupUser(ctx.User())
+	if user == nil {
+		return false
+	}
+
+	if !a.publicKey(ctx, key) || !a.certificate(ctx) || !a.authorizedKeyOptions(ctx, user) {
+		return false
+	}
+
+	if a.pam != nil {
+		if err := a.pam.CheckAccount(ctx.User()); err != nil {
+			log.WithFields(
+				log.Fields{
+					"container": *a.deviceName,
+					"username":  ctx.User(),
+				},
+			).WithError(err).Error("failed to check the user account using PAM")
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// AuthorizedKey checks if the client's public key is on the user's authorized keys file and if the entry's `from=` option
+// allows the client's address. It is used by the ShellHub's SSH server to authenticate the clients whose keys aren't
+// registered on ShellHub, before connecting them to the agent.
+func (a *Authenticator) AuthorizedKey(username string, client *modes.Client) bool {
+	user := a.osauth.LookupUser(username)
+	if user == nil {
+		return false
+	}
+
+	entry := a.authorizedKey(username, user, client)
+
+	return entry != nil && entry.AllowsAddress(client.Address)
+}
+
+// authorizedKeyOptions applies the options of the authorized keys file's entry of the key the client authenticated
+// with on the ShellHub's SSH server. It is false when the entry's `from=` option doesn't allow the client's address.
+//
+// When the entry has the `command=` option, the command is stored on the context to be executed instead of the one
+// requested by the client. When the key isn't on the file, it was authenticated by the ShellHub's API and there is
+// nothing to apply.
+func (a *Authenticator) authorizedKeyOptions(ctx gliderssh.Context, user *osauth.User) bool {
+	client := modes.ClientFromContext(ctx)
+
+	entry := a.authorizedKey(ctx.User(), user, client)
+	if entry == nil {
+		return true
+	}
+
+	log := log.WithFields(
+		log.Fields{
+			"container":   *a.deviceName,
+			"username":    ctx.User(),
+			"fingerprint": gossh.FingerprintSHA256(entry.Key),
+		},
+	)
+
+	if !entry.AllowsAddress(client.Address) {
+		log.WithField("address", client.Address).Warn("client's address not allowed by the authorized keys file")
+
 		return false
 	}
 
+	if entry.Command != "" {
+		ctx.SetValue(modes.ContextKeyForcedCommand, entry.Command)
+	}
+
+	log.Info("using authorized keys authentication")
+
+	return true
+}
+
+// authorizedKey searches the key the client authenticated with on the user's authorized keys file. It returns nil when
+// the file is disabled, when the client didn't authenticate with a plain public key or when the key isn't on the file.
+func (a *Authenticator) authorizedKey(username string, user *osauth.User, client *modes.Client) *authorizedkeys.Entry {
+	if a.authorizedKeysFile == "" || client.PublicKey == nil {
+		return nil
+	}
+
+	if _, ok := client.PublicKey.(*gossh.Certificate); ok {
+		return nil
+	}
+
+	file := a.authorizedKeysFile
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(user.HomeDir, file)
+	}
+
+	entry, err := authorizedkeys.FindFile(file, client.PublicKey)
+	if err != nil {
+		log.WithFields(
+			log.Fields{
+				"username":    username,
+				"fingerprint": gossh.FingerprintSHA256(client.PublicKey),
+				"file":        file,
+			},
+		).WithError(err).Debug("key not found on the authorized keys file")
+
+		return nil
+	}
+
+	return entry
+}
+
+// publicKey authenticates the key through the ShellHub's API.
+func (a *Authenticator) publicKey(ctx gliderssh.Context, key gliderssh.PublicKey) bool {
 	type Signature struct {
 		Username  string
 		Namespace string
@@ -185,3 +326,33 @@ func (a *Authenticator) PublicKey(ctx gliderssh.Context, _ string, key gliderssh
 
 	return true
 }
+
+// certificate checks the certificate the client authenticated with on the ShellHub's SSH server, forwarded by the
+// server, against the namespace's certificate authority. It is true when the client didn't use a certificate.
+func (a *Authenticator) certificate(ctx gliderssh.Context) bool {
+	client := modes.ClientFromContext(ctx)
+
+	cert, ok := client.PublicKey.(*gossh.Certificate)
+	if !ok {
+		return true
+	}
+
+	log := log.WithFields(
+		log.Fields{
+			"container": *a.deviceName,
+			"username":  ctx.User(),
+			"key_id":    cert.KeyId,
+			"serial":    cert.Serial,
+		},
+	)
+
+	if err := modes.CheckCertificate(a.api, a.authData.Token, cert, ctx.User(), client.Address); err != nil {
+		log.WithError(err).Error("failed to authenticate the user via certificate")
+
+		return false
+	}
+
+	log.Info("using certificate authentication")
+
+	return true
+}
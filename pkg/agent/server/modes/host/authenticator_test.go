@@ -1,6 +1,7 @@
 package host
 
 import (
+	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
@@ -8,12 +9,17 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/go-playground/assert/v2"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
 	osauthMocks "github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth/mocks"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	clientMocks "github.com/shellhub-io/shellhub/pkg/api/client/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/mock"
@@ -27,6 +33,57 @@ func TestPublicKey(t *testing.T) {
 	privKey, _ := rsa.GenerateKey(rand.Reader, 2048)
 	key, _ := gossh.NewPublicKey(&privKey.PublicKey)
 
+	// authPublicKey mocks the ShellHub's API signing the server's key for the user test on the device.
+	authPublicKey := func(apiMock *clientMocks.Client) {
+		sigBytes, _ := json.Marshal(&struct {
+			Username  string
+			Namespace string
+		}{
+			Username:  "test",
+			Namespace: "device",
+		})
+
+		digest := sha256.Sum256(sigBytes)
+
+		signature, _ := rsa.SignPKCS1v15(rand.Reader, privKey, crypto.SHA256, digest[:])
+
+		apiMock.On("AuthPublicKey", &models.PublicKeyAuthRequest{
+			Fingerprint: gossh.FingerprintLegacyMD5(key),
+			Data:        string(sigBytes),
+		}, "token").Return(&models.PublicKeyAuthResponse{
+			Signature: base64.StdEncoding.EncodeToString(signature),
+		}, nil).Once()
+	}
+
+	clientKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	clientPubKey, _ := gossh.NewPublicKey(&clientKey.PublicKey)
+
+	caKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ca, _ := gossh.NewSignerFromKey(caKey)
+
+	cert := &gossh.Certificate{
+		Key:             clientPubKey,
+		CertType:        gossh.UserCert,
+		ValidPrincipals: []string{"test"},
+		ValidAfter:      uint64(time.Now().Add(-time.Minute).Unix()),
+		ValidBefore:     uint64(time.Now().Add(time.Hour).Unix()),
+		Permissions: gossh.Permissions{
+			CriticalOptions: map[string]string{"source-address": "192.168.1.0/24"},
+		},
+	}
+	_ = cert.SignCert(rand.Reader, ca)
+
+	// clientContext is a context with the client forwarded by the ShellHub's SSH server.
+	clientContext := func(client *modes.Client) gliderssh.Context {
+		return &testSSHContext{
+			Context: context.WithValue(context.Background(), modes.ContextKeyClient, client),
+			user:    "test",
+		}
+	}
+
+	authorizedKeys := filepath.Join(t.TempDir(), "authorized_keys")
+	_ = os.WriteFile(authorizedKeys, []byte(`from="192.168.1.0/24",command="uptime" `+string(gossh.MarshalAuthorizedKey(clientPubKey))), 0o600)
+
 	tests := []struct {
 		ctx           gliderssh.Context
 		authenticator *Authenticator
@@ -35,6 +92,7 @@ func TestPublicKey(t *testing.T) {
 		key           gliderssh.PublicKey
 		requiredMocs  func(osauthMock *osauthMocks.OSAuther, apiMock *clientMocks.Client)
 		expected      bool
+		forcedCommand string
 	}{
 		{
 			ctx: &testSSHContext{
@@ -173,7 +231,8 @@ func TestPublicKey(t *testing.T) {
 		},
 		{
 			ctx: &testSSHContext{
-				user: "test",
+				Context: context.Background(),
+				user:    "test",
 			},
 			authenticator: &Authenticator{
 				authData: &models.DeviceAuthResponse{
@@ -212,6 +271,113 @@ func TestPublicKey(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			ctx: clientContext(&modes.Client{PublicKey: cert, Address: &net.IPAddr{IP: net.ParseIP("192.168.1.10")}}),
+			authenticator: &Authenticator{
+				authData: &models.DeviceAuthResponse{
+					Token: "token",
+				},
+				deviceName: stringToRef("device"),
+				api:        new(clientMocks.Client),
+				osauth:     new(osauthMocks.OSAuther),
+			},
+			name: "return false when the client's certificate is not signed by the namespace's authority",
+			user: "",
+			key:  key,
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther, apiMock *clientMocks.Client) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{}).Once()
+				authPublicKey(apiMock)
+				apiMock.On("GetCertificateAuthority", "token").Return(&models.CertificateAuthority{
+					PublicKey: string(gossh.MarshalAuthorizedKey(key)),
+				}, nil).Once()
+			},
+			expected: false,
+		},
+		{
+			ctx: clientContext(&modes.Client{PublicKey: cert, Address: &net.IPAddr{IP: net.ParseIP("10.0.0.10")}}),
+			authenticator: &Authenticator{
+				authData: &models.DeviceAuthResponse{
+					Token: "token",
+				},
+				deviceName: stringToRef("device"),
+				api:        new(clientMocks.Client),
+				osauth:     new(osauthMocks.OSAuther),
+			},
+			name: "return false when the client's certificate does not allow the client's address",
+			user: "",
+			key:  key,
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther, apiMock *clientMocks.Client) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{}).Once()
+				authPublicKey(apiMock)
+				apiMock.On("GetCertificateAuthority", "token").Return(&models.CertificateAuthority{
+					PublicKey: string(gossh.MarshalAuthorizedKey(ca.PublicKey())),
+				}, nil).Once()
+			},
+			expected: false,
+		},
+		{
+			ctx: clientContext(&modes.Client{PublicKey: cert, Address: &net.IPAddr{IP: net.ParseIP("192.168.1.10")}}),
+			authenticator: &Authenticator{
+				authData: &models.DeviceAuthResponse{
+					Token: "token",
+				},
+				deviceName: stringToRef("device"),
+				api:        new(clientMocks.Client),
+				osauth:     new(osauthMocks.OSAuther),
+			},
+			name: "return true when the client's certificate is signed by the namespace's authority",
+			user: "",
+			key:  key,
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther, apiMock *clientMocks.Client) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{}).Once()
+				authPublicKey(apiMock)
+				apiMock.On("GetCertificateAuthority", "token").Return(&models.CertificateAuthority{
+					PublicKey: string(gossh.MarshalAuthorizedKey(ca.PublicKey())),
+				}, nil).Once()
+			},
+			expected: true,
+		},
+		{
+			ctx: clientContext(&modes.Client{PublicKey: clientPubKey, Address: &net.IPAddr{IP: net.ParseIP("10.0.0.10")}}),
+			authenticator: &Authenticator{
+				authData: &models.DeviceAuthResponse{
+					Token: "token",
+				},
+				deviceName:         stringToRef("device"),
+				api:                new(clientMocks.Client),
+				osauth:             new(osauthMocks.OSAuther),
+				authorizedKeysFile: authorizedKeys,
+			},
+			name: "return false when the authorized keys' entry of the client's key does not allow the client's address",
+			user: "",
+			key:  key,
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther, apiMock *clientMocks.Client) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{}).Once()
+				authPublicKey(apiMock)
+			},
+			expected: false,
+		},
+		{
+			ctx: clientContext(&modes.Client{PublicKey: clientPubKey, Address: &net.IPAddr{IP: net.ParseIP("192.168.1.10")}}),
+			authenticator: &Authenticator{
+				authData: &models.DeviceAuthResponse{
+					Token: "token",
+				},
+				deviceName:         stringToRef("device"),
+				api:                new(clientMocks.Client),
+				osauth:             new(osauthMocks.OSAuther),
+				authorizedKeysFile: authorizedKeys,
+			},
+			name: "return true and force the command when the authorized keys' entry of the client's key allows the client's address",
+			user: "",
+			key:  key,
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther, apiMock *clientMocks.Client) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{}).Once()
+				authPublicKey(apiMock)
+			},
+			expected:      true,
+			forcedCommand: "uptime",
+		},
 	}
 
 	for _, tt := range tests {
@@ -220,6 +386,88 @@ func TestPublicKey(t *testing.T) {
 
 			ok := tt.authenticator.PublicKey(tt.ctx, tt.user, tt.key)
 			assert.Equal(t, tt.expected, ok)
+
+			if tt.forcedCommand != "" {
+				command, _ := modes.ForcedCommand(tt.ctx)
+				assert.Equal(t, tt.forcedCommand, command)
+			}
+		})
+	}
+}
+
+func TestAuthorizedKey(t *testing.T) {
+	clientKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	clientPubKey, _ := gossh.NewPublicKey(&clientKey.PublicKey)
+
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherPubKey, _ := gossh.NewPublicKey(&otherKey.PublicKey)
+
+	home := t.TempDir()
+	_ = os.Mkdir(filepath.Join(home, ".ssh"), 0o700)
+	_ = os.WriteFile(filepath.Join(home, ".ssh", "authorized_keys"), []byte(`from="192.168.1.0/24" `+string(gossh.MarshalAuthorizedKey(clientPubKey))), 0o600)
+
+	tests := []struct {
+		name               string
+		authorizedKeysFile string
+		client             *modes.Client
+		requiredMocs       func(osauthMock *osauthMocks.OSAuther)
+		expected           bool
+	}{
+		{
+			name:               "return false when the user is not found",
+			authorizedKeysFile: ".ssh/authorized_keys",
+			client:             &modes.Client{PublicKey: clientPubKey, Address: &net.IPAddr{IP: net.ParseIP("192.168.1.10")}},
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther) {
+				osauthMock.On("LookupUser", "test").Return(nil).Once()
+			},
+			expected: false,
+		},
+		{
+			name:               "return false when the authorized keys file is disabled",
+			authorizedKeysFile: "",
+			client:             &modes.Client{PublicKey: clientPubKey, Address: &net.IPAddr{IP: net.ParseIP("192.168.1.10")}},
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{HomeDir: home}).Once()
+			},
+			expected: false,
+		},
+		{
+			name:               "return false when the client's key is not on the authorized keys file",
+			authorizedKeysFile: ".ssh/authorized_keys",
+			client:             &modes.Client{PublicKey: otherPubKey, Address: &net.IPAddr{IP: net.ParseIP("192.168.1.10")}},
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{HomeDir: home}).Once()
+			},
+			expected: false,
+		},
+		{
+			name:               "return false when the entry does not allow the client's address",
+			authorizedKeysFile: ".ssh/authorized_keys",
+			client:             &modes.Client{PublicKey: clientPubKey, Address: &net.IPAddr{IP: net.ParseIP("10.0.0.10")}},
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{HomeDir: home}).Once()
+			},
+			expected: false,
+		},
+		{
+			name:               "return true when the entry allows the client's address",
+			authorizedKeysFile: ".ssh/authorized_keys",
+			client:             &modes.Client{PublicKey: clientPubKey, Address: &net.IPAddr{IP: net.ParseIP("192.168.1.10")}},
+			requiredMocs: func(osauthMock *osauthMocks.OSAuther) {
+				osauthMock.On("LookupUser", "test").Return(&osauth.User{HomeDir: home}).Once()
+			},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			osauthMock := new(osauthMocks.OSAuther)
+			tt.requiredMocs(osauthMock)
+
+			authenticator := &Authenticator{osauth: osauthMock, authorizedKeysFile: tt.authorizedKeysFile}
+
+			assert.Equal(t, tt.expected, authenticator.AuthorizedKey("test", tt.client))
 		})
 	}
 }
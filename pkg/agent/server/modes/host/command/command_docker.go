@@ -12,6 +12,12 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
 )
 
+// HostRoot is the path where the host's root filesystem is reachable by the agent.
+//
+// NOTICE: the commands run in the host's mount namespace, so the files created by the agent for them must be under
+// this path.
+const HostRoot = "/host"
+
 func NewCmd(u *osauth.User, shell, term, host string, command ...string) *exec.Cmd {
 	nscommand, _ := nsenterCommandWrapper(u.UID, u.GID, u.HomeDir, command...)
 
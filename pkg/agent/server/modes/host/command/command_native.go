@@ -14,6 +14,9 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// HostRoot is the path where the host's root filesystem is reachable by the agent.
+const HostRoot = ""
+
 func NewCmd(u *osauth.User, shell, term, host string, command ...string) *exec.Cmd {
 	user, _ := user.Lookup(u.Username)
 	userGroups, _ := user.GroupIds()
@@ -12,6 +12,7 @@ import (
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/policy"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/utmp"
@@ -37,6 +38,9 @@ func newShellCmd(deviceName string, username string, term string) *exec.Cmd {
 	return cmd
 }
 
+// ErrDeniedByPolicy is returned when the session is denied by the agent policy.
+var ErrDeniedByPolicy = errors.New("session denied by the agent policy")
+
 // NOTICE: Ensures the Sessioner interface is implemented.
 var _ modes.Sessioner = (*Sessioner)(nil)
 
@@ -65,18 +69,34 @@ func NewSessioner(deviceName *string, cmds map[string]*exec.Cmd) *Sessioner {
 }
 
 // Shell manages the SSH shell session of the server when operating in host mode.
+//
+// When a command is forced for the connection, it is executed instead of the shell.
 func (s *Sessioner) Shell(session gliderssh.Session) error {
+	if !modes.Allows(session.Context(), policy.CapabilityShell) {
+		log.WithFields(log.Fields{
+			"user": session.User(),
+		}).Warn("Shell session denied by the agent policy")
+
+		return ErrDeniedByPolicy
+	}
+
+	if _, ok := modes.ForcedCommand(session.Context()); ok {
+		return s.exec(session)
+	}
+
 	sspty, winCh, isPty := session.Pty()
 
 	scmd := newShellCmd(*s.deviceName, session.User(), sspty.Term)
 
+	u := new(osauth.OSAuth).LookupUser(session.User())
+
+	defer setAgentSocket(session, u, scmd)()
+
 	pts, err := startPty(scmd, session, winCh)
 	if err != nil {
 		log.Warn(err)
 	}
 
-	u := new(osauth.OSAuth).LookupUser(session.User())
-
 	err = os.Chown(pts.Name(), int(u.UID), -1)
 	if err != nil {
 		log.Warn(err)
@@ -123,10 +143,24 @@ func (s *Sessioner) Shell(session gliderssh.Session) error {
 // heredoc is special block of code that contains multi-line strings that will be redirected to a stdin of a shell. It
 // request a shell, but doesn't allocate a pty.
 func (s *Sessioner) Heredoc(session gliderssh.Session) error {
+	if !modes.Allows(session.Context(), policy.CapabilityShell) {
+		log.WithFields(log.Fields{
+			"user": session.User(),
+		}).Warn("Heredoc session denied by the agent policy")
+
+		return ErrDeniedByPolicy
+	}
+
+	if _, ok := modes.ForcedCommand(session.Context()); ok {
+		return s.exec(session)
+	}
+
 	_, _, isPty := session.Pty()
 
 	cmd := newShellCmd(*s.deviceName, session.User(), "")
 
+	defer setAgentSocket(session, new(osauth.OSAuth).LookupUser(session.User()), cmd)()
+
 	stdout, _ := cmd.StdoutPipe()
 	stdin, _ := cmd.StdinPipe()
 	stderr, _ := cmd.StderrPipe()
@@ -187,8 +221,31 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 }
 
 // Exec handles the SSH's server exec session when server is running in host mode.
+//
+// When a command is forced for the connection, it is executed instead of the requested one, which is exposed to it
+// through the SSH_ORIGINAL_COMMAND environment variable.
 func (s *Sessioner) Exec(session gliderssh.Session) error {
-	if len(session.Command()) == 0 {
+	if !policy.RulesFromContext(session.Context()).Allows(policy.CapabilityExec) {
+		log.WithFields(log.Fields{
+			"user": session.User(),
+		}).Warn("Exec session denied by the agent policy")
+
+		return ErrDeniedByPolicy
+	}
+
+	return s.exec(session)
+}
+
+// exec executes the requested command, or the forced one, without checking the agent policy.
+func (s *Sessioner) exec(session gliderssh.Session) error {
+	line := strings.Join(session.Command(), " ")
+
+	forced, isForced := modes.ForcedCommand(session.Context())
+	if isForced {
+		line = forced
+	}
+
+	if line == "" {
 		log.WithFields(log.Fields{
 			"user":      session.User(),
 			"localaddr": session.LocalAddr(),
@@ -213,7 +270,12 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		term = "xterm"
 	}
 
-	cmd := command.NewCmd(user, shell, term, *s.deviceName, shell, "-c", strings.Join(session.Command(), " "))
+	cmd := command.NewCmd(user, shell, term, *s.deviceName, shell, "-c", line)
+	if isForced {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("SSH_ORIGINAL_COMMAND=%s", session.RawCommand()))
+	}
+
+	defer setAgentSocket(session, user, cmd)()
 
 	wg := &sync.WaitGroup{}
 	if sIsPty {
@@ -309,6 +371,22 @@ func (s *Sessioner) SFTP(session gliderssh.Session) error {
 	}).Info("SFTP session started")
 	defer session.Close()
 
+	if !policy.RulesFromContext(session.Context()).Allows(policy.CapabilitySFTP) {
+		log.WithFields(log.Fields{
+			"user": session.User(),
+		}).Warn("SFTP session denied by the agent policy")
+
+		return ErrDeniedByPolicy
+	}
+
+	if _, ok := modes.ForcedCommand(session.Context()); ok {
+		log.WithFields(log.Fields{
+			"user": session.Context().User(),
+		}).Warn("SFTP session denied because a command is forced for the connection")
+
+		return errors.New("sftp is not allowed when a command is forced")
+	}
+
 	cmd := exec.Command("/proc/self/exe", []string{"sftp"}...)
 
 	looked, err := user.Lookup(session.User())
@@ -1,7 +1,10 @@
 // Package mode defines the interfaces used by the server to determine how to handle authentication and sessions.
 package modes
 
-import gliderssh "github.com/gliderlabs/ssh"
+import (
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/policy"
+)
 
 // Mode defines the SSH's server mode type.
 type Mode interface {
@@ -19,6 +22,14 @@ type Authenticator interface {
 	PublicKey(ctx gliderssh.Context, user string, key gliderssh.PublicKey) bool
 }
 
+// AuthorizedKeyer is implemented by the authenticators that evaluate an alternative source of public keys, like the
+// user's authorized keys file, what the ShellHub's SSH server asks before connecting a client whose key isn't
+// registered on ShellHub.
+type AuthorizedKeyer interface {
+	// AuthorizedKey checks if the client's public key is authorized for the user.
+	AuthorizedKey(user string, client *Client) bool
+}
+
 // Sessioner defines the session methods used by the SSH's server to deal wihth determining the type of session.
 //
 //go:generate mockery --name=Sessioner --filename=sessioner.go
@@ -47,3 +58,24 @@ type Subsystemer interface {
 	// SFTP must be implemented to deal with SFTP session.
 	SFTP(session gliderssh.Session) error
 }
+
+// ContextKeyForcedCommand is the key, on the SSH's context, of the command that must be executed instead of the one
+// requested by the client, like the one set by the authorized keys' `command=` option.
+const ContextKeyForcedCommand = "forced_command"
+
+// ForcedCommand returns the command forced for the connection, if any.
+func ForcedCommand(ctx gliderssh.Context) (string, bool) {
+	command, ok := ctx.Value(ContextKeyForcedCommand).(string)
+
+	return command, ok && command != ""
+}
+
+// Allows checks if the policy's rules on the context allow the capability requested by the session. When a command is
+// forced for the connection, it is executed instead of the shell, so the shell's request is evaluated as a command's.
+func Allows(ctx gliderssh.Context, capability policy.Capability) bool {
+	if _, ok := ForcedCommand(ctx); ok && capability == policy.CapabilityShell {
+		capability = policy.CapabilityExec
+	}
+
+	return policy.RulesFromContext(ctx).Allows(capability)
+}
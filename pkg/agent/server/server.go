@@ -7,6 +7,7 @@ import (
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/policy"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host"
 	"github.com/shellhub-io/shellhub/pkg/api/client"
@@ -27,6 +28,12 @@ type sshConn struct {
 	ctx           gliderssh.Context
 }
 
+// clientConn is a connection opened by the ShellHub's SSH server, with the client it forwards to the agent.
+type clientConn struct {
+	net.Conn
+	client *modes.Client
+}
+
 func (c *sshConn) Close() error {
 	if id, ok := c.ctx.Value(gliderssh.ContextKeySessionID).(string); ok {
 		c.closeCallback(id)
@@ -54,6 +61,12 @@ type Server struct {
 	// Check the [modes] package for more information.
 	mode     modes.Mode
 	Sessions sync.Map
+
+	// policyMu guards the policy, that can be changed while the server is running.
+	policyMu sync.RWMutex
+	// policy is the agent-local policy that restricts which users and features are reachable. When it is nil,
+	// everything is allowed.
+	policy *policy.Policy
 }
 
 // SSH channels supported by the SSH server.
@@ -104,6 +117,10 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 			SFTPSubsystemName: server.sftpSubsystemHandler,
 		},
 		ConnCallback: func(ctx gliderssh.Context, conn net.Conn) net.Conn {
+			if c, ok := conn.(*clientConn); ok {
+				ctx.SetValue(modes.ContextKeyClient, c.client)
+			}
+
 			closeCallback := func(id string) {
 				server.mu.Lock()
 				defer server.mu.Unlock()
@@ -117,6 +134,12 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 			return &sshConn{conn, closeCallback, ctx}
 		},
 		LocalPortForwardingCallback: func(ctx gliderssh.Context, destinationHost string, destinationPort uint32) bool {
+			if !policy.RulesFromContext(ctx).Allows(policy.CapabilityForwarding) {
+				log.WithField("username", ctx.User()).Warn("port forwarding denied by the agent policy")
+
+				return false
+			}
+
 			return true
 		},
 		ReversePortForwardingCallback: func(ctx gliderssh.Context, destinationHost string, destinationPort uint32) bool {
@@ -188,11 +211,32 @@ const (
 func (s *Server) sessionRequestCallback(session gliderssh.Session, requestType string) bool {
 	session.Context().SetValue("request_type", requestType)
 
+	var capability policy.Capability
+	switch requestType {
+	case RequestTypeShell:
+		capability = policy.CapabilityShell
+	case RequestTypeExec:
+		capability = policy.CapabilityExec
+	default:
+		// NOTICE: subsystems are checked by their own handlers.
+		return true
+	}
+
+	if !modes.Allows(session.Context(), capability) {
+		log.WithFields(log.Fields{
+			"username":     session.User(),
+			"request_type": requestType,
+		}).Warn("session request denied by the agent policy")
+
+		return false
+	}
+
 	return true
 }
 
-func (s *Server) HandleConn(conn net.Conn) {
-	s.sshd.HandleConn(conn)
+// HandleConn serves the connection opened by the ShellHub's SSH server, that forwards the client connected through it.
+func (s *Server) HandleConn(conn net.Conn, client *modes.Client) {
+	s.sshd.HandleConn(&clientConn{Conn: conn, client: client})
 }
 
 func (s *Server) SetDeviceName(name string) {
@@ -210,6 +254,22 @@ func (s *Server) CloseSession(id string) {
 	}
 }
 
+// SetPolicy sets the agent-local policy. When it is nil, everything is allowed.
+func (s *Server) SetPolicy(p *policy.Policy) {
+	s.policyMu.Lock()
+	defer s.policyMu.Unlock()
+
+	s.policy = p
+}
+
+// getPolicy returns the current agent-local policy.
+func (s *Server) getPolicy() *policy.Policy {
+	s.policyMu.RLock()
+	defer s.policyMu.RUnlock()
+
+	return s.policy
+}
+
 func (s *Server) ListenAndServe() error {
 	return s.sshd.ListenAndServe()
 }
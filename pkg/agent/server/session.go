@@ -62,6 +62,8 @@ func (s *Server) sessionHandler(session gliderssh.Session) {
 
 	log.WithField("type", sessionType).Info("Request type got")
 
+	defer observeSession(string(sessionType))()
+
 	switch sessionType {
 	case SessionTypeShell:
 		s.mode.Shell(session) //nolint:errcheck
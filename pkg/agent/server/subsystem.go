@@ -2,11 +2,23 @@ package server
 
 import (
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/policy"
+	log "github.com/sirupsen/logrus"
 )
 
 // sftpSubsystemHandler handles the SFTP subsystem session.
 func (s *Server) sftpSubsystemHandler(session gliderssh.Session) {
+	if !policy.RulesFromContext(session.Context()).Allows(policy.CapabilitySFTP) {
+		log.WithField("username", session.User()).Warn("SFTP session denied by the agent policy")
+
+		session.Exit(1) //nolint:errcheck
+
+		return
+	}
+
 	go s.startKeepAliveLoop(session)
 
+	defer observeSession(SFTPSubsystemName)()
+
 	s.mode.SFTP(session) //nolint:errcheck
 }
@@ -10,6 +10,7 @@ import (
 	"net/url"
 
 	resty "github.com/go-resty/resty/v2"
+	"github.com/gorilla/websocket"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
 	log "github.com/sirupsen/logrus"
@@ -25,6 +26,9 @@ type publicAPI interface {
 	Endpoints() (*models.Endpoints, error)
 	AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)
 	AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error)
+	GetCertificateAuthority(token string) (*models.CertificateAuthority, error)
+	ReportInventory(inventory *models.DeviceInventory, token string) error
+	ReportMetrics(metrics *models.DeviceMetrics, token string) error
 	NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error)
 }
 
@@ -40,6 +44,9 @@ type client struct {
 	port   int
 	http   *resty.Client
 	logger *log.Logger
+	// dialer is used to create the websocket connections to ShellHub's server, sharing the proxy and TLS settings with
+	// the HTTP client.
+	dialer *websocket.Dialer
 	// reverser is used to create a reverse listener to Agent from ShellHub's SSH server.
 	reverser IReverser
 }
@@ -49,6 +56,9 @@ var ErrParseAddress = fmt.Errorf("could not parse the address to the required fo
 // NewClient creates a new ShellHub HTTP client.
 //
 // Server address must contain the scheme, the host and the port. For instance: `https://cloud.shellhub.io:443/`.
+//
+// By default, the proxy is set by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables. Use [WithProxy] and
+// [WithTLSConfig] to customize how the client reaches the server.
 func NewClient(address string, opts ...Opt) (Client, error) {
 	uri, err := url.ParseRequestURI(address)
 	if err != nil {
@@ -81,7 +91,15 @@ func NewClient(address string, opts ...Opt) (Client, error) {
 		client.http.SetLogger(&LeveledLogger{client.logger})
 	}
 
-	client.reverser = NewReverser(client.http.BaseURL)
+	client.dialer = &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: websocket.DefaultDialer.HandshakeTimeout,
+	}
+
+	reverser := NewReverser(client.http.BaseURL)
+	reverser.dialer = client.dialer
+
+	client.reverser = reverser
 
 	for _, opt := range opts {
 		if err := opt(client); err != nil {
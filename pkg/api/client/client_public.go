@@ -102,6 +102,52 @@ func (c *client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (
 	return res, nil
 }
 
+// GetCertificateAuthority gets the SSH user certificate authority of the device's namespace.
+func (c *client) GetCertificateAuthority(token string) (*models.CertificateAuthority, error) {
+	var res *models.CertificateAuthority
+
+	response, err := c.http.R().
+		SetResult(&res).
+		SetAuthToken(token).
+		Get("/api/sshkeys/certificate-authority")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ErrorFromResponse(response); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ReportInventory reports the device's inventory to the server, that keeps it as the device's latest one.
+func (c *client) ReportInventory(inventory *models.DeviceInventory, token string) error {
+	response, err := c.http.R().
+		SetBody(inventory).
+		SetAuthToken(token).
+		Post("/api/devices/inventory")
+	if err != nil {
+		return err
+	}
+
+	return ErrorFromResponse(response)
+}
+
+// ReportMetrics reports a sample of the device's health metrics to the server, that aggregates it into the device's
+// time series.
+func (c *client) ReportMetrics(metrics *models.DeviceMetrics, token string) error {
+	response, err := c.http.R().
+		SetBody(metrics).
+		SetAuthToken(token).
+		Post("/api/devices/metrics")
+	if err != nil {
+		return err
+	}
+
+	return ErrorFromResponse(response)
+}
+
 // NewReverseListener creates a new reverse listener connection for the Agent from ShellHub's SSH server.
 //
 // Every time the ShellHub's SSH server receives a new connection to the Agent, the server sends that connection
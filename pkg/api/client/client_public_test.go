@@ -414,6 +414,122 @@ func TestAuthPublicKey(t *testing.T) {
 	}
 }
 
+func TestReportInventory(t *testing.T) {
+	tests := []struct {
+		description   string
+		token         string
+		requiredMocks func(client *http.Client)
+		expected      error
+	}{
+		{
+			description: "fail to report the inventory when the token is invalid",
+			token:       "",
+			requiredMocks: func(client *http.Client) {
+				responder, _ := mock.NewJsonResponder(401, nil)
+
+				mock.RegisterResponder("POST", "/api/devices/inventory", responder)
+			},
+			expected: ErrUnauthorized,
+		},
+		{
+			description: "fail to report the inventory when the device is not found",
+			token:       "token",
+			requiredMocks: func(client *http.Client) {
+				responder, _ := mock.NewJsonResponder(404, nil)
+
+				mock.RegisterResponder("POST", "/api/devices/inventory", responder)
+			},
+			expected: ErrNotFound,
+		},
+		{
+			description: "success to report the inventory",
+			token:       "token",
+			requiredMocks: func(client *http.Client) {
+				responder, _ := mock.NewJsonResponder(200, nil)
+
+				mock.RegisterResponder("POST", "/api/devices/inventory", responder)
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cli, err := NewClient("https://www.cloud.shellhub.io/")
+			assert.NoError(t, err)
+
+			client, ok := cli.(*client)
+			assert.True(t, ok)
+
+			mock.ActivateNonDefault(client.http.GetClient())
+			defer mock.DeactivateAndReset()
+
+			test.requiredMocks(client.http.GetClient())
+
+			err = cli.ReportInventory(&models.DeviceInventory{Kernel: "6.1.0"}, test.token)
+			assert.Equal(t, test.expected, err)
+		})
+	}
+}
+
+func TestReportMetrics(t *testing.T) {
+	tests := []struct {
+		description   string
+		token         string
+		requiredMocks func(client *http.Client)
+		expected      error
+	}{
+		{
+			description: "fail to report the metrics when the token is invalid",
+			token:       "",
+			requiredMocks: func(client *http.Client) {
+				responder, _ := mock.NewJsonResponder(401, nil)
+
+				mock.RegisterResponder("POST", "/api/devices/metrics", responder)
+			},
+			expected: ErrUnauthorized,
+		},
+		{
+			description: "fail to report the metrics when the device is not found",
+			token:       "token",
+			requiredMocks: func(client *http.Client) {
+				responder, _ := mock.NewJsonResponder(404, nil)
+
+				mock.RegisterResponder("POST", "/api/devices/metrics", responder)
+			},
+			expected: ErrNotFound,
+		},
+		{
+			description: "success to report the metrics",
+			token:       "token",
+			requiredMocks: func(client *http.Client) {
+				responder, _ := mock.NewJsonResponder(200, nil)
+
+				mock.RegisterResponder("POST", "/api/devices/metrics", responder)
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cli, err := NewClient("https://www.cloud.shellhub.io/")
+			assert.NoError(t, err)
+
+			client, ok := cli.(*client)
+			assert.True(t, ok)
+
+			mock.ActivateNonDefault(client.http.GetClient())
+			defer mock.DeactivateAndReset()
+
+			test.requiredMocks(client.http.GetClient())
+
+			err = cli.ReportMetrics(&models.DeviceMetrics{Load1: 0.5, Memory: 40, Disk: 75}, test.token)
+			assert.Equal(t, test.expected, err)
+		})
+	}
+}
+
 func TestReverseListener(t *testing.T) {
 	mock := new(reversermock.IReverser)
 
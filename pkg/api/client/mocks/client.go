@@ -94,6 +94,32 @@ func (_m *Client) Endpoints() (*models.Endpoints, error) {
 	return r0, r1
 }
 
+// GetCertificateAuthority provides a mock function with given fields: token
+func (_m *Client) GetCertificateAuthority(token string) (*models.CertificateAuthority, error) {
+	ret := _m.Called(token)
+
+	var r0 *models.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.CertificateAuthority, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.CertificateAuthority); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: uid
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
@@ -203,6 +229,34 @@ type mockConstructorTestingTNewClient interface {
 	Cleanup(func())
 }
 
+// ReportInventory provides a mock function with given fields: inventory, token
+func (_m *Client) ReportInventory(inventory *models.DeviceInventory, token string) error {
+	ret := _m.Called(inventory, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.DeviceInventory, string) error); ok {
+		r0 = rf(inventory, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReportMetrics provides a mock function with given fields: metrics, token
+func (_m *Client) ReportMetrics(metrics *models.DeviceMetrics, token string) error {
+	ret := _m.Called(metrics, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.DeviceMetrics, string) error); ok {
+		r0 = rf(metrics, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 func NewClient(t mockConstructorTestingTNewClient) *Client {
 	mock := &Client{}
@@ -24,6 +24,8 @@ type Reverser struct {
 	//
 	// It is used to create the websocket connection to the ShellHub's server.
 	host string
+	// dialer is used to create the websocket connections. When nil, [websocket.DefaultDialer] is used.
+	dialer *websocket.Dialer
 }
 
 var _ IReverser = new(Reverser)
@@ -45,7 +47,7 @@ func (r *Reverser) Auth(ctx context.Context, token string) error {
 		"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
 	}
 
-	conn, _, err := DialContext(ctx, uri, header)
+	conn, _, err := dial(ctx, r.dialer, uri, header)
 	if err != nil {
 		return err
 	}
@@ -71,6 +73,6 @@ func (r *Reverser) NewListener() (*revdial.Listener, error) {
 			return nil, nil, err
 		}
 
-		return DialContext(ctx, uri, nil)
+		return dial(ctx, r.dialer, uri, nil)
 	}), nil
 }
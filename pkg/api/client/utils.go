@@ -52,6 +52,16 @@ func SameDomainRedirectPolicy() resty.RedirectPolicyFunc {
 // redirect the connection with status [http.StatusTemporaryRedirect] or [http.StatusPermanentRedirect], the DialContext
 // method will follow. Any other response from the server will result in an error as result of this function.
 func DialContext(ctx context.Context, address string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	return dial(ctx, websocket.DefaultDialer, address, header)
+}
+
+// dial creates a websocket connection to ShellHub's SSH server using dialer, following the redirects like
+// [DialContext]. When dialer is nil, [websocket.DefaultDialer] is used.
+func dial(ctx context.Context, dialer *websocket.Dialer, address string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+
 	parseToWS := func(uri string) string {
 		return regexp.MustCompile(`^http`).ReplaceAllString(uri, "ws")
 	}
@@ -61,7 +71,7 @@ func DialContext(ctx context.Context, address string, header http.Header) (*webs
 		return nil, nil, err
 	}
 
-	conn, res, err := websocket.DefaultDialer.DialContext(ctx, parseToWS(uri), header)
+	conn, res, err := dialer.DialContext(ctx, parseToWS(uri), header)
 	if err != nil {
 		if res == nil {
 			return nil, nil, err
@@ -74,7 +84,7 @@ func DialContext(ctx context.Context, address string, header http.Header) (*webs
 				return nil, nil, err
 			}
 
-			return DialContext(ctx, parseToWS(location.String()), header)
+			return dial(ctx, dialer, parseToWS(location.String()), header)
 		default:
 			return nil, nil, err
 		}
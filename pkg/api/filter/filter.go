@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 var (
@@ -161,3 +162,24 @@ func (f *FilterList) IsValid() error {
 
 	return nil
 }
+
+// Filters converts the filter list to the filters used to query the store.
+func (f FilterList) Filters() []models.Filter {
+	filters := make([]models.Filter, 0, len(f))
+	for _, filter := range f {
+		switch params := filter.Params.(type) {
+		case *FilterTypeProperty:
+			filters = append(filters, models.Filter{
+				Type:   filter.Type,
+				Params: &models.PropertyParams{Name: params.Name, Operator: params.Operator, Value: params.Value},
+			})
+		case *FilterTypeOperator:
+			filters = append(filters, models.Filter{
+				Type:   filter.Type,
+				Params: &models.OperatorParams{Name: params.Name},
+			})
+		}
+	}
+
+	return filters
+}
@@ -3,6 +3,7 @@ package filter
 import (
 	"testing"
 
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +27,17 @@ func TestFilterTypeOperator(t *testing.T) {
 	f = Filter{Type: "operator", Params: &FilterTypeOperator{Name: "or"}}
 	assert.NoError(t, f.IsValid())
 }
+
+func TestFilterListFilters(t *testing.T) {
+	list := FilterList{
+		{Type: "property", Params: &FilterTypeProperty{Name: "online", Operator: "bool", Value: "true"}},
+		{Type: "property", Params: &FilterTypeProperty{Name: "info.arch", Operator: "eq", Value: "arm64"}},
+		{Type: "operator", Params: &FilterTypeOperator{Name: "and"}},
+	}
+
+	assert.Equal(t, []models.Filter{
+		{Type: "property", Params: &models.PropertyParams{Name: "online", Operator: "bool", Value: "true"}},
+		{Type: "property", Params: &models.PropertyParams{Name: "info.arch", Operator: "eq", Value: "arm64"}},
+		{Type: "operator", Params: &models.OperatorParams{Name: "and"}},
+	}, list.Filters())
+}
@@ -0,0 +1,214 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// SyntaxError is returned when a search query cannot be parsed.
+type SyntaxError struct {
+	// Message describes what is wrong with the query.
+	Message string `json:"message"`
+	// Position is the zero-based byte offset in the query where the error was found.
+	Position int `json:"position"`
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// aliases maps the short property names accepted by the search syntax to their real names.
+var aliases = map[string]string{
+	"tag": "tags",
+}
+
+// duration matches a relative duration, like 30m, 12h, 7d or 2w.
+var duration = regexp.MustCompile(`^([0-9]+)([smhdw])$`)
+
+var units = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// term is a single property's condition of a search query.
+type term struct {
+	key      string
+	operator byte
+	value    string
+	quoted   bool
+}
+
+// Parse compiles a search query into filters.
+//
+// A query is a list of terms, separated by spaces, that must all match. Each term is a property name, an operator and
+// a value, like `status:accepted online:true tag:prod name~"edge-*" info.arch:arm64 last_seen<7d`. The operators are:
+//
+//	:  the property is equal to the value; true and false values compare booleans
+//	~  the property contains the value; a value with * matches it as a glob
+//	<  the property is less than the value
+//	>  the property is greater than the value
+//
+// A value with spaces must be double-quoted, escaping its double quotes with a backslash. When a relative duration,
+// like 30m, 12h, 7d or 2w, is compared, it refers to a date that long ago, so `last_seen<7d` matches what was seen less
+// than seven days ago.
+func Parse(query string) ([]models.Filter, error) {
+	filters := make([]models.Filter, 0)
+
+	for pos := skipSpaces(query, 0); pos < len(query); pos = skipSpaces(query, pos) {
+		t, next, err := parseTerm(query, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, t.compile())
+		pos = next
+	}
+
+	if len(filters) > 0 {
+		filters = append(filters, models.Filter{Type: "operator", Params: &models.OperatorParams{Name: "and"}})
+	}
+
+	return filters, nil
+}
+
+func skipSpaces(query string, pos int) int {
+	for pos < len(query) && isSpace(query[pos]) {
+		pos++
+	}
+
+	return pos
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isKey(c byte, first bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		return true
+	case c >= '0' && c <= '9', c == '.':
+		return !first
+	default:
+		return false
+	}
+}
+
+// parseTerm parses the term starting at pos, returning it and the position right after it.
+func parseTerm(query string, pos int) (*term, int, error) {
+	start := pos
+	for pos < len(query) && isKey(query[pos], pos == start) {
+		pos++
+	}
+
+	if pos == start {
+		return nil, 0, &SyntaxError{Message: "expected a property name", Position: pos}
+	}
+
+	t := &term{key: query[start:pos]}
+
+	if pos == len(query) || !strings.ContainsRune(":~<>", rune(query[pos])) {
+		return nil, 0, &SyntaxError{Message: fmt.Sprintf("expected an operator (:, ~, < or >) after %q", t.key), Position: pos}
+	}
+
+	t.operator = query[pos]
+	pos++
+
+	if pos == len(query) || isSpace(query[pos]) {
+		return nil, 0, &SyntaxError{Message: fmt.Sprintf("expected a value for %q", t.key), Position: pos}
+	}
+
+	if query[pos] != '"' {
+		start = pos
+		for pos < len(query) && !isSpace(query[pos]) {
+			pos++
+		}
+
+		t.value = query[start:pos]
+
+		return t, pos, nil
+	}
+
+	quote := pos
+	value := new(strings.Builder)
+	for pos++; pos < len(query); pos++ {
+		switch query[pos] {
+		case '\\':
+			if pos+1 < len(query) {
+				pos++
+			}
+
+			value.WriteByte(query[pos])
+		case '"':
+			t.value = value.String()
+			t.quoted = true
+
+			if pos+1 < len(query) && !isSpace(query[pos+1]) {
+				return nil, 0, &SyntaxError{Message: "expected a space after the quoted value", Position: pos + 1}
+			}
+
+			return t, pos + 1, nil
+		default:
+			value.WriteByte(query[pos])
+		}
+	}
+
+	return nil, 0, &SyntaxError{Message: "unterminated quoted value", Position: quote}
+}
+
+// compile converts the term to a property filter.
+func (t *term) compile() models.Filter {
+	name := t.key
+	if alias, ok := aliases[name]; ok {
+		name = alias
+	}
+
+	params := &models.PropertyParams{Name: name, Value: t.value}
+
+	switch t.operator {
+	case ':':
+		params.Operator = "eq"
+		if !t.quoted && (t.value == "true" || t.value == "false") {
+			params.Operator = "bool"
+		}
+	case '~':
+		params.Operator = "contains"
+		params.Value = pattern(t.value)
+	case '<', '>':
+		params.Operator = map[byte]string{'<': "lt", '>': "gt"}[t.operator]
+
+		// NOTICE: a relative duration compares the date that long ago, so the operator is reversed: what happened less
+		// than a duration ago happened after that date.
+		if match := duration.FindStringSubmatch(t.value); !t.quoted && match != nil {
+			amount, _ := strconv.Atoi(match[1])
+
+			params.Value = clock.Now().Add(-time.Duration(amount) * units[match[2]]).UTC().Format(time.RFC3339)
+			params.Operator = map[byte]string{'<': "gt", '>': "lt"}[t.operator]
+		}
+	}
+
+	return models.Filter{Type: "property", Params: params}
+}
+
+// pattern converts a value of the contains operator to a regular expression, matching it as a glob when it has *.
+func pattern(value string) string {
+	if !strings.Contains(value, "*") {
+		return regexp.QuoteMeta(value)
+	}
+
+	parts := strings.Split(value, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	return "^" + strings.Join(parts, ".*") + "$"
+}
@@ -0,0 +1,105 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/clock/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	clockMock := new(mocks.Clock)
+	clock.DefaultBackend = clockMock
+
+	now := time.Date(2023, 1, 8, 12, 0, 0, 0, time.UTC)
+	clockMock.On("Now").Return(now)
+
+	property := func(name, operator string, value interface{}) models.Filter {
+		return models.Filter{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: name, Operator: operator, Value: value},
+		}
+	}
+
+	and := models.Filter{Type: "operator", Params: &models.OperatorParams{Name: "and"}}
+
+	cases := []struct {
+		description string
+		query       string
+		expected    []models.Filter
+		err         error
+	}{
+		{
+			description: "succeeds when query is empty",
+			query:       "  ",
+			expected:    []models.Filter{},
+		},
+		{
+			description: "succeeds when query has every operator",
+			query:       `status:accepted online:true tag:prod name~"edge-*" info.arch:arm64 last_seen<7d`,
+			expected: []models.Filter{
+				property("status", "eq", "accepted"),
+				property("online", "bool", "true"),
+				property("tags", "eq", "prod"),
+				property("name", "contains", "^edge-.*$"),
+				property("info.arch", "eq", "arm64"),
+				property("last_seen", "gt", "2023-01-01T12:00:00Z"),
+				and,
+			},
+		},
+		{
+			description: "succeeds when values are quoted, escaped and compared",
+			query:       `username:"john \"doe\"" name~db.1 started_at>2023-01-01T00:00:00Z online:"true"`,
+			expected: []models.Filter{
+				property("username", "eq", `john "doe"`),
+				property("name", "contains", `db\.1`),
+				property("started_at", "gt", "2023-01-01T00:00:00Z"),
+				property("online", "eq", "true"),
+				and,
+			},
+		},
+		{
+			description: "fails when property name is missing",
+			query:       "status:accepted :true",
+			err:         &SyntaxError{Message: "expected a property name", Position: 16},
+		},
+		{
+			description: "fails when operator is missing",
+			query:       "online",
+			err:         &SyntaxError{Message: `expected an operator (:, ~, < or >) after "online"`, Position: 6},
+		},
+		{
+			description: "fails when value is missing",
+			query:       "online: true",
+			err:         &SyntaxError{Message: `expected a value for "online"`, Position: 7},
+		},
+		{
+			description: "fails when quoted value is unterminated",
+			query:       `name~"edge`,
+			err:         &SyntaxError{Message: "unterminated quoted value", Position: 5},
+		},
+		{
+			description: "fails when quoted value is followed by other characters",
+			query:       `name:"edge"x`,
+			err:         &SyntaxError{Message: "expected a space after the quoted value", Position: 11},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			filters, err := Parse(tc.query)
+			if tc.err != nil {
+				assert.Nil(t, filters)
+				assert.Equal(t, tc.err, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, filters)
+		})
+	}
+}
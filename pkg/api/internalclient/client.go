@@ -1,6 +1,7 @@
 package internalclient
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -10,7 +11,9 @@ import (
 
 	resty "github.com/go-resty/resty/v2"
 	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -22,6 +25,7 @@ var (
 	ErrConnectionFailed = errors.New("connection failed")
 	ErrNotFound         = errors.New("not found")
 	ErrUnknown          = errors.New("unknown error")
+	ErrUnauthorized     = errors.New("unauthorized")
 )
 
 // Options wraps injectable values to a new API internal client.
@@ -29,10 +33,21 @@ var (
 // and its properties are privated.
 type Options struct {
 	Asynq *asynq.Client
+	// Context is the context of the client's requests, whose trace they continue. It must outlive the client.
+	Context context.Context
 }
 
 type Opt func(*Options) error
 
+// WithContext sets the context of the client's requests, so they continue its trace.
+func WithContext(ctx context.Context) Opt {
+	return func(o *Options) error {
+		o.Context = ctx
+
+		return nil
+	}
+}
+
 func NewClient(opts ...Opt) Client {
 	httpClient := resty.New()
 	httpClient.SetRetryCount(math.MaxInt32)
@@ -43,12 +58,14 @@ func NewClient(opts ...Opt) Client {
 
 		return r.StatusCode() >= http.StatusInternalServerError && r.StatusCode() != http.StatusNotImplemented
 	})
+	httpClient.SetTransport(tracing.Transport(httpClient.GetClient().Transport))
 
 	c := &client{
 		host:   apiHost,
 		port:   apiPort,
 		scheme: apiScheme,
 		http:   httpClient,
+		ctx:    context.Background(),
 	}
 
 	o := new(Options)
@@ -62,6 +79,10 @@ func NewClient(opts ...Opt) Client {
 		c.asynq = o.Asynq
 	}
 
+	if o.Context != nil {
+		c.ctx = o.Context
+	}
+
 	if c.logger != nil {
 		httpClient.SetLogger(&LeveledLogger{c.logger})
 	}
@@ -98,6 +119,12 @@ type commonAPI interface {
 	ListDevices() ([]models.Device, error)
 	GetDevice(uid string) (*models.Device, error)
 	GetDeviceByPublicURLAddress(address string) (*models.Device, error)
+	// AuthorizeDevicePublicURL checks the credentials required to reach a device's public URL service, returning
+	// ErrUnauthorized when they are refused.
+	AuthorizeDevicePublicURL(req requests.DevicePublicURLAuthorize) error
+	// AuthorizeDevicePublishedPort checks the token or the client certificate used to reach a device's published port,
+	// returning the device that publishes it, or ErrUnauthorized when they are refused.
+	AuthorizeDevicePublishedPort(req requests.DevicePublishedPortAuthorize) (*models.Device, error)
 }
 
 type client struct {
@@ -107,11 +134,17 @@ type client struct {
 	http   *resty.Client
 	logger *logrus.Logger
 	asynq  *asynq.Client
+	ctx    context.Context
+}
+
+// request creates a request in the client's context.
+func (c *client) request() *resty.Request {
+	return c.http.R().SetContext(c.ctx)
 }
 
 func (c *client) ListDevices() ([]models.Device, error) {
 	list := []models.Device{}
-	_, err := c.http.R().
+	_, err := c.request().
 		SetResult(list).
 		Get(buildURL(c, "/api/devices"))
 
@@ -120,7 +153,7 @@ func (c *client) ListDevices() ([]models.Device, error) {
 
 func (c *client) GetDevice(uid string) (*models.Device, error) {
 	var device *models.Device
-	resp, err := c.http.R().
+	resp, err := c.request().
 		SetResult(&device).
 		Get(buildURL(c, fmt.Sprintf("/api/devices/%s", uid)))
 	if err != nil {
@@ -158,6 +191,48 @@ func (c *client) GetDeviceByPublicURLAddress(address string) (*models.Device, er
 	}
 }
 
+func (c *client) AuthorizeDevicePublicURL(req requests.DevicePublicURLAuthorize) error {
+	resp, err := c.request().
+		SetBody(req).
+		Post(buildURL(c, fmt.Sprintf("/internal/devices/public/%s/authorize", req.PublicURLAddress)))
+	if err != nil {
+		return ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return nil
+	case 401, 403:
+		return ErrUnauthorized
+	case 404:
+		return ErrNotFound
+	default:
+		return ErrUnknown
+	}
+}
+
+func (c *client) AuthorizeDevicePublishedPort(req requests.DevicePublishedPortAuthorize) (*models.Device, error) {
+	var device *models.Device
+	resp, err := c.request().
+		SetBody(req).
+		SetResult(&device).
+		Post(buildURL(c, "/internal/devices/ports/authorize"))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		return device, nil
+	case 401, 403:
+		return nil, ErrUnauthorized
+	case 404:
+		return nil, ErrNotFound
+	default:
+		return nil, ErrUnknown
+	}
+}
+
 func buildURL(c *client, uri string) string {
 	u, _ := url.Parse(fmt.Sprintf("%s://%s:%d%s", c.scheme, c.host, c.port, uri))
 
@@ -26,8 +26,14 @@ type Client interface {
 type internalAPI interface {
 	LookupDevice()
 	GetPublicKey(fingerprint, tenant string) (*models.PublicKey, error)
+	// ListPublicKeysByFingerprint lists the public keys identified by fingerprint on every namespace.
+	ListPublicKeysByFingerprint(fingerprint string) ([]models.PublicKey, error)
 	CreatePrivateKey() (*models.PrivateKey, error)
 	EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error)
+	// EvaluateAgentForwarding checks if a client, authenticated as the member of username or with a public key
+	// registered on the namespace when it is empty, can forward its SSH agent to the namespace's devices.
+	EvaluateAgentForwarding(tenant, username string) (bool, error)
+	GetCertificateAuthority(tenant string) (*models.CertificateAuthority, error)
 	DevicesOffline(id string) error
 	DevicesHeartbeat(id string) error
 	FirewallEvaluate(lookup map[string]string) error
@@ -37,6 +43,8 @@ type internalAPI interface {
 	RecordSession(session *models.SessionRecorded, recordURL string)
 	Lookup(lookup map[string]string) (string, []error)
 	DeviceLookup(lookup map[string]string) (*models.Device, []error)
+	// NamespaceLookup gets a namespace by its name.
+	NamespaceLookup(name string) (*models.Namespace, error)
 	BillingReport(tenant string, action string) (int, error)
 	BillingEvaluate(tenantID string) (*models.BillingEvaluation, int, error)
 }
@@ -45,7 +53,7 @@ func (c *client) LookupDevice() {
 }
 
 func (c *client) BillingReport(tenant string, action string) (int, error) {
-	res, err := c.http.R().
+	res, err := c.request().
 		SetHeader("X-Tenant-ID", tenant).
 		SetQueryParam("action", action).
 		Post(fmt.Sprintf("%s://%s:%d/internal/billing/report", apiScheme, billingURL, apiPort))
@@ -58,7 +66,7 @@ func (c *client) BillingReport(tenant string, action string) (int, error) {
 
 func (c *client) BillingEvaluate(tenantID string) (*models.BillingEvaluation, int, error) {
 	var evaluation *models.BillingEvaluation
-	resp, err := c.http.R().
+	resp, err := c.request().
 		SetHeader("X-Tenant-ID", tenantID).
 		SetResult(&evaluation).
 		Post(fmt.Sprintf("%s://%s:%d/internal/billing/evaluate", apiScheme, billingURL, apiPort))
@@ -71,7 +79,7 @@ func (c *client) BillingEvaluate(tenantID string) (*models.BillingEvaluation, in
 
 func (c *client) GetPublicKey(fingerprint, tenant string) (*models.PublicKey, error) {
 	var pubKey *models.PublicKey
-	resp, err := c.http.R().
+	resp, err := c.request().
 		SetResult(&pubKey).
 		Get(buildURL(c, fmt.Sprintf("/internal/sshkeys/public-keys/%s/%s", fingerprint, tenant)))
 	if err != nil {
@@ -85,10 +93,26 @@ func (c *client) GetPublicKey(fingerprint, tenant string) (*models.PublicKey, er
 	return pubKey, nil
 }
 
+func (c *client) ListPublicKeysByFingerprint(fingerprint string) ([]models.PublicKey, error) {
+	var keys []models.PublicKey
+	resp, err := c.request().
+		SetResult(&keys).
+		Get(buildURL(c, fmt.Sprintf("/internal/sshkeys/public-keys/%s", fingerprint)))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ErrUnknown
+	}
+
+	return keys, nil
+}
+
 func (c *client) EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error) {
 	var evaluate *bool
 
-	resp, err := c.http.R().
+	resp, err := c.request().
 		SetBody(dev).
 		SetResult(&evaluate).
 		Post(buildURL(c, fmt.Sprintf("/internal/sshkeys/public-keys/evaluate/%s/%s", fingerprint, username)))
@@ -103,9 +127,53 @@ func (c *client) EvaluateKey(fingerprint string, dev *models.Device, username st
 	return false, nil
 }
 
+// EvaluateAgentForwarding checks if a client, authenticated as the member of username or with a public key registered on
+// the namespace when it is empty, can forward its SSH agent to the namespace's devices.
+func (c *client) EvaluateAgentForwarding(tenant, username string) (bool, error) {
+	var allowed bool
+	resp, err := c.request().
+		SetQueryParam("username", username).
+		SetResult(&allowed).
+		Get(buildURL(c, fmt.Sprintf("/internal/namespaces/%s/agent-forwarding", tenant)))
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return false, ErrNotFound
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return false, ErrUnknown
+	}
+
+	return allowed, nil
+}
+
+// GetCertificateAuthority gets the SSH user certificate authority of a namespace.
+func (c *client) GetCertificateAuthority(tenant string) (*models.CertificateAuthority, error) {
+	var ca *models.CertificateAuthority
+	resp, err := c.request().
+		SetResult(&ca).
+		Get(buildURL(c, fmt.Sprintf("/internal/sshkeys/certificate-authority/%s", tenant)))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ErrUnknown
+	}
+
+	return ca, nil
+}
+
 func (c *client) CreatePrivateKey() (*models.PrivateKey, error) {
 	var privKey *models.PrivateKey
-	_, err := c.http.R().
+	_, err := c.request().
 		SetResult(&privKey).
 		Post(buildURL(c, "/internal/sshkeys/private-keys"))
 	if err != nil {
@@ -116,7 +184,7 @@ func (c *client) CreatePrivateKey() (*models.PrivateKey, error) {
 }
 
 func (c *client) DevicesOffline(id string) error {
-	_, err := c.http.R().
+	_, err := c.request().
 		Post(buildURL(c, fmt.Sprintf("/internal/devices/%s/offline", id)))
 	if err != nil {
 		return err
@@ -165,7 +233,7 @@ func (c *client) FirewallEvaluate(lookup map[string]string) error {
 // SessionAsAuthenticated makes a HTTP request to ShellHub API server to mark the session as authenticated.
 func (c *client) SessionAsAuthenticated(uid string) []error {
 	var errors []error
-	_, err := c.http.R().
+	_, err := c.request().
 		SetBody(&models.Status{
 			Authenticated: true,
 		}).
@@ -179,7 +247,7 @@ func (c *client) SessionAsAuthenticated(uid string) []error {
 
 func (c *client) FinishSession(uid string) []error {
 	var errors []error
-	_, err := c.http.R().
+	_, err := c.request().
 		Post(buildURL(c, fmt.Sprintf("/internal/sessions/%s/finish", uid)))
 	if err != nil {
 		errors = append(errors, err)
@@ -190,7 +258,7 @@ func (c *client) FinishSession(uid string) []error {
 
 func (c *client) KeepAliveSession(uid string) []error {
 	var errors []error
-	_, err := c.http.R().
+	_, err := c.request().
 		Post(buildURL(c, fmt.Sprintf("/internal/sessions/%s/keepalive", uid)))
 	if err != nil {
 		errors = append(errors, err)
@@ -200,7 +268,7 @@ func (c *client) KeepAliveSession(uid string) []error {
 }
 
 func (c *client) RecordSession(session *models.SessionRecorded, recordURL string) {
-	_, _ = c.http.R().
+	_, _ = c.request().
 		SetBody(session).
 		Post(fmt.Sprintf("http://"+recordURL+"/internal/sessions/%s/record", session.UID))
 }
@@ -210,7 +278,7 @@ func (c *client) Lookup(lookup map[string]string) (string, []error) {
 		UID string `json:"uid"`
 	}
 
-	resp, _ := c.http.R().
+	resp, _ := c.request().
 		SetQueryParams(lookup).
 		SetResult(&device).
 		Get(buildURL(c, "/internal/lookup"))
@@ -225,7 +293,7 @@ func (c *client) Lookup(lookup map[string]string) (string, []error) {
 func (c *client) DeviceLookup(lookup map[string]string) (*models.Device, []error) {
 	var device *models.Device
 
-	resp, err := c.http.R().
+	resp, err := c.request().
 		SetQueryParams(lookup).
 		SetResult(&device).
 		Get(buildURL(c, "/internal/lookup"))
@@ -239,3 +307,24 @@ func (c *client) DeviceLookup(lookup map[string]string) (*models.Device, []error
 
 	return device, nil
 }
+
+func (c *client) NamespaceLookup(name string) (*models.Namespace, error) {
+	var namespace *models.Namespace
+	resp, err := c.request().
+		SetQueryParam("name", name).
+		SetResult(&namespace).
+		Get(buildURL(c, "/internal/namespaces/lookup"))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ErrUnknown
+	}
+
+	return namespace, nil
+}
@@ -0,0 +1,55 @@
+package internalclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey struct{}
+
+func TestGetDevice(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/devices/uid" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(&models.Device{UID: "uid", Name: "device"}) // nolint:errcheck
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+
+	port, err := strconv.Atoi(u.Port())
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "value")
+
+	c, ok := NewClient(WithContext(ctx)).(*client)
+	assert.True(t, ok)
+
+	c.scheme = u.Scheme
+	c.host = u.Hostname()
+	c.port = port
+
+	assert.Equal(t, ctx, c.request().Context())
+
+	device, err := c.GetDevice("uid")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Device{UID: "uid", Name: "device"}, device)
+
+	device, err = c.GetDevice("nonexistent")
+	assert.Equal(t, ErrNotFound, err)
+	assert.Nil(t, device)
+}
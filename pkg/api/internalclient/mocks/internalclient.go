@@ -5,6 +5,8 @@ package mocks
 import (
 	models "github.com/shellhub-io/shellhub/pkg/models"
 	mock "github.com/stretchr/testify/mock"
+
+	requests "github.com/shellhub-io/shellhub/pkg/api/requests"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -12,6 +14,43 @@ type Client struct {
 	mock.Mock
 }
 
+// AuthorizeDevicePublicURL provides a mock function with given fields: req
+func (_m *Client) AuthorizeDevicePublicURL(req requests.DevicePublicURLAuthorize) error {
+	ret := _m.Called(req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(requests.DevicePublicURLAuthorize) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthorizeDevicePublishedPort provides a mock function with given fields: req
+func (_m *Client) AuthorizeDevicePublishedPort(req requests.DevicePublishedPortAuthorize) (*models.Device, error) {
+	ret := _m.Called(req)
+
+	var r0 *models.Device
+	if rf, ok := ret.Get(0).(func(requests.DevicePublishedPortAuthorize) *models.Device); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(requests.DevicePublishedPortAuthorize) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BillingEvaluate provides a mock function with given fields: tenantID
 func (_m *Client) BillingEvaluate(tenantID string) (*models.BillingEvaluation, int, error) {
 	ret := _m.Called(tenantID)
@@ -151,6 +190,30 @@ func (_m *Client) DevicesOffline(id string) error {
 	return r0
 }
 
+// EvaluateAgentForwarding provides a mock function with given fields: tenant, username
+func (_m *Client) EvaluateAgentForwarding(tenant string, username string) (bool, error) {
+	ret := _m.Called(tenant, username)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(tenant, username)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(tenant, username)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tenant, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EvaluateKey provides a mock function with given fields: fingerprint, dev, username
 func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error) {
 	ret := _m.Called(fingerprint, dev, username)
@@ -205,6 +268,32 @@ func (_m *Client) FirewallEvaluate(lookup map[string]string) error {
 	return r0
 }
 
+// GetCertificateAuthority provides a mock function with given fields: tenant
+func (_m *Client) GetCertificateAuthority(tenant string) (*models.CertificateAuthority, error) {
+	ret := _m.Called(tenant)
+
+	var r0 *models.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.CertificateAuthority, error)); ok {
+		return rf(tenant)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.CertificateAuthority); ok {
+		r0 = rf(tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: uid
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
@@ -325,6 +414,32 @@ func (_m *Client) ListDevices() ([]models.Device, error) {
 	return r0, r1
 }
 
+// ListPublicKeysByFingerprint provides a mock function with given fields: fingerprint
+func (_m *Client) ListPublicKeysByFingerprint(fingerprint string) ([]models.PublicKey, error) {
+	ret := _m.Called(fingerprint)
+
+	var r0 []models.PublicKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.PublicKey, error)); ok {
+		return rf(fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.PublicKey); ok {
+		r0 = rf(fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PublicKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Lookup provides a mock function with given fields: lookup
 func (_m *Client) Lookup(lookup map[string]string) (string, []error) {
 	ret := _m.Called(lookup)
@@ -356,6 +471,32 @@ func (_m *Client) LookupDevice() {
 	_m.Called()
 }
 
+// NamespaceLookup provides a mock function with given fields: name
+func (_m *Client) NamespaceLookup(name string) (*models.Namespace, error) {
+	ret := _m.Called(name)
+
+	var r0 *models.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Namespace, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Namespace); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RecordSession provides a mock function with given fields: session, recordURL
 func (_m *Client) RecordSession(session *models.SessionRecorded, recordURL string) {
 	_m.Called(session, recordURL)
@@ -20,6 +20,7 @@ type Claims interface {
 type Token struct {
 	registeredClaims jwt.RegisteredClaims
 	privateKey       *rsa.PrivateKey
+	keyID            string
 	claims           Claims
 	method           jwt.SigningMethod
 	raw              *jwt.Token
@@ -74,6 +75,13 @@ func (t *Token) WithPrivateKey(pk *rsa.PrivateKey) *Token {
 	return t
 }
 
+// WithKeyID sets the "kid" header of the JWT, identifying the private key that signs it.
+func (t *Token) WithKeyID(id string) *Token {
+	t.keyID = id
+
+	return t
+}
+
 // Sign finalizes the configuration of the JWT and signs it with the private key.
 // If no custom claims have been set, it will sign an empty token with only the "jti" claim.
 func (t *Token) Sign() (*Token, error) {
@@ -88,6 +96,10 @@ func (t *Token) Sign() (*Token, error) {
 		token = jwt.NewWithClaims(t.method, t.registeredClaims)
 	}
 
+	if t.keyID != "" {
+		token.Header["kid"] = t.keyID
+	}
+
 	tokenStr, err := token.SignedString(t.privateKey)
 	if err != nil {
 		return nil, err
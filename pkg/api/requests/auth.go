@@ -10,3 +10,8 @@ type AuthTokenGet struct {
 type AuthTokenSwap struct {
 	TenantParam
 }
+
+// AuthTokenRefresh is the structure to represent the request body for refresh auth token endpoint.
+type AuthTokenRefresh struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
@@ -1,5 +1,7 @@
 package requests
 
+import "time"
+
 // DeviceParam is a structure to represent and validate a device UID as path param.
 type DeviceParam struct {
 	UID string `param:"uid" validate:"required"`
@@ -63,6 +65,24 @@ type DeviceUpdateTag struct {
 	Tags []string `json:"tags" validate:"required,min=0,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 }
 
+// DeviceLabelParam is a structure to represent and validate a device's label key as path param.
+type DeviceLabelParam struct {
+	Key string `param:"key" validate:"required,device_label"`
+}
+
+// DeviceUpdateLabels is the structure to represent the request data for device update labels endpoint. The labels
+// are merged into the device's ones.
+type DeviceUpdateLabels struct {
+	DeviceParam
+	Labels map[string]string `json:"labels" validate:"required,min=1,max=16,dive,keys,device_label,endkeys,required,max=255"`
+}
+
+// DeviceRemoveLabel is the structure to represent the request data for device remove label endpoint.
+type DeviceRemoveLabel struct {
+	DeviceParam
+	DeviceLabelParam
+}
+
 type DeviceIdentity struct {
 	MAC string `json:"mac"`
 }
@@ -83,6 +103,8 @@ type DeviceAuth struct {
 	Identity  *DeviceIdentity `json:"identity,omitempty" validate:"required_without=Hostname,omitempty"`
 	PublicKey string          `json:"public_key" validate:"required"`
 	TenantID  string          `json:"tenant_id" validate:"required"`
+	// Labels are the labels reported by the agent.
+	Labels map[string]string `json:"labels,omitempty" validate:"omitempty,max=16,dive,keys,device_label,endkeys,required,max=255"`
 }
 
 type DeviceGetPublicURL struct {
@@ -99,3 +121,186 @@ type DeviceUpdate struct {
 type DevicePublicURLAddress struct {
 	PublicURLAddress string `param:"address" validate:"required"`
 }
+
+// DevicePublicURLServiceParam is a structure to represent and validate a device's public URL service address as path
+// param.
+type DevicePublicURLServiceParam struct {
+	DeviceParam
+	Address string `param:"address" validate:"required"`
+}
+
+// DevicePublicURLServiceFields contains the fields that describe where a device's public URL service is listening.
+type DevicePublicURLServiceFields struct {
+	// Host is the host, relative to the device, where the service is listening. When empty, the device itself is
+	// used.
+	Host string `json:"host" validate:"omitempty,hostname_rfc1123"`
+	// Port is the port where the service is listening.
+	Port int `json:"port" validate:"required,min=1,max=65535"`
+	// Scheme is the protocol used to talk to the service. When empty, http is used.
+	Scheme string `json:"scheme" validate:"omitempty,oneof=http https"`
+	// InsecureSkipVerify disables the verification of the service's certificate when Scheme is https.
+	InsecureSkipVerify bool `json:"insecure_skip_verify"`
+	// Access is who can reach the service: public, login, basic or allowlist. When empty, the service is public.
+	Access string `json:"access" validate:"omitempty,oneof=public login basic allowlist"`
+	// Username is the user required on basic access.
+	Username string `json:"username" validate:"required_if=Access basic"`
+	// Password is the password required on basic access.
+	Password string `json:"password" validate:"required_if=Access basic"`
+	// AllowedCIDRs lists the networks, or single addresses, allowed on allowlist access.
+	AllowedCIDRs []string `json:"allowed_cidrs" validate:"required_if=Access allowlist,dive,cidr|ip"`
+}
+
+// DevicePublicURLServiceCreate is the structure to represent the request data for create device's public URL service
+// endpoint.
+type DevicePublicURLServiceCreate struct {
+	DeviceParam
+	DevicePublicURLServiceFields
+}
+
+// DevicePublicURLServiceUpdate is the structure to represent the request data for update device's public URL service
+// endpoint.
+type DevicePublicURLServiceUpdate struct {
+	DevicePublicURLServiceParam
+	DevicePublicURLServiceFields
+}
+
+// DevicePublicURLServiceDelete is the structure to represent the request data for delete device's public URL service
+// endpoint.
+type DevicePublicURLServiceDelete struct {
+	DevicePublicURLServiceParam
+}
+
+// DevicePublicURLAuthorize is the structure to represent the request data for authorize device's public URL endpoint.
+//
+// Only the credentials required by the service's access mode are evaluated.
+type DevicePublicURLAuthorize struct {
+	DevicePublicURLAddress
+	// Token is the ShellHub's user token, required on login access.
+	Token string `json:"token"`
+	// Username is the user sent through HTTP basic authentication, required on basic access.
+	Username string `json:"username"`
+	// Password is the password sent through HTTP basic authentication, required on basic access.
+	Password string `json:"password"`
+}
+
+// DevicePublishedPortParam is a structure to represent and validate a device's published port address as path param.
+type DevicePublishedPortParam struct {
+	DeviceParam
+	Address string `param:"address" validate:"required"`
+}
+
+// DevicePublishedPortCreate is the structure to represent the request data for create device's published port
+// endpoint.
+type DevicePublishedPortCreate struct {
+	DeviceParam
+	// Host is the host, relative to the device, where the port is open. When empty, the device itself is used.
+	Host string `json:"host" validate:"omitempty,hostname_rfc1123"`
+	// Port is the port to publish.
+	Port int `json:"port" validate:"required,min=1,max=65535"`
+}
+
+// DevicePublishedPortDelete is the structure to represent the request data for delete device's published port
+// endpoint.
+type DevicePublishedPortDelete struct {
+	DevicePublishedPortParam
+}
+
+// DevicePublishedPortToken is the structure to represent the request data for create device's published port token
+// endpoint.
+type DevicePublishedPortToken struct {
+	DevicePublishedPortParam
+}
+
+// DevicePublishedPortCertificate is the structure to represent the request data for create device's published port
+// certificate endpoint.
+type DevicePublishedPortCertificate struct {
+	DevicePublishedPortParam
+	// PublicKey is the member's public key, PEM encoded in the PKIX format, to be signed.
+	PublicKey string `json:"public_key" validate:"required"`
+	// Validity is the certificate's lifetime in minutes.
+	Validity int `json:"validity" validate:"required,min=1,max=1440"`
+}
+
+// DevicePublishedPortAuthorize is the structure to represent the request data for authorize device's published port
+// endpoint.
+//
+// The published port is identified by its address or by the gateway's port allocated to it, and the connection is
+// authorized by a token or by a client certificate.
+type DevicePublishedPortAuthorize struct {
+	// Address is the address of the published port, reached through its hostname.
+	Address string `json:"address" validate:"required_without=GatewayPort"`
+	// GatewayPort is the gateway's port allocated to the published port.
+	GatewayPort int `json:"gateway_port" validate:"required_without=Address,omitempty,min=1,max=65535"`
+	// Token is the short-lived token issued to connect to the published port.
+	Token string `json:"token" validate:"required_without=Certificate"`
+	// Certificate is the PEM encoded client certificate presented to connect to the published port.
+	Certificate string `json:"certificate" validate:"required_without=Token"`
+}
+
+// DeviceInventoryReport is the structure to represent the request data for the device's inventory report endpoint.
+type DeviceInventoryReport struct {
+	Kernel     string                     `json:"kernel" validate:"max=255"`
+	CPU        DeviceInventoryCPU         `json:"cpu"`
+	Memory     DeviceInventoryMemory      `json:"memory"`
+	Disks      []DeviceInventoryDisk      `json:"disks" validate:"max=64,dive"`
+	Interfaces []DeviceInventoryInterface `json:"interfaces" validate:"max=64,dive"`
+	Uptime     uint64                     `json:"uptime"`
+	Packages   int                        `json:"packages" validate:"min=0"`
+}
+
+type DeviceInventoryCPU struct {
+	Model string `json:"model" validate:"max=255"`
+	Count int    `json:"count" validate:"min=0"`
+}
+
+type DeviceInventoryMemory struct {
+	Total     uint64 `json:"total"`
+	Available uint64 `json:"available"`
+}
+
+type DeviceInventoryDisk struct {
+	Device     string `json:"device" validate:"max=255"`
+	Mountpoint string `json:"mountpoint" validate:"max=4096"`
+	Filesystem string `json:"filesystem" validate:"max=255"`
+	Total      uint64 `json:"total"`
+	Used       uint64 `json:"used"`
+}
+
+type DeviceInventoryInterface struct {
+	Name      string   `json:"name" validate:"max=255"`
+	MAC       string   `json:"mac" validate:"max=255"`
+	Addresses []string `json:"addresses" validate:"max=64,dive,max=255"`
+	Primary   bool     `json:"primary"`
+}
+
+// DeviceMetricsReport is the structure to represent the request data for the device's metrics report endpoint.
+type DeviceMetricsReport struct {
+	Load1       float64  `json:"load1" validate:"min=0"`
+	Load5       float64  `json:"load5" validate:"min=0"`
+	Load15      float64  `json:"load15" validate:"min=0"`
+	Memory      float64  `json:"memory" validate:"min=0,max=100"`
+	Disk        float64  `json:"disk" validate:"min=0,max=100"`
+	Temperature *float64 `json:"temperature"`
+}
+
+// DeviceMetricsList is the structure to represent the request data for the device's metrics endpoint. When the
+// period is not set, the last 24 hours are listed.
+type DeviceMetricsList struct {
+	DeviceParam
+	Resolution string    `query:"resolution" validate:"omitempty,oneof=minute hour day"`
+	From       time.Time `query:"from"`
+	To         time.Time `query:"to"`
+}
+
+// DeviceAvailabilityGet is the structure to represent the request data for the device's availability report endpoint.
+// When the period is not set, the last 30 days are reported.
+type DeviceAvailabilityGet struct {
+	DeviceParam
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
+
+// DeviceInventoryList is the structure to represent the request data for the device's inventory history endpoint.
+type DeviceInventoryList struct {
+	DeviceParam
+}
@@ -0,0 +1,47 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/filter"
+
+// DeviceGroupParam is a structure to represent and validate a device group ID as path param.
+type DeviceGroupParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// DeviceGroupFields is the structure to represent the fields of a device group that can be set on its creation or
+// update.
+type DeviceGroupFields struct {
+	// Name identifies the group on its namespace.
+	Name string `json:"name" validate:"required,min=1,max=64"`
+	// Filter is the list of filters the group's devices match.
+	Filter filter.FilterList `json:"filter" validate:"required,min=1"`
+	// SortBy is the device's property the group's devices are sorted by.
+	SortBy string `json:"sort_by"`
+	// OrderBy is the sort's order.
+	OrderBy string `json:"order_by" validate:"omitempty,oneof=asc desc"`
+}
+
+// DeviceGroupGet is the structure to represent the request data for get device group endpoint.
+type DeviceGroupGet struct {
+	DeviceGroupParam
+}
+
+// DeviceGroupCreate is the structure to represent the request data for create device group endpoint.
+type DeviceGroupCreate struct {
+	DeviceGroupFields
+}
+
+// DeviceGroupUpdate is the structure to represent the request data for update device group endpoint.
+type DeviceGroupUpdate struct {
+	DeviceGroupParam
+	DeviceGroupFields
+}
+
+// DeviceGroupDelete is the structure to represent the request data for delete device group endpoint.
+type DeviceGroupDelete struct {
+	DeviceGroupParam
+}
+
+// DeviceGroupDevices is the structure to represent the request data for list device group's devices endpoint.
+type DeviceGroupDevices struct {
+	DeviceGroupParam
+}
@@ -0,0 +1,29 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/filter"
+
+// DeviceJobParam is a structure to represent and validate a device job ID as path param.
+type DeviceJobParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// DeviceJobCreate is the structure to represent the request data for create device job endpoint.
+//
+// The job's devices are either listed by UID or matched by a filter, never both.
+type DeviceJobCreate struct {
+	// Operation is what is done on each device.
+	Operation string `json:"operation" validate:"required,oneof=accept reject remove rename tag untag"`
+	// UIDs are the UIDs of the devices.
+	UIDs []string `json:"uids" validate:"required_without=Filter,excluded_with=Filter,max=1000,unique,dive,required"`
+	// Filter is the list of filters the devices match.
+	Filter filter.FilterList `json:"filter" validate:"required_without=UIDs,excluded_with=UIDs"`
+	// Tag is the tag added or removed when the operation is tag or untag.
+	Tag string `json:"tag" validate:"omitempty,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Names are the devices' new names, by UID, when the operation is rename.
+	Names map[string]string `json:"names" validate:"omitempty,dive,required"`
+}
+
+// DeviceJobGet is the structure to represent the request data for get device job endpoint.
+type DeviceJobGet struct {
+	DeviceJobParam
+}
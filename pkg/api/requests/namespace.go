@@ -26,6 +26,18 @@ type NamespaceGet struct {
 	TenantParam
 }
 
+// NamespaceLookup is the structure to represent the request data for the internal lookup namespace endpoint.
+type NamespaceLookup struct {
+	Name string `query:"name" validate:"required"`
+}
+
+// NamespaceEvaluateAgentForwarding is the structure to represent the request data for the internal evaluate agent
+// forwarding endpoint.
+type NamespaceEvaluateAgentForwarding struct {
+	TenantParam
+	Username string `query:"username"`
+}
+
 // NamespaceDelete is the structure to represent the request data for delete namespace endpoint.
 type NamespaceDelete struct {
 	TenantParam
@@ -62,3 +74,22 @@ type SessionEditRecordStatus struct {
 	TenantParam
 	SessionRecord bool `json:"session_record"`
 }
+
+// NamespaceEditAgentForwarding is the structure to represent the request data for edit agent forwarding status endpoint.
+type NamespaceEditAgentForwarding struct {
+	TenantParam
+	AgentForwarding bool `json:"agent_forwarding"`
+}
+
+// NamespaceEditMetricsThresholds is the structure to represent the request data for edit devices' metrics thresholds
+// endpoint. The thresholds replace the namespace's ones.
+type NamespaceEditMetricsThresholds struct {
+	TenantParam
+	Thresholds []MetricsThreshold `json:"thresholds" validate:"max=6,unique=Metric,dive"`
+}
+
+// MetricsThreshold is a structure to represent and validate a threshold of the devices' metrics.
+type MetricsThreshold struct {
+	Metric string  `json:"metric" validate:"required,oneof=load1 load5 load15 memory disk temperature"`
+	Value  float64 `json:"value" validate:"min=0"`
+}
@@ -11,13 +11,21 @@ type PublicKeyGet struct {
 	TenantParam
 }
 
+type PublicKeyListByFingerprint struct {
+	FingerprintParam
+}
+
 type PublicKeyFilter struct {
-	Hostname string `json:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
+	Hostname string `json:"hostname,omitempty" validate:"required_without_all=Tags Group Labels,excluded_with=Tags Group Labels,regexp"`
 	// FIXME: add validation for tags when it has at least one item.
 	//
 	// If used `min=1` to do that validation, when tags is empty, its zero value, and only hostname is provided,
 	// it throws a error even with `required_without` and `excluded_with`.
-	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags []string `json:"tags,omitempty" validate:"required_without_all=Hostname Group Labels,excluded_with=Hostname Group Labels,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Group is the ID of the device group whose devices the public key can access.
+	Group string `json:"group,omitempty" validate:"required_without_all=Hostname Tags Labels,excluded_with=Hostname Tags Labels"`
+	// Labels are the labels a device must have, all of them, to be accessed by the public key.
+	Labels map[string]string `json:"labels,omitempty" validate:"required_without_all=Hostname Tags Group,excluded_with=Hostname Tags Group,omitempty,min=1,max=16,dive,keys,device_label,endkeys,required,max=255"`
 }
 
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
@@ -69,3 +77,15 @@ type PublicKeyAuth struct {
 	Fingerprint string `json:"fingerprint" validate:"required"`
 	Data        string `json:"data" validate:"required"`
 }
+
+// UserCertificateCreate is the structure to represent the request data for create user certificate endpoint.
+type UserCertificateCreate struct {
+	// PublicKey is the member's public key, in the authorized keys format, to be signed.
+	PublicKey string `json:"public_key" validate:"required"`
+	// Principals is the list of device's usernames the certificate is allowed to log in as.
+	Principals []string `json:"principals" validate:"required,min=1,max=32,unique,dive,required"`
+	// Validity is the certificate's lifetime in minutes.
+	Validity int `json:"validity" validate:"required,min=1,max=1440"`
+	// SourceAddresses restricts the addresses, IPs or CIDRs, from where the certificate can be used.
+	SourceAddresses []string `json:"source_addresses" validate:"omitempty,dive,cidr|ip"`
+}
@@ -1,5 +1,7 @@
 package requests
 
+import "time"
+
 // TagParam is a structure to represent and validate a tag as path param.
 type TagParam struct {
 	Tag string `param:"tag" validate:"required,min=3,max=255,alphanum,ascii,excludes=/@&:"`
@@ -20,3 +22,11 @@ type TagRename struct {
 	TagParam
 	NewTag string `json:"tag" validate:"required,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 }
+
+// TagAvailabilityGet is the structure to represent the request data for the tag's availability report endpoint. When
+// the period is not set, the last 30 days are reported.
+type TagAvailabilityGet struct {
+	TagParam
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
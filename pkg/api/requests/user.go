@@ -5,6 +5,11 @@ type UserParam struct {
 	ID string `param:"id" validate:"required"`
 }
 
+// UserSessionParam is a structure to represent and validate a user's session as path param.
+type UserSessionParam struct {
+	ID string `param:"session" validate:"required"`
+}
+
 // UserDataUpdate is the structure to represent the request body of the update user data endpoint.
 type UserDataUpdate struct {
 	UserParam
@@ -0,0 +1,32 @@
+package requests
+
+import "encoding/json"
+
+// WebAuthnCredentialParam is a structure to represent and validate a user's WebAuthn credential as path param.
+type WebAuthnCredentialParam struct {
+	ID string `param:"credential" validate:"required"`
+}
+
+// WebAuthnRegistrationFinish is the structure to represent the request body of the finish WebAuthn registration
+// endpoint.
+type WebAuthnRegistrationFinish struct {
+	// Ceremony is the ceremony's ID returned when the registration started.
+	Ceremony string `json:"ceremony" validate:"required"`
+	Name     string `json:"name" validate:"required,min=1,max=64"`
+	// Credential is the authenticator's response, as returned by `navigator.credentials.create`.
+	Credential json.RawMessage `json:"credential" validate:"required"`
+}
+
+// WebAuthnCredentialRename is the structure to represent the request body of the rename WebAuthn credential endpoint.
+type WebAuthnCredentialRename struct {
+	WebAuthnCredentialParam
+	Name string `json:"name" validate:"required,min=1,max=64"`
+}
+
+// WebAuthnLoginFinish is the structure to represent the request body of the finish WebAuthn login endpoints.
+type WebAuthnLoginFinish struct {
+	// Ceremony is the ceremony's ID returned when the login started.
+	Ceremony string `json:"ceremony" validate:"required"`
+	// Credential is the authenticator's assertion, as returned by `navigator.credentials.get`.
+	Credential json.RawMessage `json:"credential" validate:"required"`
+}
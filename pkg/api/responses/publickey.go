@@ -1,12 +1,16 @@
 package responses
 
 type PublicKeyFilter struct {
-	Hostname string `json:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
+	Hostname string `json:"hostname,omitempty" validate:"required_without_all=Tags Group Labels,excluded_with=Tags Group Labels,regexp"`
 	// FIXME: add validation for tags when it has at least one item.
 	//
 	// If used `min=1` to do that validation, when tags is empty, its zero value, and only hostname is provided,
 	// it throws a error even with `required_without` and `excluded_with`.
-	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Tags []string `json:"tags,omitempty" validate:"required_without_all=Hostname Group Labels,excluded_with=Hostname Group Labels,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Group is the ID of the device group whose devices the public key can access.
+	Group string `json:"group,omitempty" validate:"required_without_all=Hostname Tags Labels,excluded_with=Hostname Tags Labels"`
+	// Labels are the labels a device must have, all of them, to be accessed by the public key.
+	Labels map[string]string `json:"labels,omitempty" validate:"required_without_all=Hostname Tags Group,excluded_with=Hostname Tags Group,omitempty,min=1,max=16,dive,keys,device_label,endkeys,required,max=255"`
 }
 
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
@@ -9,4 +9,7 @@ type Cache interface {
 	Get(ctx context.Context, key string, value interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// Increment atomically increments the counter on key, creating it when missing, and sets its expire time to ttl.
+	// It returns the counter's value after the increment.
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/revdial"
 	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
@@ -30,6 +31,7 @@ func (m *ConnectionManager) Set(key string, conn *wsconnadapter.Adapter) {
 	dialer := revdial.NewDialer(conn, "/ssh/revdial")
 
 	m.dialers.Store(key, dialer)
+	connectedAgents.Inc()
 
 	if size := m.dialers.Size(key); size > 1 {
 		logrus.WithFields(logrus.Fields{
@@ -52,6 +54,7 @@ func (m *ConnectionManager) Set(key string, conn *wsconnadapter.Adapter) {
 				continue
 			case <-dialer.Done():
 				m.dialers.Delete(key, dialer)
+				connectedAgents.Dec()
 				m.DialerDoneCallback(key, dialer)
 
 				return
@@ -63,6 +66,8 @@ func (m *ConnectionManager) Set(key string, conn *wsconnadapter.Adapter) {
 func (m *ConnectionManager) Dial(ctx context.Context, key string) (net.Conn, error) {
 	dialer, ok := m.dialers.Load(key)
 	if !ok {
+		dialFailures.WithLabelValues(dialFailureNoConnection).Inc()
+
 		return nil, ErrNoConnection
 	}
 
@@ -73,5 +78,16 @@ func (m *ConnectionManager) Dial(ctx context.Context, key string) (net.Conn, err
 		}).Warning("Multiple connections found for the same identifier during reverse tunnel dialing.")
 	}
 
-	return dialer.(*revdial.Dialer).Dial(ctx)
+	start := time.Now()
+
+	conn, err := dialer.(*revdial.Dialer).Dial(ctx)
+	if err != nil {
+		dialFailures.WithLabelValues(dialFailureError).Inc()
+
+		return nil, err
+	}
+
+	dialDuration.Observe(time.Since(start).Seconds())
+
+	return conn, nil
 }
@@ -0,0 +1,18 @@
+package connman
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialNoConnection(t *testing.T) {
+	failures := testutil.ToFloat64(dialFailures.WithLabelValues(dialFailureNoConnection))
+
+	conn, err := New().Dial(context.Background(), "nonexistent")
+	assert.Nil(t, conn)
+	assert.Equal(t, ErrNoConnection, err)
+	assert.Equal(t, failures+1, testutil.ToFloat64(dialFailures.WithLabelValues(dialFailureNoConnection)))
+}
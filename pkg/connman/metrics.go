@@ -0,0 +1,38 @@
+package connman
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+)
+
+var (
+	connectedAgents = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "connman",
+		Name:      "connected_agents",
+		Help:      "Number of connections of the agents through reverse tunnels.",
+	})
+
+	dialDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "connman",
+		Name:      "dial_duration_seconds",
+		Help:      "Duration of the successful dials to the agents.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	dialFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "connman",
+		Name:      "dial_failures_total",
+		Help:      "Number of failed dials to the agents by reason.",
+	}, []string{"reason"})
+)
+
+const (
+	// dialFailureNoConnection is the reason of the dials to agents that are not connected.
+	dialFailureNoConnection = "no_connection"
+	// dialFailureError is the reason of the dials to connected agents that fail.
+	dialFailureError = "error"
+)
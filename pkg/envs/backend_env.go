@@ -7,16 +7,37 @@ import (
 	"github.com/sethvargo/go-envconfig"
 )
 
-type envBackend struct{}
+type envBackend struct {
+	// fallback looks up the values of the variables that are not set on the environment. When it is nil, only the
+	// environment is used.
+	fallback envconfig.Lookuper
+}
+
+// NewBackendWithFallback creates a backend that reads from the environment variables and, for the variables that are
+// not set on the environment, from values.
+func NewBackendWithFallback(values map[string]string) Backend {
+	return &envBackend{fallback: envconfig.MapLookuper(values)}
+}
 
 // envBackend is the default key/value store that reads from environment variables.
 func (b *envBackend) Get(name string) string {
-	return os.Getenv(name)
+	value, ok := os.LookupEnv(name)
+	if !ok && b.fallback != nil {
+		value, _ = b.fallback.Lookup(name)
+	}
+
+	return value
 }
 
 func (b *envBackend) Process(prefix string, spec interface{}) error {
-	return envconfig.ProcessWith(context.Background(), spec, envconfig.MultiLookuper(
+	lookupers := []envconfig.Lookuper{
 		envconfig.PrefixLookuper(prefix, envconfig.OsLookuper()),
 		envconfig.OsLookuper(),
-	))
+	}
+
+	if b.fallback != nil {
+		lookupers = append(lookupers, b.fallback)
+	}
+
+	return envconfig.ProcessWith(context.Background(), spec, envconfig.MultiLookuper(lookupers...))
 }
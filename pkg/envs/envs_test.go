@@ -361,3 +361,29 @@ func TestParse_with_required(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBackendWithFallback(t *testing.T) {
+	type Envs struct {
+		RedisURI string `env:"REDIS_URI,default=redis://redis:6379/default"`
+		MongoURI string `env:"MONGO_URI,default=mongodb://mongo:27017/default"`
+		Database string `env:"DATABASE,default=mongo"`
+	}
+
+	t.Setenv("SHELLHUB_REDIS_URI", "redis://redis:6379/env")
+
+	backend := NewBackendWithFallback(map[string]string{
+		"REDIS_URI": "redis://redis:6379/fallback",
+		"MONGO_URI": "mongodb://mongo:27017/fallback",
+	})
+
+	envs := new(Envs)
+	assert.NoError(t, backend.Process("SHELLHUB_", envs))
+	assert.Equal(t, &Envs{
+		RedisURI: "redis://redis:6379/env",
+		MongoURI: "mongodb://mongo:27017/fallback",
+		Database: "mongo",
+	}, envs)
+
+	assert.Equal(t, "mongodb://mongo:27017/fallback", backend.Get("MONGO_URI"))
+	assert.Equal(t, "", backend.Get("DATABASE"))
+}
@@ -5,13 +5,18 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func SetLogLevel() {
-	level := logrus.InfoLevel
-
+// defaultLogLevel returns the log level used when none is set, what is trace on development and info otherwise.
+func defaultLogLevel() logrus.Level {
 	if envs.DefaultBackend.Get("SHELLHUB_ENV") == "development" {
-		level = logrus.TraceLevel
+		return logrus.TraceLevel
 	}
 
+	return logrus.InfoLevel
+}
+
+func SetLogLevel() {
+	level := defaultLogLevel()
+
 	if env := envs.DefaultBackend.Get("SHELLHUB_LOG_LEVEL"); env != "" {
 		if v, err := logrus.ParseLevel(env); err == nil {
 			level = v
@@ -21,3 +26,20 @@ func SetLogLevel() {
 	logrus.WithField("log_level", level.String()).Info("Setting log level")
 	logrus.SetLevel(level)
 }
+
+// UpdateLogLevel sets the log level from its name. When the name is empty, the default log level is set, what is
+// trace on development and info otherwise.
+func UpdateLogLevel(name string) error {
+	level := defaultLogLevel()
+	if name != "" {
+		var err error
+		if level, err = logrus.ParseLevel(name); err != nil {
+			return err
+		}
+	}
+
+	logrus.WithField("log_level", level.String()).Info("Setting log level")
+	logrus.SetLevel(level)
+
+	return nil
+}
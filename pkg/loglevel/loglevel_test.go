@@ -57,3 +57,58 @@ func TestSetLevels(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateLogLevel(t *testing.T) {
+	mocks := &envMocks.Backend{}
+	envs.DefaultBackend = mocks
+
+	cases := []struct {
+		description   string
+		name          string
+		requiredMocks func()
+		expected      logrus.Level
+		err           bool
+	}{
+		{
+			description: "Set to the level from its name",
+			name:        "warning",
+			requiredMocks: func() {
+				mocks.On("Get", "SHELLHUB_ENV").Return("").Once()
+			},
+			expected: logrus.WarnLevel,
+		}, {
+			description: "Set to info when the name is empty",
+			name:        "",
+			requiredMocks: func() {
+				mocks.On("Get", "SHELLHUB_ENV").Return("").Once()
+			},
+			expected: logrus.InfoLevel,
+		}, {
+			description: "Set to trace when the name is empty and SHELLHUB_ENV is set to development",
+			name:        "",
+			requiredMocks: func() {
+				mocks.On("Get", "SHELLHUB_ENV").Return("development").Once()
+			},
+			expected: logrus.TraceLevel,
+		}, {
+			description: "Keep the level when the name is invalid",
+			name:        "invalid",
+			requiredMocks: func() {
+				mocks.On("Get", "SHELLHUB_ENV").Return("").Once()
+			},
+			expected: logrus.TraceLevel,
+			err:      true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := UpdateLogLevel(tc.name)
+			assert.Equal(t, tc.err, err != nil)
+			assert.Equal(t, tc.expected, logrus.GetLevel())
+		})
+	}
+
+	mocks.AssertExpectations(t)
+}
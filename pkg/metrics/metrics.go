@@ -0,0 +1,60 @@
+// Package metrics exposes the metrics of the ShellHub's services, in the OpenMetrics format, to be scraped by
+// Prometheus.
+//
+// Each package registers its own metrics on the default registry, prefixed by Namespace, so a service exposes the
+// metrics of every package it uses.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace is the prefix of the ShellHub's metrics.
+const Namespace = "shellhub"
+
+// Path is the HTTP path where the services expose their metrics.
+const Path = "/metrics"
+
+var httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: Namespace,
+	Subsystem: "http",
+	Name:      "request_duration_seconds",
+	Help:      "Duration of the HTTP requests by method, route and status code.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "code"})
+
+// Handler returns the HTTP handler that exposes the metrics registered on the default registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
+// Middleware observes the duration of the HTTP requests. The route is the path registered on the router, like
+// /api/devices/:uid, so path params do not create new series; requests that match no route have an empty one.
+//
+// As the status code is only known after the error is handled, an error returned by the next handler is handled here.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.Path() == Path {
+			return next(c)
+		}
+
+		start := time.Now()
+
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		httpRequestDuration.
+			WithLabelValues(c.Request().Method, c.Path(), strconv.Itoa(c.Response().Status)).
+			Observe(time.Since(start).Seconds())
+
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware)
+	e.GET("/devices/:uid", func(c echo.Context) error {
+		if c.Param("uid") == "missing" {
+			return echo.NewHTTPError(http.StatusNotFound)
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/failure", func(c echo.Context) error {
+		return errors.New("failure")
+	})
+	e.GET(Path, echo.WrapHandler(Handler()))
+
+	for _, path := range []string{"/devices/first", "/devices/second", "/devices/missing", "/failure", Path} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `shellhub_http_request_duration_seconds_count{code="200",method="GET",route="/devices/:uid"} 2`)
+	assert.Contains(t, rec.Body.String(), `shellhub_http_request_duration_seconds_count{code="404",method="GET",route="/devices/:uid"} 1`)
+	assert.Contains(t, rec.Body.String(), `shellhub_http_request_duration_seconds_count{code="500",method="GET",route="/failure"} 1`)
+	assert.NotContains(t, rec.Body.String(), `route="/metrics"`)
+}
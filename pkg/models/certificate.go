@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// CertificateAuthority is the SSH user certificate authority of a namespace.
+//
+// Each namespace has its own authority, used to sign short-lived OpenSSH user certificates for the public keys registered
+// on the namespace. Certificates signed by it are accepted by the ShellHub's SSH server and by the agents of the
+// namespace, to which the server forwards the certificate the client authenticated with.
+type CertificateAuthority struct {
+	// TenantID is the namespace's tenant that owns the authority.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Data is the PEM encoded private key of the authority.
+	Data []byte `json:"-" bson:"data"`
+	// PublicKey is the authority's public key in the authorized keys format.
+	PublicKey string `json:"public_key" bson:"public_key"`
+	// Fingerprint is the SHA256 fingerprint of the authority's public key.
+	Fingerprint string    `json:"fingerprint" bson:"fingerprint"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
+
+// UserCertificate is a short-lived OpenSSH user certificate signed by a namespace's CertificateAuthority.
+type UserCertificate struct {
+	// Certificate is the signed certificate in the authorized keys format.
+	Certificate string `json:"certificate"`
+	// Serial is the certificate's serial number.
+	Serial uint64 `json:"serial"`
+	// KeyID is the certificate's key identifier. It is the username of the member that requested it.
+	KeyID string `json:"key_id"`
+	// Principals is the list of device's usernames allowed by the certificate.
+	Principals []string `json:"principals"`
+	// ValidAfter is the time when the certificate starts to be valid.
+	ValidAfter time.Time `json:"valid_after"`
+	// ValidBefore is the time when the certificate expires.
+	ValidBefore time.Time `json:"valid_before"`
+}
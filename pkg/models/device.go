@@ -1,8 +1,14 @@
 package models
 
 import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	jwt "github.com/golang-jwt/jwt/v4"
 )
 
@@ -16,6 +22,15 @@ const (
 	DeviceStatusUnused   DeviceStatus = "unused"
 )
 
+// deviceLabelRegexp matches a device's label key. As the keys are used as the path of the label on filters, they
+// cannot contain dots.
+var deviceLabelRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,62}$`)
+
+// validateDeviceLabel validates the key of a device's label.
+func validateDeviceLabel(fl validator.FieldLevel) bool {
+	return deviceLabelRegexp.MatchString(fl.Field().String())
+}
+
 type Device struct {
 	// UID is the unique identifier for a device.
 	UID              string          `json:"uid"`
@@ -36,6 +51,218 @@ type Device struct {
 	PublicURL        bool            `json:"public_url" bson:"public_url,omitempty"`
 	PublicURLAddress string          `json:"public_url_address" bson:"public_url_address,omitempty"`
 	Acceptable       bool            `json:"acceptable" bson:"acceptable,omitempty"`
+	// PublicURLServices lists the device's local services published through public URLs, each one with its own
+	// address.
+	PublicURLServices []DevicePublicURLService `json:"public_url_services" bson:"public_url_services,omitempty"`
+	// PublishedPorts lists the device's local TCP ports published through the ShellHub's gateway.
+	PublishedPorts []DevicePublishedPort `json:"published_ports" bson:"published_ports,omitempty"`
+	// Labels are the device's key/value metadata, like site=lisbon, set by the users. They are the ones used by the
+	// public keys' and firewall rules' filters.
+	Labels map[string]string `json:"labels,omitempty" bson:"labels,omitempty"`
+	// AgentLabels are the labels reported by the device's agent. As the agent can report anything, they are only
+	// informative, kept apart from the labels set by the users and never used by filters.
+	AgentLabels map[string]string `json:"agent_labels,omitempty" bson:"agent_labels,omitempty"`
+	// Inventory is the latest inventory reported by the device's agent.
+	Inventory *DeviceInventory `json:"inventory,omitempty" bson:"inventory,omitempty"`
+}
+
+const (
+	DevicePublicURLSchemeHTTP  = "http"
+	DevicePublicURLSchemeHTTPS = "https"
+)
+
+// DevicePublicURLService is a device's local service published through a public URL.
+type DevicePublicURLService struct {
+	// Address is the generated address used as the device part of the public URL.
+	Address string `json:"address" bson:"address"`
+	// Host is the host, relative to the device, where the service is listening. An empty host means the device itself.
+	Host string `json:"host" bson:"host,omitempty"`
+	// Port is the port where the service is listening.
+	Port int `json:"port" bson:"port"`
+	// Scheme is the protocol used to talk to the service; either http or https.
+	Scheme string `json:"scheme" bson:"scheme"`
+	// InsecureSkipVerify disables the verification of the service's certificate when Scheme is https, what is
+	// useful for devices with self-signed certificates.
+	InsecureSkipVerify bool `json:"insecure_skip_verify" bson:"insecure_skip_verify,omitempty"`
+	// Access controls who can reach the service.
+	Access DevicePublicURLAccess `json:"access" bson:"access"`
+}
+
+const (
+	DevicePublicURLAccessPublic    = "public"
+	DevicePublicURLAccessLogin     = "login"
+	DevicePublicURLAccessBasic     = "basic"
+	DevicePublicURLAccessAllowlist = "allowlist"
+)
+
+// DevicePublicURLAccess controls who can reach a device's public URL service.
+type DevicePublicURLAccess struct {
+	// Mode is how the access is controlled: public, login (namespace members only), basic (HTTP basic authentication)
+	// or allowlist (source networks only). An empty mode means public.
+	Mode string `json:"mode" bson:"mode,omitempty"`
+	// Username is the user expected on basic authentication.
+	Username string `json:"username,omitempty" bson:"username,omitempty"`
+	// Password is the hashed password expected on basic authentication.
+	Password string `json:"-" bson:"password,omitempty"`
+	// AllowedCIDRs lists the networks, or single addresses, allowed to reach the service on allowlist mode.
+	AllowedCIDRs []string `json:"allowed_cidrs,omitempty" bson:"allowed_cidrs,omitempty"`
+}
+
+// Allows checks if the address is inside one of the allowed networks.
+func (a *DevicePublicURLAccess) Allows(address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range a.AllowedCIDRs {
+		if _, network, err := net.ParseCIDR(allowed); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		if other := net.ParseIP(allowed); other != nil && other.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Target returns the host:port pair where the service is listening.
+func (s *DevicePublicURLService) Target() string {
+	return net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+}
+
+// PublicURLService returns the device's public URL service identified by address. The legacy device's public URL
+// address resolves to the device's HTTP server on port 80 when the public URL is enabled.
+func (d *Device) PublicURLService(address string) (*DevicePublicURLService, bool) {
+	for i := range d.PublicURLServices {
+		if d.PublicURLServices[i].Address == address {
+			return &d.PublicURLServices[i], true
+		}
+	}
+
+	if d.PublicURL && d.PublicURLAddress != "" && d.PublicURLAddress == address {
+		return &DevicePublicURLService{
+			Address: d.PublicURLAddress,
+			Port:    80,
+			Scheme:  DevicePublicURLSchemeHTTP,
+		}, true
+	}
+
+	return nil, false
+}
+
+// DevicePublishedPort is a device's local TCP port published through the ShellHub's gateway.
+type DevicePublishedPort struct {
+	// Address is the generated address used as the hostname's prefix to reach the port.
+	Address string `json:"address" bson:"address"`
+	// Host is the host, relative to the device, where the port is open. An empty host means the device itself.
+	Host string `json:"host" bson:"host,omitempty"`
+	// Port is the published port.
+	Port int `json:"port" bson:"port"`
+	// GatewayPort is the gateway's port allocated to reach the published port through TLS without its hostname. It is
+	// zero when the gateway doesn't allocate ports to the published ports.
+	GatewayPort int `json:"gateway_port,omitempty" bson:"gateway_port,omitempty"`
+}
+
+// Target returns the host:port pair where the port is open.
+func (p *DevicePublishedPort) Target() string {
+	return net.JoinHostPort(p.Host, strconv.Itoa(p.Port))
+}
+
+// PublishedPort returns the device's published port identified by address.
+func (d *Device) PublishedPort(address string) (*DevicePublishedPort, bool) {
+	for i := range d.PublishedPorts {
+		if d.PublishedPorts[i].Address == address {
+			return &d.PublishedPorts[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// PublishedPortByGatewayPort returns the device's published port reached through the gateway's port.
+func (d *Device) PublishedPortByGatewayPort(port int) (*DevicePublishedPort, bool) {
+	for i := range d.PublishedPorts {
+		if port != 0 && d.PublishedPorts[i].GatewayPort == port {
+			return &d.PublishedPorts[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// PortRange is an inclusive range of TCP ports, like 20000-20099.
+type PortRange struct {
+	First int
+	Last  int
+}
+
+// ParsePortRange parses a range of TCP ports written as first-last.
+func ParsePortRange(value string) (*PortRange, error) {
+	first, last, ok := strings.Cut(value, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid port range: %s", value)
+	}
+
+	r := new(PortRange)
+
+	var err error
+	if r.First, err = strconv.Atoi(strings.TrimSpace(first)); err != nil {
+		return nil, fmt.Errorf("invalid port range: %s", value)
+	}
+
+	if r.Last, err = strconv.Atoi(strings.TrimSpace(last)); err != nil {
+		return nil, fmt.Errorf("invalid port range: %s", value)
+	}
+
+	if r.First < 1 || r.Last > 65535 || r.First > r.Last {
+		return nil, fmt.Errorf("invalid port range: %s", value)
+	}
+
+	return r, nil
+}
+
+// Contains reports whether port is in the range.
+func (r *PortRange) Contains(port int) bool {
+	return port >= r.First && port <= r.Last
+}
+
+// DevicePublishedPortClaims are the claims of the short-lived token that allows a namespace's member to connect to a
+// device's published port.
+type DevicePublishedPortClaims struct {
+	// ID is the user's ID.
+	ID string `json:"id"`
+	// Tenant is the tenant ID of the device's namespace.
+	Tenant string `json:"tenant"`
+	// Address is the address of the published port.
+	Address string `json:"address"`
+
+	AuthClaims           `mapstruct:",squash"`
+	jwt.RegisteredClaims `mapstruct:",squash"`
+}
+
+func (d *DevicePublishedPortClaims) SetRegisteredClaims(claims jwt.RegisteredClaims) {
+	d.RegisteredClaims = claims
+}
+
+// DevicePublishedPortToken is the short-lived token that allows a connection to a device's published port.
+type DevicePublishedPortToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DevicePublishedPortCertificate is the short-lived X.509 client certificate, signed by the namespace's certificate
+// authority, that allows a connection to a device's published port through TLS.
+type DevicePublishedPortCertificate struct {
+	// Certificate is the PEM encoded certificate.
+	Certificate string    `json:"certificate"`
+	ExpiresAt   time.Time `json:"expires_at"`
 }
 
 type DeviceAuthClaims struct {
@@ -52,6 +279,8 @@ func (d *DeviceAuthClaims) SetRegisteredClaims(claims jwt.RegisteredClaims) {
 type DeviceAuthRequest struct {
 	Info     *DeviceInfo `json:"info"`
 	Sessions []string    `json:"sessions,omitempty"`
+	// Labels are the labels reported by the agent, kept as the device's agent labels.
+	Labels map[string]string `json:"labels,omitempty"`
 	*DeviceAuth
 }
 
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// DeviceOnlineTimeout is how long a device is kept online without a heartbeat, as the TTL of the connected devices.
+const DeviceOnlineTimeout = 2 * time.Minute
+
+// DeviceConnectivityEvent records a transition of a device between online and offline.
+type DeviceConnectivityEvent struct {
+	UID       string    `json:"uid" bson:"uid"`
+	TenantID  string    `json:"tenant_id" bson:"tenant_id"`
+	Online    bool      `json:"online" bson:"online"`
+	Timestamp time.Time `json:"timestamp" bson:"timestamp"`
+}
+
+// DeviceOutage is a period, within the report's range, the device was offline.
+type DeviceOutage struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Duration is the outage's duration in seconds.
+	Duration int64 `json:"duration"`
+	// Ongoing is true when the device was still offline at the end of the report's range.
+	Ongoing bool `json:"ongoing"`
+}
+
+// DeviceAvailability is the availability report of a device over a range of time.
+//
+// Only the time covered by the device's connectivity events is accounted, so the period before its first event, like
+// before it was added, counts neither as uptime nor as downtime.
+type DeviceAvailability struct {
+	UID  string    `json:"uid"`
+	Name string    `json:"name"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Availability is the percentage of the accounted time the device was online.
+	Availability float64 `json:"availability"`
+	// Uptime is the time, in seconds, the device was online.
+	Uptime int64 `json:"uptime"`
+	// Downtime is the time, in seconds, the device was offline.
+	Downtime int64          `json:"downtime"`
+	Outages  []DeviceOutage `json:"outages"`
+	// MTTR is the mean time, in seconds, to recover from the outages that are not ongoing.
+	MTTR int64 `json:"mttr"`
+}
+
+// TagAvailability is the availability report of the devices with a tag over a range of time.
+type TagAvailability struct {
+	Tag  string    `json:"tag"`
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Availability is the percentage of the accounted time, of all devices, they were online.
+	Availability float64 `json:"availability"`
+	Uptime       int64   `json:"uptime"`
+	Downtime     int64   `json:"downtime"`
+	// Outages is the number of outages of all devices.
+	Outages int `json:"outages"`
+	// MTTR is the mean time, in seconds, to recover from the outages of all devices that are not ongoing.
+	MTTR    int64                `json:"mttr"`
+	Devices []DeviceAvailability `json:"devices"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// DeviceGroup is a named device filter, saved on a namespace, that lists the devices matching it when evaluated.
+type DeviceGroup struct {
+	ID       string `json:"id,omitempty" bson:"_id,omitempty"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// CreatedBy is the ID of the user that created the group.
+	CreatedBy         string    `json:"created_by" bson:"created_by"`
+	CreatedAt         time.Time `json:"created_at" bson:"created_at"`
+	DeviceGroupFields `bson:",inline"`
+}
+
+type DeviceGroupFields struct {
+	// Name identifies the group on its namespace.
+	Name string `json:"name" bson:"name"`
+	// Filter is the list of filters the group's devices match.
+	Filter []Filter `json:"filter" bson:"filter"`
+	// SortBy is the device's property the group's devices are sorted by.
+	SortBy string `json:"sort_by" bson:"sort_by"`
+	// OrderBy is the sort's order, asc or desc.
+	OrderBy   string    `json:"order_by" bson:"order_by"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+type DeviceGroupUpdate struct {
+	DeviceGroupFields `bson:",inline"`
+}
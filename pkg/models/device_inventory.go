@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// DeviceInventory is the hardware and software inventory of a device, reported periodically by its agent.
+type DeviceInventory struct {
+	// ReportedAt is when the inventory was received by the server.
+	ReportedAt time.Time             `json:"reported_at" bson:"reported_at"`
+	Kernel     string                `json:"kernel" bson:"kernel"`
+	CPU        DeviceInventoryCPU    `json:"cpu" bson:"cpu"`
+	Memory     DeviceInventoryMemory `json:"memory" bson:"memory"`
+	Disks      []DeviceInventoryDisk `json:"disks" bson:"disks"`
+	// Interfaces lists the device's network interfaces, except the loopback ones.
+	Interfaces []DeviceInventoryInterface `json:"interfaces" bson:"interfaces"`
+	// Uptime is the time, in seconds, since the device booted.
+	Uptime uint64 `json:"uptime" bson:"uptime"`
+	// Packages is the number of packages installed through the device's package manager.
+	Packages int `json:"packages" bson:"packages"`
+}
+
+type DeviceInventoryCPU struct {
+	Model string `json:"model" bson:"model"`
+	Count int    `json:"count" bson:"count"`
+}
+
+// DeviceInventoryMemory is the device's memory, in bytes.
+type DeviceInventoryMemory struct {
+	Total     uint64 `json:"total" bson:"total"`
+	Available uint64 `json:"available" bson:"available"`
+}
+
+// DeviceInventoryDisk is a device's mounted disk, with its sizes in bytes.
+type DeviceInventoryDisk struct {
+	Device     string `json:"device" bson:"device"`
+	Mountpoint string `json:"mountpoint" bson:"mountpoint"`
+	Filesystem string `json:"filesystem" bson:"filesystem"`
+	Total      uint64 `json:"total" bson:"total"`
+	Used       uint64 `json:"used" bson:"used"`
+}
+
+type DeviceInventoryInterface struct {
+	Name      string   `json:"name" bson:"name"`
+	MAC       string   `json:"mac" bson:"mac"`
+	Addresses []string `json:"addresses" bson:"addresses"`
+	// Primary indicates the interface used as the device's identity.
+	Primary bool `json:"primary" bson:"primary"`
+}
+
+// DeviceInventorySnapshot is an inventory kept in the device's inventory history.
+type DeviceInventorySnapshot struct {
+	UID             string `json:"uid" bson:"uid"`
+	TenantID        string `json:"tenant_id" bson:"tenant_id"`
+	DeviceInventory `bson:",inline"`
+}
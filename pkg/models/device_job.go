@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// DeviceJobOperation is the operation a device job runs on each of its devices.
+type DeviceJobOperation string
+
+const (
+	DeviceJobOperationAccept DeviceJobOperation = "accept"
+	DeviceJobOperationReject DeviceJobOperation = "reject"
+	DeviceJobOperationRemove DeviceJobOperation = "remove"
+	DeviceJobOperationRename DeviceJobOperation = "rename"
+	DeviceJobOperationTag    DeviceJobOperation = "tag"
+	DeviceJobOperationUntag  DeviceJobOperation = "untag"
+)
+
+type DeviceJobStatus string
+
+const (
+	DeviceJobStatusPending  DeviceJobStatus = "pending"
+	DeviceJobStatusRunning  DeviceJobStatus = "running"
+	DeviceJobStatusFinished DeviceJobStatus = "finished"
+)
+
+type DeviceJobItemStatus string
+
+const (
+	DeviceJobItemStatusPending   DeviceJobItemStatus = "pending"
+	DeviceJobItemStatusSucceeded DeviceJobItemStatus = "succeeded"
+	DeviceJobItemStatusFailed    DeviceJobItemStatus = "failed"
+)
+
+// DeviceJobItem is the result of a device job's operation on a device.
+type DeviceJobItem struct {
+	UID string `json:"uid" bson:"uid"`
+	// Name is the device's new name, when the operation is rename.
+	Name   string              `json:"name,omitempty" bson:"name,omitempty"`
+	Status DeviceJobItemStatus `json:"status" bson:"status"`
+	// Error is why the operation failed on the device.
+	Error string `json:"error,omitempty" bson:"error,omitempty"`
+}
+
+// DeviceJob is an operation, like accepting or tagging, run in background on many devices of a namespace.
+type DeviceJob struct {
+	ID       string `json:"id" bson:"_id"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// CreatedBy is the ID of the user that created the job, whose role is evaluated on each device.
+	CreatedBy string             `json:"created_by" bson:"created_by"`
+	Operation DeviceJobOperation `json:"operation" bson:"operation"`
+	// Tag is the tag added or removed, when the operation is tag or untag.
+	Tag        string          `json:"tag,omitempty" bson:"tag,omitempty"`
+	Status     DeviceJobStatus `json:"status" bson:"status"`
+	Items      []DeviceJobItem `json:"items" bson:"items"`
+	CreatedAt  time.Time       `json:"created_at" bson:"created_at"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+}
@@ -0,0 +1,172 @@
+package models
+
+import "time"
+
+// DeviceMetric is a device's health metric.
+type DeviceMetric string
+
+const (
+	DeviceMetricLoad1       DeviceMetric = "load1"
+	DeviceMetricLoad5       DeviceMetric = "load5"
+	DeviceMetricLoad15      DeviceMetric = "load15"
+	DeviceMetricMemory      DeviceMetric = "memory"
+	DeviceMetricDisk        DeviceMetric = "disk"
+	DeviceMetricTemperature DeviceMetric = "temperature"
+)
+
+// DeviceMetrics is a sample of the device's health metrics, pushed periodically by its agent.
+type DeviceMetrics struct {
+	Load1  float64 `json:"load1" bson:"load1"`
+	Load5  float64 `json:"load5" bson:"load5"`
+	Load15 float64 `json:"load15" bson:"load15"`
+	// Memory is the percentage of the device's memory in use.
+	Memory float64 `json:"memory" bson:"memory"`
+	// Disk is the percentage of the device's root filesystem in use.
+	Disk float64 `json:"disk" bson:"disk"`
+	// Temperature is the highest temperature, in Celsius, of the device's thermal zones. It is nil when the device
+	// does not report it.
+	Temperature *float64 `json:"temperature,omitempty" bson:"temperature,omitempty"`
+}
+
+// Value returns the value of the metric on the sample, and false when the sample does not have it.
+func (m *DeviceMetrics) Value(metric DeviceMetric) (float64, bool) {
+	switch metric {
+	case DeviceMetricLoad1:
+		return m.Load1, true
+	case DeviceMetricLoad5:
+		return m.Load5, true
+	case DeviceMetricLoad15:
+		return m.Load15, true
+	case DeviceMetricMemory:
+		return m.Memory, true
+	case DeviceMetricDisk:
+		return m.Disk, true
+	case DeviceMetricTemperature:
+		if m.Temperature == nil {
+			return 0, false
+		}
+
+		return *m.Temperature, true
+	default:
+		return 0, false
+	}
+}
+
+// DeviceMetricsResolution is the period of time aggregated by each bucket of the device's metrics.
+type DeviceMetricsResolution string
+
+const (
+	DeviceMetricsResolutionMinute DeviceMetricsResolution = "minute"
+	DeviceMetricsResolutionHour   DeviceMetricsResolution = "hour"
+	DeviceMetricsResolutionDay    DeviceMetricsResolution = "day"
+)
+
+// DeviceMetricsResolutions lists the resolutions every sample is aggregated into.
+var DeviceMetricsResolutions = []DeviceMetricsResolution{
+	DeviceMetricsResolutionMinute,
+	DeviceMetricsResolutionHour,
+	DeviceMetricsResolutionDay,
+}
+
+// Period returns the period of time aggregated by each bucket of the resolution.
+func (r DeviceMetricsResolution) Period() time.Duration {
+	switch r {
+	case DeviceMetricsResolutionHour:
+		return time.Hour
+	case DeviceMetricsResolutionDay:
+		return 24 * time.Hour
+	default:
+		return time.Minute
+	}
+}
+
+// Retention returns how long the buckets of the resolution are kept.
+func (r DeviceMetricsResolution) Retention() time.Duration {
+	switch r {
+	case DeviceMetricsResolutionHour:
+		return 30 * 24 * time.Hour
+	case DeviceMetricsResolutionDay:
+		return 365 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// DeviceMetricsRollup identifies the bucket, of a resolution, where a sample is aggregated.
+type DeviceMetricsRollup struct {
+	Resolution DeviceMetricsResolution
+	// Timestamp is the start of the bucket's period.
+	Timestamp time.Time
+	// ExpiresAt is when the bucket is removed.
+	ExpiresAt time.Time
+}
+
+// DeviceMetricsBucket aggregates the device's metrics samples received within a period of its resolution.
+type DeviceMetricsBucket struct {
+	UID        string                  `json:"uid" bson:"uid"`
+	TenantID   string                  `json:"tenant_id" bson:"tenant_id"`
+	Resolution DeviceMetricsResolution `json:"resolution" bson:"resolution"`
+	Timestamp  time.Time               `json:"timestamp" bson:"timestamp"`
+	ExpiresAt  time.Time               `json:"expires_at" bson:"expires_at"`
+	Samples    int                     `json:"samples" bson:"samples"`
+	// TemperatureSamples is the number of samples with the temperature.
+	TemperatureSamples int           `json:"temperature_samples" bson:"temperature_samples"`
+	Sum                DeviceMetrics `json:"sum" bson:"sum"`
+	Max                DeviceMetrics `json:"max" bson:"max"`
+}
+
+// Point returns the averages and the maximums of the bucket's samples.
+func (b *DeviceMetricsBucket) Point() DeviceMetricsPoint {
+	point := DeviceMetricsPoint{
+		Timestamp: b.Timestamp,
+		Samples:   b.Samples,
+		Max:       b.Max,
+	}
+
+	if b.Samples > 0 {
+		samples := float64(b.Samples)
+
+		point.Average = DeviceMetrics{
+			Load1:  b.Sum.Load1 / samples,
+			Load5:  b.Sum.Load5 / samples,
+			Load15: b.Sum.Load15 / samples,
+			Memory: b.Sum.Memory / samples,
+			Disk:   b.Sum.Disk / samples,
+		}
+	}
+
+	if b.TemperatureSamples > 0 && b.Sum.Temperature != nil {
+		temperature := *b.Sum.Temperature / float64(b.TemperatureSamples)
+		point.Average.Temperature = &temperature
+	}
+
+	return point
+}
+
+// DeviceMetricsPoint is a point of the device's metrics time series.
+type DeviceMetricsPoint struct {
+	Timestamp time.Time     `json:"timestamp"`
+	Samples   int           `json:"samples"`
+	Average   DeviceMetrics `json:"average"`
+	Max       DeviceMetrics `json:"max"`
+}
+
+// DeviceMetricsThreshold raises an alert when a device's metric is above its value.
+type DeviceMetricsThreshold struct {
+	Metric DeviceMetric `json:"metric" bson:"metric"`
+	Value  float64      `json:"value" bson:"value"`
+}
+
+// DeviceAlert is raised when a device's metric goes above the namespace's threshold, and resolved when it goes back.
+type DeviceAlert struct {
+	ID       string       `json:"id" bson:"_id"`
+	UID      string       `json:"uid" bson:"uid"`
+	TenantID string       `json:"tenant_id" bson:"tenant_id"`
+	Metric   DeviceMetric `json:"metric" bson:"metric"`
+	// Value is the metric's value that raised the alert.
+	Value     float64   `json:"value" bson:"value"`
+	Threshold float64   `json:"threshold" bson:"threshold"`
+	RaisedAt  time.Time `json:"raised_at" bson:"raised_at"`
+	// ResolvedAt is when the alert was resolved. It is nil while the alert is active.
+	ResolvedAt *time.Time `json:"resolved_at" bson:"resolved_at"`
+}
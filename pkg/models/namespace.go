@@ -44,6 +44,11 @@ func (n *Namespace) FindMember(id string) (*Member, bool) {
 
 type NamespaceSettings struct {
 	SessionRecord bool `json:"session_record" bson:"session_record,omitempty"`
+	// AgentForwarding defines if the namespace's members, whose role allows it, can forward their SSH agent to the
+	// devices.
+	AgentForwarding bool `json:"agent_forwarding" bson:"agent_forwarding,omitempty"`
+	// MetricsThresholds are the thresholds of the devices' metrics that raise alerts.
+	MetricsThresholds []DeviceMetricsThreshold `json:"metrics_thresholds" bson:"metrics_thresholds,omitempty"`
 }
 
 type Member struct {
@@ -9,10 +9,13 @@ import (
 
 // PublicKeyFilter contains the filter rule of a Public Key.
 //
-// A PublicKeyFilter can contain either Hostname, string, or Tags, slice of strings never both.
+// A PublicKeyFilter can contain either Hostname, string, Tags, slice of strings, Group, the ID of a device group, or
+// Labels, the labels a device must have, never more than one of them.
 type PublicKeyFilter struct {
-	Hostname string   `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without=Tags,excluded_with=Tags,regexp"`
-	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Hostname string            `json:"hostname,omitempty" bson:"hostname,omitempty" validate:"required_without_all=Tags Group Labels,excluded_with=Tags Group Labels,regexp"`
+	Tags     []string          `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without_all=Hostname Group Labels,excluded_with=Hostname Group Labels,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	Group    string            `json:"group,omitempty" bson:"group,omitempty" validate:"required_without_all=Hostname Tags Labels,excluded_with=Hostname Tags Labels"`
+	Labels   map[string]string `json:"labels,omitempty" bson:"labels,omitempty" validate:"required_without_all=Hostname Tags Group,excluded_with=Hostname Tags Group,omitempty,min=1,max=16,dive,keys,device_label,endkeys,required,max=255"`
 }
 
 type PublicKeyFields struct {
@@ -30,6 +33,8 @@ func (p *PublicKeyFields) Validate() error {
 		return err == nil
 	})
 
+	_ = v.RegisterValidation("device_label", validateDeviceLabel)
+
 	return v.Struct(p)
 }
 
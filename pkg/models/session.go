@@ -16,6 +16,7 @@ type Session struct {
 	TenantID      string          `json:"tenant_id" bson:"tenant_id"`
 	Username      string          `json:"username"`
 	IPAddress     string          `json:"ip_address" bson:"ip_address"`
+	IPNumber      string          `json:"-" bson:"ip_number,omitempty"`
 	StartedAt     time.Time       `json:"started_at" bson:"started_at"`
 	LastSeen      time.Time       `json:"last_seen" bson:"last_seen"`
 	Active        bool            `json:"active" bson:",omitempty"`
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// SigningKey is a RSA key used to sign the API's tokens.
+//
+// The keys are rotated: a new key signs the new tokens, while the retired ones keep verifying the tokens they signed
+// until they expire.
+type SigningKey struct {
+	// ID is the key's identifier, set as the "kid" header of the tokens it signs. It is the RFC 7638 thumbprint of the
+	// key.
+	ID string `json:"kid" bson:"id"`
+	// Data is the PEM encoded private key. It is empty for the key loaded from the API's key file, which is recorded
+	// only to be retired.
+	Data      []byte    `json:"-" bson:"data,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// RetiredAt is when the key was replaced by a new one. A retired key doesn't sign tokens anymore.
+	RetiredAt *time.Time `json:"retired_at,omitempty" bson:"retired_at,omitempty"`
+	// ExpiresAt is when a retired key stops verifying tokens.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty"`
+}
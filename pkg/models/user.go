@@ -90,6 +90,9 @@ type UserAuthRequest struct {
 	// TODO: change json tag from username to identifier and update the OpenAPI.
 	Identifier UserAuthIdentifier `json:"username"`
 	Password   string             `json:"password"`
+
+	// UserAgent is the user agent of the client, stored on the session created by the login.
+	UserAgent string `json:"-"`
 }
 
 type UserAuthResponse struct {
@@ -101,6 +104,10 @@ type UserAuthResponse struct {
 	Role   string `json:"role"`
 	Email  string `json:"email"`
 	MFA    MFA    `json:"mfa" bson:"mfa"`
+
+	// RefreshToken is the opaque token used to get a new access token when Token expires. Each refresh token can be
+	// used only once.
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 type UserAuthClaims struct {
@@ -109,6 +116,7 @@ type UserAuthClaims struct {
 	Tenant               string `json:"tenant"`
 	ID                   string `json:"id"`
 	Role                 string `json:"role"`
+	SessionID            string `json:"sid,omitempty"`
 	AuthClaims           `mapstruct:",squash"`
 	MFA                  MFA `json:"mfa"`
 	jwt.RegisteredClaims `mapstruct:",squash"`
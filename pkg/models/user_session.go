@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// UserSession is a user's login, identified by its refresh token.
+//
+// Each login issues a short-lived access token and an opaque refresh token. The refresh token is rotated on every use,
+// so when an old one is used again, the login is considered stolen and revoked.
+type UserSession struct {
+	// ID is the session's identifier. It is also set on the access tokens issued by the session.
+	ID string `json:"id" bson:"id"`
+	// UserID is the ID of the user that owns the session.
+	UserID string `json:"-" bson:"user_id"`
+	// TenantID is the namespace's tenant the access tokens are issued to.
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// RefreshTokenHash is the SHA256 hash of the current refresh token's secret.
+	RefreshTokenHash string `json:"-" bson:"refresh_token_hash"`
+	// RotatedRefreshTokenHashes are the SHA256 hashes of the latest refresh tokens' secrets replaced by the rotation,
+	// whose use means the session was stolen.
+	RotatedRefreshTokenHashes []string `json:"-" bson:"rotated_refresh_token_hashes,omitempty"`
+	// MFA indicates if the user has validated the MFA on the session.
+	MFA bool `json:"-" bson:"mfa"`
+	// IPAddress is the address the session was created from.
+	IPAddress string `json:"ip_address" bson:"ip_address"`
+	// UserAgent is the user agent the session was created from.
+	UserAgent  string    `json:"user_agent" bson:"user_agent"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at" bson:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at" bson:"expires_at"`
+	// Current indicates if the session is the one used by the request. It is not stored.
+	Current bool `json:"current" bson:"-"`
+}
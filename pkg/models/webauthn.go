@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// WebAuthnCredential is a WebAuthn credential, like a security key or a passkey, registered by a user.
+//
+// The credentials are used as a second factor after the password or, when discoverable by the authenticator, to login
+// without the password.
+type WebAuthnCredential struct {
+	// ID is the credential's identifier on ShellHub.
+	ID string `json:"id" bson:"id"`
+	// UserID is the ID of the user that owns the credential.
+	UserID string `json:"-" bson:"user_id"`
+	// Name is a name chosen by the user to identify the credential.
+	Name string `json:"name" bson:"name"`
+	// CredentialID is the identifier generated by the authenticator.
+	CredentialID []byte `json:"-" bson:"credential_id"`
+	// PublicKey is the COSE encoded public key that verifies the credential's assertions.
+	PublicKey       []byte   `json:"-" bson:"public_key"`
+	AttestationType string   `json:"-" bson:"attestation_type"`
+	Transports      []string `json:"transports" bson:"transports"`
+	AAGUID          []byte   `json:"-" bson:"aaguid"`
+	// SignCount is the authenticator's signature counter on the last assertion. A counter lower than the stored one
+	// indicates a cloned authenticator.
+	SignCount uint32 `json:"sign_count" bson:"sign_count"`
+	// BackupEligible indicates if the credential could be synced between devices, like a passkey.
+	BackupEligible bool       `json:"backup_eligible" bson:"backup_eligible"`
+	BackupState    bool       `json:"-" bson:"backup_state"`
+	CreatedAt      time.Time  `json:"created_at" bson:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+}
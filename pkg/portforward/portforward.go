@@ -0,0 +1,200 @@
+// Package portforward forwards local TCP connections to devices' ports published through the ShellHub's gateway.
+package portforward
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrURLInvalid is returned when the published port's URL is not an HTTP, HTTPS or TLS one.
+	ErrURLInvalid = errors.New("the published port's URL must be an http, https or tls one with the tls' port")
+	// ErrCredentials is returned when the credential required by the published port's URL is missing.
+	ErrCredentials = errors.New("a token is required by the http and https URLs and a certificate by the tls ones")
+	// ErrUpgrade is returned when the gateway refuses to upgrade the connection to the tcp protocol.
+	ErrUpgrade = errors.New("failed to upgrade the connection to the published port")
+)
+
+// conn is a connection to a published port whose bytes already read by the HTTP's response are read first.
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// Config is the configuration used to connect to a published port.
+type Config struct {
+	// Token is the short-lived token issued to connect to the port, sent as a bearer token on the http and https URLs.
+	Token string
+	// Certificate is the client certificate issued to connect to the port, presented on the tls URLs.
+	Certificate *tls.Certificate
+	// InsecureSkipVerify skips the verification of the gateway's certificate, like the self-signed one the gateway
+	// generates when it has none.
+	InsecureSkipVerify bool
+}
+
+// ConfigFunc returns the configuration used to open each new connection, so its credentials can be renewed while the
+// connections are accepted.
+type ConfigFunc func() (*Config, error)
+
+// Dial connects to the device's port published at rawURL.
+//
+// Through an http or https URL, like https://<address>.tcp.shellhub.io, the config's token is sent as a bearer token
+// and the connection is upgraded to the tcp protocol. Through a tls URL, like tls://<address>.tcp.shellhub.io:4433 or
+// tls://shellhub.io:20000, the config's client certificate is presented. In both cases, the connection's bytes are the
+// port's ones.
+func Dial(ctx context.Context, rawURL string, config *Config) (net.Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Join(ErrURLInvalid, err)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: config.InsecureSkipVerify, // nolint:gosec
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	var dialer interface {
+		DialContext(ctx context.Context, network, address string) (net.Conn, error)
+	}
+
+	port := u.Port()
+	switch u.Scheme {
+	case "http":
+		if port == "" {
+			port = "80"
+		}
+
+		dialer = new(net.Dialer)
+	case "https":
+		if port == "" {
+			port = "443"
+		}
+
+		dialer = &tls.Dialer{Config: tlsConfig}
+	case "tls":
+		if port == "" {
+			return nil, ErrURLInvalid
+		}
+
+		if config.Certificate == nil {
+			return nil, ErrCredentials
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{*config.Certificate}
+
+		// NOTICE: the gateway reaches the published port by the hostname or the port, so the TLS connection is already
+		// the port's one, without the HTTP upgrade.
+		return (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	default:
+		return nil, ErrURLInvalid
+	}
+
+	if config.Token == "" {
+		return nil, ErrCredentials
+	}
+
+	c, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+	req.Header.Set("Authorization", "Bearer "+config.Token)
+
+	if err := req.Write(c); err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	reader := bufio.NewReader(c)
+
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		defer res.Body.Close()
+		defer c.Close()
+
+		// NOTICE: the gateway replies the reason of the refusal as a short text.
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+
+		return nil, fmt.Errorf("%w: %s: %s", ErrUpgrade, res.Status, strings.TrimSpace(string(msg)))
+	}
+
+	return &conn{Conn: c, reader: reader}, nil
+}
+
+// Serve accepts the connections on listener, forwarding each one to a new connection to the device's port published
+// at rawURL. As the credentials are checked when each connection is opened, the config is got again for each one, so
+// the expired credentials can be replaced while the connections are accepted. It returns when the listener is closed.
+func Serve(ctx context.Context, listener net.Listener, rawURL string, config ConfigFunc) error {
+	for {
+		local, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			defer local.Close()
+
+			cfg, err := config()
+			if err != nil {
+				log.WithError(err).WithField("url", rawURL).Error("failed to get the published port's credentials")
+
+				return
+			}
+
+			remote, err := Dial(ctx, rawURL, cfg)
+			if err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"local": local.RemoteAddr().String(),
+					"url":   rawURL,
+				}).Error("failed to connect to the published port")
+
+				return
+			}
+
+			defer remote.Close()
+
+			done := make(chan struct{}, 2)
+			go func() {
+				io.Copy(remote, local) // nolint:errcheck
+				done <- struct{}{}
+			}()
+			go func() {
+				io.Copy(local, remote) // nolint:errcheck
+				done <- struct{}{}
+			}()
+
+			<-done
+		}()
+	}
+}
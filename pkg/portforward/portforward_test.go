@@ -0,0 +1,223 @@
+package portforward
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// gateway emulates the gateway's published port, what echoes the bytes sent by the client once upgraded.
+func gateway(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "tcp" {
+			http.Error(w, "the connection must be upgraded to the tcp protocol", http.StatusUpgradeRequired)
+
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+
+			return
+		}
+
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n") // nolint:errcheck
+		buf.Flush()                                                                                        // nolint:errcheck
+
+		io.Copy(conn, buf) // nolint:errcheck
+	}))
+}
+
+// certificate generates a self-signed certificate, used by both the gateway and the client.
+func certificate(t *testing.T) *tls.Certificate {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	assert.NoError(t, err)
+
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: priv}
+}
+
+// tlsGateway emulates the gateway's TLS listener, what echoes the bytes sent by the clients presenting a certificate.
+func tlsGateway(t *testing.T, cert *tls.Certificate) net.Listener {
+	t.Helper()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{ // nolint:gosec
+		Certificates: []tls.Certificate{*cert},
+		ClientAuth:   tls.RequireAnyClientCert,
+	})
+	assert.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				io.Copy(conn, conn) // nolint:errcheck
+			}()
+		}
+	}()
+
+	return listener
+}
+
+func TestDial(t *testing.T) {
+	server := gateway(t)
+	defer server.Close()
+
+	cert := certificate(t)
+
+	listener := tlsGateway(t, cert)
+	defer listener.Close()
+
+	cases := []struct {
+		description string
+		url         string
+		config      *Config
+		expected    error
+	}{
+		{
+			description: "fails when the URL is not an HTTP or TLS one",
+			url:         "tcp://localhost",
+			config:      &Config{Token: "token"},
+			expected:    ErrURLInvalid,
+		},
+		{
+			description: "fails when the TLS URL has no port",
+			url:         "tls://localhost",
+			config:      &Config{Certificate: cert},
+			expected:    ErrURLInvalid,
+		},
+		{
+			description: "fails when the token is missing",
+			url:         server.URL,
+			config:      &Config{},
+			expected:    ErrCredentials,
+		},
+		{
+			description: "fails when the certificate is missing",
+			url:         "tls://" + listener.Addr().String(),
+			config:      &Config{Token: "token"},
+			expected:    ErrCredentials,
+		},
+		{
+			description: "fails when the token is invalid",
+			url:         server.URL,
+			config:      &Config{Token: "invalid"},
+			expected:    ErrUpgrade,
+		},
+		{
+			description: "succeeds when the token is valid",
+			url:         server.URL,
+			config:      &Config{Token: "token"},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when the certificate is presented",
+			url:         "tls://" + listener.Addr().String(),
+			config:      &Config{Certificate: cert, InsecureSkipVerify: true},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			conn, err := Dial(context.Background(), tc.url, tc.config)
+			if tc.expected != nil {
+				assert.ErrorIs(t, err, tc.expected)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("ping\n"))
+			assert.NoError(t, err)
+
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			assert.NoError(t, err)
+			assert.Equal(t, "ping\n", line)
+		})
+	}
+}
+
+func TestServe(t *testing.T) {
+	server := gateway(t)
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	// NOTICE: the first connection is opened with an expired token, and the next ones with the renewed token.
+	var connections atomic.Int32
+	config := func() (*Config, error) {
+		if connections.Add(1) == 1 {
+			return &Config{Token: "expired"}, nil
+		}
+
+		return &Config{Token: "token"}, nil
+	}
+
+	go Serve(context.Background(), listener, server.URL, config) // nolint:errcheck
+	defer listener.Close()
+
+	t.Run("fails when the token is expired", func(t *testing.T) {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		_, err = bufio.NewReader(conn).ReadString('\n')
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("succeeds when the token is renewed", func(t *testing.T) {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		assert.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("ping\n"))
+		assert.NoError(t, err)
+
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		assert.NoError(t, err)
+		assert.Equal(t, "ping\n", line)
+	})
+}
@@ -0,0 +1,99 @@
+// Package sshcert checks OpenSSH user certificates signed by a namespace's certificate authority.
+//
+// It is used by both the ShellHub's SSH server and the agent to authenticate clients that present a certificate, instead
+// of a plain public key, during the public key authentication.
+package sshcert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// SourceAddressOption is the critical option that restricts the addresses from where a certificate can be used.
+const SourceAddressOption = "source-address"
+
+var (
+	ErrNotUserCertificate = errors.New("certificate is not a user certificate")
+	ErrUnknownAuthority   = errors.New("certificate signed by an unknown authority")
+	ErrSourceAddress      = errors.New("remote address is not allowed by the certificate")
+)
+
+// ParseAuthority parses a certificate authority's public key in the authorized keys format.
+func ParseAuthority(data string) (gossh.PublicKey, error) {
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(data)) //nolint:dogsled
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Check checks if cert is a user certificate signed by authority that allows the principal at the current time.
+//
+// When the certificate has the source-address critical option, the remote address must be one of the allowed addresses.
+// If remote is nil, the source-address check is skipped; it should only be nil when the caller does not know the real
+// client's address, what happens when the connection is proxied.
+func Check(authority gossh.PublicKey, cert *gossh.Certificate, principal string, remote net.Addr) error {
+	if cert.CertType != gossh.UserCert {
+		return ErrNotUserCertificate
+	}
+
+	if !bytes.Equal(cert.SignatureKey.Marshal(), authority.Marshal()) {
+		return ErrUnknownAuthority
+	}
+
+	checker := &gossh.CertChecker{
+		SupportedCriticalOptions: []string{SourceAddressOption},
+		Clock:                    clock.Now,
+	}
+
+	if err := checker.CheckCert(principal, cert); err != nil {
+		return err
+	}
+
+	if addresses, ok := cert.CriticalOptions[SourceAddressOption]; ok && remote != nil {
+		return checkSourceAddress(remote, addresses)
+	}
+
+	return nil
+}
+
+// checkSourceAddress checks if remote is within one of the comma separated IPs or CIDRs in addresses.
+func checkSourceAddress(remote net.Addr, addresses string) error {
+	host, _, err := net.SplitHostPort(remote.String())
+	if err != nil {
+		host = remote.String()
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%w: %s", ErrSourceAddress, remote.String())
+	}
+
+	for _, address := range strings.Split(addresses, ",") {
+		if allowed := net.ParseIP(address); allowed != nil {
+			if allowed.Equal(ip) {
+				return nil
+			}
+
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(address)
+		if err != nil {
+			return err
+		}
+
+		if network.Contains(ip) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrSourceAddress, remote.String())
+}
@@ -0,0 +1,113 @@
+package sshcert
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestCheck(t *testing.T) {
+	_, authorityKey, _ := ed25519.GenerateKey(rand.Reader)
+	authority, _ := gossh.NewSignerFromKey(authorityKey)
+
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	other, _ := gossh.NewSignerFromKey(otherKey)
+
+	userPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	user, _ := gossh.NewPublicKey(userPub)
+
+	sign := func(signer gossh.Signer, certType uint32, before time.Time, options map[string]string) *gossh.Certificate {
+		cert := &gossh.Certificate{
+			Key:             user,
+			CertType:        certType,
+			ValidPrincipals: []string{"root"},
+			ValidAfter:      uint64(time.Now().Add(-time.Minute).Unix()),
+			ValidBefore:     uint64(before.Unix()),
+			Permissions:     gossh.Permissions{CriticalOptions: options},
+		}
+
+		if err := cert.SignCert(rand.Reader, signer); err != nil {
+			t.Fatal(err)
+		}
+
+		return cert
+	}
+
+	cases := []struct {
+		description string
+		cert        *gossh.Certificate
+		principal   string
+		remote      net.Addr
+		expected    func(error)
+	}{
+		{
+			description: "fails when certificate is not a user certificate",
+			cert:        sign(authority, gossh.HostCert, time.Now().Add(time.Hour), nil),
+			principal:   "root",
+			expected: func(err error) {
+				assert.ErrorIs(t, err, ErrNotUserCertificate)
+			},
+		},
+		{
+			description: "fails when certificate is signed by another authority",
+			cert:        sign(other, gossh.UserCert, time.Now().Add(time.Hour), nil),
+			principal:   "root",
+			expected: func(err error) {
+				assert.ErrorIs(t, err, ErrUnknownAuthority)
+			},
+		},
+		{
+			description: "fails when principal is not allowed",
+			cert:        sign(authority, gossh.UserCert, time.Now().Add(time.Hour), nil),
+			principal:   "admin",
+			expected: func(err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			description: "fails when certificate has expired",
+			cert:        sign(authority, gossh.UserCert, time.Now().Add(-time.Second), nil),
+			principal:   "root",
+			expected: func(err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			description: "fails when remote address is not allowed",
+			cert:        sign(authority, gossh.UserCert, time.Now().Add(time.Hour), map[string]string{SourceAddressOption: "10.0.0.0/8,192.168.1.1"}),
+			principal:   "root",
+			remote:      &net.TCPAddr{IP: net.ParseIP("192.168.1.2"), Port: 22},
+			expected: func(err error) {
+				assert.ErrorIs(t, err, ErrSourceAddress)
+			},
+		},
+		{
+			description: "succeeds when remote address is allowed",
+			cert:        sign(authority, gossh.UserCert, time.Now().Add(time.Hour), map[string]string{SourceAddressOption: "10.0.0.0/8,192.168.1.1"}),
+			principal:   "root",
+			remote:      &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 22},
+			expected: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			description: "succeeds when certificate is valid",
+			cert:        sign(authority, gossh.UserCert, time.Now().Add(time.Hour), nil),
+			principal:   "root",
+			expected: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.expected(Check(authority.PublicKey(), tc.cert, tc.principal, tc.remote))
+		})
+	}
+}
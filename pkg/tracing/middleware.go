@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware traces the HTTP requests, continuing the trace received on their headers. The span is named by the route
+// registered on the router, like /api/devices/:uid, and has the tenant of the request when the gateway set it.
+//
+// As the status code is only known after the error is handled, an error returned by the next handler is handled here.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+
+		ctx, span := Tracer().Start(
+			Extract(req.Context(), propagation.HeaderCarrier(req.Header)),
+			req.Method+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPMethod(req.Method), semconv.HTTPRoute(c.Path())),
+		)
+		defer span.End()
+
+		if tenant := req.Header.Get("X-Tenant-ID"); tenant != "" {
+			span.SetAttributes(TenantID(tenant))
+		}
+
+		c.SetRequest(req.WithContext(ctx))
+
+		if err := next(c); err != nil {
+			span.RecordError(err)
+
+			c.Error(err)
+		}
+
+		status := c.Response().Status
+		span.SetAttributes(semconv.HTTPStatusCode(status))
+
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return nil
+	}
+}
+
+// Transport wraps the base transport to trace the HTTP requests and to send their trace context to the server. When
+// base is nil, http.DefaultTransport is used.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(base)
+}
@@ -0,0 +1,112 @@
+// Package tracing traces the requests across the ShellHub's services with OpenTelemetry, exporting the spans through
+// OTLP.
+//
+// The trace context is always propagated, through the W3C Trace Context headers, even when the exporter of a service is
+// disabled, so a trace is not broken by a service that does not export its spans.
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExporterOTLP exports the spans through OTLP over HTTP.
+const ExporterOTLP = "otlp"
+
+// instrumentation is the name of the tracer used by the ShellHub's packages.
+const instrumentation = "github.com/shellhub-io/shellhub"
+
+// ErrExporterUnknown is returned when the exporter is not supported.
+var ErrExporterUnknown = errors.New("unknown tracing exporter")
+
+// Config is the configuration of the tracing of a service.
+type Config struct {
+	// Exporter is the exporter of the spans. Only ExporterOTLP is supported. When empty, the spans are not exported.
+	Exporter string `env:"TRACING_EXPORTER,default="`
+	// Endpoint is the host and port of the OTLP collector.
+	Endpoint string `env:"TRACING_ENDPOINT,default=localhost:4318"`
+	// Insecure sends the spans to the collector without TLS.
+	Insecure bool `env:"TRACING_INSECURE,default=false"`
+	// SampleRatio is the ratio, from 0 to 1, of the traces started by the service that are sampled. The traces started
+	// by other services follow their sampling decision.
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO,default=1"`
+}
+
+// Start configures the propagation of the trace context and, when the exporter is set, the export of the spans of the
+// service. It returns the function that flushes the spans not exported yet and stops the exporter.
+func Start(ctx context.Context, service string, cfg *Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	switch cfg.Exporter {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+	default:
+		return nil, ErrExporterUnknown
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(service))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the ShellHub's spans.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentation)
+}
+
+// Inject writes the trace context of ctx to the carrier.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+}
+
+// Extract returns a copy of ctx with the trace context read from the carrier.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+const (
+	deviceUIDKey  = attribute.Key("shellhub.device.uid")
+	tenantIDKey   = attribute.Key("shellhub.tenant.id")
+	sessionUIDKey = attribute.Key("shellhub.session.uid")
+)
+
+// DeviceUID returns the attribute of the device's UID.
+func DeviceUID(uid string) attribute.KeyValue {
+	return deviceUIDKey.String(uid)
+}
+
+// TenantID returns the attribute of the namespace's tenant.
+func TenantID(tenant string) attribute.KeyValue {
+	return tenantIDKey.String(tenant)
+}
+
+// SessionUID returns the attribute of the session's UID.
+func SessionUID(uid string) attribute.KeyValue {
+	return sessionUIDKey.String(uid)
+}
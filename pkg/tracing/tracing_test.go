@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestStart(t *testing.T) {
+	shutdown, err := Start(context.TODO(), "test", &Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.TODO()))
+
+	_, err = Start(context.TODO(), "test", &Config{Exporter: "unknown"})
+	assert.ErrorIs(t, err, ErrExporterUnknown)
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	_, err := Start(context.TODO(), "test", &Config{})
+	assert.NoError(t, err)
+
+	// upstream receives the requests sent by the handler, which must continue the trace.
+	var traceparent string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+	}))
+	defer upstream.Close()
+
+	e := echo.New()
+	e.Use(Middleware)
+	e.GET("/devices/:uid", func(c echo.Context) error {
+		req, _ := http.NewRequestWithContext(c.Request().Context(), http.MethodGet, upstream.URL, nil)
+
+		res, err := (&http.Client{Transport: Transport(nil)}).Do(req)
+		if err != nil {
+			return err
+		}
+
+		res.Body.Close()
+
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/devices/uid", nil)
+	req.Header.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	req.Header.Set("X-Tenant-ID", "tenant")
+
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	assert.Equal(t, 2, len(spans))
+
+	server := spans[1]
+	assert.Equal(t, "GET /devices/:uid", server.Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	assert.Contains(t, server.Attributes(), TenantID("tenant"))
+	assert.Contains(t, server.Attributes(), attribute.Int("http.status_code", http.StatusInternalServerError))
+	assert.Equal(t, codes.Error, server.Status().Code)
+
+	client := spans[0]
+	assert.Equal(t, server.SpanContext().SpanID(), client.Parent().SpanID())
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+client.SpanContext().SpanID().String()+"-01", traceparent)
+}
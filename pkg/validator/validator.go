@@ -35,6 +35,8 @@ const (
 	UserPasswordTag = "password"
 	// DeviceNameTag contains the rule to validate the device's name.
 	DeviceNameTag = "device_name"
+	// DeviceLabelTag contains the rule to validate the key of a device's label.
+	DeviceLabelTag = "device_label"
 )
 
 // Rules is a slice that contains all validation rules.
@@ -76,6 +78,13 @@ var Rules = []Rule{
 		},
 		Error: fmt.Errorf("the device name can only contain `_`, `-` and alpha numeric characters"),
 	},
+	{
+		Tag: DeviceLabelTag,
+		Handler: func(field validator.FieldLevel) bool {
+			return regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_]{0,62}$`).MatchString(field.Field().String())
+		},
+		Error: fmt.Errorf("the label key must start with a letter and can only contain `_` and alpha numeric characters, up to 63"),
+	},
 }
 
 // Validator is the ShellHub validator.
@@ -265,3 +265,46 @@ func TestDeviceName(t *testing.T) {
 		})
 	}
 }
+
+func TestDeviceLabel(t *testing.T) {
+	tests := []struct {
+		description string
+		labels      map[string]string
+		want        bool
+	}{
+		{
+			description: "failed when the label key starts with a number",
+			labels:      map[string]string{"1site": "lisbon"},
+			want:        false,
+		},
+		{
+			description: "failed when the label key contains a dot",
+			labels:      map[string]string{"site.name": "lisbon"},
+			want:        false,
+		},
+		{
+			description: "failed when the label value is empty",
+			labels:      map[string]string{"site": ""},
+			want:        false,
+		},
+		{
+			description: "success when the labels are valid",
+			labels:      map[string]string{"site": "lisbon", "Owner_Team": "team-x", "serial": "SN 0001/A"},
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				Labels map[string]string `validate:"dive,keys,device_label,endkeys,required,max=255"`
+			}{
+				Labels: tt.labels,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
@@ -2,17 +2,21 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"runtime"
+	"strconv"
 
 	"github.com/labstack/echo-contrib/pprof"
 	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
+	"github.com/shellhub-io/shellhub/ssh/pkg/publicurl"
+	"github.com/shellhub-io/shellhub/ssh/pkg/publishedport"
 	sshTunnel "github.com/shellhub-io/shellhub/ssh/pkg/tunnel"
 	"github.com/shellhub-io/shellhub/ssh/server"
 	"github.com/shellhub-io/shellhub/ssh/server/handler"
@@ -33,6 +37,10 @@ func main() {
 		log.WithError(err).Fatal("Failed to load environment variables")
 	}
 
+	if _, err := tracing.Start(context.Background(), "ssh", &env.Tracing); err != nil {
+		log.WithError(err).Warn("Failed to start the tracing; the spans are not exported.")
+	}
+
 	// NOTICE: This redis is used by the web terminal to store its session tokens.
 	if err := cache.ConnectRedis(env.RedisURI); err != nil {
 		log.WithError(err).Fatal("Failed to connect to redis")
@@ -45,6 +53,16 @@ func main() {
 		log.Fatal("failed to create internal client")
 	}
 
+	// NOTICE: this cache keeps the clients authorized to access devices' services published through public URLs.
+	publicURLCache, err := storecache.NewRedisCache(env.RedisURI)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to connect to redis")
+	}
+
+	authorizer := publicurl.NewAuthorizer(tunnel.API, publicURLCache)
+	proxy := publicurl.NewProxy(tunnel.Dial)
+	forwarder := publishedport.NewForwarder(tunnel.API, tunnel.Dial)
+
 	router := tunnel.GetRouter()
 	router.POST("/sessions/:uid/close", func(c echo.Context) error {
 		exit := func(status int, err error) error {
@@ -89,40 +107,37 @@ func main() {
 			return c.String(code, msg)
 		}
 
-		dev, err := tunnel.API.GetDeviceByPublicURLAddress(c.Request().Header.Get("X-Public-URL-Address"))
+		address := c.Request().Header.Get("X-Public-URL-Address")
+
+		dev, err := tunnel.API.GetDeviceByPublicURLAddress(address)
 		if err != nil {
 			return replyError(err, "failed to get device data", http.StatusInternalServerError)
 		}
 
-		if !dev.PublicURL {
+		service, ok := dev.PublicURLService(address)
+		if !ok {
 			return replyError(err, "this device is not accessible via public URL", http.StatusForbidden)
 		}
 
-		in, err := tunnel.Dial(c.Request().Context(), dev.UID)
-		if err != nil {
-			return replyError(err, "failed to connect to device", http.StatusInternalServerError)
-		}
-
-		defer in.Close()
-
-		if err := c.Request().Write(in); err != nil {
-			return replyError(err, "failed to write request to device", http.StatusInternalServerError)
+		if ok, err := authorizer.Authorize(c, service); err != nil {
+			return replyError(err, "failed to authorize the access to the device", http.StatusInternalServerError)
+		} else if !ok {
+			return nil
 		}
 
-		ctr := http.NewResponseController(c.Response())
-		out, _, err := ctr.Hijack()
-		if err != nil {
-			return replyError(err, "failed to hijack response", http.StatusInternalServerError)
-		}
+		// NOTICE: these headers tell the agent where the published service is listening, overwriting anything sent
+		// by the client.
+		c.Request().Header.Set("X-Public-URL-Target", service.Target())
+		c.Request().Header.Set("X-Public-URL-Scheme", service.Scheme)
+		c.Request().Header.Set("X-Public-URL-Insecure-Skip-Verify", strconv.FormatBool(service.InsecureSkipVerify))
 
-		defer out.Close()
-		if _, err := io.Copy(out, in); errors.Is(err, io.ErrUnexpectedEOF) {
-			return replyError(err, "failed to copy response from device service to client", http.StatusInternalServerError)
-		}
+		proxy.Forward(c.Response(), c.Request(), dev.UID)
 
 		return nil
 	})
 
+	router.GET("/ssh/tcp", forwarder.Forward)
+
 	// TODO: add `/ws/ssh` route to OpenAPI repository.
 	router.GET("/ws/ssh", echo.WrapHandler(web.HandlerRestoreSession(web.RestoreSession, handler.WebSession)))
 	router.POST("/ws/ssh", echo.WrapHandler(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
@@ -133,6 +148,10 @@ func main() {
 		return c.String(http.StatusOK, "OK")
 	})
 
+	router.Use(tracing.Middleware)
+	router.Use(metrics.Middleware)
+	router.GET(metrics.Path, echo.WrapHandler(metrics.Handler()))
+
 	if envs.IsDevelopment() {
 		runtime.SetBlockProfileRate(1)
 		pprof.Register(router)
@@ -142,5 +161,20 @@ func main() {
 
 	go http.ListenAndServe(":8080", router) // nolint:errcheck
 
+	// NOTICE: the published ports are also reached through TLS, without the HTTP upgrade, when the listener by the SNI
+	// is enabled or when the gateway's ports are allocated to them.
+	if portsEnv, err := envs.Parse[publishedport.Options](); err != nil {
+		log.WithError(err).Fatal("Failed to load the published ports' environment variables")
+	} else if portsEnv.TLS || portsEnv.Range != "" {
+		ports, err := publishedport.NewServer(forwarder, portsEnv)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to create the published ports' server")
+		}
+
+		go func() {
+			log.WithError(ports.ListenAndServe(":4433")).Fatal("Failed to serve the published ports")
+		}()
+	}
+
 	log.Fatal(server.NewServer(env, tunnel.Tunnel).ListenAndServe())
 }
@@ -0,0 +1,63 @@
+// Package conntrace traces the connections to the ShellHub's SSH server.
+//
+// Each connection has a span, from its beginning until it is closed, which is the parent of the spans of its
+// authentication, its requests to the API and its dials to the device, continued by the agent.
+package conntrace
+
+import (
+	"context"
+	"net"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// span is the key to store and restore the connection's span from the context.
+const span = "conntrace_span"
+
+// Start starts the span of the connection, which ends when the connection is closed.
+func Start(ctx gliderssh.Context, conn net.Conn) {
+	_, s := tracing.Tracer().Start(ctx, "ssh connection",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("net.sock.peer.addr", conn.RemoteAddr().String())),
+	)
+
+	ctx.SetValue(span, s)
+
+	go func() {
+		<-ctx.Done()
+
+		s.End()
+	}()
+}
+
+// Span returns the span of the connection. When the connection has no span, a span that records nothing is returned.
+func Span(ctx gliderssh.Context) trace.Span {
+	if s, ok := ctx.Value(span).(trace.Span); ok {
+		return s
+	}
+
+	return trace.SpanFromContext(context.Background())
+}
+
+// Context returns a copy of ctx with the span of the connection.
+func Context(ctx gliderssh.Context) context.Context {
+	return trace.ContextWithSpan(ctx, Span(ctx))
+}
+
+// Detached returns a context with only the span of the connection, what is not canceled when the connection is closed.
+func Detached(ctx gliderssh.Context) context.Context {
+	return trace.ContextWithSpan(context.Background(), Span(ctx))
+}
+
+// SetDevice sets the device reached by the connection, and the session's UID, on the span of the connection.
+func SetDevice(ctx gliderssh.Context, device *models.Device) {
+	Span(ctx).SetAttributes(
+		tracing.DeviceUID(device.UID),
+		tracing.TenantID(device.TenantID),
+		tracing.SessionUID(ctx.SessionID()),
+	)
+}
@@ -0,0 +1,136 @@
+// Package jump lets the ShellHub's SSH server act as a standard jump host, like `ssh -J gateway user@device.namespace`.
+//
+// A jump login is a connection whose username isn't a SSHID. It authenticates with a public key registered on
+// ShellHub, and it is only allowed to open direct-tcpip channels to devices of the namespaces where the key is
+// registered. Each of these channels is served as a new connection to the same server, what authenticates the user on
+// the device as usual, with the SSHID built from the channel's destination.
+package jump
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// keys is the key to store and restore the public keys used by a jump login from the context.
+	keys = "jump_keys"
+
+	// destination is the key to store and restore the destination of a connection opened through a jump login.
+	destination = "jump_destination"
+)
+
+var ErrInvalidDestination = errors.New("destination must be in the device.namespace format")
+
+// Destination is the device reached through a jump login.
+type Destination struct {
+	// Namespace is the device's namespace name.
+	Namespace string
+	// Name is the device's name.
+	Name string
+}
+
+// ParseDestination parses the host requested through a direct-tcpip channel, in the device.namespace format, the
+// reverse of a SSHID, as DNS names are.
+func ParseDestination(host string) (*Destination, error) {
+	name, namespace, ok := strings.Cut(host, ".")
+	if !ok || name == "" || namespace == "" {
+		return nil, ErrInvalidDestination
+	}
+
+	return &Destination{Namespace: strings.ToLower(namespace), Name: strings.ToLower(name)}, nil
+}
+
+// IsLogin checks if the sshid sent by the client is a jump login, what is a username without the SSHID's "@".
+func IsLogin(sshid string) bool {
+	return !strings.Contains(sshid, "@")
+}
+
+// StoreKeys stores on the context the public keys registered on ShellHub that authenticated a jump login.
+func StoreKeys(ctx gliderssh.Context, value []models.PublicKey) {
+	ctx.SetValue(keys, value)
+}
+
+// RestoreKeys restores the public keys that authenticated a jump login. It returns nil when the connection isn't a
+// jump login.
+func RestoreKeys(ctx gliderssh.Context) []models.PublicKey {
+	value, _ := ctx.Value(keys).([]models.PublicKey)
+
+	return value
+}
+
+// Allows checks if the jump login authenticated by the keys can reach the devices of the namespace identified by tenant.
+func Allows(keys []models.PublicKey, tenant string) bool {
+	for _, key := range keys {
+		if key.TenantID == tenant {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SSHID returns the SSHID of the connection. For connections opened through a jump login, it is built from the
+// username and the channel's destination; otherwise, it is the username itself.
+func SSHID(ctx gliderssh.Context) string {
+	dest, ok := ctx.Value(destination).(*Destination)
+	if !ok {
+		return ctx.User()
+	}
+
+	user, _, _ := strings.Cut(ctx.User(), "@")
+
+	return user + "@" + dest.Namespace + "." + dest.Name
+}
+
+// ConnCallback stores on the context the destination of the connections opened through a jump login, to be used as
+// the server's [gliderssh.ConnCallback].
+func ConnCallback(ctx gliderssh.Context, conn net.Conn) net.Conn {
+	if c, ok := conn.(*Conn); ok {
+		ctx.SetValue(destination, c.destination)
+	}
+
+	return conn
+}
+
+// Conn is a direct-tcpip channel opened through a jump login, served as a connection to the SSH server.
+type Conn struct {
+	gossh.Channel
+	local       net.Addr
+	remote      net.Addr
+	destination *Destination
+}
+
+var _ net.Conn = (*Conn)(nil)
+
+// NewConn wraps the channel as a connection to destination, keeping the addresses of the jump login's connection.
+func NewConn(channel gossh.Channel, local, remote net.Addr, destination *Destination) *Conn {
+	return &Conn{Channel: channel, local: local, remote: remote, destination: destination}
+}
+
+func (c *Conn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+// NOTICE: channels don't support deadlines; the timeouts are enforced by the jump login's connection.
+
+func (c *Conn) SetDeadline(_ time.Time) error {
+	return nil
+}
+
+func (c *Conn) SetReadDeadline(_ time.Time) error {
+	return nil
+}
+
+func (c *Conn) SetWriteDeadline(_ time.Time) error {
+	return nil
+}
@@ -0,0 +1,126 @@
+package jump
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"io"
+	"net"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestParseDestination(t *testing.T) {
+	type Expected struct {
+		destination *Destination
+		err         error
+	}
+
+	cases := []struct {
+		description string
+		host        string
+		expected    Expected
+	}{
+		{
+			description: "fails when the host has no namespace",
+			host:        "device",
+			expected:    Expected{nil, ErrInvalidDestination},
+		},
+		{
+			description: "fails when the device is empty",
+			host:        ".namespace",
+			expected:    Expected{nil, ErrInvalidDestination},
+		},
+		{
+			description: "succeeds when the host names a device",
+			host:        "Device.Namespace",
+			expected:    Expected{&Destination{Namespace: "namespace", Name: "device"}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			destination, err := ParseDestination(tc.host)
+			assert.Equal(t, tc.expected, Expected{destination, err})
+		})
+	}
+}
+
+func TestIsLogin(t *testing.T) {
+	assert.True(t, IsLogin("user"))
+	assert.False(t, IsLogin("user@namespace.device"))
+}
+
+func TestAllows(t *testing.T) {
+	keys := []models.PublicKey{{TenantID: "00000000-0000-4000-0000-000000000000"}}
+
+	assert.True(t, Allows(keys, "00000000-0000-4000-0000-000000000000"))
+	assert.False(t, Allows(keys, "00000000-0000-4000-0000-000000000001"))
+	assert.False(t, Allows(nil, "00000000-0000-4000-0000-000000000000"))
+}
+
+// channel is a gossh.Channel over a network connection.
+type channel struct {
+	net.Conn
+}
+
+func (c *channel) CloseWrite() error {
+	return c.Close()
+}
+
+func (c *channel) SendRequest(_ string, _ bool, _ []byte) (bool, error) {
+	return false, nil
+}
+
+func (c *channel) Stderr() io.ReadWriter {
+	return c.Conn
+}
+
+func TestSSHID(t *testing.T) {
+	sshids := make(chan string, 1)
+
+	server := &gliderssh.Server{
+		ConnCallback: ConnCallback,
+		PasswordHandler: func(ctx gliderssh.Context, _ string) bool {
+			sshids <- SSHID(ctx)
+
+			return false
+		},
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	signer, err := gossh.NewSignerFromKey(key)
+	assert.NoError(t, err)
+
+	server.AddHostKey(signer)
+
+	// NOTICE: the channel is simulated by a TCP connection, as both sides of the handshake write before reading.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	defer listener.Close()
+
+	client, err := net.Dial("tcp", listener.Addr().String())
+	assert.NoError(t, err)
+
+	defer client.Close()
+
+	jumped, err := listener.Accept()
+	assert.NoError(t, err)
+
+	go server.HandleConn(NewConn(&channel{jumped}, jumped.LocalAddr(), jumped.RemoteAddr(), &Destination{Namespace: "namespace", Name: "device"}))
+
+	_, _, _, err = gossh.NewClientConn(client, "device.namespace:22", &gossh.ClientConfig{
+		User:            "root",
+		Auth:            []gossh.AuthMethod{gossh.Password("password")},
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint:gosec
+	})
+	assert.Error(t, err)
+
+	assert.Equal(t, "root@namespace.device", <-sshids)
+}
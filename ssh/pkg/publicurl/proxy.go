@@ -0,0 +1,85 @@
+package publicurl
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DialFunc opens a connection to the device identified by its UID.
+type DialFunc func(ctx context.Context, uid string) (net.Conn, error)
+
+type deviceKey struct{}
+
+// Proxy forwards the requests to devices' public URL services through the devices' tunnels.
+//
+// The connections to a device are kept alive and reused between requests, and upgraded connections, like websockets,
+// and streamed responses are forwarded as they are.
+type Proxy struct {
+	proxy *httputil.ReverseProxy
+}
+
+// NewProxy creates a new [Proxy] that reaches the devices through dial.
+func NewProxy(dial DialFunc) *Proxy {
+	transport := &http.Transport{
+		// NOTICE: the outgoing request's host is the device's UID, so the connections are pooled by device.
+		DialContext: func(ctx context.Context, _, addr string) (net.Conn, error) {
+			uid, _, err := net.SplitHostPort(addr)
+			if err != nil {
+				return nil, err
+			}
+
+			return dial(ctx, uid)
+		},
+		MaxIdleConnsPerHost:   16,
+		IdleConnTimeout:       90 * time.Second,
+		ResponseHeaderTimeout: 60 * time.Second,
+	}
+
+	return &Proxy{
+		proxy: &httputil.ReverseProxy{
+			Rewrite:       rewrite,
+			Transport:     transport,
+			FlushInterval: -1,
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				log.WithError(err).WithFields(log.Fields{
+					"device":  r.Context().Value(deviceKey{}),
+					"address": r.Header.Get("X-Public-URL-Address"),
+					"path":    r.Header.Get("X-Path"),
+				}).Error("failed to forward the request to the device")
+
+				w.WriteHeader(http.StatusBadGateway)
+			},
+		},
+	}
+}
+
+// Forward forwards the request to the device identified by uid, writing the device's response to w.
+func (p *Proxy) Forward(w http.ResponseWriter, r *http.Request, uid string) {
+	p.proxy.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), deviceKey{}, uid)))
+}
+
+func rewrite(pr *httputil.ProxyRequest) {
+	uid, _ := pr.In.Context().Value(deviceKey{}).(string)
+
+	pr.Out.URL.Scheme = "http"
+	pr.Out.URL.Host = uid
+	pr.Out.URL.Path = "/ssh/http"
+	pr.Out.URL.RawPath = ""
+	pr.Out.URL.RawQuery = ""
+
+	// NOTICE: the request reaches this service through the gateway, so the client's address comes on X-Real-IP.
+	pr.Out.Header.Set("X-Forwarded-For", remoteIP(pr.In))
+	pr.Out.Header.Set("X-Forwarded-Host", pr.In.Host)
+
+	proto := pr.In.Header.Get("X-Forwarded-Proto")
+	if proto == "" {
+		proto = "http"
+	}
+
+	pr.Out.Header.Set("X-Forwarded-Proto", proto)
+}
@@ -0,0 +1,67 @@
+package publicurl
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyForward(t *testing.T) {
+	// NOTICE: the agent is simulated by a server that replies with what it received.
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ssh/http", r.URL.Path)
+		assert.Equal(t, "/dashboard", r.Header.Get("X-Path"))
+		assert.Equal(t, "203.0.113.1", r.Header.Get("X-Forwarded-For"))
+		assert.Equal(t, "ns.address.example.com", r.Header.Get("X-Forwarded-Host"))
+		assert.Equal(t, "https", r.Header.Get("X-Forwarded-Proto"))
+
+		w.Write([]byte(r.Host)) // nolint:errcheck
+	}))
+	defer agent.Close()
+
+	var dials int32
+	proxy := NewProxy(func(_ context.Context, uid string) (net.Conn, error) {
+		assert.Equal(t, "device", uid)
+
+		atomic.AddInt32(&dials, 1)
+
+		return net.Dial("tcp", strings.TrimPrefix(agent.URL, "http://"))
+	})
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+		req.Host = "ns.address.example.com"
+		req.Header.Set("X-Path", "/dashboard")
+		req.Header.Set("X-Real-IP", "203.0.113.1")
+		req.Header.Set("X-Forwarded-Proto", "https")
+		rec := httptest.NewRecorder()
+
+		proxy.Forward(rec, req, "device")
+
+		body, err := io.ReadAll(rec.Result().Body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "ns.address.example.com", string(body))
+	}
+
+	// NOTICE: the connection to the device is kept alive between the requests.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&dials))
+}
+
+func TestProxyForwardFailure(t *testing.T) {
+	proxy := NewProxy(func(_ context.Context, _ string) (net.Conn, error) {
+		return nil, io.ErrUnexpectedEOF
+	})
+
+	rec := httptest.NewRecorder()
+	proxy.Forward(rec, httptest.NewRequest(http.MethodGet, "/ssh/http", nil), "device")
+
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+}
@@ -0,0 +1,199 @@
+// Package publicurl enforces the access modes of devices' services published through public URLs.
+package publicurl
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+const (
+	// CookieName is the name of the cookie that keeps a client authorized on a public URL service.
+	CookieName = "shellhub_public_url"
+	// TokenParam is the query parameter used to send the ShellHub's user token to a service on login access.
+	TokenParam = "shellhub_token"
+	// SessionTTL is how long a client stays authorized on a public URL service after sending valid credentials.
+	SessionTTL = 12 * time.Hour
+)
+
+// Authorizer checks if a request can reach a device's public URL service.
+type Authorizer struct {
+	api   internalclient.Client
+	cache cache.Cache
+}
+
+// NewAuthorizer creates a new [Authorizer] that checks the credentials through the API and keeps the authorized
+// clients on cache.
+func NewAuthorizer(api internalclient.Client, cache cache.Cache) *Authorizer {
+	return &Authorizer{api: api, cache: cache}
+}
+
+// Authorize checks if the request can reach the service, according to the service's access mode.
+//
+// When the request is refused, or it must be redirected, the reply is written to the client and false is returned. When
+// the request is authorized, the credentials used by ShellHub are removed from it, so they don't reach the device.
+func (a *Authorizer) Authorize(c echo.Context, service *models.DevicePublicURLService) (bool, error) {
+	switch service.Access.Mode {
+	case "", models.DevicePublicURLAccessPublic:
+		return true, nil
+	case models.DevicePublicURLAccessAllowlist:
+		if !service.Access.Allows(remoteIP(c.Request())) {
+			return false, c.String(http.StatusForbidden, "your address is not allowed to access this service")
+		}
+
+		return true, nil
+	case models.DevicePublicURLAccessBasic, models.DevicePublicURLAccessLogin:
+		ok, err := a.authorizeWithCredentials(c, service)
+		if ok {
+			c.Request().Header.Del(echo.HeaderAuthorization)
+			removeCookie(c.Request(), CookieName)
+		}
+
+		return ok, err
+	default:
+		return false, c.String(http.StatusForbidden, "this service has an unknown access mode")
+	}
+}
+
+func (a *Authorizer) authorizeWithCredentials(c echo.Context, service *models.DevicePublicURLService) (bool, error) {
+	ctx := c.Request().Context()
+
+	if cookie, err := c.Request().Cookie(CookieName); err == nil {
+		var address string
+		if err := a.cache.Get(ctx, sessionKey(cookie.Value), &address); err != nil {
+			return false, err
+		}
+
+		if address == service.Address {
+			return true, nil
+		}
+	}
+
+	req := requests.DevicePublicURLAuthorize{
+		DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: service.Address},
+	}
+
+	// NOTICE: the path requested by the client comes on the X-Path header, as the request itself was rewritten by the
+	// gateway.
+	path, err := url.Parse(c.Request().Header.Get("X-Path"))
+	if err != nil {
+		return false, c.String(http.StatusBadRequest, "invalid path")
+	}
+
+	redirect := false
+	switch service.Access.Mode {
+	case models.DevicePublicURLAccessBasic:
+		username, password, ok := c.Request().BasicAuth()
+		if !ok {
+			return unauthorized(c, service)
+		}
+
+		req.Username = username
+		req.Password = password
+	case models.DevicePublicURLAccessLogin:
+		if token := path.Query().Get(TokenParam); token != "" {
+			req.Token = token
+			redirect = true
+		} else if token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer "); ok {
+			req.Token = token
+		} else {
+			return unauthorized(c, service)
+		}
+	}
+
+	if err := a.api.AuthorizeDevicePublicURL(req); err != nil {
+		if errors.Is(err, internalclient.ErrUnauthorized) {
+			return unauthorized(c, service)
+		}
+
+		return false, err
+	}
+
+	if err := a.startSession(ctx, c, service.Address); err != nil {
+		return false, err
+	}
+
+	// NOTICE: the token sent on the URL is removed through a redirect, so it doesn't stay on the browser's history nor
+	// reach the device.
+	if redirect {
+		query := path.Query()
+		query.Del(TokenParam)
+		path.RawQuery = query.Encode()
+
+		return false, c.Redirect(http.StatusSeeOther, path.String())
+	}
+
+	return true, nil
+}
+
+func (a *Authorizer) startSession(ctx context.Context, c echo.Context, address string) error {
+	id := uuid.Generate()
+	if err := a.cache.Set(ctx, sessionKey(id), address, SessionTTL); err != nil {
+		return err
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     CookieName,
+		Value:    id,
+		Path:     "/",
+		MaxAge:   int(SessionTTL.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return nil
+}
+
+func unauthorized(c echo.Context, service *models.DevicePublicURLService) (bool, error) {
+	if service.Access.Mode == models.DevicePublicURLAccessBasic {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="ShellHub", charset="UTF-8"`)
+
+		return false, c.String(http.StatusUnauthorized, "invalid credentials")
+	}
+
+	return false, c.String(http.StatusUnauthorized, "log in on ShellHub and send your token through the "+TokenParam+" query parameter")
+}
+
+func sessionKey(id string) string {
+	return "public_url_session_" + id
+}
+
+// remoteIP returns the client's address set by the gateway, or the address of the connection when it isn't set.
+//
+// The gateway always overwrites the X-Real-IP header with the address of the client's connection, so a value sent by
+// the client never reaches here.
+func remoteIP(req *http.Request) string {
+	if ip := req.Header.Get("X-Real-IP"); ip != "" {
+		return ip
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+// removeCookie removes the cookie identified by name from the request, keeping the other ones.
+func removeCookie(req *http.Request, name string) {
+	cookies := req.Cookies()
+	req.Header.Del("Cookie")
+
+	for _, cookie := range cookies {
+		if cookie.Name != name {
+			req.AddCookie(cookie)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package publicurl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCache is a cache.Cache kept in memory, to check the sessions started by the authorizer.
+type memoryCache map[string][]byte
+
+func (m memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	data, ok := m[key]
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(data, value)
+}
+
+func (m memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m[key] = data
+
+	return nil
+}
+
+func (m memoryCache) Delete(_ context.Context, key string) error {
+	delete(m, key)
+
+	return nil
+}
+
+func (m memoryCache) Increment(_ context.Context, key string, _ time.Duration) (int64, error) {
+	var counter int64
+	if data, ok := m[key]; ok {
+		if err := json.Unmarshal(data, &counter); err != nil {
+			return 0, err
+		}
+	}
+
+	counter++
+
+	data, err := json.Marshal(counter)
+	m[key] = data
+
+	return counter, err
+}
+
+func TestAuthorize(t *testing.T) {
+	type Expected struct {
+		ok     bool
+		status int
+	}
+
+	cases := []struct {
+		description   string
+		service       *models.DevicePublicURLService
+		request       func() *http.Request
+		requiredMocks func(api *mocks.Client)
+		expected      Expected
+	}{
+		{
+			description: "succeeds when the service is public",
+			service:     &models.DevicePublicURLService{Address: "address"},
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+			},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{ok: true, status: http.StatusOK},
+		},
+		{
+			description: "fails when the address is not on the allowlist",
+			service: &models.DevicePublicURLService{
+				Address: "address",
+				Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessAllowlist, AllowedCIDRs: []string{"10.0.0.0/8"}},
+			},
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+				req.Header.Set("X-Real-IP", "192.168.0.1")
+
+				return req
+			},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{ok: false, status: http.StatusForbidden},
+		},
+		{
+			description: "succeeds when the address is on the allowlist",
+			service: &models.DevicePublicURLService{
+				Address: "address",
+				Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessAllowlist, AllowedCIDRs: []string{"10.0.0.0/8"}},
+			},
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+				req.Header.Set("X-Real-IP", "10.1.2.3")
+
+				return req
+			},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{ok: true, status: http.StatusOK},
+		},
+		{
+			description: "fails when the address is spoofed along the client's one",
+			service: &models.DevicePublicURLService{
+				Address: "address",
+				Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessAllowlist, AllowedCIDRs: []string{"10.0.0.0/8"}},
+			},
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+				req.Header.Set("X-Real-IP", "10.1.2.3, 192.168.0.1")
+
+				return req
+			},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{ok: false, status: http.StatusForbidden},
+		},
+		{
+			description: "fails when the address is spoofed without the gateway",
+			service: &models.DevicePublicURLService{
+				Address: "address",
+				Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessAllowlist, AllowedCIDRs: []string{"10.0.0.0/8"}},
+			},
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+				req.Header.Set("X-Real-IP", "")
+				req.Header.Set("X-Forwarded-For", "10.1.2.3")
+				req.RemoteAddr = "192.168.0.1:1234"
+
+				return req
+			},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{ok: false, status: http.StatusForbidden},
+		},
+		{
+			description: "fails when the basic credentials are missing",
+			service: &models.DevicePublicURLService{
+				Address: "address",
+				Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessBasic},
+			},
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+			},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{ok: false, status: http.StatusUnauthorized},
+		},
+		{
+			description: "fails when the basic credentials are refused",
+			service: &models.DevicePublicURLService{
+				Address: "address",
+				Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessBasic},
+			},
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+				req.SetBasicAuth("admin", "wrong")
+
+				return req
+			},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublicURL", requests.DevicePublicURLAuthorize{
+					DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "address"},
+					Username:               "admin",
+					Password:               "wrong",
+				}).Return(internalclient.ErrUnauthorized).Once()
+			},
+			expected: Expected{ok: false, status: http.StatusUnauthorized},
+		},
+		{
+			description: "succeeds when the basic credentials are accepted",
+			service: &models.DevicePublicURLService{
+				Address: "address",
+				Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessBasic},
+			},
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+				req.SetBasicAuth("admin", "secret")
+
+				return req
+			},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublicURL", requests.DevicePublicURLAuthorize{
+					DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "address"},
+					Username:               "admin",
+					Password:               "secret",
+				}).Return(nil).Once()
+			},
+			expected: Expected{ok: true, status: http.StatusOK},
+		},
+		{
+			description: "redirects without the token when the login token is accepted",
+			service: &models.DevicePublicURLService{
+				Address: "address",
+				Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessLogin},
+			},
+			request: func() *http.Request {
+				req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+				req.Header.Set("X-Path", "/dashboard?"+TokenParam+"=token")
+
+				return req
+			},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublicURL", requests.DevicePublicURLAuthorize{
+					DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "address"},
+					Token:                  "token",
+				}).Return(nil).Once()
+			},
+			expected: Expected{ok: false, status: http.StatusSeeOther},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(tc.request(), rec)
+
+			ok, err := NewAuthorizer(api, memoryCache{}).Authorize(c, tc.service)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, Expected{ok: ok, status: rec.Code})
+
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthorizeWithSession(t *testing.T) {
+	api := new(mocks.Client)
+	api.On("AuthorizeDevicePublicURL", requests.DevicePublicURLAuthorize{
+		DevicePublicURLAddress: requests.DevicePublicURLAddress{PublicURLAddress: "address"},
+		Token:                  "token",
+	}).Return(nil).Once()
+
+	service := &models.DevicePublicURLService{
+		Address: "address",
+		Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessLogin},
+	}
+
+	authorizer := NewAuthorizer(api, memoryCache{})
+
+	req := httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+	rec := httptest.NewRecorder()
+
+	ok, err := authorizer.Authorize(echo.New().NewContext(req, rec), service)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, req.Header.Get(echo.HeaderAuthorization))
+
+	cookies := rec.Result().Cookies()
+	assert.Len(t, cookies, 1)
+
+	// NOTICE: the following request is authorized by the session's cookie, without asking the API again.
+	req = httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+	req.AddCookie(cookies[0])
+	req.AddCookie(&http.Cookie{Name: "device", Value: "kept"})
+	req.Header.Set(echo.HeaderAuthorization, "Bearer token")
+
+	ok, err = authorizer.Authorize(echo.New().NewContext(req, httptest.NewRecorder()), service)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Empty(t, req.Header.Get(echo.HeaderAuthorization))
+
+	_, err = req.Cookie(CookieName)
+	assert.ErrorIs(t, err, http.ErrNoCookie)
+
+	kept, err := req.Cookie("device")
+	assert.NoError(t, err)
+	assert.Equal(t, "kept", kept.Value)
+
+	// NOTICE: the session is bound to the service that started it.
+	req = httptest.NewRequest(http.MethodGet, "/ssh/http", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+
+	ok, err = authorizer.Authorize(echo.New().NewContext(req, rec), &models.DevicePublicURLService{
+		Address: "other",
+		Access:  models.DevicePublicURLAccess{Mode: models.DevicePublicURLAccessLogin},
+	})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	api.AssertExpectations(t)
+}
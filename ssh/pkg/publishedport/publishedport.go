@@ -0,0 +1,172 @@
+// Package publishedport connects clients to devices' TCP ports published through the gateway.
+package publishedport
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// DialFunc opens a connection to the device identified by its UID.
+type DialFunc func(ctx context.Context, uid string) (net.Conn, error)
+
+// Forwarder connects the clients to the devices' published ports through the devices' tunnels.
+//
+// Through the HTTP gateway, the client asks for a connection upgrade to the "tcp" protocol, sending the token issued
+// to connect to the port as a bearer token. Once upgraded, the connection's bytes are copied as they are between the
+// client and the device's port. The clients that don't speak HTTP reach the ports through the [Server], by TLS.
+type Forwarder struct {
+	api  internalclient.Client
+	dial DialFunc
+}
+
+// NewForwarder creates a new [Forwarder] that checks the tokens through the API and reaches the devices through dial.
+func NewForwarder(api internalclient.Client, dial DialFunc) *Forwarder {
+	return &Forwarder{api: api, dial: dial}
+}
+
+// Forward connects the client to the published port identified by the address on the X-Published-Port-Address
+// header, set by the gateway.
+func (f *Forwarder) Forward(c echo.Context) error {
+	address := c.Request().Header.Get("X-Published-Port-Address")
+
+	replyError := func(err error, msg string, code int) error {
+		log.WithError(err).WithFields(log.Fields{
+			"remote":  c.Request().RemoteAddr,
+			"address": address,
+		}).Error(msg)
+
+		return c.String(code, msg)
+	}
+
+	if !strings.EqualFold(c.Request().Header.Get(echo.HeaderUpgrade), "tcp") {
+		c.Response().Header().Set(echo.HeaderUpgrade, "tcp")
+
+		return c.String(http.StatusUpgradeRequired, "the connection must be upgraded to the tcp protocol")
+	}
+
+	token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok || token == "" {
+		return c.String(http.StatusUnauthorized, "a token is required to connect to the port")
+	}
+
+	device, err := f.api.AuthorizeDevicePublishedPort(requests.DevicePublishedPortAuthorize{Address: address, Token: token})
+	switch {
+	case errors.Is(err, internalclient.ErrUnauthorized):
+		return c.String(http.StatusUnauthorized, "invalid token")
+	case errors.Is(err, internalclient.ErrNotFound):
+		return c.String(http.StatusNotFound, "port not found")
+	case err != nil:
+		return replyError(err, "failed to authorize the connection to the port", http.StatusInternalServerError)
+	}
+
+	port, ok := device.PublishedPort(address)
+	if !ok {
+		return c.String(http.StatusNotFound, "port not found")
+	}
+
+	conn, err := f.connect(c.Request().Context(), device, port)
+	if err != nil {
+		return replyError(err, "failed to connect to the port on device", http.StatusBadGateway)
+	}
+	defer conn.Close()
+
+	client, buf, err := http.NewResponseController(c.Response()).Hijack()
+	if err != nil {
+		return replyError(err, "failed to hijack the connection", http.StatusInternalServerError)
+	}
+	defer client.Close()
+
+	if _, err := buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n"); err != nil {
+		return nil
+	}
+
+	if err := buf.Flush(); err != nil {
+		return nil
+	}
+
+	pipe(&bufferedConn{Conn: client, reader: buf.Reader}, conn)
+
+	return nil
+}
+
+// connect opens a connection to the device's published port through the device's tunnel. The connection's bytes are
+// the port's ones.
+func (f *Forwarder) connect(ctx context.Context, device *models.Device, port *models.DevicePublishedPort) (net.Conn, error) {
+	conn, err := f.dial(ctx, device.UID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/ssh/tcp", nil)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	// NOTICE: the agent reaches the port described by this header, what is never taken from the client.
+	req.Header.Set("X-Published-Port-Target", port.Target())
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusSwitchingProtocols {
+		res.Body.Close()
+		conn.Close()
+
+		return nil, fmt.Errorf("unexpected agent's response: %s", res.Status)
+	}
+
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// bufferedConn is a connection whose bytes already buffered by reader are read first.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// pipe copies the bytes between the client and the device's port until one of them is closed.
+func pipe(client, device net.Conn) {
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(device, client) // nolint:errcheck
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(client, device) // nolint:errcheck
+		done <- struct{}{}
+	}()
+
+	<-done
+}
@@ -0,0 +1,146 @@
+package publishedport
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// agent simulates the agent's TCP handler, upgrading the connection and echoing back what it receives.
+func agent(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ssh/tcp", r.URL.Path)
+		assert.Equal(t, "localhost:5432", r.Header.Get("X-Published-Port-Target"))
+
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+
+		defer conn.Close()
+
+		buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: tcp\r\n\r\n") // nolint:errcheck
+		buf.Flush()                                                                                        // nolint:errcheck
+
+		io.Copy(conn, buf) // nolint:errcheck
+	}))
+}
+
+func TestForward(t *testing.T) {
+	device := &models.Device{
+		UID:            "device",
+		PublishedPorts: []models.DevicePublishedPort{{Address: "address", Host: "localhost", Port: 5432}},
+	}
+
+	cases := []struct {
+		description   string
+		headers       map[string]string
+		requiredMocks func(api *mocks.Client)
+		expected      int
+	}{
+		{
+			description:   "fails when the connection isn't upgraded",
+			headers:       map[string]string{"Authorization": "Bearer token"},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      http.StatusUpgradeRequired,
+		},
+		{
+			description:   "fails when the token is missing",
+			headers:       map[string]string{"Connection": "Upgrade", "Upgrade": "tcp"},
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      http.StatusUnauthorized,
+		},
+		{
+			description: "fails when the token is refused",
+			headers:     map[string]string{"Connection": "Upgrade", "Upgrade": "tcp", "Authorization": "Bearer token"},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublishedPort", requests.DevicePublishedPortAuthorize{Address: "address", Token: "token"}).Return(nil, internalclient.ErrUnauthorized).Once()
+			},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			description: "fails when the port is not found",
+			headers:     map[string]string{"Connection": "Upgrade", "Upgrade": "tcp", "Authorization": "Bearer token"},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublishedPort", requests.DevicePublishedPortAuthorize{Address: "address", Token: "token"}).Return(nil, internalclient.ErrNotFound).Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds when the token is accepted",
+			headers:     map[string]string{"Connection": "Upgrade", "Upgrade": "tcp", "Authorization": "Bearer token"},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublishedPort", requests.DevicePublishedPortAuthorize{Address: "address", Token: "token"}).Return(device, nil).Once()
+			},
+			expected: http.StatusSwitchingProtocols,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			device := agent(t)
+			defer device.Close()
+
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			forwarder := NewForwarder(api, func(_ context.Context, uid string) (net.Conn, error) {
+				assert.Equal(t, "device", uid)
+
+				return net.Dial("tcp", strings.TrimPrefix(device.URL, "http://"))
+			})
+
+			router := echo.New()
+			router.GET("/ssh/tcp", forwarder.Forward)
+
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+			assert.NoError(t, err)
+
+			defer conn.Close()
+
+			req, err := http.NewRequest(http.MethodGet, "/ssh/tcp", nil)
+			assert.NoError(t, err)
+
+			req.Header.Set("X-Published-Port-Address", "address")
+			for key, value := range tc.headers {
+				req.Header.Set(key, value)
+			}
+
+			assert.NoError(t, req.Write(conn))
+
+			reader := bufio.NewReader(conn)
+
+			res, err := http.ReadResponse(reader, req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, res.StatusCode)
+
+			if res.StatusCode == http.StatusSwitchingProtocols {
+				_, err = conn.Write([]byte("ping\n"))
+				assert.NoError(t, err)
+
+				line, err := reader.ReadString('\n')
+				assert.NoError(t, err)
+				assert.Equal(t, "ping\n", line)
+			}
+
+			api.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package publishedport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// handshakeTimeout is the maximum time waited for the client to finish the TLS handshake.
+const handshakeTimeout = 10 * time.Second
+
+// Options are the configuration of the TLS listeners where the clients reach the published ports without the HTTP
+// upgrade, like the standard TLS clients, e.g. openssl, socat or stunnel, do.
+type Options struct {
+	// TLS enables the listener where the published ports are reached by their hostnames, <address>.tcp.<domain>, sent
+	// through the TLS's SNI.
+	TLS bool `env:"PUBLISHED_PORTS_TLS,default=false"`
+	// Range is the range of the gateway's ports allocated to the published ports, like 20000-20099. A listener is
+	// opened on each port, reaching the published port allocated to it. It is empty when the ports are not allocated.
+	Range string `env:"PUBLISHED_PORTS_RANGE,default="`
+	// Domain is the domain of the published ports' hostnames.
+	Domain string `env:"PUBLIC_URL_DOMAIN,default="`
+	// Certificate is the path of the server's certificate presented to the clients. When it or the key is empty, a
+	// self-signed certificate is generated on start.
+	Certificate string `env:"PUBLISHED_PORTS_CERTIFICATE,default="`
+	// Key is the path of the server's certificate key.
+	Key string `env:"PUBLISHED_PORTS_KEY,default="`
+}
+
+// Server connects the clients to the devices' published ports through TLS.
+//
+// The client presents the X.509 client certificate issued to connect to the port, which is checked by the API on
+// each connection. The published port is chosen by the hostname sent through the SNI or, on the gateway's ports
+// allocated to the published ports, by the port where the client is connected to.
+type Server struct {
+	forwarder *Forwarder
+	opts      *Options
+	config    *tls.Config
+}
+
+// NewServer creates a new [Server] that reaches the devices through the forwarder.
+func NewServer(forwarder *Forwarder, opts *Options) (*Server, error) {
+	var certificate tls.Certificate
+
+	var err error
+	if opts.Certificate != "" && opts.Key != "" {
+		certificate, err = tls.LoadX509KeyPair(opts.Certificate, opts.Key)
+	} else {
+		certificate, err = selfSignedCertificate(opts.Domain)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		forwarder: forwarder,
+		opts:      opts,
+		config: &tls.Config{ // nolint:gosec
+			Certificates: []tls.Certificate{certificate},
+			// NOTICE: the client certificates are signed by each namespace's certificate authority, so they are checked
+			// by the API, which knows the authorities, after the client proves it owns the certificate's key.
+			ClientAuth: tls.RequireAnyClientCert,
+			MinVersion: tls.VersionTLS12,
+		},
+	}, nil
+}
+
+// Serve accepts the clients' connections on listener until it is closed. The gatewayPort is the gateway's port
+// allocated to the published port reached through the listener, or zero when the port is chosen by the SNI.
+func (s *Server) Serve(listener net.Listener, gatewayPort int) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go s.handle(conn, gatewayPort)
+	}
+}
+
+// ListenAndServe listens on address, by the SNI, when the TLS is enabled, and on each gateway's port of the range,
+// serving the clients until one of the listeners fails.
+func (s *Server) ListenAndServe(address string) error {
+	errs := make(chan error)
+
+	listen := func(address string, gatewayPort int) error {
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			errs <- s.Serve(listener, gatewayPort)
+		}()
+
+		return nil
+	}
+
+	if s.opts.TLS {
+		if err := listen(address, 0); err != nil {
+			return err
+		}
+	}
+
+	if s.opts.Range != "" {
+		ports, err := models.ParsePortRange(s.opts.Range)
+		if err != nil {
+			return err
+		}
+
+		for port := ports.First; port <= ports.Last; port++ {
+			if err := listen(net.JoinHostPort("", strconv.Itoa(port)), port); err != nil {
+				return err
+			}
+		}
+	}
+
+	return <-errs
+}
+
+func (s *Server) handle(conn net.Conn, gatewayPort int) {
+	defer conn.Close()
+
+	logger := log.WithFields(log.Fields{"remote": conn.RemoteAddr().String(), "gateway_port": gatewayPort})
+
+	client := tls.Server(conn, s.config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+
+	if err := client.HandshakeContext(ctx); err != nil {
+		logger.WithError(err).Debug("failed to handshake with the client")
+
+		return
+	}
+
+	state := client.ConnectionState()
+
+	req := requests.DevicePublishedPortAuthorize{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: state.PeerCertificates[0].Raw})),
+	}
+
+	if gatewayPort != 0 {
+		req.GatewayPort = gatewayPort
+	} else {
+		address, err := s.address(state.ServerName)
+		if err != nil {
+			logger.WithError(err).WithField("server_name", state.ServerName).Warn("failed to get the published port's address")
+
+			return
+		}
+
+		req.Address = address
+	}
+
+	logger = logger.WithField("address", req.Address)
+
+	device, err := s.forwarder.api.AuthorizeDevicePublishedPort(req)
+	if err != nil {
+		logger.WithError(err).Warn("failed to authorize the connection to the port")
+
+		return
+	}
+
+	port, ok := device.PublishedPort(req.Address)
+	if gatewayPort != 0 {
+		port, ok = device.PublishedPortByGatewayPort(gatewayPort)
+	}
+
+	if !ok {
+		logger.Warn("port not found")
+
+		return
+	}
+
+	remote, err := s.forwarder.connect(context.Background(), device, port)
+	if err != nil {
+		logger.WithError(err).WithField("device", device.UID).Error("failed to connect to the port on device")
+
+		return
+	}
+
+	defer remote.Close()
+
+	pipe(client, remote)
+}
+
+// address returns the published port's address from the hostname sent by the client, <address>.tcp.<domain>.
+func (s *Server) address(serverName string) (string, error) {
+	if s.opts.Domain == "" {
+		return "", errors.New("the published ports' domain is not set")
+	}
+
+	address, ok := strings.CutSuffix(strings.ToLower(serverName), ".tcp."+strings.ToLower(s.opts.Domain))
+	if !ok || address == "" || strings.Contains(address, ".") {
+		return "", errors.New("the hostname isn't a published port's one")
+	}
+
+	return address, nil
+}
+
+// selfSignedCertificate generates a certificate for the published ports' hostnames, used when no certificate is set.
+func selfSignedCertificate(domain string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "ShellHub published ports"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	if domain != "" {
+		template.DNSNames = []string{"*.tcp." + domain}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
@@ -0,0 +1,147 @@
+package publishedport
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// clientCertificate generates a client certificate like the ones signed by the namespaces' certificate authorities.
+func clientCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "member"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	assert.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: priv}
+}
+
+func TestServer(t *testing.T) {
+	device := &models.Device{
+		UID:            "device",
+		PublishedPorts: []models.DevicePublishedPort{{Address: "address", Host: "localhost", Port: 5432, GatewayPort: 20000}},
+	}
+
+	certificate := clientCertificate(t)
+
+	cases := []struct {
+		description   string
+		serverName    string
+		gatewayPort   int
+		requiredMocks func(api *mocks.Client)
+		expected      bool
+	}{
+		{
+			description:   "fails when the hostname isn't a published port's one",
+			serverName:    "address.shellhub.io",
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      false,
+		},
+		{
+			description: "fails when the certificate is refused",
+			serverName:  "address.tcp.shellhub.io",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublishedPort", mock.MatchedBy(func(req requests.DevicePublishedPortAuthorize) bool {
+					return req.Address == "address" && strings.HasPrefix(req.Certificate, "-----BEGIN CERTIFICATE-----")
+				})).Return(nil, internalclient.ErrUnauthorized).Once()
+			},
+			expected: false,
+		},
+		{
+			description: "succeeds when the port is reached by its hostname",
+			serverName:  "address.tcp.shellhub.io",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublishedPort", mock.MatchedBy(func(req requests.DevicePublishedPortAuthorize) bool {
+					return req.Address == "address" && req.GatewayPort == 0
+				})).Return(device, nil).Once()
+			},
+			expected: true,
+		},
+		{
+			description: "succeeds when the port is reached by its gateway's port",
+			serverName:  "gateway",
+			gatewayPort: 20000,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("AuthorizeDevicePublishedPort", mock.MatchedBy(func(req requests.DevicePublishedPortAuthorize) bool {
+					return req.Address == "" && req.GatewayPort == 20000
+				})).Return(device, nil).Once()
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			device := agent(t)
+			defer device.Close()
+
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			forwarder := NewForwarder(api, func(_ context.Context, uid string) (net.Conn, error) {
+				assert.Equal(t, "device", uid)
+
+				return net.Dial("tcp", strings.TrimPrefix(device.URL, "http://"))
+			})
+
+			server, err := NewServer(forwarder, &Options{TLS: true, Domain: "shellhub.io"})
+			assert.NoError(t, err)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			assert.NoError(t, err)
+
+			defer listener.Close()
+
+			go server.Serve(listener, tc.gatewayPort) // nolint:errcheck
+
+			conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{ // nolint:gosec
+				ServerName:         tc.serverName,
+				Certificates:       []tls.Certificate{certificate},
+				InsecureSkipVerify: true,
+			})
+			assert.NoError(t, err)
+
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("ping\n"))
+			assert.NoError(t, err)
+
+			line, err := bufio.NewReader(conn).ReadString('\n')
+			if tc.expected {
+				assert.NoError(t, err)
+				assert.Equal(t, "ping\n", line)
+			} else {
+				assert.Error(t, err)
+			}
+
+			api.AssertExpectations(t)
+		})
+	}
+}
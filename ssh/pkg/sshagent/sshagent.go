@@ -0,0 +1,119 @@
+// Package sshagent forwards the SSH agent of a client, connected to ShellHub's SSH server, to the device's session.
+//
+// The agent forwarding is opt-in. It is only permitted when the client authenticates with a user certificate, signed
+// by the namespace's certificate authority, that carries the "permit-agent-forwarding" extension, what the API only
+// grants when the namespace has enabled it and the member's role allows it.
+package sshagent
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	// ChannelType is the type of the channel opened by the server to reach the client's agent.
+	ChannelType = "auth-agent@openssh.com"
+
+	// Extension is the certificate's extension that permits the agent forwarding.
+	Extension = "permit-agent-forwarding"
+
+	// permitted is the key to store and restore from the context if the agent forwarding is permitted.
+	permitted = "agent_forwarding"
+)
+
+// ErrAlreadyForwarding is returned when the agent's channels are already handled by the connection to the device.
+var ErrAlreadyForwarding = errors.New("agent channels are already handled by the connection")
+
+// Allows checks if a user certificate permits the agent forwarding.
+func Allows(cert *gossh.Certificate) bool {
+	if cert == nil {
+		return false
+	}
+
+	_, ok := cert.Permissions.Extensions[Extension]
+
+	return ok
+}
+
+// Permit stores on the context that the connection is permitted to forward its agent.
+func Permit(ctx gliderssh.Context) {
+	ctx.SetValue(permitted, true)
+}
+
+// Permitted checks if the connection is permitted to forward its agent.
+func Permitted(ctx gliderssh.Context) bool {
+	value, ok := ctx.Value(permitted).(bool)
+
+	return ok && value
+}
+
+// Forward requests the agent forwarding on the device's session and serves each agent channel opened by the device
+// through a new agent channel opened to the client.
+//
+// It must be called before the session requests a shell or a command, as the device only exposes the agent to the
+// processes started after the request.
+func Forward(client gliderssh.Session, device *gossh.Client, session *gossh.Session) error {
+	channels := device.HandleChannelOpen(ChannelType)
+	if channels == nil {
+		return ErrAlreadyForwarding
+	}
+
+	if err := agent.RequestAgentForwarding(session); err != nil {
+		return err
+	}
+
+	conn := client.Context().Value(gliderssh.ContextKeyConn).(gossh.Conn)
+
+	go func() {
+		for channel := range channels {
+			go serve(conn, channel)
+		}
+	}()
+
+	return nil
+}
+
+// serve pipes an agent channel opened by the device to a new agent channel opened to the client.
+func serve(conn gossh.Conn, newChannel gossh.NewChannel) {
+	target, reqs, err := conn.OpenChannel(ChannelType, nil)
+	if err != nil {
+		newChannel.Reject(gossh.ConnectionFailed, err.Error()) // nolint: errcheck
+
+		return
+	}
+	defer target.Close()
+
+	go gossh.DiscardRequests(reqs)
+
+	channel, creqs, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+
+	go gossh.DiscardRequests(creqs)
+
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		io.Copy(channel, target) // nolint: errcheck
+		channel.CloseWrite()     // nolint: errcheck
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		io.Copy(target, channel) // nolint: errcheck
+		target.CloseWrite()      // nolint: errcheck
+	}()
+
+	wg.Wait()
+}
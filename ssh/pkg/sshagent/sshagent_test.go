@@ -0,0 +1,130 @@
+package sshagent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// listen starts the server on a random port of the loopback, returning its address.
+func listen(t *testing.T, server *gliderssh.Server) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	signer, err := gossh.NewSignerFromKey(key)
+	assert.NoError(t, err)
+
+	server.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	go server.Serve(listener) // nolint: errcheck
+
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return listener.Addr().String()
+}
+
+func TestAllows(t *testing.T) {
+	assert.False(t, Allows(nil))
+	assert.False(t, Allows(&gossh.Certificate{}))
+	assert.True(t, Allows(&gossh.Certificate{
+		Permissions: gossh.Permissions{Extensions: map[string]string{Extension: ""}},
+	}))
+}
+
+func TestForward(t *testing.T) {
+	keys := make(chan int, 1)
+
+	// NOTICE: the device lists the keys of the client's agent through the agent channel opened to the server.
+	device := listen(t, &gliderssh.Server{
+		Handler: func(session gliderssh.Session) {
+			if !gliderssh.AgentRequested(session) {
+				keys <- -1
+
+				return
+			}
+
+			conn := session.Context().Value(gliderssh.ContextKeyConn).(gossh.Conn)
+
+			channel, reqs, err := conn.OpenChannel(ChannelType, nil)
+			if err != nil {
+				keys <- -1
+
+				return
+			}
+			defer channel.Close()
+
+			go gossh.DiscardRequests(reqs)
+
+			list, err := agent.NewClient(channel).List()
+			if err != nil {
+				keys <- -1
+
+				return
+			}
+
+			keys <- len(list)
+		},
+	})
+
+	gateway := listen(t, &gliderssh.Server{
+		Handler: func(session gliderssh.Session) {
+			client, err := gossh.Dial("tcp", device, &gossh.ClientConfig{
+				User:            "root",
+				HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint:gosec
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer client.Close()
+
+			sess, err := client.NewSession()
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer sess.Close()
+
+			assert.NoError(t, Forward(session, client, sess))
+			assert.NoError(t, sess.Run(""))
+		},
+	})
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	keyring := agent.NewKeyring()
+	assert.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: priv}))
+
+	client, err := gossh.Dial("tcp", gateway, &gossh.ClientConfig{
+		User:            "root",
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint:gosec
+	})
+	assert.NoError(t, err)
+
+	defer client.Close()
+
+	assert.NoError(t, agent.ForwardToAgent(client, keyring))
+
+	session, err := client.NewSession()
+	assert.NoError(t, err)
+
+	defer session.Close()
+
+	assert.NoError(t, agent.RequestAgentForwarding(session))
+	assert.NoError(t, session.Run(""))
+
+	assert.Equal(t, 1, <-keys)
+}
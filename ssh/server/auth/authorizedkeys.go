@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/conntrace"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// authorizedKeysTimeout is the maximum time waited for the agent to reply if a key is on its authorized keys.
+const authorizedKeysTimeout = 10 * time.Second
+
+// dialer opens a connection to the agent of a device, like the [httptunnel.Tunnel] does.
+type dialer interface {
+	Dial(ctx context.Context, id string) (net.Conn, error)
+}
+
+// authorizedByDevice asks the device's agent if the public key, not registered on the namespace, is on the username's
+// authorized keys file, and if the entry allows the client's address. The key and the address are sent as they are
+// forwarded to the agent when the client is connected, so the agent evaluates the same key again on its own
+// authentication, applying the entry's options.
+//
+// It is false when the agent doesn't evaluate the authorized keys, what includes the agents that don't support it.
+func authorizedByDevice(ctx gliderssh.Context, tunnel dialer, device *models.Device, username string, key gliderssh.PublicKey) bool {
+	if tunnel == nil {
+		return false
+	}
+
+	dialCtx, cancel := context.WithTimeout(conntrace.Context(ctx), authorizedKeysTimeout)
+	defer cancel()
+
+	conn, err := tunnel.Dial(dialCtx, device.UID)
+	if err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{"session": ctx.SessionID(), "device": device.UID}).
+			Error("failed to dial the agent to evaluate its authorized keys")
+
+		return false
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := dialCtx.Deadline(); ok {
+		conn.SetDeadline(deadline) // nolint:errcheck
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/ssh/authorized-keys/%s", url.PathEscape(username)), nil)
+	req.Header.Set("X-Client-Public-Key", strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key))))
+
+	if host, _, err := net.SplitHostPort(ctx.RemoteAddr().String()); err == nil {
+		req.Header.Set("X-Client-Address", host)
+	}
+
+	if err := req.Write(conn); err != nil {
+		return false
+	}
+
+	res, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return false
+	}
+
+	defer res.Body.Close()
+
+	return res.StatusCode == http.StatusOK
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sshsrvtest"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// agentDialer emulates the tunnel to the agent, replying status to the request sent through the connection.
+type agentDialer struct {
+	err     error
+	status  int
+	request *http.Request
+}
+
+func (d *agentDialer) Dial(_ context.Context, _ string) (net.Conn, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	server, client := net.Pipe()
+
+	go func() {
+		defer server.Close()
+
+		req, err := http.ReadRequest(bufio.NewReader(server))
+		if err != nil {
+			return
+		}
+
+		d.request = req
+
+		(&http.Response{StatusCode: d.status, ProtoMajor: 1, ProtoMinor: 1}).Write(server) // nolint:errcheck
+	}()
+
+	return client, nil
+}
+
+func TestAuthorizedByDevice(t *testing.T) {
+	key := generateTestPubKey(t)
+	device := &models.Device{UID: "uid"}
+
+	cases := []struct {
+		description string
+		tunnel      *agentDialer
+		expected    bool
+	}{
+		{
+			description: "fails when the tunnel is nil",
+			tunnel:      nil,
+			expected:    false,
+		},
+		{
+			description: "fails when the agent cannot be dialed",
+			tunnel:      &agentDialer{err: errors.New("error")},
+			expected:    false,
+		},
+		{
+			description: "fails when the agent doesn't authorize the key",
+			tunnel:      &agentDialer{status: http.StatusForbidden},
+			expected:    false,
+		},
+		{
+			description: "fails when the agent doesn't support the authorized keys",
+			tunnel:      &agentDialer{status: http.StatusNotFound},
+			expected:    false,
+		},
+		{
+			description: "succeeds when the agent authorizes the key",
+			tunnel:      &agentDialer{status: http.StatusOK},
+			expected:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var ctx gliderssh.Context
+
+			srv := sshsrvtest.New(
+				&gliderssh.Server{
+					Handler: func(s gliderssh.Session) {
+						ctx = s.Context()
+					},
+				},
+				&gossh.ClientConfig{
+					User:            "user",
+					HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+				},
+			)
+			defer srv.Teardown()
+
+			srv.Start()
+			assert.NoError(t, srv.Agent.Run("cmd"))
+
+			var tunnel dialer
+			if tc.tunnel != nil {
+				tunnel = tc.tunnel
+			}
+
+			assert.Equal(t, tc.expected, authorizedByDevice(ctx, tunnel, device, "root", key))
+
+			if tc.tunnel != nil && tc.tunnel.request != nil {
+				assert.Equal(t, "/ssh/authorized-keys/root", tc.tunnel.request.URL.Path)
+				assert.Equal(t, "127.0.0.1", tc.tunnel.request.Header.Get("X-Client-Address"))
+
+				forwarded, _, _, _, err := gossh.ParseAuthorizedKey([]byte(tc.tunnel.request.Header.Get("X-Client-Public-Key"))) //nolint:dogsled
+				assert.NoError(t, err)
+				assert.Equal(t, key.Marshal(), forwarded.Marshal())
+			}
+		})
+	}
+}
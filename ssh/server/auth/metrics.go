@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+)
+
+const (
+	methodPassword  = "password"
+	methodPublicKey = "publickey"
+)
+
+var attempts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "ssh",
+	Name:      "auth_attempts_total",
+	Help:      "Number of SSH authentication attempts by method and result.",
+}, []string{"method", "result"})
+
+// observeAttempt counts an authentication attempt of the method as a success or a failure.
+func observeAttempt(method string, authenticated bool) {
+	result := "failure"
+	if authenticated {
+		result = "success"
+	}
+
+	attempts.WithLabelValues(method, result).Inc()
+}
@@ -3,6 +3,8 @@ package auth
 import (
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/ssh/pkg/conntrace"
+	"github.com/shellhub-io/shellhub/ssh/pkg/jump"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	log "github.com/sirupsen/logrus"
 )
@@ -11,8 +13,10 @@ import (
 // Password authentication is the second authentication method tried by the server to connect the client to the agent.
 // It receives the password from the client and attempts to authenticate it.
 // Returns true if the password authentication method is used and false otherwise.
-func PasswordHandler(ctx gliderssh.Context, password string) bool {
-	sshid := metadata.MaybeStoreSSHID(ctx, ctx.User())
+func PasswordHandler(ctx gliderssh.Context, password string) (authenticated bool) {
+	defer func() { observeAttempt(methodPassword, authenticated) }()
+
+	sshid := metadata.MaybeStoreSSHID(ctx, jump.SSHID(ctx))
 
 	log.WithFields(log.Fields{"session": ctx.SessionID(), "sshid": sshid}).
 		Trace("trying to use password authentication")
@@ -26,7 +30,7 @@ func PasswordHandler(ctx gliderssh.Context, password string) bool {
 		return false
 	}
 
-	api := metadata.MaybeSetAPI(ctx, internalclient.NewClient())
+	api := metadata.MaybeSetAPI(ctx, internalclient.NewClient(internalclient.WithContext(conntrace.Detached(ctx))))
 
 	lookup, err := metadata.MaybeStoreLookup(ctx, tag, api)
 	if err != nil {
@@ -37,7 +41,7 @@ func PasswordHandler(ctx gliderssh.Context, password string) bool {
 		return false
 	}
 
-	_, errs := metadata.MaybeStoreDevice(ctx, lookup, api)
+	device, errs := metadata.MaybeStoreDevice(ctx, lookup, api)
 	if len(errs) > 0 {
 		log.WithError(err).
 			WithFields(log.Fields{"session": ctx.SessionID(), "sshid": sshid}).
@@ -46,6 +50,8 @@ func PasswordHandler(ctx gliderssh.Context, password string) bool {
 		return false
 	}
 
+	conntrace.SetDevice(ctx, device)
+
 	metadata.StorePassword(ctx, password)
 	metadata.StoreAuthenticationMethod(ctx, metadata.PasswordAuthenticationMethod)
 
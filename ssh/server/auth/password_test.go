@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	internalclientMocks "github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	metadataMocks "github.com/shellhub-io/shellhub/ssh/pkg/metadata/mocks"
 	"github.com/shellhub-io/shellhub/ssh/pkg/sshsrvtest"
@@ -190,7 +192,7 @@ func TestPasswordHandler(t *testing.T) {
 					Once()
 
 				metadataMock.On("MaybeStoreDevice", ctx, lookup, api).
-					Return(nil, []error{}).
+					Return(&models.Device{UID: "uid", TenantID: "00000000-0000-4000-0000-000000000000"}, []error{}).
 					Once()
 
 				metadataMock.On("StorePassword", ctx, "secret")
@@ -212,8 +214,15 @@ func TestPasswordHandler(t *testing.T) {
 
 			tc.mocks(ctx)
 
-			result := PasswordHandler(ctx, tc.password)
-			assert.Equal(t, tc.expected, result)
+			result := "failure"
+			if tc.expected {
+				result = "success"
+			}
+
+			attempted := testutil.ToFloat64(attempts.WithLabelValues(methodPassword, result))
+
+			assert.Equal(t, tc.expected, PasswordHandler(ctx, tc.password))
+			assert.Equal(t, attempted+1, testutil.ToFloat64(attempts.WithLabelValues(methodPassword, result)))
 		})
 	}
 }
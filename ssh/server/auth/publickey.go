@@ -1,10 +1,18 @@
 package auth
 
 import (
+	"bytes"
+
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/sshcert"
+	"github.com/shellhub-io/shellhub/ssh/pkg/conntrace"
+	"github.com/shellhub-io/shellhub/ssh/pkg/jump"
 	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sshagent"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -13,8 +21,24 @@ import (
 // Public key authentication is the first authentication method tried by the server to connect the client to the agent.
 // It receives the public key from the client and attempts to authenticate it.
 // Returns true if the public key authentication method is used and false otherwise.
-func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool {
-	sshid := metadata.MaybeStoreSSHID(ctx, ctx.User())
+//
+// A key not registered on the namespace is accepted when the device's agent, reached through tunnel, finds it on the
+// user's authorized keys file.
+func PublicKeyHandler(tunnel *httptunnel.Tunnel) gliderssh.PublicKeyHandler {
+	var d dialer
+	if tunnel != nil {
+		d = tunnel
+	}
+
+	return func(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool {
+		return publicKeyHandler(ctx, d, publicKey)
+	}
+}
+
+func publicKeyHandler(ctx gliderssh.Context, tunnel dialer, publicKey gliderssh.PublicKey) (authenticated bool) {
+	defer func() { observeAttempt(methodPublicKey, authenticated) }()
+
+	sshid := metadata.MaybeStoreSSHID(ctx, jump.SSHID(ctx))
 	fingerprint := metadata.MaybeStoreFingerprint(ctx, gossh.FingerprintLegacyMD5(publicKey))
 
 	log.WithFields(log.Fields{
@@ -23,6 +47,23 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 		"fingerprint": fingerprint,
 	}).Trace("trying to use public key authentication")
 
+	// NOTICE: the key accepted last is the one forwarded to the agent, but a key queried before can still be used by
+	// the client to authenticate, as its result is cached for the connection. So, once a key is accepted, no other key
+	// is accepted on the same connection.
+	if accepted, ok := ctx.Value(gliderssh.ContextKeyPublicKey).(gliderssh.PublicKey); ok && !bytes.Equal(accepted.Marshal(), publicKey.Marshal()) {
+		log.WithFields(log.Fields{
+			"session":     ctx.SessionID(),
+			"sshid":       sshid,
+			"fingerprint": fingerprint,
+		}).Warn("another public key was already accepted on the connection")
+
+		return false
+	}
+
+	if jump.IsLogin(sshid) {
+		return jumpPublicKeyHandler(ctx, publicKey, fingerprint)
+	}
+
 	tag, err := metadata.MaybeStoreTarget(ctx, sshid)
 	if err != nil {
 		log.WithError(err).
@@ -36,7 +77,7 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 		return false
 	}
 
-	api := metadata.MaybeSetAPI(ctx, internalclient.NewClient())
+	api := metadata.MaybeSetAPI(ctx, internalclient.NewClient(internalclient.WithContext(conntrace.Detached(ctx))))
 
 	lookup, err := metadata.MaybeStoreLookup(ctx, tag, api)
 	if err != nil {
@@ -64,6 +105,47 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 		return false
 	}
 
+	conntrace.SetDevice(ctx, device)
+
+	if cert, ok := publicKey.(*gossh.Certificate); ok {
+		if err := checkCertificate(ctx, api, cert, device.TenantID, tag.Username); err != nil {
+			log.WithError(err).
+				WithFields(log.Fields{
+					"session":     ctx.SessionID(),
+					"sshid":       sshid,
+					"fingerprint": fingerprint,
+					"key_id":      cert.KeyId,
+					"serial":      cert.Serial,
+				}).
+				Error("failed to check the certificate")
+
+			return false
+		}
+
+		// NOTICE: the certificates are only signed to public keys registered on the namespace, so the filter and the
+		// username of the certificate's key apply to it as well.
+		if !evaluateKey(ctx, api, gossh.FingerprintLegacyMD5(cert.Key), device, tag.Username) {
+			return false
+		}
+
+		metadata.StoreAuthenticationMethod(ctx, metadata.PublicKeyAuthenticationMethod)
+
+		// NOTICE: the certificate's key ID is the username of the member it was signed to.
+		if sshagent.Allows(cert) {
+			permitAgentForwarding(ctx, api, device.TenantID, cert.KeyId)
+		}
+
+		log.WithFields(log.Fields{
+			"session":     ctx.SessionID(),
+			"sshid":       sshid,
+			"fingerprint": fingerprint,
+			"key_id":      cert.KeyId,
+			"serial":      cert.Serial,
+		}).Info("using certificate authentication method to connect the client to agent")
+
+		return true
+	}
+
 	magic, err := gossh.NewPublicKey(&magickey.GetRerefence().PublicKey)
 	if err != nil {
 		log.WithError(err).
@@ -77,34 +159,21 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 		return false
 	}
 
-	if gossh.FingerprintLegacyMD5(magic) != fingerprint {
-		if _, err = api.GetPublicKey(fingerprint, device.TenantID); err != nil {
-			log.WithError(err).
-				WithFields(log.Fields{
-					"session":     ctx.SessionID(),
-					"sshid":       sshid,
-					"fingerprint": fingerprint,
-				}).
-				Error("failed to get the existent public key")
-
-			return false
-		}
+	isMagic := gossh.FingerprintLegacyMD5(magic) == fingerprint
 
-		if ok, err := api.EvaluateKey(fingerprint, device, tag.Username); !ok || err != nil {
-			log.WithError(err).
-				WithFields(log.Fields{
-					"session":     ctx.SessionID(),
-					"sshid":       sshid,
-					"fingerprint": fingerprint,
-				}).
-				Error("failed to evaluate the key")
-
-			return false
-		}
+	registered := !isMagic && evaluateKey(ctx, api, fingerprint, device, tag.Username)
+	if !isMagic && !registered && !authorizedByDevice(ctx, tunnel, device, tag.Username, publicKey) {
+		return false
 	}
 
 	metadata.StoreAuthenticationMethod(ctx, metadata.PublicKeyAuthenticationMethod)
 
+	// NOTICE: only the keys registered on the namespace can forward the agent, as the ones authorized by the device
+	// aren't known by ShellHub.
+	if registered {
+		permitAgentForwarding(ctx, api, device.TenantID, "")
+	}
+
 	log.WithFields(log.Fields{
 		"session":     ctx.SessionID(),
 		"sshid":       sshid,
@@ -113,3 +182,99 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 
 	return true
 }
+
+// permitAgentForwarding permits the client to forward its SSH agent to the device when the namespace has enabled it and,
+// when username isn't empty, when the role of the member it identifies is allowed to. They are checked on each
+// connection, so a change on them applies to the next ones.
+func permitAgentForwarding(ctx gliderssh.Context, api internalclient.Client, tenant, username string) {
+	allowed, err := api.EvaluateAgentForwarding(tenant, username)
+	if err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{
+				"session":  ctx.SessionID(),
+				"tenant":   tenant,
+				"username": username,
+			}).
+			Error("failed to evaluate the agent forwarding")
+
+		return
+	}
+
+	if allowed {
+		sshagent.Permit(ctx)
+	}
+}
+
+// evaluateKey checks if the public key identified by fingerprint is registered on the device's namespace and if its
+// filter and username allow the device and the target's username.
+func evaluateKey(ctx gliderssh.Context, api internalclient.Client, fingerprint string, device *models.Device, username string) bool {
+	if _, err := api.GetPublicKey(fingerprint, device.TenantID); err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{
+				"session":     ctx.SessionID(),
+				"fingerprint": fingerprint,
+			}).
+			Error("failed to get the existent public key")
+
+		return false
+	}
+
+	if ok, err := api.EvaluateKey(fingerprint, device, username); !ok || err != nil {
+		log.WithError(err).
+			WithFields(log.Fields{
+				"session":     ctx.SessionID(),
+				"fingerprint": fingerprint,
+			}).
+			Error("failed to evaluate the key")
+
+		return false
+	}
+
+	return true
+}
+
+// checkCertificate checks if a user certificate was signed by the certificate authority of the device's namespace and
+// if it allows the target's username, considering its validity and critical options.
+func checkCertificate(ctx gliderssh.Context, api internalclient.Client, cert *gossh.Certificate, tenant, username string) error {
+	ca, err := api.GetCertificateAuthority(tenant)
+	if err != nil {
+		return err
+	}
+
+	authority, err := sshcert.ParseAuthority(ca.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	return sshcert.Check(authority, cert, username, ctx.RemoteAddr())
+}
+
+// jumpPublicKeyHandler authenticates a jump login, what is allowed when the public key is registered on, at least, one
+// namespace. The namespaces where the key is registered are the ones whose devices the login can reach.
+func jumpPublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey, fingerprint string) bool {
+	if _, ok := publicKey.(*gossh.Certificate); ok {
+		log.WithFields(log.Fields{"session": ctx.SessionID(), "user": ctx.User()}).
+			Warn("certificates are not supported on jump logins")
+
+		return false
+	}
+
+	api := metadata.MaybeSetAPI(ctx, internalclient.NewClient(internalclient.WithContext(conntrace.Detached(ctx))))
+
+	keys, err := api.ListPublicKeysByFingerprint(fingerprint)
+	if err != nil || len(keys) == 0 {
+		log.WithError(err).
+			WithFields(log.Fields{"session": ctx.SessionID(), "user": ctx.User(), "fingerprint": fingerprint}).
+			Error("failed to find the public key of the jump login")
+
+		return false
+	}
+
+	jump.StoreKeys(ctx, keys)
+	metadata.StoreAuthenticationMethod(ctx, metadata.PublicKeyAuthenticationMethod)
+
+	log.WithFields(log.Fields{"session": ctx.SessionID(), "user": ctx.User(), "fingerprint": fingerprint}).
+		Info("using public key authentication method to log in the jump host")
+
+	return true
+}
@@ -5,12 +5,14 @@ import (
 	"crypto/rsa"
 	"errors"
 	"testing"
+	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	internalclientMocks "github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	metadataMocks "github.com/shellhub-io/shellhub/ssh/pkg/metadata/mocks"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sshagent"
 	"github.com/shellhub-io/shellhub/ssh/pkg/sshsrvtest"
 	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	"github.com/stretchr/testify/assert"
@@ -18,12 +20,18 @@ import (
 	gossh "golang.org/x/crypto/ssh"
 )
 
-func generateTestPubKey(t *testing.T) gliderssh.PublicKey {
+func generateTestPrivateKey(t *testing.T) *rsa.PrivateKey {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	return privateKey
+}
+
+func generateTestPubKey(t *testing.T) gliderssh.PublicKey {
+	privateKey := generateTestPrivateKey(t)
+
 	publicRsaKey, err := gossh.NewPublicKey(&privateKey.PublicKey)
 	if err != nil {
 		t.Fatal(err)
@@ -33,6 +41,8 @@ func generateTestPubKey(t *testing.T) gliderssh.PublicKey {
 }
 
 func TestPublicKeyHandler(t *testing.T) {
+	accepted := generateTestPubKey(t)
+
 	cases := []struct {
 		description string
 		setup       func(ctx *gliderssh.Context) *sshsrvtest.Conn
@@ -286,6 +296,39 @@ func TestPublicKeyHandler(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			description: "fails when another public key was already accepted on the connection",
+			setup: func(ctx *gliderssh.Context) *sshsrvtest.Conn {
+				srv := sshsrvtest.New(
+					&gliderssh.Server{
+						Handler: func(s gliderssh.Session) {
+							*ctx = s.Context()
+						},
+					},
+					&gossh.ClientConfig{
+						User:            "user@namespace.00-00-00-00-00-00",
+						HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+					},
+				)
+
+				return srv
+			},
+			mocks: func(ctx gliderssh.Context) {
+				metadataMock := new(metadataMocks.Metadata)
+				metadata.SetBackend(metadataMock)
+
+				metadataMock.On("MaybeStoreSSHID", ctx, "user@namespace.00-00-00-00-00-00").
+					Return("user@namespace.00-00-00-00-00-00").
+					Once()
+
+				metadataMock.On("MaybeStoreFingerprint", ctx, mock.Anything).
+					Return("fingerprint").
+					Once()
+
+				ctx.SetValue(gliderssh.ContextKeyPublicKey, accepted)
+			},
+			expected: false,
+		},
 		{
 			description: "succeeds to authenticate the session",
 			setup: func(ctx *gliderssh.Context) *sshsrvtest.Conn {
@@ -344,6 +387,92 @@ func TestPublicKeyHandler(t *testing.T) {
 					Once()
 
 				metadataMock.On("StoreAuthenticationMethod", ctx, metadata.PublicKeyAuthenticationMethod)
+
+				api.On("EvaluateAgentForwarding", "00000000-0000-4000-0000-000000000000", "").
+					Return(true, nil).
+					Once()
+			},
+			expected: true,
+		},
+		{
+			description: "fails when the jump login's public key is not registered",
+			setup: func(ctx *gliderssh.Context) *sshsrvtest.Conn {
+				srv := sshsrvtest.New(
+					&gliderssh.Server{
+						Handler: func(s gliderssh.Session) {
+							*ctx = s.Context()
+						},
+					},
+					&gossh.ClientConfig{
+						User:            "user",
+						HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+					},
+				)
+
+				return srv
+			},
+			mocks: func(ctx gliderssh.Context) {
+				metadataMock := new(metadataMocks.Metadata)
+				metadata.SetBackend(metadataMock)
+
+				metadataMock.On("MaybeStoreSSHID", ctx, "user").
+					Return("user").
+					Once()
+
+				metadataMock.On("MaybeStoreFingerprint", ctx, mock.Anything).
+					Return("fingerprint").
+					Once()
+
+				api := new(internalclientMocks.Client)
+				metadataMock.On("MaybeSetAPI", ctx, mock.Anything).
+					Return(api).
+					Once()
+
+				api.On("ListPublicKeysByFingerprint", "fingerprint").
+					Return([]models.PublicKey{}, nil).
+					Once()
+			},
+			expected: false,
+		},
+		{
+			description: "succeeds when the jump login's public key is registered",
+			setup: func(ctx *gliderssh.Context) *sshsrvtest.Conn {
+				srv := sshsrvtest.New(
+					&gliderssh.Server{
+						Handler: func(s gliderssh.Session) {
+							*ctx = s.Context()
+						},
+					},
+					&gossh.ClientConfig{
+						User:            "user",
+						HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+					},
+				)
+
+				return srv
+			},
+			mocks: func(ctx gliderssh.Context) {
+				metadataMock := new(metadataMocks.Metadata)
+				metadata.SetBackend(metadataMock)
+
+				metadataMock.On("MaybeStoreSSHID", ctx, "user").
+					Return("user").
+					Once()
+
+				metadataMock.On("MaybeStoreFingerprint", ctx, mock.Anything).
+					Return("fingerprint").
+					Once()
+
+				api := new(internalclientMocks.Client)
+				metadataMock.On("MaybeSetAPI", ctx, mock.Anything).
+					Return(api).
+					Once()
+
+				api.On("ListPublicKeysByFingerprint", "fingerprint").
+					Return([]models.PublicKey{{Fingerprint: "fingerprint", TenantID: "00000000-0000-4000-0000-000000000000"}}, nil).
+					Once()
+
+				metadataMock.On("StoreAuthenticationMethod", ctx, metadata.PublicKeyAuthenticationMethod)
 			},
 			expected: true,
 		},
@@ -361,8 +490,207 @@ func TestPublicKeyHandler(t *testing.T) {
 
 			tc.mocks(ctx)
 
-			result := PublicKeyHandler(ctx, generateTestPubKey(t))
+			result := PublicKeyHandler(nil)(ctx, generateTestPubKey(t))
 			assert.Equal(t, tc.expected, result)
 		})
 	}
 }
+
+func TestPublicKeyHandlerWithCertificate(t *testing.T) {
+	authority, err := gossh.NewSignerFromKey(generateTestPrivateKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := gossh.NewSignerFromKey(generateTestPrivateKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(signer gossh.Signer, principals []string, extensions ...string) *gossh.Certificate {
+		cert := &gossh.Certificate{
+			Key:             generateTestPubKey(t),
+			CertType:        gossh.UserCert,
+			KeyId:           "john_doe",
+			ValidPrincipals: principals,
+			ValidAfter:      uint64(time.Now().Add(-time.Minute).Unix()),
+			ValidBefore:     uint64(time.Now().Add(time.Hour).Unix()),
+			Permissions:     gossh.Permissions{Extensions: map[string]string{}},
+		}
+
+		for _, extension := range extensions {
+			cert.Permissions.Extensions[extension] = ""
+		}
+
+		if err := cert.SignCert(rand.Reader, signer); err != nil {
+			t.Fatal(err)
+		}
+
+		return cert
+	}
+
+	ca := &models.CertificateAuthority{
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		PublicKey: string(gossh.MarshalAuthorizedKey(authority.PublicKey())),
+	}
+
+	device := &models.Device{TenantID: "00000000-0000-4000-0000-000000000000"}
+
+	cases := []struct {
+		description   string
+		cert          *gossh.Certificate
+		requiredMocks func(api *internalclientMocks.Client, cert *gossh.Certificate)
+		expected      bool
+		permitted     bool
+	}{
+		{
+			description:   "fails when certificate is signed by other authority",
+			cert:          sign(other, []string{"user"}),
+			requiredMocks: func(_ *internalclientMocks.Client, _ *gossh.Certificate) {},
+			expected:      false,
+		},
+		{
+			description:   "fails when certificate does not allow the target's username",
+			cert:          sign(authority, []string{"root"}),
+			requiredMocks: func(_ *internalclientMocks.Client, _ *gossh.Certificate) {},
+			expected:      false,
+		},
+		{
+			description: "fails when certificate's key is not registered on the namespace",
+			cert:        sign(authority, []string{"user"}),
+			requiredMocks: func(api *internalclientMocks.Client, cert *gossh.Certificate) {
+				api.On("GetPublicKey", gossh.FingerprintLegacyMD5(cert.Key), "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error")).
+					Once()
+			},
+			expected: false,
+		},
+		{
+			description: "fails when certificate's key filter does not allow the device",
+			cert:        sign(authority, []string{"user"}),
+			requiredMocks: func(api *internalclientMocks.Client, cert *gossh.Certificate) {
+				api.On("GetPublicKey", gossh.FingerprintLegacyMD5(cert.Key), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{}, nil).
+					Once()
+				api.On("EvaluateKey", gossh.FingerprintLegacyMD5(cert.Key), device, "user").
+					Return(false, nil).
+					Once()
+			},
+			expected: false,
+		},
+		{
+			description: "succeeds to authenticate the session",
+			cert:        sign(authority, []string{"user"}),
+			requiredMocks: func(api *internalclientMocks.Client, cert *gossh.Certificate) {
+				api.On("GetPublicKey", gossh.FingerprintLegacyMD5(cert.Key), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{}, nil).
+					Once()
+				api.On("EvaluateKey", gossh.FingerprintLegacyMD5(cert.Key), device, "user").
+					Return(true, nil).
+					Once()
+			},
+			expected:  true,
+			permitted: false,
+		},
+		{
+			description: "succeeds without permitting the agent forwarding when the member is no longer allowed to",
+			cert:        sign(authority, []string{"user"}, sshagent.Extension),
+			requiredMocks: func(api *internalclientMocks.Client, cert *gossh.Certificate) {
+				api.On("GetPublicKey", gossh.FingerprintLegacyMD5(cert.Key), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{}, nil).
+					Once()
+				api.On("EvaluateKey", gossh.FingerprintLegacyMD5(cert.Key), device, "user").
+					Return(true, nil).
+					Once()
+				api.On("EvaluateAgentForwarding", "00000000-0000-4000-0000-000000000000", "john_doe").
+					Return(false, nil).
+					Once()
+			},
+			expected:  true,
+			permitted: false,
+		},
+		{
+			description: "succeeds permitting the agent forwarding when the member is allowed to",
+			cert:        sign(authority, []string{"user"}, sshagent.Extension),
+			requiredMocks: func(api *internalclientMocks.Client, cert *gossh.Certificate) {
+				api.On("GetPublicKey", gossh.FingerprintLegacyMD5(cert.Key), "00000000-0000-4000-0000-000000000000").
+					Return(&models.PublicKey{}, nil).
+					Once()
+				api.On("EvaluateKey", gossh.FingerprintLegacyMD5(cert.Key), device, "user").
+					Return(true, nil).
+					Once()
+				api.On("EvaluateAgentForwarding", "00000000-0000-4000-0000-000000000000", "john_doe").
+					Return(true, nil).
+					Once()
+			},
+			expected:  true,
+			permitted: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var ctx gliderssh.Context
+
+			srv := sshsrvtest.New(
+				&gliderssh.Server{
+					Handler: func(s gliderssh.Session) {
+						ctx = s.Context()
+					},
+				},
+				&gossh.ClientConfig{
+					User:            "user@namespace.00-00-00-00-00-00",
+					HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+				},
+			)
+			defer srv.Teardown()
+
+			srv.Start()
+			assert.NoError(t, srv.Agent.Run("cmd"))
+
+			metadataMock := new(metadataMocks.Metadata)
+			metadata.SetBackend(metadataMock)
+
+			metadataMock.On("MaybeStoreSSHID", ctx, "user@namespace.00-00-00-00-00-00").
+				Return("user@namespace.00-00-00-00-00-00").
+				Once()
+
+			metadataMock.On("MaybeStoreFingerprint", ctx, mock.Anything).
+				Return("fingerprint").
+				Once()
+
+			tag := &target.Target{Username: "user", Data: "namespace.00-00-00-00-00-00"}
+			metadataMock.On("MaybeStoreTarget", ctx, "user@namespace.00-00-00-00-00-00").
+				Return(tag, nil).
+				Once()
+
+			api := new(internalclientMocks.Client)
+			metadataMock.On("MaybeSetAPI", ctx, mock.Anything).
+				Return(api).
+				Once()
+
+			lookup := map[string]string{}
+			metadataMock.On("MaybeStoreLookup", ctx, tag, api).
+				Return(lookup, nil).
+				Once()
+
+			metadataMock.On("MaybeStoreDevice", ctx, lookup, api).
+				Return(device, []error{}).
+				Once()
+
+			api.On("GetCertificateAuthority", "00000000-0000-4000-0000-000000000000").
+				Return(ca, nil).
+				Once()
+
+			tc.requiredMocks(api, tc.cert)
+
+			metadataMock.On("StoreAuthenticationMethod", ctx, metadata.PublicKeyAuthenticationMethod)
+
+			result := PublicKeyHandler(nil)(ctx, tc.cert)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.permitted, sshagent.Permitted(ctx))
+
+			api.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package channels
+
+import (
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/jump"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// jumpDirectTCPIPHandler handles the direct-tcpip channels opened through a jump login, like `ssh -J`.
+//
+// The channel's destination names a device, in the device.namespace format, and the channel is served as a new
+// connection to the same server, where the client authenticates on the device as usual. The destination's port is
+// ignored, as the device is always reached through its SSH server.
+func jumpDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context, keys []models.PublicKey) {
+	type channelData struct {
+		DestAddr   string
+		DestPort   uint32
+		OriginAddr string
+		OriginPort uint32
+	}
+
+	data := new(channelData)
+	if err := gossh.Unmarshal(newChan.ExtraData(), data); err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "faild to parse forward data: "+err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	logger := log.WithFields(log.Fields{
+		"session":   ctx.SessionID(),
+		"user":      ctx.User(),
+		"dest_addr": data.DestAddr,
+		"dest_port": data.DestPort,
+	})
+
+	dest, err := jump.ParseDestination(data.DestAddr)
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, err.Error()) //nolint:errcheck
+		logger.WithError(err).Error("failed to parse the jump destination")
+
+		return
+	}
+
+	api := metadata.RestoreAPI(ctx)
+	if api == nil {
+		newChan.Reject(gossh.ConnectionFailed, "failed to get the API from context") //nolint:errcheck
+		logger.Error("failed to get the API from context")
+
+		return
+	}
+
+	// NOTICE: the namespace is authorized before the device is looked up, and every failure is rejected with the same
+	// reason, so a jump login can't probe the devices of the namespaces its keys aren't registered on.
+	namespace, err := api.NamespaceLookup(dest.Namespace)
+	if err != nil || !jump.Allows(keys, namespace.TenantID) {
+		newChan.Reject(gossh.ConnectionFailed, "device not found") //nolint:errcheck
+		logger.WithError(err).Warn("jump login is not allowed to reach the namespace")
+
+		return
+	}
+
+	device, errs := api.DeviceLookup(map[string]string{"domain": dest.Namespace, "name": dest.Name})
+	if len(errs) > 0 || device == nil {
+		newChan.Reject(gossh.ConnectionFailed, "device not found") //nolint:errcheck
+		logger.WithField("errors", errs).Error("failed to find the jump destination")
+
+		return
+	}
+
+	channel, reqs, err := newChan.Accept()
+	if err != nil {
+		logger.WithError(err).Error("failed accepting the channel")
+
+		return
+	}
+
+	go gossh.DiscardRequests(reqs)
+
+	logger.WithField("device", device.UID).Info("serving the jump destination")
+
+	go server.HandleConn(jump.NewConn(channel, conn.LocalAddr(), conn.RemoteAddr(), dest))
+}
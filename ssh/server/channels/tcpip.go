@@ -7,6 +7,7 @@ import (
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/ssh/pkg/jump"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
@@ -29,6 +30,12 @@ const (
 
 func TunnelDefaultDirectTCPIPHandler(tunnel *httptunnel.Tunnel) func(server *gliderssh.Server, _ *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
 	return func(server *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+		if keys := jump.RestoreKeys(ctx); keys != nil {
+			jumpDirectTCPIPHandler(server, conn, newChan, ctx, keys)
+
+			return
+		}
+
 		target := metadata.RestoreTarget(ctx)
 
 		log.WithFields(log.Fields{
@@ -14,6 +14,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/flow"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/sshagent"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -155,6 +156,19 @@ func connectSSH(ctx context.Context, client gliderssh.Session, sess *session.Ses
 	}
 	defer agent.Close()
 
+	if gliderssh.AgentRequested(client) {
+		if sshagent.Permitted(ctx.(gliderssh.Context)) {
+			if err := sshagent.Forward(client, connection, agent); err != nil {
+				log.WithError(err).
+					WithFields(log.Fields{"session": sess.UID, "sshid": client.User()}).
+					Warning("failed to forward the agent to the device")
+			}
+		} else {
+			log.WithFields(log.Fields{"session": sess.UID, "sshid": client.User()}).
+				Info("agent forwarding requested but not permitted")
+		}
+	}
+
 	go session.HandleRequests(ctx, reqs, api, ctx.Done())
 
 	metadata.MaybeStoreEstablished(ctx.(gliderssh.Context), true)
@@ -8,6 +8,9 @@ import (
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/pires/go-proxyproto"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
+	"github.com/shellhub-io/shellhub/ssh/pkg/conntrace"
+	"github.com/shellhub-io/shellhub/ssh/pkg/jump"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	"github.com/shellhub-io/shellhub/ssh/server/auth"
 	"github.com/shellhub-io/shellhub/ssh/server/channels"
@@ -18,6 +21,8 @@ import (
 type Options struct {
 	ConnectTimeout time.Duration `env:"CONNECT_TIMEOUT,default=30s"`
 	RedisURI       string        `env:"REDIS_URI,default=redis://redis:6379"`
+	// Tracing is the configuration of the export of the SSH server's spans, disabled by default.
+	Tracing tracing.Config
 }
 
 type Server struct {
@@ -36,8 +41,21 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
 	server.sshd = &gliderssh.Server{ // nolint: exhaustruct
 		Addr:             ":2222",
 		PasswordHandler:  auth.PasswordHandler,
-		PublicKeyHandler: auth.PublicKeyHandler,
+		PublicKeyHandler: auth.PublicKeyHandler(tunnel),
+		ConnCallback: func(ctx gliderssh.Context, conn net.Conn) net.Conn {
+			conntrace.Start(ctx, conn)
+
+			return jump.ConnCallback(ctx, conn)
+		},
 		SessionRequestCallback: func(client gliderssh.Session, request string) bool {
+			// NOTICE: jump logins are only allowed to reach devices, through direct-tcpip channels.
+			if jump.RestoreKeys(client.Context()) != nil {
+				log.WithFields(log.Fields{"user": client.User(), "request": request}).
+					Warn("session request refused on jump login")
+
+				return false
+			}
+
 			metadata.StoreRequest(client.Context(), request)
 
 			target := metadata.RestoreTarget(client.Context())
@@ -0,0 +1,14 @@
+package session
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/shellhub-io/shellhub/pkg/metrics"
+)
+
+var activeSessions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "ssh",
+	Name:      "sessions_active",
+	Help:      "Number of active SSH sessions by type.",
+}, []string{"type"})
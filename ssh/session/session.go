@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
@@ -15,9 +16,14 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/tracing"
+	"github.com/shellhub-io/shellhub/ssh/pkg/conntrace"
 	"github.com/shellhub-io/shellhub/ssh/pkg/host"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	gossh "golang.org/x/crypto/ssh"
 )
 
@@ -77,13 +83,36 @@ func (s *Session) checkBilling(ctx gliderssh.Context, device string) (bool, erro
 }
 
 // dial dials the a connection between SSH server and the device agent.
-func (s *Session) dial(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, device string, session string) (net.Conn, error) {
-	dialed, err := tunnel.Dial(ctx, device)
+//
+// The request sent to the agent carries the trace context of the connection, so the agent continues its trace, and the
+// client's key and address, so the agent evaluates the client on its own authentication.
+func (s *Session) dial(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, device string, session string, address string) (net.Conn, error) {
+	dialCtx, span := tracing.Tracer().Start(conntrace.Context(ctx), "tunnel dial",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(tracing.DeviceUID(device), tracing.SessionUID(session)),
+	)
+	defer span.End()
+
+	dialed, err := tunnel.Dial(dialCtx, device)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
 		return nil, errors.Join(ErrDial, err)
 	}
 
 	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/ssh/%s", session), nil)
+	tracing.Inject(conntrace.Context(ctx), propagation.HeaderCarrier(req.Header))
+
+	// NOTICE: the server authenticates on the agent with its own key, so the key the client authenticated with is
+	// forwarded on the request, when the client used the public key authentication.
+	if key, ok := ctx.Value(gliderssh.ContextKeyPublicKey).(gliderssh.PublicKey); ok &&
+		metadata.RestoreAuthenticationMethod(ctx) == metadata.PublicKeyAuthenticationMethod {
+		req.Header.Set("X-Client-Public-Key", strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key))))
+	}
+
+	req.Header.Set("X-Client-Address", address)
+
 	if err = req.Write(dialed); err != nil {
 		return nil, err
 	}
@@ -133,7 +162,7 @@ func NewSession(client gliderssh.Session, tunnel *httptunnel.Tunnel) (*Session,
 		return nil, err
 	}
 
-	dialed, err := session.dial(ctx, tunnel, device.UID, uid)
+	dialed, err := session.dial(ctx, tunnel, device.UID, uid, hos.Host)
 	if err != nil {
 		log.WithError(err).
 			WithFields(log.Fields{"session": uid, "sshid": client.User()}).
@@ -155,6 +184,8 @@ func NewSession(client gliderssh.Session, tunnel *httptunnel.Tunnel) (*Session,
 
 	session.Register(client) // nolint:errcheck
 
+	activeSessions.WithLabelValues(session.Type).Inc()
+
 	return session, nil
 }
 
@@ -194,7 +225,7 @@ func NewSessionWithoutClient(ctx gliderssh.Context, tunnel *httptunnel.Tunnel) (
 		return nil, err
 	}
 
-	dialed, err := session.dial(ctx, tunnel, device.UID, uid)
+	dialed, err := session.dial(ctx, tunnel, device.UID, uid, hos.Host)
 	if err != nil {
 		log.WithError(err).
 			WithFields(log.Fields{"session": uid, "sshid": target.Username}).
@@ -272,6 +303,11 @@ func (s *Session) Register(_ gliderssh.Session) error {
 }
 
 func (s *Session) Finish() error {
+	// NOTICE: the sessions without a client, like the port forwarding ones, are not registered nor counted as active.
+	if s.Client != nil {
+		activeSessions.WithLabelValues(s.Type).Dec()
+	}
+
 	if s.Dialed != nil {
 		request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/ssh/close/%s", s.UID), nil)
 
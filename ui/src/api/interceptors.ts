@@ -17,11 +17,25 @@ const onResponse = (response: AxiosResponse): AxiosResponse => {
   return response;
 };
 
-const onResponseError = async (error: AxiosError): Promise<AxiosError> => {
+// The access token is short-lived, so, when a request is unauthorized, the token is refreshed and the request retried
+// once before logging the user out.
+const onResponseError = (axiosInstance: AxiosInstance) => async (error: AxiosError): Promise<AxiosError | AxiosResponse> => {
   store.dispatch("spinner/setStatus", false);
   // eslint-disable-next-line @typescript-eslint/ban-ts-comment
   // @ts-ignore
   if (error.response.status === 401) {
+    const config = error.config as InternalAxiosRequestConfig & { retried?: boolean };
+    if (config && !config.retried && localStorage.getItem("refresh_token")) {
+      config.retried = true;
+
+      const token = await store.dispatch("auth/refreshToken").catch(() => undefined);
+      if (token) {
+        config.headers.Authorization = `Bearer ${token}`;
+
+        return axiosInstance(config);
+      }
+    }
+
     await store.dispatch("auth/logout");
     await router.push({ name: "login" });
   }
@@ -31,6 +45,6 @@ const onResponseError = async (error: AxiosError): Promise<AxiosError> => {
 // eslint-disable-next-line import/prefer-default-export
 export function setupInterceptorsTo(axiosInstance: AxiosInstance): AxiosInstance {
   axiosInstance.interceptors.request.use(onRequest, onRequestError);
-  axiosInstance.interceptors.response.use(onResponse, onResponseError);
+  axiosInstance.interceptors.response.use(onResponse, onResponseError(axiosInstance));
   return axiosInstance;
 }
@@ -1,3 +1,4 @@
+import axios from "axios";
 import { IUserLogin, MfaValidation, MfaCode } from "@/interfaces/IUserLogin";
 import { usersApi, mfaApi } from "../../api/http";
 
@@ -18,3 +19,9 @@ export const validateRecoveryCodes = async (validation: MfaCode) => mfaApi.recov
 export const generateMfa = async () => mfaApi.generateMFA();
 
 export const info = async () => usersApi.getUserInfo();
+
+// The refresh doesn't use the API's client as its interceptors would try to refresh the token again when it fails.
+export const refresh = async (refreshToken: string) => axios.post<{ token?: string, refresh_token?: string }>(
+  `${window.location.protocol}//${window.location.host}/api/auth/refresh`,
+  { refresh_token: refreshToken },
+);
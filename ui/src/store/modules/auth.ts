@@ -3,6 +3,12 @@ import * as apiAuth from "../api/auth";
 import { IUserLogin } from "@/interfaces/IUserLogin";
 import { State } from "..";
 
+// saveRefreshToken stores the refresh token, when sent by the API, used to get a new token when the current expires.
+const saveRefreshToken = (data: object) => {
+  const { refresh_token: refreshToken } = data as { refresh_token?: string };
+  if (refreshToken) localStorage.setItem("refresh_token", refreshToken);
+};
+
 export interface AuthState {
   status: string;
   token: string;
@@ -145,6 +151,7 @@ export const auth: Module<AuthState, State> = {
         const resp = await apiAuth.login(user);
 
         localStorage.setItem("token", resp.data.token || "");
+        saveRefreshToken(resp.data);
         localStorage.setItem("user", resp.data.user || "");
         localStorage.setItem("name", resp.data.name || "");
         localStorage.setItem("tenant", resp.data.tenant || "");
@@ -202,6 +209,7 @@ export const auth: Module<AuthState, State> = {
         if (resp.status === 200) {
           context.commit("mfaToken", resp.data.token);
           localStorage.setItem("token", resp.data.token || "");
+          saveRefreshToken(resp.data);
           localStorage.setItem("mfa", "true");
           context.commit("mfaEnabled", true);
           context.commit("showCongratulationsModal");
@@ -218,6 +226,7 @@ export const auth: Module<AuthState, State> = {
 
         if (resp.status === 200) {
           localStorage.setItem("token", resp.data.token || "");
+          saveRefreshToken(resp.data);
           context.commit("mfaToken", resp.data.token);
         }
       } catch (error) {
@@ -255,6 +264,7 @@ export const auth: Module<AuthState, State> = {
         const resp = await apiAuth.validateRecoveryCodes(data);
         if (resp.status === 200) {
           localStorage.setItem("token", resp.data.token || "");
+          saveRefreshToken(resp.data);
           context.commit("mfaToken", resp.data.token);
         }
       } catch (error) {
@@ -263,9 +273,20 @@ export const auth: Module<AuthState, State> = {
       }
     },
 
+    async refreshToken(context) {
+      const resp = await apiAuth.refresh(localStorage.getItem("refresh_token") || "");
+
+      localStorage.setItem("token", resp.data.token || "");
+      saveRefreshToken(resp.data);
+      context.commit("mfaToken", resp.data.token);
+
+      return resp.data.token;
+    },
+
     logout(context) {
       context.commit("logout");
       localStorage.removeItem("token");
+      localStorage.removeItem("refresh_token");
       localStorage.removeItem("user");
       localStorage.removeItem("tenant");
       localStorage.removeItem("namespacesWelcome");